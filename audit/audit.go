@@ -0,0 +1,268 @@
+// Package audit writes the append-only audit log of tool calls.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/auth"
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+	"kanboard-mcp/rbac"
+	"kanboard-mcp/tools"
+)
+
+// Audit log rotation defaults when MCP_AUDIT_LOG_MAX_SIZE_MB / MCP_AUDIT_LOG_MAX_FILES are not set
+const (
+	defaultAuditLogMaxSizeMB = 100
+	defaultAuditLogMaxFiles  = 10
+)
+
+// auditRecord is one line of the audit log, written once per tool call
+type auditRecord struct {
+	Time       time.Time              `json:"time"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Tool       string                 `json:"tool"`
+	Arguments  map[string]interface{} `json:"arguments"`
+	User       string                 `json:"user,omitempty"`
+	Caller     string                 `json:"caller,omitempty"`
+	SessionID  string                 `json:"session_id,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	DryRun     bool                   `json:"dry_run,omitempty"`
+	RBAC       *rbac.Decision         `json:"rbac,omitempty"`
+	Calls      []auditCall            `json:"calls"`
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error,omitempty"`
+	DurationMS int64                  `json:"duration_ms"`
+
+	mu sync.Mutex
+}
+
+// auditCall is a Kanboard JSON-RPC call made while serving a tool call
+type auditCall struct {
+	Method     string `json:"method"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// ObserveCall records a Kanboard API call made while serving the tool call
+func (r *auditRecord) ObserveCall(method, status string, err error, duration time.Duration) {
+	call := auditCall{Method: method, Status: status, DurationMS: duration.Milliseconds()}
+	if err != nil {
+		call.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Calls = append(r.Calls, call)
+}
+
+// Logger appends audit records to a JSON lines file and rotates it by size
+type Logger struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewLoggerFromEnv opens the audit log configured by MCP_AUDIT_LOG (nil when auditing is off)
+func NewLoggerFromEnv() (*Logger, error) {
+	path := os.Getenv("MCP_AUDIT_LOG")
+	if path == "" {
+		return nil, nil
+	}
+
+	maxSizeMB, err := auditEnvInt("MCP_AUDIT_LOG_MAX_SIZE_MB", defaultAuditLogMaxSizeMB)
+	if err != nil {
+		return nil, err
+	}
+	maxFiles, err := auditEnvInt("MCP_AUDIT_LOG_MAX_FILES", defaultAuditLogMaxFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := &Logger{
+		path:     path,
+		maxSize:  int64(maxSizeMB) * 1024 * 1024,
+		maxFiles: maxFiles,
+	}
+	if err := logger.open(); err != nil {
+		return nil, err
+	}
+	return logger, nil
+}
+
+func auditEnvInt(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %q (expected a non-negative integer)", name, value)
+	}
+	return n, nil
+}
+
+// open opens the log file in append-only mode
+func (l *Logger) open() error {
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts path -> path.1 -> path.2 ... and drops files beyond maxFiles
+func (l *Logger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+
+	if l.maxFiles > 0 {
+		os.Remove(fmt.Sprintf("%s.%d", l.path, l.maxFiles))
+		for i := l.maxFiles - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+		}
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	} else if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+
+	return l.open()
+}
+
+// write appends one record as a single JSON line
+func (l *Logger) write(record *auditRecord) error {
+	record.mu.Lock()
+	line, err := json.Marshal(record)
+	record.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// Middleware writes one audit record per tool call, including calls that were denied.
+// A nil logger disables auditing.
+func (l *Logger) Middleware(r *tools.Registry) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if l == nil {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			started := time.Now()
+			record := &auditRecord{
+				Time:      started.UTC(),
+				RequestID: logging.RequestIDFromContext(ctx),
+				Tool:      request.Params.Name,
+				Arguments: sanitizeAuditArguments(request.GetArguments()),
+				Calls:     []auditCall{},
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				record.SessionID = session.SessionID()
+			}
+			if principal := auth.PrincipalFromContext(ctx); principal != nil {
+				record.Caller = principal.Name
+			}
+			if r.Mutating(record.Tool) && r.IsDryRunRequest(request) {
+				record.DryRun = true
+			}
+
+			callCtx, decision := rbac.TrackDecision(kanboard.WithCallObserver(ctx, record))
+			result, err := next(callCtx, request)
+
+			record.DurationMS = time.Since(started).Milliseconds()
+			record.RBAC = decision()
+			switch {
+			case err != nil:
+				record.Status = "error"
+				record.Error = err.Error()
+			case result != nil && result.IsError:
+				record.Status = "error"
+				record.Error = logging.TruncateText(logging.ResultText(result))
+			default:
+				record.Status = "ok"
+			}
+			if instance := tools.InstanceFromContext(ctx); instance != nil {
+				record.Instance = instance.Name
+			}
+			if record.RBAC != nil {
+				// The user the permission check ran for, no extra Kanboard call
+				record.User = record.RBAC.User
+			}
+
+			if writeErr := l.write(record); writeErr != nil {
+				logging.Tools.ErrorContext(ctx, "failed to write audit record", "error", writeErr)
+			}
+			return result, err
+		}
+	}
+}
+
+// sanitizeAuditArguments copies tool arguments with secrets and file contents redacted
+func sanitizeAuditArguments(args map[string]interface{}) map[string]interface{} {
+	sanitized := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if logging.IsSecretKey(key) {
+			if text, ok := value.(string); ok && key == "blob" {
+				sanitized[key] = fmt.Sprintf("[REDACTED %d bytes]", len(text))
+			} else {
+				sanitized[key] = "[REDACTED]"
+			}
+			continue
+		}
+		sanitized[key] = sanitizeAuditValue(value)
+	}
+	return sanitized
+}
+
+// sanitizeAuditValue redacts the secrets of objects, including those nested in arrays
+func sanitizeAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return sanitizeAuditArguments(v)
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, item := range v {
+			sanitized[i] = sanitizeAuditValue(item)
+		}
+		return sanitized
+	}
+	return value
+}
@@ -0,0 +1,252 @@
+// Package auth authenticates the callers of the MCP HTTP endpoints and limits the tool
+// domains each of them may use.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/logging"
+	"kanboard-mcp/tools"
+)
+
+// Authentication methods of a Principal
+const (
+	MethodStatic = "static"
+	MethodHMAC   = "hmac"
+	MethodJWT    = "jwt"
+)
+
+// AllScopes grants every tool domain
+const AllScopes = "*"
+
+// Principal is the authenticated caller of an MCP request
+type Principal struct {
+	// Name identifies the caller: the name of a static token, the subject of a signed token
+	Name   string
+	Method string
+	// Scopes are the tool domains the caller may use, AllScopes for every domain. Without
+	// scopes only the always-enabled tools may be used.
+	Scopes []string
+}
+
+// Allows reports whether the caller may use the tools of the domain
+func (p *Principal) Allows(domain string) bool {
+	return slices.Contains(p.Scopes, AllScopes) || slices.Contains(p.Scopes, domain)
+}
+
+// allowsTool reports whether the caller may call the tool. Tools unknown to the registry
+// are left to the MCP server to refuse, always-enabled tools like tool_search are allowed.
+func (p *Principal) allowsTool(r *tools.Registry, name string) (*tools.Tool, bool) {
+	tool, ok := r.Lookup(name)
+	if !ok || tool.AlwaysEnabled {
+		return tool, true
+	}
+	return tool, p.Allows(tool.Domain)
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated caller
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, nil when authentication is off
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return principal
+}
+
+// errUnauthorized is returned for tokens that match none of the configured methods
+var errUnauthorized = errors.New("invalid or expired token")
+
+// Authenticator checks the bearer tokens of the MCP HTTP requests against static tokens,
+// HMAC-signed tokens and JWTs, whichever are configured
+type Authenticator struct {
+	registry *tools.Registry
+	static   *staticTokens
+	hmac     *hmacVerifier
+	jwt      *jwtVerifier
+}
+
+// NewFromEnv configures authentication from MCP_AUTH_TOKENS_FILE, MCP_AUTH_HMAC_SECRET
+// and MCP_AUTH_JWKS_FILE (with MCP_AUTH_JWT_ISSUER and MCP_AUTH_JWT_AUDIENCE). It returns
+// nil when none is set, which leaves the endpoints open.
+func NewFromEnv(r *tools.Registry) (*Authenticator, error) {
+	a := &Authenticator{registry: r}
+	var err error
+	if path := os.Getenv("MCP_AUTH_TOKENS_FILE"); path != "" {
+		if a.static, err = loadStaticTokens(path, r); err != nil {
+			return nil, err
+		}
+	}
+	if secret := os.Getenv("MCP_AUTH_HMAC_SECRET"); secret != "" {
+		if a.hmac, err = newHMACVerifier(secret); err != nil {
+			return nil, err
+		}
+	}
+	if path := os.Getenv("MCP_AUTH_JWKS_FILE"); path != "" {
+		if a.jwt, err = loadJWTVerifier(path, os.Getenv("MCP_AUTH_JWT_ISSUER"), os.Getenv("MCP_AUTH_JWT_AUDIENCE")); err != nil {
+			return nil, err
+		}
+	} else if os.Getenv("MCP_AUTH_JWT_ISSUER") != "" || os.Getenv("MCP_AUTH_JWT_AUDIENCE") != "" {
+		return nil, fmt.Errorf("MCP_AUTH_JWT_ISSUER and MCP_AUTH_JWT_AUDIENCE need MCP_AUTH_JWKS_FILE")
+	}
+	if a.static == nil && a.hmac == nil && a.jwt == nil {
+		return nil, nil
+	}
+	return a, nil
+}
+
+// Methods lists the configured authentication methods
+func (a *Authenticator) Methods() []string {
+	var methods []string
+	if a.static != nil {
+		methods = append(methods, MethodStatic)
+	}
+	if a.hmac != nil {
+		methods = append(methods, MethodHMAC)
+	}
+	if a.jwt != nil {
+		methods = append(methods, MethodJWT)
+	}
+	return methods
+}
+
+// Authenticate returns the caller a bearer token belongs to
+func (a *Authenticator) Authenticate(token string) (*Principal, error) {
+	if a.static != nil {
+		if principal := a.static.lookup(token); principal != nil {
+			return principal, nil
+		}
+	}
+	if a.hmac != nil && strings.HasPrefix(token, hmacTokenPrefix) {
+		return a.hmac.verify(token)
+	}
+	if a.jwt != nil && strings.Count(token, ".") == 2 {
+		return a.jwt.verify(token)
+	}
+	return nil, errUnauthorized
+}
+
+// Handler authenticates the requests to an MCP endpoint. Requests without a valid
+// "Authorization: Bearer" token get a 401, HTTP Basic credentials included, tool calls
+// outside the scopes of the token a 403. A nil authenticator lets every request through.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	if a == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if len(authorization) > 6 && strings.EqualFold(authorization[:6], "Basic ") {
+			// Kanboard session credentials can't share the header with the bearer token
+			writeAuthError(w, http.StatusUnauthorized, `Bearer realm="kanboard-mcp"`, "unauthorized",
+				"HTTP Basic credentials are not accepted when the endpoints require a bearer token; "+
+					"send the Kanboard credentials in the X-Kanboard-Username and X-Kanboard-Token headers")
+			return
+		}
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			writeAuthError(w, http.StatusUnauthorized, `Bearer realm="kanboard-mcp"`,
+				"unauthorized", "send a bearer token in the Authorization header")
+			return
+		}
+		principal, err := a.Authenticate(strings.TrimSpace(token))
+		if err != nil {
+			logging.Transport.Warn("rejected MCP request", "path", r.URL.Path, "remote_addr", r.RemoteAddr, "error", err)
+			writeAuthError(w, http.StatusUnauthorized, `Bearer realm="kanboard-mcp", error="invalid_token"`,
+				"invalid_token", err.Error())
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			if denied := a.deniedTool(principal, body); denied != nil {
+				logging.Transport.Warn("tool call outside token scopes", "caller", principal.Name, "tool", denied.Name(), "domain", denied.Domain)
+				writeAuthError(w, http.StatusForbidden,
+					fmt.Sprintf(`Bearer realm="kanboard-mcp", error="insufficient_scope", scope=%q`, denied.Domain),
+					"insufficient_scope", fmt.Sprintf("%s is in the %s domain, which the token of %s does not grant (scopes: %s)",
+						denied.Name(), denied.Domain, principal.Name, strings.Join(principal.Scopes, ", ")))
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// deniedTool returns the tool a tools/call message asks for when the caller may not use it
+func (a *Authenticator) deniedTool(principal *Principal, body []byte) *tools.Tool {
+	var message struct {
+		Method string `json:"method"`
+		Params struct {
+			Name string `json:"name"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(body, &message) != nil || message.Method != string(mcp.MethodToolsCall) {
+		return nil
+	}
+	if tool, ok := principal.allowsTool(a.registry, message.Params.Name); !ok {
+		return tool
+	}
+	return nil
+}
+
+// ToolFilter hides the tools outside the scopes of the caller from tools/list and tool_search
+func (a *Authenticator) ToolFilter() server.ToolFilterFunc {
+	return func(ctx context.Context, list []mcp.Tool) []mcp.Tool {
+		principal := PrincipalFromContext(ctx)
+		if a == nil || principal == nil {
+			return list
+		}
+		allowed := make([]mcp.Tool, 0, len(list))
+		for _, tool := range list {
+			if _, ok := principal.allowsTool(a.registry, tool.Name); ok {
+				allowed = append(allowed, tool)
+			}
+		}
+		return allowed
+	}
+}
+
+// writeAuthError answers with an OAuth style error: the WWW-Authenticate challenge and
+// a JSON body holding the error code and its description
+func writeAuthError(w http.ResponseWriter, status int, challenge, code, description string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": description})
+}
+
+// ValidateScopes checks that the scopes name tool domains of the registry
+func ValidateScopes(scopes []string, r *tools.Registry) error {
+	for _, scope := range scopes {
+		if scope == AllScopes {
+			continue
+		}
+		known := false
+		for _, domain := range r.Domains() {
+			known = known || domain.Name == scope
+		}
+		if !known {
+			return fmt.Errorf("unknown scope %q (expected a tool domain or %s)", scope, AllScopes)
+		}
+	}
+	return nil
+}
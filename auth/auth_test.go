@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signJWT creates an RS256 or ES256 JWT with the claims
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTVerification(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+	}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := loadJWTVerifier(path, "https://idp.example.com", "kanboard-mcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := func(changes map[string]any) map[string]any {
+		c := map[string]any{"sub": "alice", "iss": "https://idp.example.com", "aud": []string{"kanboard-mcp", "other"},
+			"exp": time.Now().Add(time.Hour).Unix(), "scope": "tasks comments"}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"mallory","exp":9999999999}`)) + "."
+
+	cases := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"rs256", signJWT(t, "RS256", "rsa-1", rsaKey, claims(nil)), ""},
+		{"es256 without kid", signJWT(t, "ES256", "", ecKey, claims(map[string]any{"aud": "kanboard-mcp"})), ""},
+		{"wrong key", signJWT(t, "RS256", "ec-1", rsaKey, claims(nil)), "bad signature"},
+		{"alg none", none, "bad signature"},
+		{"expired", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})), "expired"},
+		{"no exp", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": nil})), "no exp"},
+		{"issuer", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"iss": "https://evil.example.com"})), "issuer"},
+		{"audience", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"aud": "other"})), "audience"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			principal, err := verifier.verify(c.token)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("expected %q, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if principal.Name != "alice" || principal.Method != MethodJWT || strings.Join(principal.Scopes, ",") != "tasks,comments" {
+				t.Fatalf("unexpected principal %+v", principal)
+			}
+		})
+	}
+}
+
+func TestHMACTokens(t *testing.T) {
+	secret := strings.Repeat("s", 32)
+	verifier, err := newHMACVerifier(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := SignToken(secret, Claims{Subject: "ci", Scopes: []string{"tasks"}, ExpiresAt: time.Now().Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal, err := verifier.verify(token)
+	if err != nil || principal.Name != "ci" || !principal.Allows("tasks") || principal.Allows("users") {
+		t.Fatalf("unexpected principal %+v, %v", principal, err)
+	}
+
+	unscoped, _ := SignToken(secret, Claims{Subject: "ci"})
+	if principal, err := verifier.verify(unscoped); err != nil || principal.Allows("tasks") {
+		t.Fatalf("a token without scopes must grant no domain: %+v, %v", principal, err)
+	}
+
+	forged, _ := SignToken(strings.Repeat("x", 32), Claims{Subject: "ci"})
+	expired, _ := SignToken(secret, Claims{Subject: "ci", ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+	for token, want := range map[string]string{forged: "bad signature", expired: "expired", "kmcp_garbage": "invalid"} {
+		if _, err := verifier.verify(token); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// jwtLeeway is the clock skew tolerated on the exp and nbf claims
+const jwtLeeway = time.Minute
+
+// jwk is a key of a JSON Web Key Set, RSA, EC or Ed25519
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// verificationKey is a public key of the JWKS file
+type verificationKey struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+// jwtVerifier checks JWTs against the keys of a local JWKS file
+type jwtVerifier struct {
+	keys     []verificationKey
+	issuer   string
+	audience string
+}
+
+// loadJWTVerifier reads the JWKS file. The issuer and audience are checked when set.
+func loadJWTVerifier(path, issuer, audience string) (*jwtVerifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", path, err)
+	}
+
+	v := &jwtVerifier{issuer: issuer, audience: audience}
+	for i, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		public, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS file %s, key %d (%s): %w", path, i+1, key.Kid, err)
+		}
+		v.keys = append(v.keys, verificationKey{kid: key.Kid, alg: key.Alg, key: public})
+	}
+	if len(v.keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s holds no signing keys", path)
+	}
+	return v, nil
+}
+
+// publicKey decodes the key parameters
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid EC key")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("EC key is not on curve %s", k.Crv)
+		}
+		return key, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if k.Crv != "Ed25519" || err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid or unsupported OKP key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// jwtClaims are the registered claims read from a JWT, and its scopes
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	// Scope is the space-separated OAuth scope claim, Scp the array some providers use
+	Scope string          `json:"scope"`
+	Scp   json.RawMessage `json:"scp"`
+}
+
+// verify checks the signature and the claims of a JWT
+func (v *jwtVerifier) verify(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errUnauthorized
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("JWT: malformed header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("JWT: malformed signature")
+	}
+	if !v.verifySignature(header.Alg, header.Kid, []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, fmt.Errorf("JWT: bad signature or unsupported algorithm %q", header.Alg)
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("JWT: malformed claims")
+	}
+	now := time.Now()
+	switch {
+	case claims.Subject == "":
+		return nil, fmt.Errorf("JWT: no sub claim")
+	case claims.ExpiresAt == nil:
+		return nil, fmt.Errorf("JWT: no exp claim")
+	case now.After(time.Unix(int64(*claims.ExpiresAt), 0).Add(jwtLeeway)):
+		return nil, fmt.Errorf("JWT of %s expired", claims.Subject)
+	case claims.NotBefore != nil && now.Add(jwtLeeway).Before(time.Unix(int64(*claims.NotBefore), 0)):
+		return nil, fmt.Errorf("JWT of %s is not valid yet", claims.Subject)
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return nil, fmt.Errorf("JWT: unexpected issuer %q", claims.Issuer)
+	case v.audience != "" && !slices.Contains(stringOrList(claims.Audience), v.audience):
+		return nil, fmt.Errorf("JWT: audience does not include %q", v.audience)
+	}
+
+	scopes := strings.Fields(claims.Scope)
+	if len(scopes) == 0 {
+		scopes = strings.Fields(strings.Join(stringOrList(claims.Scp), " "))
+	}
+	return &Principal{Name: claims.Subject, Method: MethodJWT, Scopes: scopes}, nil
+}
+
+// verifySignature tries the keys matching the kid, or every key without a kid
+func (v *jwtVerifier) verifySignature(alg, kid string, signed, signature []byte) bool {
+	for _, key := range v.keys {
+		if (kid != "" && key.kid != "" && key.kid != kid) || (key.alg != "" && key.alg != alg) {
+			continue
+		}
+		if verifyJWTSignature(alg, key.key, signed, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyJWTSignature checks an RS*, PS*, ES* or EdDSA signature. Other algorithms,
+// "none" and the HMAC ones among them, are refused.
+func verifyJWTSignature(alg string, key crypto.PublicKey, signed, signature []byte) bool {
+	hashes := map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+	if alg == "EdDSA" {
+		public, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(public, signed, signature)
+	}
+	if len(alg) != 5 {
+		return false
+	}
+	hash, ok := hashes[alg[2:]]
+	if !ok {
+		return false
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS":
+		public, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(public, hash, digest, signature) == nil
+	case "PS":
+		public, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPSS(public, hash, digest, signature, nil) == nil
+	case "ES":
+		public, ok := key.(*ecdsa.PublicKey)
+		curves := map[string]elliptic.Curve{"ES256": elliptic.P256(), "ES384": elliptic.P384(), "ES512": elliptic.P521()}
+		if !ok || public.Curve != curves[alg] {
+			return false
+		}
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(public, digest, r, s)
+	}
+	return false
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// stringOrList reads a claim that is either a string or an array of strings
+func stringOrList(raw json.RawMessage) []string {
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var single string
+	if json.Unmarshal(raw, &single) == nil && single != "" {
+		return []string{single}
+	}
+	return nil
+}
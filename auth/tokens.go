@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"kanboard-mcp/logging"
+	"kanboard-mcp/tools"
+)
+
+// StaticToken is an entry of the MCP_AUTH_TOKENS_FILE file. The token is given as is,
+// as "${NAME}" to be read from the environment, or as its SHA-256 in hex.
+type StaticToken struct {
+	Name   string   `yaml:"name"`
+	Token  string   `yaml:"token"`
+	SHA256 string   `yaml:"sha256"`
+	Scopes []string `yaml:"scopes"`
+}
+
+// staticTokens are the tokens of the tokens file, kept as SHA-256 hashes
+type staticTokens struct {
+	hashes     [][]byte
+	principals []*Principal
+}
+
+var envReferencePattern = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
+// loadStaticTokens reads and validates the tokens file
+func loadStaticTokens(path string, r *tools.Registry) (*staticTokens, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+	var file struct {
+		Tokens []StaticToken `yaml:"tokens"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens file %s: %w", path, err)
+	}
+	if len(file.Tokens) == 0 {
+		return nil, fmt.Errorf("tokens file %s defines no tokens", path)
+	}
+
+	tokens := &staticTokens{}
+	names := make(map[string]bool)
+	for i, entry := range file.Tokens {
+		if match := envReferencePattern.FindStringSubmatch(entry.Token); match != nil {
+			entry.Token = os.Getenv(match[1])
+		}
+		var hash []byte
+		switch {
+		case entry.Name == "":
+			return nil, fmt.Errorf("token %d of %s has no name", i+1, path)
+		case names[entry.Name]:
+			return nil, fmt.Errorf("token %s is defined twice in %s", entry.Name, path)
+		case entry.Token != "" && entry.SHA256 != "":
+			return nil, fmt.Errorf("token %s: set token or sha256, not both", entry.Name)
+		case entry.Token != "":
+			if len(entry.Token) < 16 {
+				return nil, fmt.Errorf("token %s is shorter than 16 characters", entry.Name)
+			}
+			sum := sha256.Sum256([]byte(entry.Token))
+			hash = sum[:]
+		case entry.SHA256 != "":
+			hash, err = hex.DecodeString(entry.SHA256)
+			if err != nil || len(hash) != sha256.Size {
+				return nil, fmt.Errorf("token %s: sha256 must be 64 hexadecimal characters", entry.Name)
+			}
+		default:
+			return nil, fmt.Errorf("token %s has no token or sha256 (an unset environment variable?)", entry.Name)
+		}
+		if err := ValidateScopes(entry.Scopes, r); err != nil {
+			return nil, fmt.Errorf("token %s: %w", entry.Name, err)
+		}
+		if len(entry.Scopes) == 0 {
+			logging.Transport.Warn("token has no scopes, it can only call the always-enabled tools", "token", entry.Name, "hint", `scopes: ["*"] grants every domain`)
+		}
+		names[entry.Name] = true
+		tokens.hashes = append(tokens.hashes, hash)
+		tokens.principals = append(tokens.principals, &Principal{Name: entry.Name, Method: MethodStatic, Scopes: entry.Scopes})
+	}
+	return tokens, nil
+}
+
+// lookup returns the caller of a static token, comparing it with every token in constant time
+func (t *staticTokens) lookup(token string) *Principal {
+	sum := sha256.Sum256([]byte(token))
+	var found *Principal
+	for i, hash := range t.hashes {
+		if subtle.ConstantTimeCompare(sum[:], hash) == 1 {
+			found = t.principals[i]
+		}
+	}
+	return found
+}
+
+// hmacTokenPrefix starts the HMAC-signed tokens, "kmcp_<claims>.<signature>" with both
+// parts in unpadded base64url
+const hmacTokenPrefix = "kmcp_"
+
+// Claims are the content of an HMAC-signed token
+type Claims struct {
+	Subject string   `json:"sub"`
+	Scopes  []string `json:"scopes,omitempty"`
+	// ExpiresAt is a Unix time, the token never expires when it is 0
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+// hmacVerifier checks tokens signed with MCP_AUTH_HMAC_SECRET
+type hmacVerifier struct {
+	secret []byte
+}
+
+func newHMACVerifier(secret string) (*hmacVerifier, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("MCP_AUTH_HMAC_SECRET must be at least 32 characters")
+	}
+	return &hmacVerifier{secret: []byte(secret)}, nil
+}
+
+// SignToken creates an HMAC-signed token holding the claims
+func SignToken(secret string, claims Claims) (string, error) {
+	if claims.Subject == "" {
+		return "", fmt.Errorf("the token needs a subject")
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return hmacTokenPrefix + encoded + "." + base64.RawURLEncoding.EncodeToString(hmacSign([]byte(secret), encoded)), nil
+}
+
+func hmacSign(secret []byte, encoded string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// verify checks the signature and expiry of an HMAC-signed token
+func (v *hmacVerifier) verify(token string) (*Principal, error) {
+	encoded, signature, ok := strings.Cut(strings.TrimPrefix(token, hmacTokenPrefix), ".")
+	if !ok {
+		return nil, errUnauthorized
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, hmacSign(v.secret, encoded)) {
+		return nil, fmt.Errorf("signed token: bad signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errUnauthorized
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" {
+		return nil, fmt.Errorf("signed token: malformed claims")
+	}
+	if claims.ExpiresAt != 0 && time.Now().Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("signed token of %s expired at %s", claims.Subject, time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return &Principal{Name: claims.Subject, Method: MethodHMAC, Scopes: claims.Scopes}, nil
+}
@@ -0,0 +1,144 @@
+// Command gentools writes docs/TOOLS.md and mcp-tools-config.yaml from the tool
+// registry, so the reference docs and the default configuration never drift from
+// the tools the server actually registers. Run it with go generate from the
+// repository root.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"kanboard-mcp/tools"
+	"kanboard-mcp/tools/catalog"
+)
+
+func main() {
+	docsPath := flag.String("docs", "docs/TOOLS.md", "Markdown tool reference to write")
+	configPath := flag.String("config", "mcp-tools-config.yaml", "Default tools configuration to write")
+	flag.Parse()
+
+	// Handlers are not called, so the registry needs no RBAC manager
+	r := catalog.New(nil)
+
+	if err := os.WriteFile(*docsPath, []byte(toolsMarkdown(r)), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "gentools:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*configPath, []byte(toolsConfigYAML(r)), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "gentools:", err)
+		os.Exit(1)
+	}
+}
+
+// toolsMarkdown renders the tool reference: an index of domains, then one table per domain
+func toolsMarkdown(r *tools.Registry) string {
+	var b strings.Builder
+	b.WriteString("<!-- Code generated by cmd/gentools; DO NOT EDIT. -->\n\n")
+	b.WriteString("# Tools\n\n")
+	fmt.Fprintf(&b, "KanboardMCP registers %d tools in %d domains. Domains are the keys of\n", len(r.Tools()), len(r.Domains()))
+	b.WriteString("`mcp-tools-config.yaml`; the `" + tools.CoreDomain + "` pseudo-domain holds the tools marked *core*.\n\n")
+	b.WriteString("Access is *read* for tools that never change Kanboard data, *write* for tools that do and\n")
+	b.WriteString("*destructive* for tools that remove data. Read-only mode (`--read-only`) exposes only *read* tools.\n\n")
+
+	b.WriteString("| Domain | Description | Tools |\n|---|---|---|\n")
+	for _, domain := range r.Domains() {
+		fmt.Fprintf(&b, "| [%s](#%s) | %s | %d |\n", domain.Name, anchor(domain.Name), domain.Description, len(r.DomainTools(domain.Name)))
+	}
+
+	for _, domain := range r.Domains() {
+		fmt.Fprintf(&b, "\n## %s\n\n%s.\n\n", domain.Name, domain.Description)
+		b.WriteString("| Tool | Description | Parameters | Access | Kanboard method |\n|---|---|---|---|---|\n")
+		for _, tool := range r.DomainTools(domain.Name) {
+			name := "`" + tool.Name() + "`"
+			if tool.Core {
+				name += " *core*"
+			}
+			method := "-"
+			if tool.Method != "" {
+				method = "`" + tool.Method + "`"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", name, cell(tool.Definition.Description), parameters(tool), access(tool), method)
+		}
+	}
+	return b.String()
+}
+
+// toolsConfigYAML renders the default configuration: the core tools enabled, every
+// domain listed with all of its tools but commented out
+func toolsConfigYAML(r *tools.Registry) string {
+	var b strings.Builder
+	b.WriteString(`# MCP Tools Configuration
+# Generated by cmd/gentools from the tool registry, see docs/TOOLS.md for every tool.
+# This file controls which tools are exposed by the Kanboard MCP server.
+#
+# Each key is a tool domain. An enabled domain without a "tools" list exposes all of
+# its tools; a "tools" list narrows it to the listed tools. Without this file every
+# tool is exposed. The 'tool_search' tool is always enabled.
+`)
+
+	fmt.Fprintf(&b, "\n# Domain: %s\n# The tools most assistants need to work with tasks, files and comments\n", tools.CoreDomain)
+	fmt.Fprintf(&b, "%s:\n  enabled: true\n  tools:\n", tools.CoreDomain)
+	for _, tool := range r.CoreTools() {
+		fmt.Fprintf(&b, "    - %s\n", tool.Name())
+	}
+
+	b.WriteString("\n# All other domains are disabled (commented out)\n# Uncomment and enable domains as needed\n")
+	for _, domain := range r.Domains() {
+		var names []string
+		for _, tool := range r.DomainTools(domain.Name) {
+			if !tool.AlwaysEnabled {
+				names = append(names, tool.Name())
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n# # Domain: %s\n# # %s\n# %s:\n#   enabled: false\n#   tools:\n", domain.Name, domain.Description, domain.Name)
+		for _, name := range names {
+			fmt.Fprintf(&b, "#     - %s\n", name)
+		}
+	}
+	return b.String()
+}
+
+func access(tool *tools.Tool) string {
+	switch {
+	case tool.ReadOnly:
+		return "read"
+	case tool.Destructive:
+		return "destructive"
+	default:
+		return "write"
+	}
+}
+
+// parameters lists the input schema properties, required ones first and in bold
+func parameters(tool *tools.Tool) string {
+	schema := tool.Definition.InputSchema
+	var required, optional []string
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		if slices.Contains(schema.Required, name) {
+			required = append(required, "**"+name+"**")
+		} else {
+			optional = append(optional, name)
+		}
+	}
+	if len(required)+len(optional) == 0 {
+		return "-"
+	}
+	return strings.Join(append(required, optional...), ", ")
+}
+
+// cell escapes text for a Markdown table cell
+func cell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func anchor(heading string) string {
+	return strings.ToLower(strings.ReplaceAll(heading, " ", "-"))
+}
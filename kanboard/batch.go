@@ -0,0 +1,232 @@
+package kanboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"kanboard-mcp/logging"
+	"kanboard-mcp/metrics"
+	"kanboard-mcp/tracing"
+)
+
+// BatchCall is one method call inside a JSON-RPC batch
+type BatchCall struct {
+	Method string
+	Params interface{}
+}
+
+// BatchResult is the outcome of one call of a batch, in the order the calls were given
+type BatchResult struct {
+	Result interface{}
+	Err    error
+}
+
+// BatchObject decodes the result of a single-object lookup in a batch, see DecodeObject
+func BatchObject[T any](r BatchResult, kind string, id int) (*T, error) {
+	if r.Err != nil {
+		return nil, fmt.Errorf("failed to load %s %d: %w", kind, id, r.Err)
+	}
+	return DecodeObject[T](r.Result, kind, id)
+}
+
+// BatchList decodes the result of a list method in a batch, with the call's error wrapped in the method name
+func BatchList[T any](r BatchResult, method string) ([]T, error) {
+	if r.Err != nil {
+		return nil, fmt.Errorf("%s failed: %w", method, r.Err)
+	}
+	return DecodeList[T](r.Result, method)
+}
+
+func (kc *Client) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	return kc.CallBatchWithConfig(ctx, calls, DefaultRequestConfig())
+}
+
+// CallBatchWithConfig sends independent calls to Kanboard in a single POST.
+// The returned error is set only when the batch as a whole failed; failures of
+// individual calls are reported in their BatchResult.
+func (kc *Client) CallBatchWithConfig(ctx context.Context, calls []BatchCall, config *RequestConfig) ([]BatchResult, error) {
+	if config == nil {
+		config = DefaultRequestConfig()
+	}
+
+	results := make([]BatchResult, len(calls))
+	if len(calls) == 0 {
+		return results, nil
+	}
+
+	started := time.Now()
+	ctx, batchSpan := tracing.Default.Start(ctx, "callKanboardBatch", tracing.KindInternal)
+	defer batchSpan.End()
+	batchSpan.SetAttribute("rpc.system", "jsonrpc")
+	batchSpan.SetAttribute("kanboard.batch_size", len(calls))
+
+	// Read-only and dry-run apply per call, only the remaining calls are sent
+	recorder := DryRunRecorderFromContext(ctx)
+	var pending []int
+	for i, call := range calls {
+		switch {
+		case call.Method == "":
+			results[i].Err = fmt.Errorf("method cannot be empty")
+		case kc.ReadOnly && IsMutatingMethod(call.Method):
+			err := fmt.Errorf("read-only mode: Kanboard method %s is not allowed", call.Method)
+			results[i].Err = err
+			observeCall(ctx, call.Method, "blocked", err, started)
+		case recorder != nil && IsMutatingMethod(call.Method):
+			results[i].Result, results[i].Err = recorder.record(kc.apiEndpoint, call.Method, call.Params)
+			observeCall(ctx, call.Method, "dry_run", nil, started)
+		default:
+			if cached, ok := kc.Responses.get(kc.username, call.Method, call.Params); ok {
+				results[i].Result = cached
+				observeCall(ctx, call.Method, "cached", nil, started)
+				continue
+			}
+			if IsMutatingMethod(call.Method) {
+				defer kc.Responses.invalidateFor(call.Method, call.Params)
+			}
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	methods := make([]string, len(pending))
+	for j, i := range pending {
+		methods[j] = calls[i].Method
+	}
+	label := "batch[" + strings.Join(methods, ",") + "]"
+	batchSpan.SetAttribute("rpc.method", label)
+
+	// A batch is only as safe to repeat as its least idempotent call
+	idempotent := true
+	for _, method := range methods {
+		idempotent = idempotent && isIdempotentCall(method, config)
+	}
+
+	var lastErr error
+	attempts := 0
+	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay, ok := retryDelay(config, attempt, lastErr)
+			if !ok {
+				break
+			}
+			for _, method := range methods {
+				metrics.Default.JSONRPCRetries.Inc(method)
+			}
+			logging.HTTP.WarnContext(ctx, "retrying Kanboard API batch", "methods", methods, "attempt", attempt+1, "max_attempts", config.MaxRetries+1, "delay", delay, "error_kind", errorKind(lastErr), "error", lastErr)
+			select {
+			case <-ctx.Done():
+				lastErr = ctx.Err()
+				batchSpan.RecordError(lastErr)
+				kc.observeBatchFailure(ctx, methods, lastErr, started)
+				return nil, lastErr
+			case <-time.After(delay):
+			}
+		}
+		attempts++
+
+		attemptCtx, attemptSpan := tracing.Default.Start(ctx, "POST "+label, tracing.KindClient)
+		attemptSpan.SetAttribute("rpc.method", label)
+		attemptSpan.SetAttribute("kanboard.attempt", attempt+1)
+		var responses []BatchResult
+		done, err := kc.guard(attemptCtx, methods...)
+		if err == nil {
+			responses, err = kc.executeBatchRequest(attemptCtx, label, calls, pending, config)
+			done(err)
+		}
+		attemptSpan.RecordError(err)
+		attemptSpan.End()
+		if err == nil {
+			for j, i := range pending {
+				results[i] = responses[j]
+				if responses[j].Err == nil {
+					kc.Responses.set(kc.username, calls[i].Method, calls[i].Params, responses[j].Result)
+				}
+				status := "ok"
+				if responses[j].Err != nil {
+					status = "error"
+				}
+				metrics.Default.ObserveJSONRPC(calls[i].Method, responses[j].Err, started)
+				observeCall(ctx, calls[i].Method, status, responses[j].Err, started)
+			}
+			return results, nil
+		}
+
+		lastErr = err
+
+		// Only transient failures are retried, and writes only when repeating them is safe
+		if !isRetryableError(err, idempotent) {
+			break
+		}
+	}
+
+	err := retriesExhaustedError("API batch", attempts, lastErr)
+	batchSpan.RecordError(err)
+	kc.observeBatchFailure(ctx, methods, err, started)
+	return nil, err
+}
+
+// observeBatchFailure records every call of a failed batch in the metrics and the audit log
+func (kc *Client) observeBatchFailure(ctx context.Context, methods []string, err error, started time.Time) {
+	for _, method := range methods {
+		metrics.Default.ObserveJSONRPC(method, err, started)
+		observeCall(ctx, method, "error", err, started)
+	}
+}
+
+// executeBatchRequest posts the pending calls as one JSON-RPC batch and matches the responses by ID
+func (kc *Client) executeBatchRequest(ctx context.Context, label string, calls []BatchCall, pending []int, config *RequestConfig) ([]BatchResult, error) {
+	requests := make([]map[string]interface{}, len(pending))
+	for j, i := range pending {
+		requests[j] = map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  calls[i].Method,
+			"id":      j + 1,
+			"params":  calls[i].Params,
+		}
+	}
+	jsonBody, err := json.Marshal(requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch request body: %w", err)
+	}
+
+	if logging.HTTP.Enabled(ctx, slog.LevelDebug) {
+		logging.HTTP.DebugContext(ctx, "API batch request", "endpoint", kc.apiEndpoint, "body", logging.RedactJSON(jsonBody))
+	}
+
+	body, err := kc.postJSONRPC(ctx, label, jsonBody, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []APIResponse
+	if err := json.Unmarshal(body, &responses); err != nil {
+		// Errors affecting the whole batch come back as a single response object
+		var single APIResponse
+		if json.Unmarshal(body, &single) == nil && single.Error != nil {
+			return nil, newJSONRPCError(single.Error)
+		}
+		return nil, &Error{Kind: ErrorKindProtocol, Message: "failed to decode API batch response", Err: err}
+	}
+
+	byID := make(map[int]APIResponse, len(responses))
+	for _, response := range responses {
+		byID[response.ID] = response
+	}
+
+	results := make([]BatchResult, len(pending))
+	for j, i := range pending {
+		response, ok := byID[j+1]
+		if !ok {
+			results[j].Err = &Error{Kind: ErrorKindProtocol, Message: fmt.Sprintf("no response from Kanboard for %s", calls[i].Method)}
+			continue
+		}
+		results[j].Result, results[j].Err = apiResponseResult(response)
+	}
+	return results, nil
+}
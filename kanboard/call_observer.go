@@ -0,0 +1,26 @@
+package kanboard
+
+import (
+	"context"
+	"time"
+)
+
+// CallObserver is told about every Kanboard API call made with its context,
+// the audit log uses it to list the calls behind a tool call
+type CallObserver interface {
+	ObserveCall(method, status string, err error, duration time.Duration)
+}
+
+type callObserverContextKey struct{}
+
+// WithCallObserver returns a context whose Kanboard API calls are reported to observer
+func WithCallObserver(ctx context.Context, observer CallObserver) context.Context {
+	return context.WithValue(ctx, callObserverContextKey{}, observer)
+}
+
+// observeCall reports a Kanboard API call to the context's observer, if any
+func observeCall(ctx context.Context, method, status string, err error, started time.Time) {
+	if observer, ok := ctx.Value(callObserverContextKey{}).(CallObserver); ok {
+		observer.ObserveCall(method, status, err, time.Since(started))
+	}
+}
@@ -0,0 +1,376 @@
+package kanboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kanboard-mcp/logging"
+)
+
+// cassetteVersion is the format version written to cassette files
+const cassetteVersion = 1
+
+// CassetteInteraction is one recorded JSON-RPC call and Kanboard's answer
+type CassetteInteraction struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *APIError       `json:"error,omitempty"`
+}
+
+type cassetteFile struct {
+	Version      int                   `json:"version"`
+	Endpoint     string                `json:"endpoint,omitempty"`
+	RecordedAt   time.Time             `json:"recorded_at"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// Cassette records the JSON-RPC traffic with Kanboard to a file, or replays a recording
+// without a live Kanboard. A nil cassette does neither.
+//
+// Replay matches calls on the method and the normalized params: keys are sorted, secrets
+// redacted, numeric strings compared as numbers and empty params treated alike. Calls
+// with the same key are answered in the order they were recorded, the last answer being
+// repeated once they run out. Calls that were never recorded fail and are listed by
+// Unmatched.
+type Cassette struct {
+	path      string
+	replaying bool
+
+	mu        sync.Mutex
+	file      cassetteFile
+	recorded  map[string][]int // interaction indexes by match key, for replay
+	played    map[string]int
+	unmatched []CassetteInteraction
+}
+
+// RecordCassette starts a new recording at path, replacing any previous one
+func RecordCassette(path, endpoint string) (*Cassette, error) {
+	c := &Cassette{path: path, file: cassetteFile{
+		Version:      cassetteVersion,
+		Endpoint:     redactEndpoint(endpoint),
+		RecordedAt:   time.Now().UTC(),
+		Interactions: []CassetteInteraction{},
+	}}
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ReplayCassette loads the recording at path to answer calls in place of Kanboard
+func ReplayCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	c := &Cassette{path: path, replaying: true, recorded: make(map[string][]int), played: make(map[string]int)}
+	if err := json.Unmarshal(data, &c.file); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	if c.file.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s (expected %d)", c.file.Version, path, cassetteVersion)
+	}
+	for i, interaction := range c.file.Interactions {
+		key := cassetteKey(interaction.Method, interaction.Params)
+		c.recorded[key] = append(c.recorded[key], i)
+	}
+	return c, nil
+}
+
+// NewCassetteFromEnv records to KANBOARD_RECORD or replays KANBOARD_REPLAY; nil when neither is set
+func NewCassetteFromEnv(endpoint string) (*Cassette, error) {
+	record, replay := os.Getenv("KANBOARD_RECORD"), os.Getenv("KANBOARD_REPLAY")
+	switch {
+	case record != "" && replay != "":
+		return nil, fmt.Errorf("KANBOARD_RECORD and KANBOARD_REPLAY are mutually exclusive")
+	case record != "":
+		return RecordCassette(record, endpoint)
+	case replay != "":
+		return ReplayCassette(replay)
+	}
+	return nil, nil
+}
+
+// Replaying reports whether calls are answered from the cassette instead of Kanboard
+func (c *Cassette) Replaying() bool {
+	return c != nil && c.replaying
+}
+
+// Interactions returns the calls recorded so far, or loaded for replay
+func (c *Cassette) Interactions() []CassetteInteraction {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CassetteInteraction(nil), c.file.Interactions...)
+}
+
+// Unmatched returns the replayed calls the cassette had no answer for
+func (c *Cassette) Unmatched() []CassetteInteraction {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CassetteInteraction(nil), c.unmatched...)
+}
+
+// cassetteRequest is a JSON-RPC request as posted to Kanboard
+type cassetteRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// cassetteResponse is a JSON-RPC response, kept raw so results are recorded exactly
+type cassetteResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *APIError       `json:"error,omitempty"`
+}
+
+// record stores the calls of a request or batch with the responses Kanboard gave.
+// Failing to write the cassette is logged, the call itself has succeeded.
+func (c *Cassette) record(requestBody, responseBody []byte) {
+	if c == nil || c.replaying {
+		return
+	}
+	requests, batch, err := decodeCassetteRequests(requestBody)
+	if err != nil {
+		logging.HTTP.Warn("failed to record Kanboard call", "cassette", c.path, "error", err)
+		return
+	}
+
+	var responses []cassetteResponse
+	if batch {
+		err = json.Unmarshal(responseBody, &responses)
+	} else {
+		responses = make([]cassetteResponse, 1)
+		err = json.Unmarshal(responseBody, &responses[0])
+	}
+	if err != nil {
+		// Not JSON-RPC: parsing the response reports it, there is nothing to replay
+		return
+	}
+	byID := make(map[string]cassetteResponse, len(responses))
+	for _, response := range responses {
+		byID[string(response.ID)] = response
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, request := range requests {
+		response, ok := byID[string(request.ID)]
+		if !batch && len(responses) == 1 {
+			// Kanboard echoes the ID of single requests, but nothing depends on it
+			response, ok = responses[0], true
+		}
+		if !ok {
+			continue
+		}
+		c.file.Interactions = append(c.file.Interactions, CassetteInteraction{
+			Method: request.Method,
+			Params: redactParamsJSON(request.Method, request.Params),
+			Result: redactJSON(response.Result, logging.Redact),
+			Error:  response.Error,
+		})
+	}
+	if err := c.save(); err != nil {
+		logging.HTTP.Warn("failed to write cassette", "cassette", c.path, "error", err)
+	}
+}
+
+// replay answers a request or batch from the cassette, as Kanboard would have.
+// When any call was never recorded nothing is answered and the error names the calls.
+func (c *Cassette) replay(requestBody []byte) ([]byte, error) {
+	requests, batch, err := decodeCassetteRequests(requestBody)
+	if err != nil {
+		return nil, &Error{Kind: ErrorKindValidation, Unprocessed: true, Message: "replay: invalid request", Err: err}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	responses := make([]cassetteResponse, len(requests))
+	var missing []string
+	for i, request := range requests {
+		key := cassetteKey(request.Method, request.Params)
+		indexes := c.recorded[key]
+		if len(indexes) == 0 {
+			interaction := CassetteInteraction{Method: request.Method, Params: redactParamsJSON(request.Method, request.Params)}
+			c.unmatched = append(c.unmatched, interaction)
+			missing = append(missing, describeInteraction(interaction))
+			logging.HTTP.Warn("no recorded response for Kanboard call", "cassette", c.path, "method", request.Method, "params", string(interaction.Params))
+			continue
+		}
+		index := indexes[min(c.played[key], len(indexes)-1)]
+		c.played[key]++
+		interaction := c.file.Interactions[index]
+		responses[i] = cassetteResponse{Jsonrpc: "2.0", ID: request.ID, Result: interaction.Result, Error: interaction.Error}
+		if interaction.Error == nil && interaction.Result == nil {
+			responses[i].Result = json.RawMessage("null")
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &Error{Kind: ErrorKindNotFound, Unprocessed: true,
+			Message: fmt.Sprintf("replay: cassette %s has no recorded response for %s", c.path, strings.Join(missing, ", "))}
+	}
+
+	if batch {
+		return json.Marshal(responses)
+	}
+	return json.Marshal(responses[0])
+}
+
+// save rewrites the cassette file, through a temporary file so a crash never leaves it truncated
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(c.file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	temp := c.path + ".tmp"
+	if err := os.WriteFile(temp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(temp, c.path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// decodeCassetteRequests splits a posted body into its calls, reporting whether it was a batch
+func decodeCassetteRequests(body []byte) ([]cassetteRequest, bool, error) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []cassetteRequest
+		err := json.Unmarshal(trimmed, &requests)
+		return requests, true, err
+	}
+	var request cassetteRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, false, err
+	}
+	return []cassetteRequest{request}, false, nil
+}
+
+func describeInteraction(interaction CassetteInteraction) string {
+	if len(interaction.Params) == 0 {
+		return interaction.Method + "()"
+	}
+	return interaction.Method + string(interaction.Params)
+}
+
+// cassetteKey identifies a call for replay by its method and normalized params
+func cassetteKey(method string, params json.RawMessage) string {
+	value, ok := decodeJSON(params)
+	if !ok {
+		return method + " " + string(params)
+	}
+	encoded, _ := json.Marshal(normalizeParams(logging.RedactParams(method, value)))
+	return method + " " + string(encoded)
+}
+
+// normalizeParams makes equivalent params encode the same: numbers and numeric strings
+// become int64 or float64 and empty params null. Maps are sorted when encoded.
+func normalizeParams(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+		for key, item := range v {
+			v[key] = normalizeParam(item)
+		}
+		return v
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, item := range v {
+			v[i] = normalizeParam(item)
+		}
+		return v
+	}
+	return normalizeParam(value)
+}
+
+func normalizeParam(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		return normalizeNumber(string(v), value)
+	case string:
+		return normalizeNumber(v, value)
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeParam(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeParam(item)
+		}
+	}
+	return value
+}
+
+func normalizeNumber(s string, fallback any) any {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && strconv.FormatInt(n, 10) == s {
+		return n
+	}
+	if _, isNumber := fallback.(json.Number); isNumber {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+// redactParamsJSON redacts the encoded params of a call to method, see redactJSON
+func redactParamsJSON(method string, params json.RawMessage) json.RawMessage {
+	return redactJSON(params, func(value any) any { return logging.RedactParams(method, value) })
+}
+
+// redactJSON replaces the secrets in an encoded value with redact, logging.Redact or
+// logging.RedactParams, so cassettes follow the same policy as the logs
+func redactJSON(raw json.RawMessage, redact func(any) any) json.RawMessage {
+	value, ok := decodeJSON(raw)
+	if !ok || value == nil {
+		return raw
+	}
+	encoded, err := json.Marshal(redact(value))
+	if err != nil {
+		return raw
+	}
+	return encoded
+}
+
+// decodeJSON decodes raw keeping numbers exact; empty input is not a value
+func decodeJSON(raw json.RawMessage) (any, bool) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, true
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// redactEndpoint drops credentials embedded in the endpoint URL
+func redactEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.User == nil {
+		return endpoint
+	}
+	u.User = nil
+	return u.String()
+}
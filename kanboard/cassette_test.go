@@ -0,0 +1,106 @@
+package kanboard
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kanboard-mcp/kanboard/kanboardtest"
+)
+
+func TestCassetteKeyNormalizesParams(t *testing.T) {
+	same := [][2]string{
+		{`{"task_id":1}`, `{"task_id":"1"}`},
+		{`{"project_id":1,"title":"A"}`, `{"title":"A","project_id":1.0}`},
+		{`{}`, `[]`},
+		{`{}`, ``},
+		{`{"password":"one"}`, `{"password":"two"}`},
+		{`{"api_access_token":"one"}`, `{"api_access_token":"two"}`},
+		{`{"filename":"a.txt","blob":"QUJD"}`, `{"filename":"a.txt","blob":"REVG"}`},
+	}
+	for _, pair := range same {
+		if a, b := cassetteKey("m", json.RawMessage(pair[0])), cassetteKey("m", json.RawMessage(pair[1])); a != b {
+			t.Errorf("%s and %s should match: %s != %s", pair[0], pair[1], a, b)
+		}
+	}
+	different := [][2]string{
+		{`{"task_id":1}`, `{"task_id":2}`},
+		{`{"reference":"007"}`, `{"reference":"7"}`},
+		{`[1,2]`, `[2,1]`},
+		// Secrets are found by the names the logs redact, not by substrings
+		{`{"tokens":1}`, `{"tokens":2}`},
+	}
+	for _, pair := range different {
+		if cassetteKey("m", json.RawMessage(pair[0])) == cassetteKey("m", json.RawMessage(pair[1])) {
+			t.Errorf("%s and %s should not match", pair[0], pair[1])
+		}
+	}
+}
+
+func TestCassetteRecordsAndReplaysBatches(t *testing.T) {
+	kb := kanboardtest.NewServer()
+	defer kb.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+	calls := []BatchCall{
+		{Method: "getTask", Params: map[string]interface{}{"task_id": 1}},
+		{Method: "getColumn", Params: map[string]interface{}{"column_id": 99}},
+		{Method: "createUser", Params: map[string]interface{}{"username": "eve", "password": "eve-secret"}},
+	}
+
+	client := NewClient("http://jsonrpc:"+kanboardtest.Token+"@"+strings.TrimPrefix(kb.Endpoint(), "http://"), kanboardtest.Token, "", "")
+	var err error
+	client.Cassette, err = RecordCassette(path, client.apiEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := client.CallBatch(ctx, calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Call(ctx, "getVersion", nil); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"eve-secret", kanboardtest.Token} {
+		if strings.Contains(string(content), secret) {
+			t.Fatalf("the cassette holds %q:\n%s", secret, content)
+		}
+	}
+
+	// Kanboard is gone, and the calls are replayed in another order with equivalent params
+	kb.Close()
+	replayer, err := ReplayCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Cassette = replayer
+	replayed, err := client.CallBatch(ctx, []BatchCall{calls[2], calls[1], {Method: "getTask", Params: map[string]interface{}{"task_id": "1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, j := range []int{2, 1, 0} {
+		want, _ := json.Marshal(recorded[j].Result)
+		got, _ := json.Marshal(replayed[i].Result)
+		if string(got) != string(want) {
+			t.Errorf("%s replayed %s, recorded %s", calls[j].Method, got, want)
+		}
+	}
+	if version, err := client.Call(ctx, "getVersion", map[string]interface{}{}); err != nil || version != "1.2.35" {
+		t.Errorf("getVersion replayed %v, %v", version, err)
+	}
+
+	_, err = client.CallBatch(ctx, []BatchCall{calls[0], {Method: "getTask", Params: map[string]interface{}{"task_id": 2}}})
+	if err == nil || !strings.Contains(err.Error(), `no recorded response for getTask{"task_id":2}`) {
+		t.Fatalf("expected an unmatched call error, got %v", err)
+	}
+	if unmatched := replayer.Unmatched(); len(unmatched) != 1 {
+		t.Fatalf("unmatched calls: %+v", unmatched)
+	}
+}
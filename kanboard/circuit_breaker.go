@@ -0,0 +1,211 @@
+package kanboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"kanboard-mcp/logging"
+)
+
+// Circuit breaker defaults when KANBOARD_BREAKER_FAILURES / KANBOARD_BREAKER_COOLDOWN are not set
+const (
+	defaultBreakerFailures = 5
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// CircuitBreaker stops sending requests to Kanboard after repeated failures.
+// After the cooldown a single probe request is let through: success closes the
+// breaker, failure opens it again.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu          sync.Mutex
+	state       string
+	failures    int
+	openedAt    time.Time
+	probing     bool
+	lastFailure string
+
+	now func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, state: BreakerClosed, now: time.Now}
+}
+
+// NewCircuitBreakerFromEnv builds the breaker; KANBOARD_BREAKER_FAILURES=0 disables it
+func NewCircuitBreakerFromEnv() (*CircuitBreaker, error) {
+	threshold := defaultBreakerFailures
+	if value := os.Getenv("KANBOARD_BREAKER_FAILURES"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid KANBOARD_BREAKER_FAILURES: %q (expected a non-negative integer)", value)
+		}
+		threshold = n
+	}
+	if threshold == 0 {
+		return nil, nil
+	}
+
+	cooldown := defaultBreakerCooldown
+	if value := os.Getenv("KANBOARD_BREAKER_COOLDOWN"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid KANBOARD_BREAKER_COOLDOWN: %q (expected a duration such as 30s)", value)
+		}
+		cooldown = d
+	}
+	return newCircuitBreaker(threshold, cooldown), nil
+}
+
+// allow reports whether a request may be sent, moving an expired open breaker to half-open.
+// A nil breaker allows everything.
+func (b *CircuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return b.unavailableError()
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		logging.HTTP.Info("circuit breaker half-open, probing Kanboard")
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return b.unavailableError()
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request let through by allow.
+// Only failures that say Kanboard is unreachable or overloaded count; a validation
+// or permission error proves the server is up.
+func (b *CircuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	var kbErr *Error
+	failed := errors.As(err, &kbErr) && kbErr.temporary()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		// An abandoned request says nothing about Kanboard
+		return
+	}
+	if !failed {
+		if b.state != BreakerClosed {
+			logging.HTTP.Info("circuit breaker closed, Kanboard is reachable again")
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	b.lastFailure = err.Error()
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		if b.state != BreakerOpen {
+			logging.HTTP.Warn("circuit breaker opened, failing Kanboard calls fast", "consecutive_failures", b.failures, "cooldown", b.cooldown, "error", err)
+		}
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// OpenError returns the "Kanboard unavailable" error while the breaker is open and cooling down
+func (b *CircuitBreaker) OpenError() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) < b.cooldown {
+		return b.unavailableError()
+	}
+	return nil
+}
+
+// unavailableError must be called with b.mu held
+func (b *CircuitBreaker) unavailableError() error {
+	retryIn := (b.cooldown - b.now().Sub(b.openedAt)).Truncate(time.Second) + time.Second
+	return &Error{
+		Kind: ErrorKindUnavailable,
+		Message: fmt.Sprintf("Kanboard unavailable: %d consecutive requests failed (last error: %s); calls are paused, retry in %s",
+			b.failures, logging.TruncateText(b.lastFailure), retryIn),
+		Unprocessed: true,
+	}
+}
+
+// BreakerStatus is the breaker state reported on /health
+type BreakerStatus struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Threshold           int        `json:"threshold"`
+	Cooldown            string     `json:"cooldown"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+func (b *CircuitBreaker) Status() *BreakerStatus {
+	if b == nil {
+		return &BreakerStatus{State: "disabled"}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := &BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Threshold:           b.threshold,
+		Cooldown:            b.cooldown.String(),
+		LastError:           logging.TruncateText(b.lastFailure),
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt.UTC()
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// guard waits for the rate limits and asks the circuit breaker before an HTTP attempt.
+// The returned function must be called with the attempt's outcome.
+func (kc *Client) guard(ctx context.Context, methods ...string) (func(error), error) {
+	release, err := kc.throttle(ctx, methods...)
+	if err != nil {
+		return nil, err
+	}
+	if err := kc.Breaker.allow(); err != nil {
+		release()
+		return nil, err
+	}
+	return func(err error) {
+		kc.Breaker.record(err)
+		release()
+	}, nil
+}
@@ -0,0 +1,94 @@
+package kanboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	clock := newFakeClock()
+	breaker := newCircuitBreaker(3, 30*time.Second)
+	breaker.now = clock.Now
+
+	down := &Error{Kind: ErrorKindHTTP, StatusCode: http.StatusServiceUnavailable, Message: "HTTP 503"}
+	invalid := &Error{Kind: ErrorKindValidation, StatusCode: http.StatusBadRequest}
+
+	call := func(err error) error {
+		if err := breaker.allow(); err != nil {
+			return err
+		}
+		breaker.record(err)
+		return nil
+	}
+	expectState := func(step, state string) {
+		t.Helper()
+		if got := breaker.Status().State; got != state {
+			t.Fatalf("%s: state %s, want %s", step, got, state)
+		}
+	}
+
+	// Closed: failures below the threshold, and a success resets the count
+	call(down)
+	call(down)
+	call(nil)
+	call(down)
+	call(down)
+	expectState("two failures after a success", BreakerClosed)
+	// Errors that prove Kanboard is up and abandoned requests don't count
+	call(invalid)
+	call(down)
+	call(down)
+	call(context.Canceled)
+	expectState("validation error and cancelled call", BreakerClosed)
+
+	// Open: the threshold is reached and calls fail fast until the cooldown is over
+	call(down)
+	expectState("third consecutive failure", BreakerOpen)
+	clock.Advance(29 * time.Second)
+	err := call(nil)
+	var kbErr *Error
+	if !errors.As(err, &kbErr) || kbErr.Kind != ErrorKindUnavailable || !kbErr.Unprocessed {
+		t.Fatalf("expected an unavailable error while open, got %v", err)
+	}
+	if breaker.OpenError() == nil {
+		t.Fatal("OpenError must report the open breaker")
+	}
+
+	// Half-open: one probe goes through, concurrent calls are refused
+	clock.Advance(time.Second)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("the probe must be let through: %v", err)
+	}
+	expectState("cooldown over", BreakerHalfOpen)
+	if err := breaker.allow(); err == nil {
+		t.Fatal("only one probe may be in flight")
+	}
+	// A failed probe opens the breaker again for a full cooldown
+	breaker.record(down)
+	expectState("failed probe", BreakerOpen)
+	clock.Advance(29 * time.Second)
+	if err := breaker.allow(); err == nil {
+		t.Fatal("a failed probe must restart the cooldown")
+	}
+
+	// A successful probe closes the breaker
+	clock.Advance(time.Second)
+	if err := call(nil); err != nil {
+		t.Fatal(err)
+	}
+	status := breaker.Status()
+	if status.State != BreakerClosed || status.ConsecutiveFailures != 0 || status.OpenedAt != nil {
+		t.Fatalf("unexpected status after a successful probe: %+v", status)
+	}
+	if err := call(nil); err != nil {
+		t.Fatalf("a closed breaker must let calls through: %v", err)
+	}
+
+	var disabled *CircuitBreaker
+	if disabled.allow() != nil || disabled.OpenError() != nil || disabled.Status().State != "disabled" {
+		t.Fatal("a nil breaker must allow everything")
+	}
+}
@@ -0,0 +1,885 @@
+// Package kanboard is the JSON-RPC client for the Kanboard API: retries, rate limits,
+// the circuit breaker, the response cache, name resolution and typed entity getters.
+package kanboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"kanboard-mcp/logging"
+	"kanboard-mcp/metrics"
+	"kanboard-mcp/tracing"
+)
+
+type Client struct {
+	apiEndpoint string
+	apiKey      string
+	username    string
+	password    string
+	// AuthMethod is global_token, user_token or bearer; KANBOARD_AUTH_METHOD applies when empty
+	AuthMethod    string
+	ReadOnly      bool // refuse mutating methods whatever tool issues them
+	HTTPClient    *http.Client
+	RateLimiter   *rateLimiter
+	MethodLimiter *methodLimiter
+	Breaker       *CircuitBreaker
+	Responses     *ResponseCache
+	FuzzyNames    bool      // accept partial names for projects, users, columns, swimlanes, categories and tags
+	Cassette      *Cassette // records the traffic with Kanboard, or replays it instead of calling Kanboard
+}
+
+func NewClient(apiEndpoint, apiKey, username, password string) *Client {
+	return &Client{
+		apiEndpoint: apiEndpoint,
+		apiKey:      apiKey,
+		username:    username,
+		password:    password,
+		HTTPClient:  defaultHTTPClient(),
+	}
+}
+
+// WithUserToken returns a client calling the same Kanboard as username, authenticated
+// with their personal API token (or password). It shares the connection pool, rate
+// limits, circuit breaker, cassette and response cache, in which its entries are kept
+// apart since what Kanboard returns depends on the user.
+func (kc *Client) WithUserToken(username, token string) *Client {
+	clone := *kc
+	clone.username, clone.apiKey, clone.password = username, token, ""
+	clone.AuthMethod = "user_token"
+	return &clone
+}
+
+// Endpoint returns the URL of the Kanboard JSON-RPC API
+func (kc *Client) Endpoint() string {
+	return kc.apiEndpoint
+}
+
+// APIResponse represents the standard Kanboard JSON-RPC response structure
+type APIResponse struct {
+	Jsonrpc string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Result  interface{} `json:"result"`
+	Error   *APIError   `json:"error"`
+}
+
+// APIError represents a Kanboard API error response
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// RequestConfig holds configuration for API requests
+type RequestConfig struct {
+	MaxRetries    int
+	RetryDelay    time.Duration // base delay, doubled on every retry
+	MaxRetryDelay time.Duration // cap for the backoff and the longest Retry-After honoured
+	Timeout       time.Duration
+	// Idempotent marks a write as safe to retry; reads and idempotentWriteMethods always are
+	Idempotent bool
+}
+
+// DefaultRequestConfig returns default configuration for API requests
+func DefaultRequestConfig() *RequestConfig {
+	return &RequestConfig{
+		MaxRetries:    3,
+		RetryDelay:    time.Millisecond * 500,
+		MaxRetryDelay: time.Second * 10,
+		Timeout:       time.Second * 30,
+	}
+}
+
+func (kc *Client) Call(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	logging.HTTP.DebugContext(ctx, "calling Kanboard API", "method", method)
+	return kc.CallWithConfig(ctx, method, params, DefaultRequestConfig())
+}
+
+func (kc *Client) CallWithConfig(ctx context.Context, method string, params interface{}, config *RequestConfig) (interface{}, error) {
+	if config == nil {
+		config = DefaultRequestConfig()
+	}
+
+	started := time.Now()
+	ctx, callSpan := tracing.Default.Start(ctx, "callKanboardAPI "+method, tracing.KindInternal)
+	defer callSpan.End()
+	callSpan.SetAttribute("rpc.system", "jsonrpc")
+	callSpan.SetAttribute("rpc.method", method)
+
+	// Last line of defence for read-only mode, whatever tool issued the call
+	if kc.ReadOnly && IsMutatingMethod(method) {
+		err := fmt.Errorf("read-only mode: Kanboard method %s is not allowed", method)
+		callSpan.RecordError(err)
+		observeCall(ctx, method, "blocked", err, started)
+		return nil, err
+	}
+
+	// Dry runs record mutating calls instead of sending them
+	if recorder := DryRunRecorderFromContext(ctx); recorder != nil && IsMutatingMethod(method) {
+		callSpan.SetAttribute("kanboard.dry_run", true)
+		observeCall(ctx, method, "dry_run", nil, started)
+		return recorder.record(kc.apiEndpoint, method, params)
+	}
+
+	// Reference data is served from the response cache, writes invalidate it once sent
+	if cached, ok := kc.Responses.get(kc.username, method, params); ok {
+		callSpan.SetAttribute("kanboard.cache_hit", true)
+		observeCall(ctx, method, "cached", nil, started)
+		return cached, nil
+	}
+	if IsMutatingMethod(method) {
+		defer kc.Responses.invalidateFor(method, params)
+	}
+
+	idempotent := isIdempotentCall(method, config)
+	var lastErr error
+	attempts := 0
+	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay, ok := retryDelay(config, attempt, lastErr)
+			if !ok {
+				break
+			}
+			metrics.Default.JSONRPCRetries.Inc(method)
+			logging.HTTP.WarnContext(ctx, "retrying Kanboard API call", "method", method, "attempt", attempt+1, "max_attempts", config.MaxRetries+1, "delay", delay, "error_kind", errorKind(lastErr), "error", lastErr)
+			select {
+			case <-ctx.Done():
+				callSpan.RecordError(ctx.Err())
+				metrics.Default.ObserveJSONRPC(method, ctx.Err(), started)
+				observeCall(ctx, method, "error", ctx.Err(), started)
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		attempts++
+
+		attemptCtx, attemptSpan := tracing.Default.Start(ctx, "POST "+method, tracing.KindClient)
+		attemptSpan.SetAttribute("rpc.method", method)
+		attemptSpan.SetAttribute("kanboard.attempt", attempt+1)
+		var result interface{}
+		done, err := kc.guard(attemptCtx, method)
+		if err == nil {
+			result, err = kc.executeAPIRequest(attemptCtx, method, params, config)
+			done(err)
+		}
+		attemptSpan.RecordError(err)
+		attemptSpan.End()
+		if err == nil {
+			kc.Responses.set(kc.username, method, params, result)
+			metrics.Default.ObserveJSONRPC(method, nil, started)
+			observeCall(ctx, method, "ok", nil, started)
+			return result, nil
+		}
+
+		lastErr = err
+
+		// Only transient failures are retried, and writes only when repeating them is safe
+		if !isRetryableError(err, idempotent) {
+			break
+		}
+	}
+
+	err := retriesExhaustedError("API call", attempts, lastErr)
+	callSpan.RecordError(err)
+	metrics.Default.ObserveJSONRPC(method, err, started)
+	observeCall(ctx, method, "error", err, started)
+	return nil, err
+}
+
+func (kc *Client) executeAPIRequest(ctx context.Context, method string, params interface{}, config *RequestConfig) (interface{}, error) {
+	// Validate inputs
+	if method == "" {
+		return nil, fmt.Errorf("method cannot be empty")
+	}
+
+	jsonBody, err := newJSONRPCRequestBody(method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if logging.HTTP.Enabled(ctx, slog.LevelDebug) {
+		logging.HTTP.DebugContext(ctx, "API request", "endpoint", kc.apiEndpoint, "body", logging.RedactJSON(jsonBody))
+	}
+
+	body, err := kc.postJSONRPC(ctx, method, jsonBody, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	return kc.parseAPIResponse(bytes.NewReader(body), config)
+}
+
+// postJSONRPC sends an encoded JSON-RPC request or batch and returns the response body.
+// label names the call in log lines: the method, or a summary of the batch.
+func (kc *Client) postJSONRPC(ctx context.Context, label string, jsonBody []byte, config *RequestConfig) ([]byte, error) {
+	// Replays never reach Kanboard, nor need credentials
+	if kc.Cassette.Replaying() {
+		logging.HTTP.DebugContext(ctx, "replaying API call", "method", label)
+		return kc.Cassette.replay(jsonBody)
+	}
+
+	// The client is shared, so the per-request timeout lives on the context
+	parent := ctx
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, "POST", kc.apiEndpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "KanboardMCP/1.0")
+
+	// Set authentication
+	if err := kc.setAuthentication(req); err != nil {
+		logging.HTTP.DebugContext(ctx, "authentication setup failed", "error", err)
+		return nil, &Error{Kind: ErrorKindAuth, Unprocessed: true, Err: err}
+	}
+
+	// Propagate the trace to Kanboard
+	tracing.InjectTraceparent(ctx, req)
+
+	// Execute request
+	logging.HTTP.DebugContext(ctx, "making API call", "method", label)
+
+	resp, err := kc.HTTPClient.Do(req)
+	if err != nil {
+		logging.HTTP.DebugContext(ctx, "HTTP request failed", "method", label, "error", err)
+		return nil, newTransportError(parent, err)
+	}
+
+	logging.HTTP.DebugContext(ctx, "HTTP response", "method", label, "status", resp.Status, "headers", resp.Header)
+	tracing.SpanFromContext(ctx).SetAttribute("http.response.status_code", resp.StatusCode)
+
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			logging.HTTP.WarnContext(ctx, "failed to close response body", "error", closeErr)
+		}
+	}()
+
+	// Handle HTTP status errors
+	if err := kc.handleHTTPStatus(resp); err != nil {
+		logging.HTTP.DebugContext(ctx, "HTTP status error", "method", label, "error", err)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newTransportError(parent, fmt.Errorf("failed to read API response: %w", err))
+	}
+	kc.Cassette.record(jsonBody, body)
+	return body, nil
+}
+
+func (kc *Client) setAuthentication(req *http.Request) error {
+	// Determine authentication method based on available credentials
+	authMethod := kc.authMethod()
+
+	// Priority: API key over username/password
+	if kc.isValidAPIKey() {
+		switch authMethod {
+		case "global_token", "":
+			// Default: Global API token (Kanboard application token)
+			// Format: jsonrpc:<global-token>
+			auth := "jsonrpc:" + kc.apiKey
+			basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+			req.Header.Set("Authorization", basicAuth)
+			logging.HTTP.DebugContext(req.Context(), "using global API token auth (jsonrpc:token)")
+			return nil
+
+		case "user_token":
+			// User-specific API token (limited access)
+			// Format: <username>:<user-token>
+			if kc.username == "" || kc.username == "your-kanboard-username" {
+				return fmt.Errorf("KANBOARD_AUTH_METHOD=user_token requires KANBOARD_USERNAME to be set")
+			}
+			auth := kc.username + ":" + kc.apiKey
+			basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+			req.Header.Set("Authorization", basicAuth)
+			logging.HTTP.DebugContext(req.Context(), "using user API token auth", "username", kc.username)
+			return nil
+
+		case "bearer":
+			// Bearer token authentication
+			req.Header.Set("Authorization", "Bearer "+kc.apiKey)
+			logging.HTTP.DebugContext(req.Context(), "using bearer token auth")
+			return nil
+
+		default:
+			return fmt.Errorf("unsupported KANBOARD_AUTH_METHOD: %s (supported: global_token, user_token, bearer)", authMethod)
+		}
+	}
+
+	// Fallback to username/password authentication
+	if kc.isValidCredentials() {
+		auth := kc.username + ":" + kc.password
+		basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+		req.Header.Set("Authorization", basicAuth)
+		logging.HTTP.DebugContext(req.Context(), "using username/password auth", "username", kc.username)
+		return nil
+	}
+
+	return fmt.Errorf("no valid authentication credentials provided")
+}
+
+func (kc *Client) isValidAPIKey() bool {
+	isValid := kc.apiKey != "" && kc.apiKey != "your-kanboard-api-key"
+	if !isValid {
+		logging.HTTP.Debug("API key is empty or a placeholder", "key_length", len(kc.apiKey))
+	}
+	return isValid
+}
+
+// UsesApplicationToken reports whether requests authenticate with the global application token
+func (kc *Client) UsesApplicationToken() bool {
+	authMethod := kc.authMethod()
+	return (authMethod == "" || authMethod == "global_token") && kc.isValidAPIKey()
+}
+
+// authMethod returns the configured authentication method, lower-cased
+func (kc *Client) authMethod() string {
+	if kc.AuthMethod != "" {
+		return strings.ToLower(strings.TrimSpace(kc.AuthMethod))
+	}
+	return strings.ToLower(strings.TrimSpace(os.Getenv("KANBOARD_AUTH_METHOD")))
+}
+
+// Authentication names how requests authenticate: global_token, user_token, bearer,
+// password or none
+func (kc *Client) Authentication() string {
+	switch {
+	case kc.apiKey != "" && kc.apiKey != "your-kanboard-api-key":
+		if method := kc.authMethod(); method != "" {
+			return method
+		}
+		return "global_token"
+	case kc.isValidCredentials():
+		return "password"
+	}
+	return "none"
+}
+
+func (kc *Client) isValidCredentials() bool {
+	return kc.username != "" && kc.password != "" &&
+		kc.username != "your-kanboard-username" && kc.password != "your-kanboard-password"
+}
+
+func (kc *Client) handleHTTPStatus(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	// Read response body for error details
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newHTTPStatusError(resp, fmt.Sprintf("HTTP %d: %s (failed to read response body: %v)", resp.StatusCode, resp.Status, err))
+	}
+
+	errorMsg := string(bodyBytes)
+	logging.HTTP.DebugContext(resp.Request.Context(), "HTTP error response", "status", resp.Status, "body", errorMsg, "headers", resp.Header)
+
+	// Provide specific guidance for 403 errors
+	if resp.StatusCode == 403 {
+		return newHTTPStatusError(resp, fmt.Sprintf("HTTP 403 Forbidden - %s\nPossible causes:\n1. Invalid API key or credentials\n2. API key doesn't have required permissions\n3. User account is disabled or doesn't have access\n4. Project access restrictions\n\nDebug: Set MCP_LOG_LEVELS=http=debug to see detailed auth info", errorMsg))
+	}
+
+	return newHTTPStatusError(resp, fmt.Sprintf("HTTP %d: %s - %s", resp.StatusCode, resp.Status, errorMsg))
+}
+
+func (kc *Client) parseAPIResponse(body io.Reader, config *RequestConfig) (interface{}, error) {
+	var apiResponse APIResponse
+
+	if err := json.NewDecoder(body).Decode(&apiResponse); err != nil {
+		return nil, &Error{Kind: ErrorKindProtocol, Message: "failed to decode API response", Err: err}
+	}
+
+	return apiResponseResult(apiResponse)
+}
+
+// apiResponseResult extracts the result of a single JSON-RPC response, alone or from a batch
+func apiResponseResult(apiResponse APIResponse) (interface{}, error) {
+	// Check for JSON-RPC protocol errors
+	if apiResponse.Error != nil {
+		return nil, newJSONRPCError(apiResponse.Error)
+	}
+
+	// Validate JSON-RPC response
+	if apiResponse.Jsonrpc != "2.0" {
+		return nil, &Error{Kind: ErrorKindProtocol, Message: fmt.Sprintf("invalid JSON-RPC version: %s", apiResponse.Jsonrpc)}
+	}
+
+	return apiResponse.Result, nil
+}
+
+// newJSONRPCRequestBody encodes the JSON-RPC envelope posted to Kanboard
+func newJSONRPCRequestBody(method string, params interface{}) ([]byte, error) {
+	requestBody := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"id":      generateRequestID(),
+		"params":  params,
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	return jsonBody, nil
+}
+
+func generateRequestID() int {
+	return int(time.Now().UnixNano() % 1000000)
+}
+
+// Helper functions for masking sensitive data in debug output
+func MaskAPIKey(key string) string {
+	if key == "" || key == "your-kanboard-api-key" {
+		return key
+	}
+	if len(key) <= 8 {
+		return "****"
+	}
+	return key[:4] + "****" + key[len(key)-4:]
+}
+
+func MaskPassword(password string) string {
+	if password == "" || password == "your-kanboard-password" {
+		return password
+	}
+	return strings.Repeat("*", len(password))
+}
+
+func (kc *Client) CreateTaskFile(ctx context.Context, projectID, taskID int, filename, blob string) (int, error) {
+	params := []interface{}{projectID, taskID, filename, blob}
+	result, err := kc.Call(ctx, "createTaskFile", params)
+	if err != nil {
+		return 0, err
+	}
+
+	return DecodeID(result, "createTaskFile")
+}
+
+func (kc *Client) GetAllTaskFiles(ctx context.Context, taskID int) ([]TaskFile, error) {
+	params := map[string]interface{}{"task_id": taskID}
+	result, err := kc.Call(ctx, "getAllTaskFiles", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeList[TaskFile](result, "getAllTaskFiles")
+}
+
+func (kc *Client) GetTaskFile(ctx context.Context, fileID int) (*TaskFile, error) {
+	params := []interface{}{fileID}
+	result, err := kc.Call(ctx, "getTaskFile", params)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[TaskFile](result, "task file", fileID)
+}
+
+func (kc *Client) DownloadTaskFile(ctx context.Context, fileID int) (string, error) {
+	params := []interface{}{fileID}
+	result, err := kc.Call(ctx, "downloadTaskFile", params)
+	if err != nil {
+		return "", err
+	}
+
+	if content, ok := result.(string); ok {
+		return content, nil
+	}
+	return "", fmt.Errorf("unexpected result type for DownloadTaskFile: %T", result)
+}
+
+func (kc *Client) RemoveTaskFile(ctx context.Context, fileID int) (bool, error) {
+	params := []interface{}{fileID}
+	result, err := kc.Call(ctx, "removeTaskFile", params)
+	if err != nil {
+		return false, err
+	}
+
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for RemoveTaskFile: %T", result)
+}
+
+func (kc *Client) RemoveAllTaskFiles(ctx context.Context, taskID int) (bool, error) {
+	params := map[string]interface{}{"task_id": taskID}
+	result, err := kc.Call(ctx, "removeAllTaskFiles", params)
+	if err != nil {
+		return false, err
+	}
+
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for RemoveAllTaskFiles: %T", result)
+}
+
+func (kc *Client) GetVersion(ctx context.Context) (string, error) {
+	result, err := kc.Call(ctx, "getVersion", nil)
+	if err != nil {
+		return "", err
+	}
+
+	if version, ok := result.(string); ok {
+		return version, nil
+	}
+	return "", fmt.Errorf("unexpected result type for GetVersion: %T", result)
+}
+
+func (kc *Client) GetTimezone(ctx context.Context) (string, error) {
+	result, err := kc.Call(ctx, "getTimezone", nil)
+	if err != nil {
+		return "", err
+	}
+
+	if timezone, ok := result.(string); ok {
+		return timezone, nil
+	}
+	return "", fmt.Errorf("unexpected result type for GetTimezone: %T", result)
+}
+
+func (kc *Client) GetDefaultTaskColors(ctx context.Context) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, "getDefaultTaskColors", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if colors, ok := result.(map[string]interface{}); ok {
+		return colors, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetDefaultTaskColors: %T", result)
+}
+
+func (kc *Client) GetDefaultTaskColor(ctx context.Context) (string, error) {
+	result, err := kc.Call(ctx, "getDefaultTaskColor", nil)
+	if err != nil {
+		return "", err
+	}
+
+	if colorID, ok := result.(string); ok {
+		return colorID, nil
+	}
+	return "", fmt.Errorf("unexpected result type for GetDefaultTaskColor: %T", result)
+}
+
+func (kc *Client) GetColorList(ctx context.Context) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, "getColorList", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if colorList, ok := result.(map[string]interface{}); ok {
+		return colorList, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetColorList: %T", result)
+}
+
+func (kc *Client) GetApplicationRoles(ctx context.Context) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, "getApplicationRoles", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if roles, ok := result.(map[string]interface{}); ok {
+		return roles, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetApplicationRoles: %T", result)
+}
+
+func (kc *Client) GetProjectRoles(ctx context.Context) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, "getProjectRoles", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if roles, ok := result.(map[string]interface{}); ok {
+		return roles, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetProjectRoles: %T", result)
+}
+
+func (kc *Client) GetAvailableActions(ctx context.Context) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, "getAvailableActions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if actions, ok := result.(map[string]interface{}); ok {
+		return actions, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetAvailableActions: %T", result)
+}
+
+func (kc *Client) GetAvailableActionEvents(ctx context.Context) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, "getAvailableActionEvents", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if events, ok := result.(map[string]interface{}); ok {
+		return events, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetAvailableActionEvents: %T", result)
+}
+
+func (kc *Client) GetCompatibleActionEvents(ctx context.Context, actionName string) (map[string]interface{}, error) {
+	params := []interface{}{actionName}
+	result, err := kc.Call(ctx, "getCompatibleActionEvents", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if events, ok := result.(map[string]interface{}); ok {
+		return events, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetCompatibleActionEvents: %T", result)
+}
+
+func (kc *Client) GetActions(ctx context.Context, projectID int) ([]Action, error) {
+	params := []interface{}{projectID}
+	result, err := kc.Call(ctx, "getActions", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeList[Action](result, "getActions")
+}
+
+func (kc *Client) CreateAction(ctx context.Context, projectID int, eventName, actionName string, params map[string]interface{}) (int, error) {
+	realParams := map[string]interface{}{
+		"project_id":  projectID,
+		"event_name":  eventName,
+		"action_name": actionName,
+		"params":      params,
+	}
+	result, err := kc.Call(ctx, "createAction", realParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return DecodeID(result, "createAction")
+}
+
+func (kc *Client) RemoveAction(ctx context.Context, actionID int) (bool, error) {
+	params := []interface{}{actionID}
+	result, err := kc.Call(ctx, "removeAction", params)
+	if err != nil {
+		return false, err
+	}
+
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for RemoveAction: %T", result)
+}
+
+func (kc *Client) GetActiveSwimlanes(ctx context.Context, projectID int) ([]Swimlane, error) {
+	params := []interface{}{projectID}
+	result, err := kc.Call(ctx, "getActiveSwimlanes", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeList[Swimlane](result, "getActiveSwimlanes")
+}
+
+func (kc *Client) GetAllSwimlanes(ctx context.Context, projectID int) ([]Swimlane, error) {
+	params := []interface{}{projectID}
+	result, err := kc.Call(ctx, "getAllSwimlanes", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeList[Swimlane](result, "getAllSwimlanes")
+}
+
+func (kc *Client) GetSwimlaneById(ctx context.Context, swimlaneID int) (*Swimlane, error) {
+	params := []interface{}{swimlaneID}
+	result, err := kc.Call(ctx, "getSwimlaneById", params)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Swimlane](result, "swimlane", swimlaneID)
+}
+
+func (kc *Client) GetSwimlaneByName(ctx context.Context, projectID int, name string) (*Swimlane, error) {
+	params := []interface{}{projectID, name}
+	result, err := kc.Call(ctx, "getSwimlaneByName", params)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Swimlane](result, "swimlane", quoted(name))
+}
+
+func (kc *Client) ChangeSwimlanePosition(ctx context.Context, projectID, swimlaneID, position int) (bool, error) {
+	params := []interface{}{projectID, swimlaneID, position}
+	result, err := kc.Call(ctx, "changeSwimlanePosition", params)
+	if err != nil {
+		return false, err
+	}
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for ChangeSwimlanePosition: %T", result)
+}
+
+func (kc *Client) UpdateSwimlane(ctx context.Context, projectID, swimlaneID int, name, description string) (bool, error) {
+	params := map[string]interface{}{
+		"project_id":  projectID,
+		"swimlane_id": swimlaneID,
+		"name":        name,
+	}
+	if description != "" {
+		params["description"] = description
+	}
+	result, err := kc.Call(ctx, "updateSwimlane", params)
+	if err != nil {
+		return false, err
+	}
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for UpdateSwimlane: %T", result)
+}
+
+func (kc *Client) AddSwimlane(ctx context.Context, projectID int, name, description string) (int, error) {
+	params := map[string]interface{}{
+		"project_id": projectID,
+		"name":       name,
+	}
+	if description != "" {
+		params["description"] = description
+	}
+	result, err := kc.Call(ctx, "addSwimlane", params)
+	if err != nil {
+		return 0, err
+	}
+	return DecodeID(result, "addSwimlane")
+}
+
+func (kc *Client) RemoveSwimlane(ctx context.Context, projectID, swimlaneID int) (bool, error) {
+	params := []interface{}{projectID, swimlaneID}
+	result, err := kc.Call(ctx, "removeSwimlane", params)
+	if err != nil {
+		return false, err
+	}
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for RemoveSwimlane: %T", result)
+}
+
+func (kc *Client) DisableSwimlane(ctx context.Context, projectID, swimlaneID int) (bool, error) {
+	params := []interface{}{projectID, swimlaneID}
+	result, err := kc.Call(ctx, "disableSwimlane", params)
+	if err != nil {
+		return false, err
+	}
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for DisableSwimlane: %T", result)
+}
+
+func (kc *Client) EnableSwimlane(ctx context.Context, projectID, swimlaneID int) (bool, error) {
+	params := []interface{}{projectID, swimlaneID}
+	result, err := kc.Call(ctx, "enableSwimlane", params)
+	if err != nil {
+		return false, err
+	}
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for EnableSwimlane: %T", result)
+}
+
+func (kc *Client) GetSwimlane(ctx context.Context, swimlaneID int) (*Swimlane, error) {
+	params := []interface{}{swimlaneID}
+	result, err := kc.Call(ctx, "getSwimlane", params)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Swimlane](result, "swimlane", swimlaneID)
+}
+
+// GetTaskMetadata Task Metadata API Procedures
+func (kc *Client) GetTaskMetadata(ctx context.Context, taskID int) (map[string]interface{}, error) {
+	params := []interface{}{taskID}
+	result, err := kc.Call(ctx, "getTaskMetadata", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata, ok := result.(map[string]interface{}); ok {
+		return metadata, nil
+	}
+
+	if result == nil {
+		return map[string]interface{}{}, nil
+	}
+	return nil, fmt.Errorf("unexpected result type for GetTaskMetadata: %T", result)
+}
+
+func (kc *Client) GetTaskMetadataByName(ctx context.Context, taskID int, name string) (string, error) {
+	params := []interface{}{taskID, name}
+	result, err := kc.Call(ctx, "getTaskMetadataByName", params)
+	if err != nil {
+		return "", err
+	}
+
+	if value, ok := result.(string); ok {
+		return value, nil
+	}
+
+	if result == nil {
+		return "", nil // Kanboard returns null for empty string
+	}
+	return "", fmt.Errorf("unexpected result type for GetTaskMetadataByName: %T", result)
+}
+
+func (kc *Client) SaveTaskMetadata(ctx context.Context, taskID int, values map[string]string) (bool, error) {
+	params := map[string]interface{}{
+		"task_id": taskID,
+		"values":  values,
+	}
+	result, err := kc.Call(ctx, "saveTaskMetadata", params)
+	if err != nil {
+		return false, err
+	}
+
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for SaveTaskMetadata: %T", result)
+}
+
+func (kc *Client) RemoveTaskMetadata(ctx context.Context, taskID int, name string) (bool, error) {
+	params := []interface{}{taskID, name}
+	result, err := kc.Call(ctx, "removeTaskMetadata", params)
+	if err != nil {
+		return false, err
+	}
+
+	if success, ok := result.(bool); ok {
+		return success, nil
+	}
+	return false, fmt.Errorf("unexpected result type for RemoveTaskMetadata: %T", result)
+}
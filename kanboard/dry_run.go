@@ -0,0 +1,84 @@
+package kanboard
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"kanboard-mcp/logging"
+)
+
+// DryRunID stands in for the ID of an object a recorded call would have created. It is
+// positive so that handlers carry on, and far above the IDs of a real Kanboard.
+const DryRunID = 1<<31 - 1
+
+// dryRunIDMethods are the mutating methods not named create* that return the ID of a new object
+var dryRunIDMethods = map[string]bool{
+	"addColumn":              true,
+	"addSwimlane":            true,
+	"duplicateTaskToProject": true,
+}
+
+// DryRunCall is a mutating JSON-RPC request that was recorded instead of sent
+type DryRunCall struct {
+	Endpoint string          `json:"endpoint"`
+	Method   string          `json:"method"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// DryRunRecorder collects the mutating calls made while a tool runs in dry-run mode
+type DryRunRecorder struct {
+	mu    sync.Mutex
+	calls []DryRunCall
+}
+
+type dryRunContextKey struct{}
+
+// WithDryRunRecorder returns a context whose mutating API calls are recorded, not sent
+func WithDryRunRecorder(ctx context.Context) (context.Context, *DryRunRecorder) {
+	recorder := &DryRunRecorder{}
+	return context.WithValue(ctx, dryRunContextKey{}, recorder), recorder
+}
+
+func DryRunRecorderFromContext(ctx context.Context) *DryRunRecorder {
+	recorder, _ := ctx.Value(dryRunContextKey{}).(*DryRunRecorder)
+	return recorder
+}
+
+// record stores the exact request body that would have been posted and
+// returns a stand-in result so the handler can carry on
+func (r *DryRunRecorder) record(endpoint, method string, params interface{}) (interface{}, error) {
+	body, err := newJSONRPCRequestBody(method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, DryRunCall{Endpoint: endpoint, Method: method, Payload: body})
+	return dryRunResult(method), nil
+}
+
+// dryRunResult is what Kanboard answers a successful call to method with: the new ID
+// for creations, true otherwise
+func dryRunResult(method string) interface{} {
+	if strings.HasPrefix(method, "create") || dryRunIDMethods[method] {
+		return DryRunID
+	}
+	return true
+}
+
+// Redacted returns the call with the secrets in its payload redacted like in the logs,
+// for previews shown to the client
+func (c DryRunCall) Redacted() DryRunCall {
+	c.Payload = redactJSON(c.Payload, logging.Redact)
+	return c
+}
+
+// Calls returns the calls recorded so far
+func (r *DryRunRecorder) Calls() []DryRunCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DryRunCall(nil), r.calls...)
+}
@@ -0,0 +1,67 @@
+package kanboard
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"kanboard-mcp/kanboard/kanboardtest"
+)
+
+func TestDryRunRecorderStandIns(t *testing.T) {
+	kb := kanboardtest.NewServer()
+	defer kb.Close()
+	client := NewClient(kb.Endpoint(), kanboardtest.Token, "", "")
+	ctx, recorder := WithDryRunRecorder(context.Background())
+
+	cases := []struct {
+		method string
+		params map[string]interface{}
+		want   interface{}
+	}{
+		{"createTask", map[string]interface{}{"project_id": 1, "title": "Preview"}, DryRunID},
+		{"createUser", map[string]interface{}{"username": "eve", "password": "eve-secret"}, DryRunID},
+		{"addSwimlane", map[string]interface{}{"project_id": 1, "name": "Urgent"}, DryRunID},
+		{"duplicateTaskToProject", map[string]interface{}{"task_id": 1, "project_id": 2}, DryRunID},
+		{"updateTask", map[string]interface{}{"id": 1, "title": "Renamed"}, true},
+		{"removeTask", map[string]interface{}{"task_id": 1}, true},
+	}
+	for _, c := range cases {
+		result, err := client.Call(ctx, c.method, c.params)
+		if err != nil {
+			t.Fatalf("%s: %v", c.method, err)
+		}
+		if result != c.want {
+			t.Errorf("%s answered %v, want %v", c.method, result, c.want)
+		}
+	}
+	if id, err := DecodeID(DryRunID, "createTask"); err != nil || id != DryRunID {
+		t.Errorf("DecodeID rejects the stand-in ID: %d, %v", id, err)
+	}
+
+	// Reads still reach Kanboard, writes never do
+	task, err := client.GetTask(ctx, 1)
+	if err != nil || task.Title != "Design landing page" {
+		t.Fatalf("GetTask = %v, %v", task, err)
+	}
+	if methods := kb.Methods(); !slices.Equal(methods, []string{"getTask"}) {
+		t.Errorf("Kanboard received %v, want only getTask", methods)
+	}
+
+	calls := recorder.Calls()
+	if len(calls) != len(cases) {
+		t.Fatalf("recorded %d calls, want %d", len(calls), len(cases))
+	}
+	for i, call := range calls {
+		if call.Method != cases[i].method || !strings.Contains(string(call.Payload), `"method":"`+cases[i].method+`"`) {
+			t.Errorf("call %d recorded as %s %s", i, call.Method, call.Payload)
+		}
+	}
+	if payload := string(calls[1].Payload); !strings.Contains(payload, "eve-secret") {
+		t.Errorf("the recorded payload should be the exact body: %s", payload)
+	}
+	if payload := string(calls[1].Redacted().Payload); strings.Contains(payload, "eve-secret") || !strings.Contains(payload, `"username":"eve"`) {
+		t.Errorf("the redacted payload should only hide the password: %s", payload)
+	}
+}
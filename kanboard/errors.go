@@ -0,0 +1,256 @@
+package kanboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind classifies failed Kanboard API calls
+type ErrorKind string
+
+const (
+	ErrorKindHTTP        ErrorKind = "http"        // unexpected HTTP status
+	ErrorKindJSONRPC     ErrorKind = "jsonrpc"     // JSON-RPC error object returned by Kanboard
+	ErrorKindNetwork     ErrorKind = "network"     // connection could not be made or was lost
+	ErrorKindTimeout     ErrorKind = "timeout"     // no response within the request timeout
+	ErrorKindAuth        ErrorKind = "auth"        // credentials missing or rejected
+	ErrorKindNotFound    ErrorKind = "not_found"   // endpoint, method or object does not exist
+	ErrorKindValidation  ErrorKind = "validation"  // request rejected as malformed or invalid
+	ErrorKindProtocol    ErrorKind = "protocol"    // response is not valid JSON-RPC
+	ErrorKindUnavailable ErrorKind = "unavailable" // not sent, the circuit breaker is open
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	jsonrpcParseError     = -32700
+	jsonrpcInvalidRequest = -32600
+	jsonrpcMethodNotFound = -32601
+	jsonrpcInvalidParams  = -32602
+)
+
+// idempotentWriteMethods are mutating Kanboard methods that are safe to send twice,
+// because they set state rather than create or remove it
+var idempotentWriteMethods = map[string]bool{
+	"updateTask":                 true,
+	"updateProject":              true,
+	"updateColumn":               true,
+	"updateSwimlane":             true,
+	"updateCategory":             true,
+	"updateComment":              true,
+	"updateSubtask":              true,
+	"updateUser":                 true,
+	"updateGroup":                true,
+	"updateTag":                  true,
+	"updateLink":                 true,
+	"updateTaskLink":             true,
+	"updateExternalTaskLink":     true,
+	"updateSprint":               true,
+	"openTask":                   true,
+	"closeTask":                  true,
+	"enableProject":              true,
+	"disableProject":             true,
+	"enableProjectPublicAccess":  true,
+	"disableProjectPublicAccess": true,
+	"enableSwimlane":             true,
+	"disableSwimlane":            true,
+	"enableUser":                 true,
+	"disableUser":                true,
+	"setTaskTags":                true,
+	"saveTaskMetadata":           true,
+	"saveProjectMetadata":        true,
+	"changeProjectUserRole":      true,
+	"changeProjectGroupRole":     true,
+	"changeColumnPosition":       true,
+	"changeSwimlanePosition":     true,
+	"moveTaskPosition":           true,
+	"moveTaskToProject":          true,
+}
+
+// Error is a failed Kanboard API call
+type Error struct {
+	Kind       ErrorKind
+	Message    string
+	StatusCode int           // HTTP status, if a response was received
+	Code       int           // JSON-RPC error code, for ErrorKindJSONRPC and the kinds derived from it
+	RetryAfter time.Duration // from the Retry-After header
+	// Unprocessed is set when Kanboard certainly did not act on the request,
+	// so that even a non-idempotent write may be sent again
+	Unprocessed bool
+	Err         error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Message == "" && e.Err != nil:
+		return e.Err.Error()
+	case e.Err != nil:
+		return e.Message + ": " + e.Err.Error()
+	default:
+		return e.Message
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// temporary reports whether the same request could succeed later
+func (e *Error) temporary() bool {
+	switch e.Kind {
+	case ErrorKindNetwork, ErrorKindTimeout:
+		return true
+	case ErrorKindHTTP:
+		switch e.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// errorKind returns the kind of a Kanboard API error, or "" for other errors
+func errorKind(err error) ErrorKind {
+	var kbErr *Error
+	if errors.As(err, &kbErr) {
+		return kbErr.Kind
+	}
+	return ""
+}
+
+// isRetryableError reports whether a failed call may be sent again.
+// Non-idempotent writes are only repeated when Kanboard never processed them.
+func isRetryableError(err error, idempotent bool) bool {
+	var kbErr *Error
+	if !errors.As(err, &kbErr) || !kbErr.temporary() {
+		return false
+	}
+	return idempotent || kbErr.Unprocessed
+}
+
+// isIdempotentCall reports whether sending method twice has the same effect as sending it once
+func isIdempotentCall(method string, config *RequestConfig) bool {
+	return config.Idempotent || !IsMutatingMethod(method) || idempotentWriteMethods[method]
+}
+
+// retryDelay returns how long to wait before the given retry (1 for the first), using
+// exponential backoff with jitter. A Retry-After from Kanboard is honoured; when it is
+// longer than MaxRetryDelay the call is not retried.
+func retryDelay(config *RequestConfig, retry int, err error) (time.Duration, bool) {
+	delay := config.RetryDelay
+	for i := 1; i < retry && delay < config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if config.MaxRetryDelay > 0 && delay > config.MaxRetryDelay {
+		delay = config.MaxRetryDelay
+	}
+	// Equal jitter: half the delay is fixed, the other half random
+	if delay > 1 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+
+	var kbErr *Error
+	if errors.As(err, &kbErr) && kbErr.RetryAfter > delay {
+		if config.MaxRetryDelay > 0 && kbErr.RetryAfter > config.MaxRetryDelay {
+			return 0, false
+		}
+		delay = kbErr.RetryAfter
+	}
+	return delay, true
+}
+
+// retriesExhaustedError wraps the last error of a call with the number of attempts made
+func retriesExhaustedError(what string, attempts int, err error) error {
+	if attempts <= 1 {
+		return fmt.Errorf("%s failed: %w", what, err)
+	}
+	return fmt.Errorf("%s failed after %d attempts: %w", what, attempts, err)
+}
+
+// newTransportError classifies an error returned by http.Client.Do. parent is the
+// caller's context, used to tell a per-request timeout from a cancelled tool call.
+func newTransportError(parent context.Context, err error) error {
+	if parent.Err() != nil {
+		return fmt.Errorf("HTTP request failed: %w", parent.Err())
+	}
+
+	kbErr := &Error{Kind: ErrorKindNetwork, Message: "HTTP request failed", Err: err}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kbErr.Kind = ErrorKindTimeout
+	}
+
+	// Failures to resolve or connect happen before anything is sent
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	if errors.As(err, &dnsErr) || (errors.As(err, &opErr) && opErr.Op == "dial") {
+		kbErr.Unprocessed = true
+	}
+	return kbErr
+}
+
+// newHTTPStatusError classifies a non-200 response from Kanboard
+func newHTTPStatusError(resp *http.Response, message string) *Error {
+	kbErr := &Error{Kind: ErrorKindHTTP, Message: message, StatusCode: resp.StatusCode}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		kbErr.Kind = ErrorKindAuth
+	case http.StatusNotFound:
+		kbErr.Kind = ErrorKindNotFound
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		kbErr.Kind = ErrorKindValidation
+	case http.StatusTooManyRequests:
+		// Rate limited requests are rejected before they are handled
+		kbErr.Unprocessed = true
+	}
+
+	kbErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return kbErr
+}
+
+// newJSONRPCError classifies a JSON-RPC error object returned by Kanboard
+func newJSONRPCError(apiError *APIError) *Error {
+	kbErr := &Error{
+		Kind:    ErrorKindJSONRPC,
+		Message: fmt.Sprintf("kanboard API error (code %d): %s", apiError.Code, apiError.Message),
+		Code:    apiError.Code,
+	}
+
+	switch apiError.Code {
+	case jsonrpcParseError, jsonrpcInvalidRequest, jsonrpcInvalidParams:
+		kbErr.Kind = ErrorKindValidation
+	case jsonrpcMethodNotFound:
+		kbErr.Kind = ErrorKindNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Kanboard reports authentication and access failures with HTTP-like codes
+		kbErr.Kind = ErrorKindAuth
+	}
+	return kbErr
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package kanboard
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryDelayBackoffAndJitter(t *testing.T) {
+	config := &RequestConfig{RetryDelay: 100 * time.Millisecond, MaxRetryDelay: time.Second}
+	cases := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{4, 400 * time.Millisecond, 800 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second}, // capped at MaxRetryDelay
+		{30, 500 * time.Millisecond, time.Second},
+	}
+	for _, c := range cases {
+		for i := 0; i < 200; i++ {
+			delay, ok := retryDelay(config, c.retry, errors.New("boom"))
+			if !ok || delay < c.min || delay > c.max {
+				t.Fatalf("retry %d: delay %v (ok %v) outside [%v, %v]", c.retry, delay, ok, c.min, c.max)
+			}
+		}
+	}
+}
+
+func TestRetryDelayHonoursRetryAfter(t *testing.T) {
+	config := &RequestConfig{RetryDelay: 100 * time.Millisecond, MaxRetryDelay: 10 * time.Second}
+	cases := []struct {
+		name       string
+		retryAfter time.Duration
+		want       time.Duration // 0 when only the backoff bounds are checked
+		retry      bool
+	}{
+		{"longer than backoff", 3 * time.Second, 3 * time.Second, true},
+		{"at the cap", 10 * time.Second, 10 * time.Second, true},
+		{"beyond the cap", 11 * time.Second, 0, false},
+		{"shorter than backoff", time.Millisecond, 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := fmt.Errorf("call failed: %w", &Error{Kind: ErrorKindHTTP, StatusCode: http.StatusTooManyRequests, RetryAfter: c.retryAfter})
+			delay, ok := retryDelay(config, 1, err)
+			if ok != c.retry {
+				t.Fatalf("retry = %v, want %v", ok, c.retry)
+			}
+			switch {
+			case !ok:
+			case c.want != 0 && delay != c.want:
+				t.Errorf("delay = %v, want %v", delay, c.want)
+			case c.want == 0 && (delay < 50*time.Millisecond || delay > 100*time.Millisecond):
+				t.Errorf("delay = %v, want the backoff", delay)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                                  0,
+		"120":                               2 * time.Minute,
+		" 5 ":                               5 * time.Second,
+		"0":                                 0,
+		"-3":                                0,
+		"Wed, 01 May 2024 12:00:30 GMT":     30 * time.Second,
+		"Wednesday, 01-May-24 12:01:00 GMT": time.Minute,
+		"Wed, 01 May 2024 11:59:00 GMT":     0, // in the past
+		"soon":                              0,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	unavailable := &Error{Kind: ErrorKindHTTP, StatusCode: http.StatusServiceUnavailable}
+	rateLimited := &Error{Kind: ErrorKindHTTP, StatusCode: http.StatusTooManyRequests, Unprocessed: true}
+	dialFailed := &Error{Kind: ErrorKindNetwork, Unprocessed: true}
+	connectionLost := &Error{Kind: ErrorKindNetwork}
+	timeout := &Error{Kind: ErrorKindTimeout}
+
+	cases := []struct {
+		name   string
+		method string
+		config RequestConfig
+		err    error
+		want   bool
+	}{
+		{"read on 503", "getTask", RequestConfig{}, unavailable, true},
+		{"read on lost connection", "getAllProjects", RequestConfig{}, connectionLost, true},
+		{"read on timeout", "searchTasks", RequestConfig{}, timeout, true},
+		{"read on 400", "getTask", RequestConfig{}, &Error{Kind: ErrorKindValidation, StatusCode: http.StatusBadRequest}, false},
+		{"read on auth failure", "getTask", RequestConfig{}, &Error{Kind: ErrorKindAuth, StatusCode: http.StatusUnauthorized}, false},
+		{"read on JSON-RPC error", "getTask", RequestConfig{}, &Error{Kind: ErrorKindJSONRPC, Code: 1}, false},
+		{"read on open breaker", "getTask", RequestConfig{}, &Error{Kind: ErrorKindUnavailable}, false},
+		{"read on plain error", "getTask", RequestConfig{}, errors.New("boom"), false},
+		{"idempotent write on 503", "updateTask", RequestConfig{}, unavailable, true},
+		{"idempotent write on timeout", "closeTask", RequestConfig{}, timeout, true},
+		{"create on 503", "createTask", RequestConfig{}, unavailable, false},
+		{"create on timeout", "createTask", RequestConfig{}, timeout, false},
+		{"create on lost connection", "createComment", RequestConfig{}, connectionLost, false},
+		{"remove on 502", "removeTask", RequestConfig{}, &Error{Kind: ErrorKindHTTP, StatusCode: http.StatusBadGateway}, false},
+		{"create on 429", "createTask", RequestConfig{}, rateLimited, true},
+		{"create on failed dial", "createTask", RequestConfig{}, dialFailed, true},
+		{"create marked idempotent", "createTask", RequestConfig{Idempotent: true}, timeout, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", c.err)
+			if got := isRetryableError(err, isIdempotentCall(c.method, &c.config)); got != c.want {
+				t.Errorf("isRetryableError(%s, %v) = %v, want %v", c.method, c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestIdempotentWriteMethodsAreWrites(t *testing.T) {
+	for method := range idempotentWriteMethods {
+		if !IsMutatingMethod(method) {
+			t.Errorf("%s is listed as an idempotent write but is not a mutating method", method)
+		}
+	}
+	for _, method := range []string{"createTask", "createProject", "removeTask", "removeProject", "addProjectUser", "createTaskFile", "duplicateTaskToProject"} {
+		if idempotentWriteMethods[method] {
+			t.Errorf("%s must not be retried after Kanboard may have processed it", method)
+		}
+	}
+}
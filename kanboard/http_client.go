@@ -0,0 +1,157 @@
+package kanboard
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// httpClientConfig tunes the transport shared by every Kanboard API call
+type httpClientConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	TLSHandshakeTimeout time.Duration
+	HTTP2               bool
+	CAFile              string
+	ClientCertFile      string
+	ClientKeyFile       string
+}
+
+// defaultHTTPClientConfig keeps enough idle connections for parallel tool calls against one Kanboard
+func defaultHTTPClientConfig() httpClientConfig {
+	return httpClientConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		MaxConnsPerHost:     0,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		HTTP2:               true,
+	}
+}
+
+// httpClientConfigFromEnv reads the KANBOARD_HTTP_* and TLS variables on top of the defaults
+func httpClientConfigFromEnv() (httpClientConfig, error) {
+	config := defaultHTTPClientConfig()
+	var problems []string
+
+	intVar := func(name string, target *int) {
+		if value := os.Getenv(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				problems = append(problems, fmt.Sprintf("%s: expected a non-negative integer, got %q", name, value))
+				return
+			}
+			*target = n
+		}
+	}
+	durationVar := func(name string, target *time.Duration) {
+		if value := os.Getenv(name); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				problems = append(problems, fmt.Sprintf("%s: expected a duration such as 90s, got %q", name, value))
+				return
+			}
+			*target = d
+		}
+	}
+
+	intVar("KANBOARD_HTTP_MAX_IDLE_CONNS", &config.MaxIdleConns)
+	intVar("KANBOARD_HTTP_MAX_IDLE_CONNS_PER_HOST", &config.MaxIdleConnsPerHost)
+	intVar("KANBOARD_HTTP_MAX_CONNS_PER_HOST", &config.MaxConnsPerHost)
+	durationVar("KANBOARD_HTTP_IDLE_CONN_TIMEOUT", &config.IdleConnTimeout)
+	durationVar("KANBOARD_HTTP_TLS_HANDSHAKE_TIMEOUT", &config.TLSHandshakeTimeout)
+	if value := os.Getenv("KANBOARD_HTTP2"); value != "" {
+		config.HTTP2 = !strings.EqualFold(value, "false")
+	}
+
+	config.CAFile = os.Getenv("KANBOARD_CA_FILE")
+	config.ClientCertFile = os.Getenv("KANBOARD_CLIENT_CERT")
+	config.ClientKeyFile = os.Getenv("KANBOARD_CLIENT_KEY")
+	if (config.ClientCertFile == "") != (config.ClientKeyFile == "") {
+		problems = append(problems, "KANBOARD_CLIENT_CERT and KANBOARD_CLIENT_KEY must be set together")
+	}
+
+	if len(problems) > 0 {
+		return config, fmt.Errorf("invalid HTTP client configuration: %s", strings.Join(problems, "; "))
+	}
+	return config, nil
+}
+
+// newHTTPClient builds the long-lived client used for all Kanboard requests.
+// Timeouts are applied per attempt through the request context, not on the client.
+func newHTTPClient(config httpClientConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := &http.Transport{
+		// Honours HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     config.HTTP2,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if !config.HTTP2 {
+		// A non-nil empty map disables the HTTP/2 upgrade
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// NewHTTPClientFromEnv builds the shared client from the environment
+func NewHTTPClientFromEnv() (*http.Client, error) {
+	config, err := httpClientConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return newHTTPClient(config)
+}
+
+// defaultHTTPClient is the pooled client used until main applies the environment settings
+func defaultHTTPClient() *http.Client {
+	client, err := newHTTPClient(defaultHTTPClientConfig())
+	if err != nil {
+		// Unreachable: the defaults load no files
+		return &http.Client{}
+	}
+	return client
+}
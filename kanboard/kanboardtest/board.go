@@ -0,0 +1,269 @@
+package kanboardtest
+
+import (
+	"slices"
+	"strings"
+)
+
+var boardProcedures = map[string]procedure{
+	"getBoard": proc(func(s *Server, c *call) (any, error) {
+		if s.find("projects", c.int("project_id")) == nil {
+			return []any{}, nil
+		}
+		var board []map[string]any
+		for _, swimlane := range s.list("swimlanes", where("project_id", c.int("project_id"))) {
+			if swimlane.int("is_active") != 1 {
+				continue
+			}
+			columns := make([]map[string]any, 0)
+			for _, column := range s.list("columns", where("project_id", c.int("project_id"))) {
+				tasks := s.list("tasks", func(task record) bool {
+					return task.int("column_id") == column.int("id") && task.int("swimlane_id") == swimlane.int("id") && task.int("is_active") == 1
+				})
+				row := column.row()
+				row["tasks"] = s.taskRows(tasks)
+				row["nb_tasks"] = len(tasks)
+				columns = append(columns, row)
+			}
+			row := swimlane.row()
+			row["columns"] = columns
+			row["nb_columns"] = len(columns)
+			board = append(board, row)
+		}
+		return board, nil
+	}, "project_id"),
+
+	"getColumns": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.ordered("columns", c.int("project_id"))), nil
+	}, "project_id"),
+	"getColumn": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("columns", c.int("column_id"))), nil
+	}, "column_id"),
+	"addColumn": proc(func(s *Server, c *call) (any, error) {
+		if s.find("projects", c.int("project_id")) == nil || c.str("title") == "" {
+			return false, nil
+		}
+		column := record{"title": c.str("title"), "project_id": c.int("project_id"), "task_limit": 0, "description": "",
+			"hide_in_dashboard": 0, "position": len(s.list("columns", where("project_id", c.int("project_id")))) + 1}
+		c.set(column, "task_limit", "description")
+		return s.insert("columns", column), nil
+	}, "project_id", "title", "?task_limit", "?description"),
+	"updateColumn": proc(func(s *Server, c *call) (any, error) {
+		column := s.find("columns", c.int("column_id"))
+		if column == nil || c.str("title") == "" {
+			return false, nil
+		}
+		c.set(column, "title", "task_limit", "description")
+		return true, nil
+	}, "column_id", "title", "?task_limit", "?description"),
+	"changeColumnPosition": proc(func(s *Server, c *call) (any, error) {
+		return s.reorder("columns", c.int("project_id"), c.int("column_id"), c.int("position")), nil
+	}, "project_id", "column_id", "position"),
+	"removeColumn": proc(func(s *Server, c *call) (any, error) {
+		column := s.find("columns", c.int("column_id"))
+		if column == nil || len(s.list("tasks", where("column_id", column.int("id")))) > 0 {
+			return false, nil
+		}
+		s.remove("columns", byID(column.int("id")))
+		s.renumber("columns", column.int("project_id"))
+		return true, nil
+	}, "column_id"),
+
+	"getActiveSwimlanes": proc(func(s *Server, c *call) (any, error) {
+		var active []record
+		for _, swimlane := range s.ordered("swimlanes", c.int("project_id")) {
+			if swimlane.int("is_active") == 1 {
+				active = append(active, swimlane)
+			}
+		}
+		return rows(active), nil
+	}, "project_id"),
+	"getAllSwimlanes": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.ordered("swimlanes", c.int("project_id"))), nil
+	}, "project_id"),
+	"getSwimlane": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("swimlanes", c.int("swimlane_id"))), nil
+	}, "swimlane_id"),
+	"getSwimlaneById": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("swimlanes", c.int("swimlane_id"))), nil
+	}, "swimlane_id"),
+	"getSwimlaneByName": proc(func(s *Server, c *call) (any, error) {
+		for _, swimlane := range s.list("swimlanes", where("project_id", c.int("project_id"))) {
+			if swimlane.str("name") == c.str("name") {
+				return swimlane.row(), nil
+			}
+		}
+		return false, nil
+	}, "project_id", "name"),
+	"addSwimlane": proc(func(s *Server, c *call) (any, error) {
+		if s.find("projects", c.int("project_id")) == nil || c.str("name") == "" || s.swimlaneNamed(c.int("project_id"), c.str("name")) {
+			return false, nil
+		}
+		return s.insert("swimlanes", record{"name": c.str("name"), "description": c.str("description"), "is_active": 1, "task_limit": 0,
+			"project_id": c.int("project_id"), "position": len(s.list("swimlanes", where("project_id", c.int("project_id")))) + 1}), nil
+	}, "project_id", "name", "?description"),
+	"updateSwimlane": proc(func(s *Server, c *call) (any, error) {
+		swimlane := s.find("swimlanes", c.int("swimlane_id"))
+		if swimlane == nil || swimlane.int("project_id") != c.int("project_id") || c.str("name") == "" {
+			return false, nil
+		}
+		c.set(swimlane, "name", "description")
+		return true, nil
+	}, "project_id", "swimlane_id", "name", "?description"),
+	"changeSwimlanePosition": proc(func(s *Server, c *call) (any, error) {
+		return s.reorder("swimlanes", c.int("project_id"), c.int("swimlane_id"), c.int("position")), nil
+	}, "project_id", "swimlane_id", "position"),
+	"removeSwimlane": proc(func(s *Server, c *call) (any, error) {
+		swimlane := s.find("swimlanes", c.int("swimlane_id"))
+		if swimlane == nil || swimlane.int("project_id") != c.int("project_id") ||
+			len(s.list("tasks", where("swimlane_id", swimlane.int("id")))) > 0 {
+			return false, nil
+		}
+		s.remove("swimlanes", byID(swimlane.int("id")))
+		s.renumber("swimlanes", swimlane.int("project_id"))
+		return true, nil
+	}, "project_id", "swimlane_id"),
+	"enableSwimlane":  swimlaneFlag(1),
+	"disableSwimlane": swimlaneFlag(0),
+
+	"getCategory": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("categories", c.int("category_id"))), nil
+	}, "category_id"),
+	"getAllCategories": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("categories", where("project_id", c.int("project_id")))), nil
+	}, "project_id"),
+	"createCategory": proc(func(s *Server, c *call) (any, error) {
+		if s.find("projects", c.int("project_id")) == nil || c.str("name") == "" {
+			return false, nil
+		}
+		for _, category := range s.list("categories", where("project_id", c.int("project_id"))) {
+			if category.str("name") == c.str("name") {
+				return false, nil
+			}
+		}
+		return s.insert("categories", record{"name": c.str("name"), "project_id": c.int("project_id"), "description": "",
+			"color_id": c.str("color_id")}), nil
+	}, "project_id", "name", "?color_id"),
+	"updateCategory": proc(func(s *Server, c *call) (any, error) {
+		category := s.find("categories", c.int("id"))
+		if category == nil || c.str("name") == "" {
+			return false, nil
+		}
+		c.set(category, "name", "color_id")
+		return true, nil
+	}, "id", "name", "?color_id"),
+	"removeCategory": proc(func(s *Server, c *call) (any, error) {
+		if s.remove("categories", byID(c.int("category_id"))) == 0 {
+			return false, nil
+		}
+		for _, task := range s.list("tasks", where("category_id", c.int("category_id"))) {
+			task["category_id"] = 0
+		}
+		return true, nil
+	}, "category_id"),
+}
+
+var linkProcedures = map[string]procedure{
+	"getAllLinks": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("links", nil)), nil
+	}),
+	"getLinkById": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("links", c.int("link_id"))), nil
+	}, "link_id"),
+	"getLinkByLabel": proc(func(s *Server, c *call) (any, error) {
+		for _, link := range s.list("links", nil) {
+			if link.str("label") == c.str("label") {
+				return link.row(), nil
+			}
+		}
+		return false, nil
+	}, "label"),
+	"getOppositeLinkId": proc(func(s *Server, c *call) (any, error) {
+		link := s.find("links", c.int("link_id"))
+		if link == nil {
+			return false, nil
+		}
+		if link.int("opposite_id") == 0 {
+			return link.int("id"), nil
+		}
+		return link.int("opposite_id"), nil
+	}, "link_id"),
+	"createLink": proc(func(s *Server, c *call) (any, error) {
+		if c.str("label") == "" || s.linkLabeled(c.str("label")) {
+			return false, nil
+		}
+		id := s.insert("links", record{"label": c.str("label"), "opposite_id": 0})
+		if opposite := c.str("opposite_label"); opposite != "" {
+			oppositeID := s.insert("links", record{"label": opposite, "opposite_id": id})
+			s.find("links", id)["opposite_id"] = oppositeID
+		}
+		return id, nil
+	}, "label", "?opposite_label"),
+	"updateLink": proc(func(s *Server, c *call) (any, error) {
+		link := s.find("links", c.int("link_id"))
+		if link == nil || c.str("label") == "" {
+			return false, nil
+		}
+		link["label"], link["opposite_id"] = c.str("label"), c.int("opposite_link_id")
+		return true, nil
+	}, "link_id", "opposite_link_id", "label"),
+	"removeLink": proc(func(s *Server, c *call) (any, error) {
+		if s.remove("links", byID(c.int("link_id"))) == 0 {
+			return false, nil
+		}
+		for _, link := range s.list("links", where("opposite_id", c.int("link_id"))) {
+			link["opposite_id"] = 0
+		}
+		s.remove("task_has_links", where("link_id", c.int("link_id")))
+		return true, nil
+	}, "link_id"),
+}
+
+func swimlaneFlag(active int) procedure {
+	return proc(func(s *Server, c *call) (any, error) {
+		swimlane := s.find("swimlanes", c.int("swimlane_id"))
+		if swimlane == nil || swimlane.int("project_id") != c.int("project_id") {
+			return false, nil
+		}
+		swimlane["is_active"] = active
+		return true, nil
+	}, "project_id", "swimlane_id")
+}
+
+// ordered returns the columns or swimlanes of a project by position
+func (s *Server) ordered(name string, projectID int) []record {
+	records := s.list(name, where("project_id", projectID))
+	slices.SortStableFunc(records, func(a, b record) int { return a.int("position") - b.int("position") })
+	return records
+}
+
+// reorder moves a column or swimlane to position, shifting the others like Kanboard
+func (s *Server) reorder(name string, projectID, id, position int) bool {
+	records := s.ordered(name, projectID)
+	index := slices.IndexFunc(records, func(r record) bool { return r.int("id") == id })
+	if index < 0 || position < 1 || position > len(records) {
+		return false
+	}
+	moved := records[index]
+	records = slices.Insert(slices.Delete(records, index, index+1), position-1, moved)
+	for i, r := range records {
+		r["position"] = i + 1
+	}
+	return true
+}
+
+func (s *Server) renumber(name string, projectID int) {
+	for i, r := range s.ordered(name, projectID) {
+		r["position"] = i + 1
+	}
+}
+
+func (s *Server) swimlaneNamed(projectID int, name string) bool {
+	return slices.ContainsFunc(s.list("swimlanes", where("project_id", projectID)), func(r record) bool {
+		return strings.EqualFold(r.str("name"), name)
+	})
+}
+
+func (s *Server) linkLabeled(label string) bool {
+	return slices.ContainsFunc(s.list("links", nil), func(r record) bool { return r.str("label") == label })
+}
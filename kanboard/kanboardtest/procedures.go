@@ -0,0 +1,620 @@
+package kanboardtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// call is one JSON-RPC call bound to the procedure's arguments
+type call struct {
+	user record // nil for the application token
+	args map[string]any
+}
+
+func (c *call) has(name string) bool {
+	value, ok := c.args[name]
+	return ok && value != nil
+}
+
+func (c *call) int(name string) int {
+	value, _ := toInt(c.args[name])
+	return value
+}
+
+func (c *call) str(name string) string {
+	switch value := c.args[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+// set copies the given arguments into r, when present
+func (c *call) set(r record, names ...string) {
+	for _, name := range names {
+		if !c.has(name) {
+			continue
+		}
+		value := c.args[name]
+		if number, ok := value.(json.Number); ok {
+			if n, err := number.Int64(); err == nil {
+				value = int(n)
+			} else if f, err := number.Float64(); err == nil {
+				value = f
+			}
+		}
+		r[name] = value
+	}
+}
+
+// procedure is a Kanboard API method with its PHP signature: params in order, the
+// required ones first
+type procedure struct {
+	params   []string
+	required int
+	fn       func(s *Server, c *call) (any, error)
+	// plugin procedures are answered only while plugins are enabled
+	plugin bool
+	// userOnly procedures are rejected for the application token
+	userOnly bool
+}
+
+// proc declares a procedure; params prefixed with "?" are optional
+func proc(fn func(s *Server, c *call) (any, error), params ...string) procedure {
+	p := procedure{fn: fn}
+	for _, param := range params {
+		if name, optional := strings.CutPrefix(param, "?"); optional {
+			p.params = append(p.params, name)
+		} else {
+			p.params = append(p.params, param)
+			p.required++
+		}
+	}
+	return p
+}
+
+func userProc(fn func(s *Server, c *call) (any, error), params ...string) procedure {
+	p := proc(fn, params...)
+	p.userOnly = true
+	return p
+}
+
+func pluginProc(fn func(s *Server, c *call) (any, error), params ...string) procedure {
+	p := proc(fn, params...)
+	p.plugin = true
+	return p
+}
+
+var errInvalidParams = errors.New("invalid params")
+
+// bind maps named or positional params to the procedure's arguments like Kanboard's
+// JSON-RPC server: unknown names, missing required arguments and surplus positional
+// arguments are rejected
+func (p procedure) bind(raw json.RawMessage) (map[string]any, error) {
+	args := make(map[string]any)
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		if p.required > 0 {
+			return nil, errInvalidParams
+		}
+		return args, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if raw[0] == '[' {
+		var positional []any
+		if err := decoder.Decode(&positional); err != nil {
+			return nil, errInvalidParams
+		}
+		if len(positional) < p.required || len(positional) > len(p.params) {
+			return nil, errInvalidParams
+		}
+		for i, value := range positional {
+			args[p.params[i]] = value
+		}
+		return args, nil
+	}
+
+	if err := decoder.Decode(&args); err != nil {
+		return nil, errInvalidParams
+	}
+	for name := range args {
+		if !slices.Contains(p.params, name) {
+			return nil, errInvalidParams
+		}
+	}
+	for _, name := range p.params[:p.required] {
+		if _, ok := args[name]; !ok {
+			return nil, errInvalidParams
+		}
+	}
+	return args, nil
+}
+
+// found returns the row, or false when there is none
+func found(r record) any {
+	if r == nil {
+		return false
+	}
+	return r.row()
+}
+
+func (s *Server) taskRow(task record) map[string]any {
+	row := task.row()
+	row["url"] = fmt.Sprintf("%s/?controller=TaskViewController&action=show&task_id=%d&project_id=%d", s.URL, task.int("id"), task.int("project_id"))
+	row["color"] = map[string]any{"name": task.str("color_id"), "background": "rgb(245, 247, 196)", "border": "rgb(223, 227, 45)"}
+	return row
+}
+
+func (s *Server) projectRow(project record) map[string]any {
+	row := project.row()
+	id := project.int("id")
+	row["url"] = map[string]any{
+		"board": fmt.Sprintf("%s/?controller=BoardViewController&action=show&project_id=%d", s.URL, id),
+		"list":  fmt.Sprintf("%s/?controller=TaskListController&action=show&project_id=%d", s.URL, id),
+	}
+	return row
+}
+
+func (s *Server) taskRows(tasks []record) []map[string]any {
+	out := make([]map[string]any, 0, len(tasks))
+	for _, task := range tasks {
+		out = append(out, s.taskRow(task))
+	}
+	return out
+}
+
+// projectRole is the role of userID in projectID, directly or through a group
+func (s *Server) projectRole(projectID, userID int) string {
+	for _, member := range s.list("project_has_users", where("project_id", projectID)) {
+		if member.int("user_id") == userID {
+			return member.str("role")
+		}
+	}
+	for _, group := range s.list("project_has_groups", where("project_id", projectID)) {
+		if len(s.list("group_has_users", func(r record) bool {
+			return r.int("group_id") == group.int("group_id") && r.int("user_id") == userID
+		})) > 0 {
+			return group.str("role")
+		}
+	}
+	return ""
+}
+
+func (s *Server) userProjects(user record) []record {
+	return s.list("projects", func(project record) bool {
+		return user.str("role") == "app-admin" || s.projectRole(project.int("id"), user.int("id")) != ""
+	})
+}
+
+func (s *Server) overdue(task record) bool {
+	return task.int("is_active") == 1 && task.int("date_due") > 0 && task.int("date_due") < s.now
+}
+
+// decodeBlob decodes base64 file content; Kanboard stores an empty file for invalid input
+func decodeBlob(blob string) []byte {
+	content, err := base64.StdEncoding.DecodeString(blob)
+	if err != nil {
+		return nil
+	}
+	return content
+}
+
+func (s *Server) metadataOf(key string) map[string]string {
+	values, ok := s.metadata[key]
+	if !ok {
+		values = make(map[string]string)
+		s.metadata[key] = values
+	}
+	return values
+}
+
+// saveMetadata stores a values object, Kanboard answers false for anything else
+func (s *Server) saveMetadata(key string, values any) any {
+	object, ok := values.(map[string]any)
+	if !ok {
+		return false
+	}
+	metadata := s.metadataOf(key)
+	for name, value := range object {
+		metadata[name] = fmt.Sprint(value)
+	}
+	return true
+}
+
+func colors() map[string]any {
+	names := map[string]string{
+		"yellow": "Yellow", "blue": "Blue", "green": "Green", "purple": "Purple", "red": "Red", "orange": "Orange",
+		"grey": "Grey", "brown": "Brown", "deep_orange": "Deep Orange", "dark_grey": "Dark Grey", "pink": "Pink",
+		"teal": "Teal", "cyan": "Cyan", "lime": "Lime", "light_green": "Light Green", "amber": "Amber",
+	}
+	out := make(map[string]any, len(names))
+	for id, name := range names {
+		out[id] = name
+	}
+	return out
+}
+
+// procedures are the Kanboard API methods the server implements
+var procedures map[string]procedure
+
+func init() {
+	procedures = make(map[string]procedure)
+	maps.Copy(procedures, applicationProcedures)
+	maps.Copy(procedures, projectProcedures)
+	maps.Copy(procedures, taskProcedures)
+	maps.Copy(procedures, boardProcedures)
+	maps.Copy(procedures, linkProcedures)
+	maps.Copy(procedures, userProcedures)
+}
+
+var applicationProcedures = map[string]procedure{
+	"getVersion":  proc(func(s *Server, c *call) (any, error) { return "1.2.35", nil }),
+	"getTimezone": proc(func(s *Server, c *call) (any, error) { return "UTC", nil }),
+	"getDefaultTaskColors": proc(func(s *Server, c *call) (any, error) {
+		out := make(map[string]any)
+		for id, name := range colors() {
+			out[id] = map[string]any{"name": name, "background": "rgb(245, 247, 196)", "border": "rgb(223, 227, 45)"}
+		}
+		return out, nil
+	}),
+	"getDefaultTaskColor": proc(func(s *Server, c *call) (any, error) { return "yellow", nil }),
+	"getColorList":        proc(func(s *Server, c *call) (any, error) { return colors(), nil }),
+	"getApplicationRoles": proc(func(s *Server, c *call) (any, error) {
+		return map[string]any{"app-admin": "Administrator", "app-manager": "Manager", "app-user": "User"}, nil
+	}),
+	"getProjectRoles": proc(func(s *Server, c *call) (any, error) {
+		return map[string]any{"project-manager": "Project Manager", "project-member": "Project Member", "project-viewer": "Project Viewer"}, nil
+	}),
+
+	"getMe": userProc(func(s *Server, c *call) (any, error) {
+		return c.user.row(), nil
+	}),
+	"getMyDashboard": userProc(func(s *Server, c *call) (any, error) {
+		return s.taskRows(s.list("tasks", func(task record) bool {
+			return task.int("is_active") == 1 && task.int("owner_id") == c.user.int("id")
+		})), nil
+	}),
+	"getMyActivityStream": userProc(func(s *Server, c *call) (any, error) {
+		projects := s.userProjects(c.user)
+		return rows(s.list("project_activities", func(event record) bool {
+			return slices.ContainsFunc(projects, byID(event.int("project_id")))
+		})), nil
+	}),
+	"getMyProjects": userProc(func(s *Server, c *call) (any, error) {
+		var out []map[string]any
+		for _, project := range s.userProjects(c.user) {
+			row := s.projectRow(project)
+			if role := s.projectRole(project.int("id"), c.user.int("id")); role != "" {
+				row["role"] = role
+			}
+			out = append(out, row)
+		}
+		return out, nil
+	}),
+	"getMyProjectsList": userProc(func(s *Server, c *call) (any, error) {
+		out := make(map[string]any)
+		for _, project := range s.userProjects(c.user) {
+			out[strconv.Itoa(project.int("id"))] = project.str("name")
+		}
+		return out, nil
+	}),
+	"getMyOverdueTasks": userProc(func(s *Server, c *call) (any, error) {
+		return s.taskRows(s.list("tasks", func(task record) bool {
+			return s.overdue(task) && task.int("owner_id") == c.user.int("id")
+		})), nil
+	}),
+	"createMyPrivateProject": userProc(func(s *Server, c *call) (any, error) {
+		if c.str("name") == "" {
+			return false, nil
+		}
+		project := record{"name": c.str("name"), "description": c.str("description"), "is_private": 1, "owner_id": c.user.int("id")}
+		id := s.insertProject(project)
+		s.insert("project_has_users", record{"project_id": id, "user_id": c.user.int("id"), "role": "project-manager"})
+		return id, nil
+	}, "name", "?description"),
+}
+
+var projectFields = []string{"name", "description", "owner_id", "identifier", "start_date", "end_date",
+	"priority_default", "priority_start", "priority_end", "email"}
+
+var projectProcedures = map[string]procedure{
+	"getProjectById": proc(func(s *Server, c *call) (any, error) {
+		if project := s.find("projects", c.int("project_id")); project != nil {
+			return s.projectRow(project), nil
+		}
+		return nil, nil
+	}, "project_id"),
+	"getProjectByName": proc(func(s *Server, c *call) (any, error) {
+		for _, project := range s.list("projects", nil) {
+			if project.str("name") == c.str("name") {
+				return s.projectRow(project), nil
+			}
+		}
+		return nil, nil
+	}, "name"),
+	"getProjectByIdentifier": proc(func(s *Server, c *call) (any, error) {
+		for _, project := range s.list("projects", nil) {
+			if project.str("identifier") != "" && project.str("identifier") == strings.ToUpper(c.str("identifier")) {
+				return s.projectRow(project), nil
+			}
+		}
+		return nil, nil
+	}, "identifier"),
+	"getProjectByEmail": proc(func(s *Server, c *call) (any, error) {
+		for _, project := range s.list("projects", nil) {
+			if project.str("email") != "" && project.str("email") == c.str("email") {
+				return s.projectRow(project), nil
+			}
+		}
+		return nil, nil
+	}, "email"),
+	"getAllProjects": proc(func(s *Server, c *call) (any, error) {
+		var out []map[string]any
+		for _, project := range s.list("projects", nil) {
+			out = append(out, s.projectRow(project))
+		}
+		return out, nil
+	}),
+	"createProject": proc(func(s *Server, c *call) (any, error) {
+		if c.str("name") == "" {
+			return false, nil
+		}
+		identifier := strings.ToUpper(c.str("identifier"))
+		for _, project := range s.list("projects", nil) {
+			if identifier != "" && project.str("identifier") == identifier {
+				return false, nil
+			}
+		}
+		project := record{}
+		c.set(project, projectFields...)
+		project["identifier"] = identifier
+		return s.insertProject(project), nil
+	}, "name", "?description", "?owner_id", "?identifier", "?start_date", "?end_date",
+		"?priority_default", "?priority_start", "?priority_end", "?email"),
+	"updateProject": proc(func(s *Server, c *call) (any, error) {
+		project := s.find("projects", c.int("project_id"))
+		if project == nil {
+			return false, nil
+		}
+		c.set(project, projectFields...)
+		project["identifier"] = strings.ToUpper(project.str("identifier"))
+		project["last_modified"] = s.now
+		return true, nil
+	}, "project_id", "?name", "?description", "?owner_id", "?identifier", "?start_date", "?end_date",
+		"?priority_default", "?priority_start", "?priority_end", "?email"),
+	"removeProject": proc(func(s *Server, c *call) (any, error) {
+		id := c.int("project_id")
+		if s.remove("projects", byID(id)) == 0 {
+			return false, nil
+		}
+		for _, name := range []string{"columns", "swimlanes", "categories", "tasks", "project_has_users", "project_has_groups",
+			"project_has_files", "actions", "sprints", "project_activities", "tags"} {
+			s.remove(name, where("project_id", id))
+		}
+		delete(s.metadata, "project:"+strconv.Itoa(id))
+		return true, nil
+	}, "project_id"),
+	"enableProject":              projectFlag("is_active", 1),
+	"disableProject":             projectFlag("is_active", 0),
+	"enableProjectPublicAccess":  projectFlag("is_public", 1),
+	"disableProjectPublicAccess": projectFlag("is_public", 0),
+	"getProjectActivity": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("project_activities", where("project_id", c.int("project_id")))), nil
+	}, "project_id"),
+	"getProjectActivities": proc(func(s *Server, c *call) (any, error) {
+		ids, ok := c.args["project_ids"].([]any)
+		if !ok {
+			return nil, &rpcError{codeInvalidParams, "Invalid params"}
+		}
+		return rows(s.list("project_activities", func(event record) bool {
+			return slices.ContainsFunc(ids, func(id any) bool {
+				n, _ := toInt(id)
+				return n == event.int("project_id")
+			})
+		})), nil
+	}, "project_ids"),
+
+	"getProjectUsers": proc(func(s *Server, c *call) (any, error) {
+		out := make(map[string]any)
+		for _, member := range s.list("project_has_users", where("project_id", c.int("project_id"))) {
+			if user := s.find("users", member.int("user_id")); user != nil {
+				out[strconv.Itoa(user.int("id"))] = user.str("name")
+			}
+		}
+		return out, nil
+	}, "project_id"),
+	"getAssignableUsers": proc(func(s *Server, c *call) (any, error) {
+		out := make(map[string]any)
+		if prepend, _ := toInt(c.args["prepend_unassigned"]); prepend == 1 {
+			out["0"] = "Unassigned"
+		}
+		for _, member := range s.list("project_has_users", where("project_id", c.int("project_id"))) {
+			if user := s.find("users", member.int("user_id")); user != nil && member.str("role") != "project-viewer" {
+				out[strconv.Itoa(user.int("id"))] = user.str("name")
+			}
+		}
+		return out, nil
+	}, "project_id", "?prepend_unassigned"),
+	"addProjectUser": proc(func(s *Server, c *call) (any, error) {
+		return s.addMember("project_has_users", "user_id", "users", c), nil
+	}, "project_id", "user_id", "?role"),
+	"addProjectGroup": proc(func(s *Server, c *call) (any, error) {
+		return s.addMember("project_has_groups", "group_id", "groups", c), nil
+	}, "project_id", "group_id", "?role"),
+	"removeProjectUser": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("project_has_users", s.member(c, "user_id")) > 0, nil
+	}, "project_id", "user_id"),
+	"removeProjectGroup": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("project_has_groups", s.member(c, "group_id")) > 0, nil
+	}, "project_id", "group_id"),
+	"changeProjectUserRole": proc(func(s *Server, c *call) (any, error) {
+		return s.changeRole("project_has_users", "user_id", c), nil
+	}, "project_id", "user_id", "role"),
+	"changeProjectGroupRole": proc(func(s *Server, c *call) (any, error) {
+		return s.changeRole("project_has_groups", "group_id", c), nil
+	}, "project_id", "group_id", "role"),
+	"getProjectUserRole": proc(func(s *Server, c *call) (any, error) {
+		return s.projectRole(c.int("project_id"), c.int("user_id")), nil
+	}, "project_id", "user_id"),
+
+	// Kanboard loads project files by their ID alone, project_id is ignored
+	"getProjectFile": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("project_has_files", c.int("file_id"))), nil
+	}, "project_id", "file_id"),
+	"getAllProjectFiles": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("project_has_files", where("project_id", c.int("project_id")))), nil
+	}, "project_id"),
+	"downloadProjectFile": proc(func(s *Server, c *call) (any, error) {
+		file := s.find("project_has_files", c.int("file_id"))
+		if file == nil {
+			return "", nil
+		}
+		return base64.StdEncoding.EncodeToString(s.blobs["project_has_files:"+strconv.Itoa(file.int("id"))]), nil
+	}, "project_id", "file_id"),
+	"createProjectFile": proc(func(s *Server, c *call) (any, error) {
+		if s.find("projects", c.int("project_id")) == nil || c.str("filename") == "" {
+			return false, nil
+		}
+		return s.insertFile("project_has_files", record{"project_id": c.int("project_id")}, c.str("filename"), decodeBlob(c.str("blob"))), nil
+	}, "project_id", "filename", "blob"),
+	"removeProjectFile": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("project_has_files", byID(c.int("file_id"))) > 0, nil
+	}, "project_id", "file_id"),
+	"removeAllProjectFiles": proc(func(s *Server, c *call) (any, error) {
+		s.remove("project_has_files", where("project_id", c.int("project_id")))
+		return true, nil
+	}, "project_id"),
+
+	"getProjectMetadata": proc(func(s *Server, c *call) (any, error) {
+		return s.metadataOf("project:" + strconv.Itoa(c.int("project_id"))), nil
+	}, "project_id"),
+	"getProjectMetadataByName": proc(func(s *Server, c *call) (any, error) {
+		return s.metadataOf("project:" + strconv.Itoa(c.int("project_id")))[c.str("name")], nil
+	}, "project_id", "name"),
+	"saveProjectMetadata": proc(func(s *Server, c *call) (any, error) {
+		return s.saveMetadata("project:"+strconv.Itoa(c.int("project_id")), c.args["values"]), nil
+	}, "project_id", "values"),
+	"removeProjectMetadata": proc(func(s *Server, c *call) (any, error) {
+		metadata := s.metadataOf("project:" + strconv.Itoa(c.int("project_id")))
+		_, ok := metadata[c.str("name")]
+		delete(metadata, c.str("name"))
+		return ok, nil
+	}, "project_id", "name"),
+
+	"getActions": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("actions", where("project_id", c.int("project_id")))), nil
+	}, "project_id"),
+	"createAction": proc(func(s *Server, c *call) (any, error) {
+		params, ok := c.args["params"].(map[string]any)
+		if !ok || s.find("projects", c.int("project_id")) == nil || availableActions[c.str("action_name")] == "" {
+			return false, nil
+		}
+		return s.insert("actions", record{"project_id": c.int("project_id"), "event_name": c.str("event_name"),
+			"action_name": c.str("action_name"), "params": params}), nil
+	}, "project_id", "event_name", "action_name", "params"),
+	"removeAction": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("actions", byID(c.int("action_id"))) > 0, nil
+	}, "action_id"),
+	"getAvailableActions": proc(func(s *Server, c *call) (any, error) {
+		return availableActions, nil
+	}),
+	"getAvailableActionEvents": proc(func(s *Server, c *call) (any, error) {
+		return actionEvents, nil
+	}),
+	"getCompatibleActionEvents": proc(func(s *Server, c *call) (any, error) {
+		if availableActions[c.str("action_name")] == "" {
+			return []any{}, nil
+		}
+		return map[string]string{"task.move.column": actionEvents["task.move.column"]}, nil
+	}, "action_name"),
+
+	"createSprint": pluginProc(func(s *Server, c *call) (any, error) {
+		if s.find("projects", c.int("project_id")) == nil || c.str("name") == "" {
+			return false, nil
+		}
+		sprint := record{"is_active": 0, "is_completed": 0, "sprint_goal": ""}
+		c.set(sprint, "project_id", "name", "start_date", "end_date", "sprint_goal")
+		return s.insert("sprints", sprint), nil
+	}, "project_id", "name", "start_date", "end_date", "?sprint_goal"),
+	"getSprintById": pluginProc(func(s *Server, c *call) (any, error) {
+		return found(s.find("sprints", c.int("sprint_id"))), nil
+	}, "sprint_id"),
+	"getAllSprintsByProject": pluginProc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("sprints", where("project_id", c.int("project_id")))), nil
+	}, "project_id"),
+	"updateSprint": pluginProc(func(s *Server, c *call) (any, error) {
+		sprint := s.find("sprints", c.int("sprint_id"))
+		if sprint == nil {
+			return false, nil
+		}
+		c.set(sprint, "name", "start_date", "end_date", "sprint_goal", "is_active", "is_completed")
+		return true, nil
+	}, "sprint_id", "?name", "?start_date", "?end_date", "?sprint_goal", "?is_active", "?is_completed"),
+	"removeSprint": pluginProc(func(s *Server, c *call) (any, error) {
+		return s.remove("sprints", byID(c.int("sprint_id"))) > 0, nil
+	}, "sprint_id"),
+}
+
+// availableActions are a few of Kanboard's automatic actions
+var availableActions = map[string]string{
+	"\\Kanboard\\Action\\TaskClose":           "Close a task",
+	"\\Kanboard\\Action\\TaskAssignColorUser": "Assign a color to a specific user",
+}
+
+var actionEvents = map[string]string{
+	"task.move.column": "Move a task to another column",
+	"task.create":      "Task creation",
+	"task.close":       "Closing a task",
+}
+
+func projectFlag(field string, value int) procedure {
+	return proc(func(s *Server, c *call) (any, error) {
+		project := s.find("projects", c.int("project_id"))
+		if project == nil {
+			return false, nil
+		}
+		project[field] = value
+		return true, nil
+	}, "project_id")
+}
+
+func (s *Server) member(c *call, key string) func(record) bool {
+	return func(r record) bool {
+		return r.int("project_id") == c.int("project_id") && r.int(key) == c.int(key)
+	}
+}
+
+func (s *Server) addMember(name, key, entities string, c *call) bool {
+	if s.find("projects", c.int("project_id")) == nil || s.find(entities, c.int(key)) == nil {
+		return false
+	}
+	role := c.str("role")
+	if role == "" {
+		role = "project-member"
+	}
+	s.remove(name, s.member(c, key))
+	s.insert(name, record{"project_id": c.int("project_id"), key: c.int(key), "role": role})
+	return true
+}
+
+func (s *Server) changeRole(name, key string, c *call) bool {
+	members := s.list(name, s.member(c, key))
+	if len(members) == 0 {
+		return false
+	}
+	members[0]["role"] = c.str("role")
+	return true
+}
@@ -0,0 +1,282 @@
+// Package kanboardtest provides an in-memory Kanboard JSON-RPC server for tests, in the
+// spirit of net/http/httptest.
+//
+// The server keeps its state between calls and answers like Kanboard does, quirks
+// included: IDs and flags in returned rows are numeric strings ("7", "1") while the IDs
+// of created objects are JSON numbers, lookups of missing objects answer false (a few
+// getters answer null), rejected writes answer false rather than an error, and params
+// may be named or positional, with missing, surplus or unknown arguments rejected as
+// "Invalid params".
+//
+// NewServer seeds these fixtures:
+//
+//	users      1 admin (app-admin), 2 alice (app-user), 3 bob (app-manager), 4 viewer (app-user);
+//	           the password of each user is "<username>-secret"
+//	groups     1 Developers (alice)
+//	projects   1 "Website Redesign" (WEB): alice member, bob manager, viewer viewer
+//	           2 "Mobile App" (APP): bob manager
+//	columns    1-4 Backlog, Ready, Work in progress, Done of project 1; 5-8 of project 2
+//	swimlanes  1 Default swimlane, 3 Expedite (project 1); 2 Default swimlane (project 2)
+//	categories 1 Bug, 2 Feature (project 1); 3 Design (project 2)
+//	tags       1 urgent (global), 2 frontend (project 1), 3 ios (project 2)
+//	tasks      1 "Design landing page" (project 1, overdue, alice, JIRA-101, tag frontend)
+//	           2 "Fix login bug" (project 1, bob, JIRA-102), 3 "Write release notes"
+//	           (project 1, closed), 4 "Set up CI" (project 2, bob)
+//	links      the 11 default link types, 2 "blocks" and 3 "is blocked by" among them
+//	others     comment 1, subtask 1, task link 1 (task 1 blocks task 2), external link 1
+//	           and file 1 on task 1; project file 1, metadata, action 1 and sprint 1 on
+//	           project 1
+package kanboardtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Token is the application API token the server accepts as "jsonrpc:<token>"
+const Token = "test-token"
+
+// JSON-RPC error codes used by Kanboard
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// Call is a JSON-RPC call received by the server
+type Call struct {
+	Method string
+	Params json.RawMessage
+	// User is the authenticated username, "jsonrpc" for the application token
+	User string
+}
+
+// Server is a fake Kanboard instance listening on a local HTTP port
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tables map[string]*table
+	blobs  map[string][]byte // file contents by table and ID
+	// metadata by "task:<id>" and "project:<id>"
+	metadata map[string]map[string]string
+	calls    []Call
+	plugins  bool
+	now      int
+}
+
+// NewServer starts a server seeded with the fixtures listed in the package
+// documentation. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		tables:   make(map[string]*table),
+		blobs:    make(map[string][]byte),
+		metadata: make(map[string]map[string]string),
+		plugins:  true,
+		now:      1700000000,
+	}
+	s.seed()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Endpoint is the JSON-RPC endpoint URL
+func (s *Server) Endpoint() string {
+	return s.URL + "/jsonrpc.php"
+}
+
+// DisablePlugins makes the server answer like a Kanboard without the sprint plugin
+func (s *Server) DisablePlugins() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plugins = false
+}
+
+// Calls returns the calls received so far, batch members included
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.calls)
+}
+
+// Methods returns the methods called so far, in order
+func (s *Server) Methods() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := make([]string, len(s.calls))
+	for i, call := range s.calls {
+		methods[i] = call.Method
+	}
+	return methods
+}
+
+// ResetCalls forgets the calls received so far
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	ID      json.RawMessage `json:"id"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// MarshalJSON leaves out the result of error responses, like Kanboard
+func (r rpcResponse) MarshalJSON() ([]byte, error) {
+	if r.Error != nil {
+		return json.Marshal(struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Error   *rpcError       `json:"error"`
+		}{r.JSONRPC, r.ID, r.Error})
+	}
+	return json.Marshal(struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  any             `json:"result"`
+	}{r.JSONRPC, r.ID, r.Result})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	user, ok := s.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="API Access"`)
+		http.Error(w, "Not Authorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response any
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var requests []rpcRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			response = rpcResponse{JSONRPC: "2.0", Error: &rpcError{codeParseError, "Parse error"}}
+		} else {
+			responses := make([]rpcResponse, 0, len(requests))
+			for _, request := range requests {
+				responses = append(responses, s.handle(user, request))
+			}
+			response = responses
+		}
+	} else {
+		var request rpcRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			response = rpcResponse{JSONRPC: "2.0", Error: &rpcError{codeParseError, "Parse error"}}
+		} else {
+			response = s.handle(user, request)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// authenticate accepts the application token and the username/password of any active user
+func (s *Server) authenticate(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+		return "jsonrpc", token == Token
+	}
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		if encoded, found := strings.CutPrefix(header, "Basic "); found {
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err == nil {
+				username, password, ok = strings.Cut(string(decoded), ":")
+			}
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	if username == "jsonrpc" {
+		return username, password == Token
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user := s.userByName(username)
+	return username, user != nil && user.str("password") == password && user.int("is_active") == 1
+}
+
+func (s *Server) handle(username string, request rpcRequest) rpcResponse {
+	response := rpcResponse{JSONRPC: "2.0", ID: request.ID}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		response.Error = &rpcError{codeInvalidRequest, "Invalid Request"}
+		return response
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, Call{Method: request.Method, Params: request.Params, User: username})
+
+	proc, ok := procedures[request.Method]
+	if !ok || (proc.plugin && !s.plugins) {
+		response.Error = &rpcError{codeMethodNotFound, "Method not found"}
+		return response
+	}
+	args, err := proc.bind(request.Params)
+	if err != nil {
+		response.Error = &rpcError{codeInvalidParams, "Invalid params"}
+		return response
+	}
+
+	var user record
+	if username != "jsonrpc" {
+		user = s.userByName(username)
+	} else if proc.userOnly {
+		response.Error = &rpcError{codeInternalError, "This procedure is not available with the API credentials"}
+		return response
+	}
+
+	s.now++
+	result, err := proc.fn(s, &call{user: user, args: args})
+	if err != nil {
+		if rpcErr, ok := err.(*rpcError); ok {
+			response.Error = rpcErr
+		} else {
+			response.Error = &rpcError{codeInternalError, err.Error()}
+		}
+		return response
+	}
+	response.Result = result
+	return response
+}
@@ -0,0 +1,97 @@
+package kanboardtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// post sends a raw JSON-RPC body as the given user and decodes the response
+func post(t *testing.T, s *Server, username, password, body string) (int, any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, s.Endpoint(), strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(username, password)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var decoded any
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp.StatusCode, decoded
+}
+
+func rpc(method, params string) string {
+	return `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+}
+
+func TestServerQuirks(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	cases := []struct {
+		name, body, want string
+	}{
+		{"named params", rpc("getTask", `{"task_id":1}`), `"title":"Design landing page"`},
+		{"positional params", rpc("getTask", `[1]`), `"title":"Design landing page"`},
+		{"numeric strings in rows", rpc("getTask", `{"task_id":1}`), `"project_id":"1"`},
+		{"null for a missing task", rpc("getTask", `{"task_id":99}`), `"result":null`},
+		{"false for a missing column", rpc("getColumn", `{"column_id":99}`), `"result":false`},
+		{"numbers for created IDs", rpc("createTask", `{"title":"New","project_id":1}`), `"result":5`},
+		{"false for rejected writes", rpc("createTask", `{"title":"New","project_id":9}`), `"result":false`},
+		{"unknown argument", rpc("getTask", `{"id":1}`), `"code":-32602`},
+		{"missing argument", rpc("getTask", `{}`), `"code":-32602`},
+		{"too many positional arguments", rpc("getTask", `[1,2]`), `"code":-32602`},
+		{"unknown method", rpc("getTaskById", `{"task_id":1}`), `"code":-32601`},
+		{"user API with the application token", rpc("getMe", `{}`), `"code":-32603`},
+		{"batch", `[` + rpc("getVersion", `{}`) + `,` + rpc("getColumn", `[2]`) + `]`, `"title":"Ready"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status, response := post(t, s, "jsonrpc", Token, c.body)
+			if status != http.StatusOK {
+				t.Fatalf("status %d", status)
+			}
+			encoded, _ := json.Marshal(response)
+			if !strings.Contains(string(encoded), c.want) {
+				t.Fatalf("expected %s in %s", c.want, encoded)
+			}
+		})
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	if status, _ := post(t, s, "alice", "wrong", rpc("getVersion", `{}`)); status != http.StatusUnauthorized {
+		t.Fatalf("wrong password: status %d", status)
+	}
+	_, response := post(t, s, "alice", "alice-secret", rpc("getMe", `{}`))
+	if encoded, _ := json.Marshal(response); !strings.Contains(string(encoded), `"username":"alice"`) {
+		t.Fatalf("getMe as alice: %s", encoded)
+	}
+	if methods := s.Methods(); len(methods) != 1 || methods[0] != "getMe" {
+		t.Fatalf("recorded calls: %v", methods)
+	}
+}
+
+func TestServerDisablePlugins(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.DisablePlugins()
+
+	_, response := post(t, s, "jsonrpc", Token, rpc("getSprintById", `{"sprint_id":1}`))
+	if encoded, _ := json.Marshal(response); !strings.Contains(string(encoded), `"code":-32601`) {
+		t.Fatalf("sprint plugin still answers: %s", encoded)
+	}
+}
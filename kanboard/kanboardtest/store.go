@@ -0,0 +1,274 @@
+package kanboardtest
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// record is a database row. Integers are stored as int and returned as numeric strings.
+type record map[string]any
+
+func (r record) int(key string) int {
+	value, _ := toInt(r[key])
+	return value
+}
+
+func (r record) str(key string) string {
+	value, _ := r[key].(string)
+	return value
+}
+
+// row returns the record as Kanboard returns it: integers as numeric strings
+func (r record) row() map[string]any {
+	if r == nil {
+		return nil
+	}
+	out := make(map[string]any, len(r))
+	for key, value := range r {
+		if key == "password" {
+			continue
+		}
+		if n, ok := value.(int); ok {
+			out[key] = strconv.Itoa(n)
+		} else {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// table holds the rows of one entity, keyed by their auto-increment ID
+type table struct {
+	nextID int
+	rows   map[int]record
+}
+
+func (s *Server) table(name string) *table {
+	t, ok := s.tables[name]
+	if !ok {
+		t = &table{nextID: 1, rows: make(map[int]record)}
+		s.tables[name] = t
+	}
+	return t
+}
+
+// insert stores a copy of r with a new ID and returns the ID
+func (s *Server) insert(name string, r record) int {
+	t := s.table(name)
+	id := t.nextID
+	t.nextID++
+	row := maps.Clone(r)
+	row["id"] = id
+	t.rows[id] = row
+	return id
+}
+
+// find returns the row with the given ID, nil when there is none
+func (s *Server) find(name string, id int) record {
+	return s.table(name).rows[id]
+}
+
+// list returns the rows matching match (all rows when nil) ordered by ID
+func (s *Server) list(name string, match func(record) bool) []record {
+	t := s.table(name)
+	var rows []record
+	for _, id := range slices.Sorted(maps.Keys(t.rows)) {
+		if match == nil || match(t.rows[id]) {
+			rows = append(rows, t.rows[id])
+		}
+	}
+	return rows
+}
+
+// remove deletes the rows matching match and returns how many were deleted
+func (s *Server) remove(name string, match func(record) bool) int {
+	t := s.table(name)
+	removed := 0
+	for id, row := range t.rows {
+		if match(row) {
+			delete(t.rows, id)
+			delete(s.blobs, name+":"+strconv.Itoa(id))
+			removed++
+		}
+	}
+	return removed
+}
+
+func byID(id int) func(record) bool {
+	return func(r record) bool { return r.int("id") == id }
+}
+
+func where(key string, value int) func(record) bool {
+	return func(r record) bool { return r.int(key) == value }
+}
+
+func rows(records []record) []map[string]any {
+	out := make([]map[string]any, 0, len(records))
+	for _, r := range records {
+		out = append(out, r.row())
+	}
+	return out
+}
+
+// toInt converts JSON numbers, numeric strings and ints
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), v == float64(int(v))
+	case json.Number:
+		n, err := strconv.Atoi(string(v))
+		return n, err == nil
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// seed loads the fixtures described in the package documentation
+func (s *Server) seed() {
+	for _, user := range []struct{ username, name, role string }{
+		{"admin", "Administrator", "app-admin"},
+		{"alice", "Alice Martin", "app-user"},
+		{"bob", "Bob Stone", "app-manager"},
+		{"viewer", "Vera Viewer", "app-user"},
+	} {
+		s.insert("users", record{
+			"username": user.username, "password": user.username + "-secret", "name": user.name,
+			"email": user.username + "@example.com", "role": user.role, "is_active": 1,
+			"is_ldap_user": 0, "notifications_enabled": 0, "timezone": "", "language": "",
+			"avatar_path": "",
+		})
+	}
+
+	s.insert("groups", record{"name": "Developers", "external_id": ""})
+	s.insert("group_has_users", record{"group_id": 1, "user_id": 2})
+
+	s.insertProject(record{"name": "Website Redesign", "identifier": "WEB", "description": "Company website", "owner_id": 1, "email": "web@example.com"})
+	s.insertProject(record{"name": "Mobile App", "identifier": "APP", "description": "iOS and Android apps", "owner_id": 3})
+	s.insert("project_has_users", record{"project_id": 1, "user_id": 2, "role": "project-member"})
+	s.insert("project_has_users", record{"project_id": 1, "user_id": 3, "role": "project-manager"})
+	s.insert("project_has_users", record{"project_id": 1, "user_id": 4, "role": "project-viewer"})
+	s.insert("project_has_users", record{"project_id": 2, "user_id": 3, "role": "project-manager"})
+	s.insert("swimlanes", record{"name": "Expedite", "description": "", "position": 2, "is_active": 1, "project_id": 1, "task_limit": 0})
+
+	s.insert("categories", record{"name": "Bug", "project_id": 1, "description": "", "color_id": "red"})
+	s.insert("categories", record{"name": "Feature", "project_id": 1, "description": "", "color_id": "green"})
+	s.insert("categories", record{"name": "Design", "project_id": 2, "description": "", "color_id": ""})
+
+	s.insert("tags", record{"name": "urgent", "project_id": 0, "color_id": "red"})
+	s.insert("tags", record{"name": "frontend", "project_id": 1, "color_id": ""})
+	s.insert("tags", record{"name": "ios", "project_id": 2, "color_id": ""})
+
+	s.insertTask(record{"title": "Design landing page", "project_id": 1, "column_id": 1, "swimlane_id": 1, "owner_id": 2, "creator_id": 1,
+		"category_id": 2, "reference": "JIRA-101", "color_id": "blue", "date_due": s.now - 86400})
+	s.insertTask(record{"title": "Fix login bug", "project_id": 1, "column_id": 3, "swimlane_id": 1, "owner_id": 3, "creator_id": 1,
+		"category_id": 1, "reference": "JIRA-102"})
+	s.insertTask(record{"title": "Write release notes", "project_id": 1, "column_id": 4, "swimlane_id": 1, "owner_id": 0, "creator_id": 1, "is_active": 0})
+	s.insertTask(record{"title": "Set up CI", "project_id": 2, "column_id": 5, "swimlane_id": 2, "owner_id": 3, "creator_id": 3})
+	s.insert("task_has_tags", record{"task_id": 1, "tag_id": 2})
+
+	s.insert("comments", record{"task_id": 1, "user_id": 2, "comment": "Looks good", "reference": "", "visibility": "app-user",
+		"date_creation": s.now, "date_modification": s.now})
+	s.insert("subtasks", record{"task_id": 1, "title": "Draft wireframes", "status": 0, "user_id": 2, "time_estimated": 2, "time_spent": 0, "position": 1})
+
+	for _, link := range []struct {
+		label    string
+		opposite int
+	}{
+		{"relates to", 0}, {"blocks", 3}, {"is blocked by", 2}, {"duplicates", 5}, {"is duplicated by", 4},
+		{"is a child of", 7}, {"is a parent of", 6}, {"targets milestone", 9}, {"is a milestone of", 8},
+		{"fixes", 11}, {"is fixed by", 10},
+	} {
+		s.insert("links", record{"label": link.label, "opposite_id": link.opposite})
+	}
+	s.createTaskLink(1, 2, 2)
+
+	s.insert("task_has_external_links", record{"task_id": 1, "link_type": "weblink", "dependency": "related", "title": "Specification",
+		"url": "https://example.com/spec", "creator_id": 1, "date_creation": s.now, "date_modification": s.now})
+	s.insertFile("task_has_files", record{"task_id": 1, "project_id": 1}, "spec.txt", []byte("landing page spec"))
+	s.insertFile("project_has_files", record{"project_id": 1}, "brief.txt", []byte("project brief"))
+
+	s.metadata["task:1"] = map[string]string{"estimate": "3d"}
+	s.metadata["project:1"] = map[string]string{"client": "ACME"}
+
+	s.insert("actions", record{"project_id": 1, "event_name": "task.move.column", "action_name": "\\Kanboard\\Action\\TaskClose",
+		"params": map[string]any{"column_id": "4"}})
+	s.insert("sprints", record{"project_id": 1, "name": "Sprint 1", "start_date": "2024-01-01", "end_date": "2024-01-14",
+		"is_active": 1, "is_completed": 0, "sprint_goal": ""})
+}
+
+// insertProject creates a project with the default columns and swimlane
+func (s *Server) insertProject(r record) int {
+	project := record{
+		"is_active": 1, "token": "", "last_modified": s.now, "is_public": 0, "is_private": 0,
+		"description": "", "identifier": "", "start_date": "", "end_date": "", "owner_id": 0,
+		"priority_default": 0, "priority_start": 0, "priority_end": 3, "email": "",
+	}
+	maps.Copy(project, r)
+	id := s.insert("projects", project)
+	for position, title := range []string{"Backlog", "Ready", "Work in progress", "Done"} {
+		s.insert("columns", record{"title": title, "position": position + 1, "project_id": id, "task_limit": 0, "description": "", "hide_in_dashboard": 0})
+	}
+	s.insert("swimlanes", record{"name": "Default swimlane", "description": "", "position": 1, "is_active": 1, "project_id": id, "task_limit": 0})
+	return id
+}
+
+// insertTask creates a task with Kanboard's defaults for the fields not in r
+func (s *Server) insertTask(r record) int {
+	task := record{
+		"description": "", "date_creation": s.now, "date_modification": s.now, "date_completed": 0,
+		"date_due": 0, "date_started": 0, "date_moved": s.now, "color_id": "yellow", "owner_id": 0,
+		"creator_id": 0, "is_active": 1, "score": 0, "category_id": 0, "priority": 0, "reference": "",
+		"recurrence_status": 0, "recurrence_trigger": 0, "recurrence_factor": 0, "recurrence_timeframe": 0,
+		"recurrence_basedate": 0, "recurrence_parent": 0, "recurrence_child": 0, "time_estimated": 0, "time_spent": 0,
+	}
+	maps.Copy(task, r)
+	position := 1
+	for _, other := range s.list("tasks", where("column_id", task.int("column_id"))) {
+		position = max(position, other.int("position")+1)
+	}
+	task["position"] = position
+	id := s.insert("tasks", task)
+	s.insert("project_activities", record{"date_creation": s.now, "event_name": "task.create", "creator_id": task.int("creator_id"),
+		"project_id": task.int("project_id"), "task_id": id, "data": map[string]any{"task": map[string]any{"title": task["title"]}}})
+	return id
+}
+
+// insertFile stores a file row and its content
+func (s *Server) insertFile(name string, r record, filename string, content []byte) int {
+	file := record{"name": filename, "path": "files/" + filename, "is_image": 0, "date": s.now, "user_id": 0, "size": len(content)}
+	maps.Copy(file, r)
+	id := s.insert(name, file)
+	s.blobs[name+":"+strconv.Itoa(id)] = content
+	return id
+}
+
+// createTaskLink links two tasks and adds the opposite link, like Kanboard
+func (s *Server) createTaskLink(taskID, oppositeTaskID, linkID int) int {
+	id := s.insert("task_has_links", record{"task_id": taskID, "opposite_task_id": oppositeTaskID, "link_id": linkID})
+	oppositeLinkID := linkID
+	if link := s.find("links", linkID); link != nil && link.int("opposite_id") != 0 {
+		oppositeLinkID = link.int("opposite_id")
+	}
+	s.insert("task_has_links", record{"task_id": oppositeTaskID, "opposite_task_id": taskID, "link_id": oppositeLinkID})
+	return id
+}
+
+func (s *Server) userByName(username string) record {
+	for _, user := range s.list("users", nil) {
+		if user.str("username") == username {
+			return user
+		}
+	}
+	return nil
+}
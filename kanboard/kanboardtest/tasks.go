@@ -0,0 +1,605 @@
+package kanboardtest
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var taskFields = []string{"title", "color_id", "owner_id", "creator_id", "description", "category_id", "score",
+	"priority", "recurrence_status", "recurrence_trigger", "recurrence_factor", "recurrence_timeframe",
+	"recurrence_basedate", "reference"}
+
+var taskProcedures = map[string]procedure{
+	"getTask": proc(func(s *Server, c *call) (any, error) {
+		if task := s.find("tasks", c.int("task_id")); task != nil {
+			return s.taskRow(task), nil
+		}
+		return nil, nil
+	}, "task_id"),
+	"getTaskByReference": proc(func(s *Server, c *call) (any, error) {
+		for _, task := range s.list("tasks", where("project_id", c.int("project_id"))) {
+			if task.str("reference") != "" && task.str("reference") == c.str("reference") {
+				return s.taskRow(task), nil
+			}
+		}
+		return false, nil
+	}, "project_id", "reference"),
+	"getAllTasks": proc(func(s *Server, c *call) (any, error) {
+		status := 1
+		if c.has("status_id") {
+			status = c.int("status_id")
+		}
+		return s.taskRows(s.list("tasks", func(task record) bool {
+			return task.int("project_id") == c.int("project_id") && task.int("is_active") == status
+		})), nil
+	}, "project_id", "?status_id"),
+	"getOverdueTasks": proc(func(s *Server, c *call) (any, error) {
+		return s.overdueRows(s.list("tasks", s.overdue)), nil
+	}),
+	"getOverdueTasksByProject": proc(func(s *Server, c *call) (any, error) {
+		return s.overdueRows(s.list("tasks", func(task record) bool {
+			return s.overdue(task) && task.int("project_id") == c.int("project_id")
+		})), nil
+	}, "project_id"),
+	"searchTasks": proc(func(s *Server, c *call) (any, error) {
+		return s.taskRows(s.list("tasks", func(task record) bool {
+			return task.int("project_id") == c.int("project_id") && s.matchQuery(task, c.str("query"))
+		})), nil
+	}, "project_id", "query"),
+	"openTask":  taskFlag(1),
+	"closeTask": taskFlag(0),
+	"removeTask": proc(func(s *Server, c *call) (any, error) {
+		id := c.int("task_id")
+		if s.remove("tasks", byID(id)) == 0 {
+			return false, nil
+		}
+		for _, name := range []string{"comments", "subtasks", "task_has_files", "task_has_tags", "task_has_external_links"} {
+			s.remove(name, where("task_id", id))
+		}
+		s.remove("task_has_links", func(link record) bool {
+			return link.int("task_id") == id || link.int("opposite_task_id") == id
+		})
+		delete(s.metadata, "task:"+strconv.Itoa(id))
+		return true, nil
+	}, "task_id"),
+	"moveTaskPosition": proc(func(s *Server, c *call) (any, error) {
+		task := s.find("tasks", c.int("task_id"))
+		column := s.find("columns", c.int("column_id"))
+		swimlane := s.find("swimlanes", c.int("swimlane_id"))
+		if task == nil || column == nil || swimlane == nil || task.int("project_id") != c.int("project_id") ||
+			column.int("project_id") != c.int("project_id") || swimlane.int("project_id") != c.int("project_id") || c.int("position") < 1 {
+			return false, nil
+		}
+		task["column_id"], task["swimlane_id"], task["position"], task["date_moved"] = column.int("id"), swimlane.int("id"), c.int("position"), s.now
+		return true, nil
+	}, "project_id", "task_id", "column_id", "position", "swimlane_id"),
+	"moveTaskToProject": proc(func(s *Server, c *call) (any, error) {
+		task := s.find("tasks", c.int("task_id"))
+		if task == nil || !s.placeTask(task, c) {
+			return false, nil
+		}
+		return true, nil
+	}, "task_id", "project_id", "?swimlane_id", "?column_id", "?category_id", "?owner_id"),
+	"duplicateTaskToProject": proc(func(s *Server, c *call) (any, error) {
+		task := s.find("tasks", c.int("task_id"))
+		if task == nil {
+			return false, nil
+		}
+		duplicate := make(record, len(task))
+		for key, value := range task {
+			duplicate[key] = value
+		}
+		if !s.placeTask(duplicate, c) {
+			return false, nil
+		}
+		delete(duplicate, "id")
+		return s.insertTask(duplicate), nil
+	}, "task_id", "project_id", "?swimlane_id", "?column_id", "?category_id", "?owner_id"),
+	"createTask": proc(func(s *Server, c *call) (any, error) {
+		project := s.find("projects", c.int("project_id"))
+		if project == nil || strings.TrimSpace(c.str("title")) == "" {
+			return false, nil
+		}
+		task := record{"project_id": project.int("id")}
+		c.set(task, taskFields...)
+		c.set(task, "column_id", "swimlane_id")
+		if c.user != nil && !c.has("creator_id") {
+			task["creator_id"] = c.user.int("id")
+		}
+		if !s.validPlacement(task) {
+			return false, nil
+		}
+		for _, field := range []string{"date_due", "date_started"} {
+			if c.has(field) {
+				task[field] = parseDate(c.str(field))
+			}
+		}
+		id := s.insertTask(task)
+		if tags, ok := c.args["tags"].([]any); ok {
+			s.setTaskTags(project.int("id"), id, tags)
+		}
+		return id, nil
+	}, "title", "project_id", "?color_id", "?column_id", "?owner_id", "?creator_id", "?date_due", "?description",
+		"?category_id", "?score", "?swimlane_id", "?priority", "?recurrence_status", "?recurrence_trigger",
+		"?recurrence_factor", "?recurrence_timeframe", "?recurrence_basedate", "?reference", "?tags", "?date_started"),
+	"updateTask": proc(func(s *Server, c *call) (any, error) {
+		task := s.find("tasks", c.int("id"))
+		if task == nil || (c.has("title") && strings.TrimSpace(c.str("title")) == "") {
+			return false, nil
+		}
+		c.set(task, taskFields...)
+		for _, field := range []string{"date_due", "date_started"} {
+			if c.has(field) {
+				task[field] = parseDate(c.str(field))
+			}
+		}
+		if tags, ok := c.args["tags"].([]any); ok {
+			s.setTaskTags(task.int("project_id"), task.int("id"), tags)
+		}
+		task["date_modification"] = s.now
+		return true, nil
+	}, "id", "?title", "?color_id", "?owner_id", "?date_due", "?description", "?category_id", "?score", "?priority",
+		"?recurrence_status", "?recurrence_trigger", "?recurrence_factor", "?recurrence_timeframe",
+		"?recurrence_basedate", "?reference", "?tags", "?date_started"),
+
+	"getTaskFile": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("task_has_files", c.int("file_id"))), nil
+	}, "file_id"),
+	"getAllTaskFiles": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("task_has_files", where("task_id", c.int("task_id")))), nil
+	}, "task_id"),
+	"downloadTaskFile": proc(func(s *Server, c *call) (any, error) {
+		if s.find("task_has_files", c.int("file_id")) == nil {
+			return "", nil
+		}
+		return base64.StdEncoding.EncodeToString(s.blobs["task_has_files:"+strconv.Itoa(c.int("file_id"))]), nil
+	}, "file_id"),
+	"createTaskFile": proc(func(s *Server, c *call) (any, error) {
+		task := s.find("tasks", c.int("task_id"))
+		if task == nil || task.int("project_id") != c.int("project_id") || c.str("filename") == "" {
+			return false, nil
+		}
+		return s.insertFile("task_has_files", record{"task_id": task.int("id"), "project_id": c.int("project_id")}, c.str("filename"), decodeBlob(c.str("blob"))), nil
+	}, "project_id", "task_id", "filename", "blob"),
+	"removeTaskFile": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("task_has_files", byID(c.int("file_id"))) > 0, nil
+	}, "file_id"),
+	"removeAllTaskFiles": proc(func(s *Server, c *call) (any, error) {
+		s.remove("task_has_files", where("task_id", c.int("task_id")))
+		return true, nil
+	}, "task_id"),
+
+	"getTaskLinkById": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("task_has_links", c.int("task_link_id"))), nil
+	}, "task_link_id"),
+	"createTaskLink": proc(func(s *Server, c *call) (any, error) {
+		if s.find("tasks", c.int("task_id")) == nil || s.find("tasks", c.int("opposite_task_id")) == nil ||
+			s.find("links", c.int("link_id")) == nil || c.int("task_id") == c.int("opposite_task_id") {
+			return false, nil
+		}
+		return s.createTaskLink(c.int("task_id"), c.int("opposite_task_id"), c.int("link_id")), nil
+	}, "task_id", "opposite_task_id", "link_id"),
+	"updateTaskLink": proc(func(s *Server, c *call) (any, error) {
+		link := s.find("task_has_links", c.int("task_link_id"))
+		if link == nil || s.find("tasks", c.int("opposite_task_id")) == nil || s.find("links", c.int("link_id")) == nil {
+			return false, nil
+		}
+		link["task_id"], link["opposite_task_id"], link["link_id"] = c.int("task_id"), c.int("opposite_task_id"), c.int("link_id")
+		return true, nil
+	}, "task_link_id", "task_id", "opposite_task_id", "link_id"),
+	"getAllTaskLinks": proc(func(s *Server, c *call) (any, error) {
+		var out []map[string]any
+		for _, link := range s.list("task_has_links", where("task_id", c.int("task_id"))) {
+			opposite := s.find("tasks", link.int("opposite_task_id"))
+			if opposite == nil {
+				continue
+			}
+			out = append(out, map[string]any{
+				"id": strconv.Itoa(link.int("id")), "task_id": strconv.Itoa(opposite.int("id")), "link_id": strconv.Itoa(link.int("link_id")),
+				"label": s.find("links", link.int("link_id")).str("label"), "title": opposite.str("title"),
+				"is_active": strconv.Itoa(opposite.int("is_active")), "project_id": strconv.Itoa(opposite.int("project_id")),
+				"column_title": s.find("columns", opposite.int("column_id")).str("title"),
+			})
+		}
+		if out == nil {
+			return []any{}, nil
+		}
+		return out, nil
+	}, "task_id"),
+	"removeTaskLink": proc(func(s *Server, c *call) (any, error) {
+		link := s.find("task_has_links", c.int("task_link_id"))
+		if link == nil {
+			return false, nil
+		}
+		s.remove("task_has_links", func(other record) bool {
+			return other.int("id") == link.int("id") ||
+				other.int("task_id") == link.int("opposite_task_id") && other.int("opposite_task_id") == link.int("task_id")
+		})
+		return true, nil
+	}, "task_link_id"),
+
+	"getExternalTaskLinkTypes": proc(func(s *Server, c *call) (any, error) {
+		return map[string]any{"auto": "Auto", "attachment": "Attachment", "weblink": "Web Link"}, nil
+	}),
+	"getExternalTaskLinkProviderDependencies": proc(func(s *Server, c *call) (any, error) {
+		switch c.str("providerName") {
+		case "weblink", "attachment":
+			return map[string]any{"related": "Related"}, nil
+		}
+		return false, nil
+	}, "providerName"),
+	// Kanboard loads external links by their ID alone, task_id is ignored
+	"getExternalTaskLinkById": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("task_has_external_links", c.int("link_id"))), nil
+	}, "task_id", "link_id"),
+	"getAllExternalTaskLinks": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("task_has_external_links", where("task_id", c.int("task_id")))), nil
+	}, "task_id"),
+	"createExternalTaskLink": proc(func(s *Server, c *call) (any, error) {
+		if s.find("tasks", c.int("task_id")) == nil || !strings.Contains(c.str("url"), "://") {
+			return false, nil
+		}
+		linkType := c.str("type")
+		if linkType == "" || linkType == "auto" {
+			linkType = "weblink"
+		}
+		title := c.str("title")
+		if title == "" {
+			title = c.str("url")
+		}
+		link := record{"task_id": c.int("task_id"), "url": c.str("url"), "dependency": c.str("dependency"), "link_type": linkType,
+			"title": title, "date_creation": s.now, "date_modification": s.now, "creator_id": 0}
+		if c.user != nil {
+			link["creator_id"] = c.user.int("id")
+		}
+		return s.insert("task_has_external_links", link), nil
+	}, "task_id", "url", "dependency", "?type", "?title"),
+	"updateExternalTaskLink": proc(func(s *Server, c *call) (any, error) {
+		link := s.find("task_has_external_links", c.int("link_id"))
+		if link == nil {
+			return false, nil
+		}
+		c.set(link, "title", "url", "dependency")
+		link["date_modification"] = s.now
+		return true, nil
+	}, "task_id", "link_id", "?title", "?url", "?dependency"),
+	"removeExternalTaskLink": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("task_has_external_links", byID(c.int("link_id"))) > 0, nil
+	}, "task_id", "link_id"),
+
+	"getTaskMetadata": proc(func(s *Server, c *call) (any, error) {
+		return s.metadataOf("task:" + strconv.Itoa(c.int("task_id"))), nil
+	}, "task_id"),
+	"getTaskMetadataByName": proc(func(s *Server, c *call) (any, error) {
+		return s.metadataOf("task:" + strconv.Itoa(c.int("task_id")))[c.str("name")], nil
+	}, "task_id", "name"),
+	"saveTaskMetadata": proc(func(s *Server, c *call) (any, error) {
+		return s.saveMetadata("task:"+strconv.Itoa(c.int("task_id")), c.args["values"]), nil
+	}, "task_id", "values"),
+	"removeTaskMetadata": proc(func(s *Server, c *call) (any, error) {
+		metadata := s.metadataOf("task:" + strconv.Itoa(c.int("task_id")))
+		_, ok := metadata[c.str("name")]
+		delete(metadata, c.str("name"))
+		return ok, nil
+	}, "task_id", "name"),
+
+	"getAllTags": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("tags", nil)), nil
+	}),
+	"getTagsByProject": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("tags", where("project_id", c.int("project_id")))), nil
+	}, "project_id"),
+	"createTag": proc(func(s *Server, c *call) (any, error) {
+		if c.str("tag") == "" || s.findTag(c.int("project_id"), c.str("tag")) != nil {
+			return false, nil
+		}
+		return s.insert("tags", record{"name": c.str("tag"), "project_id": c.int("project_id"), "color_id": c.str("color_id")}), nil
+	}, "project_id", "tag", "?color_id"),
+	"updateTag": proc(func(s *Server, c *call) (any, error) {
+		tag := s.find("tags", c.int("tag_id"))
+		if tag == nil || c.str("tag") == "" {
+			return false, nil
+		}
+		tag["name"] = c.str("tag")
+		if c.has("color_id") {
+			tag["color_id"] = c.str("color_id")
+		}
+		return true, nil
+	}, "tag_id", "tag", "?color_id"),
+	"removeTag": proc(func(s *Server, c *call) (any, error) {
+		if s.remove("tags", byID(c.int("tag_id"))) == 0 {
+			return false, nil
+		}
+		s.remove("task_has_tags", where("tag_id", c.int("tag_id")))
+		return true, nil
+	}, "tag_id"),
+	"setTaskTags": proc(func(s *Server, c *call) (any, error) {
+		tags, ok := c.args["tags"].([]any)
+		if !ok || s.find("tasks", c.int("task_id")) == nil {
+			return false, nil
+		}
+		s.setTaskTags(c.int("project_id"), c.int("task_id"), tags)
+		return true, nil
+	}, "project_id", "task_id", "tags"),
+	"getTaskTags": proc(func(s *Server, c *call) (any, error) {
+		out := make(map[string]any)
+		for _, assignment := range s.list("task_has_tags", where("task_id", c.int("task_id"))) {
+			if tag := s.find("tags", assignment.int("tag_id")); tag != nil {
+				out[strconv.Itoa(tag.int("id"))] = tag.str("name")
+			}
+		}
+		if len(out) == 0 {
+			return []any{}, nil
+		}
+		return out, nil
+	}, "task_id"),
+
+	"getComment": proc(func(s *Server, c *call) (any, error) {
+		comment := s.find("comments", c.int("comment_id"))
+		if comment == nil {
+			return nil, nil
+		}
+		return s.withUser(comment.row(), comment.int("user_id")), nil
+	}, "comment_id"),
+	"getAllComments": proc(func(s *Server, c *call) (any, error) {
+		var out []map[string]any
+		for _, comment := range s.list("comments", where("task_id", c.int("task_id"))) {
+			out = append(out, s.withUser(comment.row(), comment.int("user_id")))
+		}
+		if out == nil {
+			return []any{}, nil
+		}
+		return out, nil
+	}, "task_id"),
+	"createComment": proc(func(s *Server, c *call) (any, error) {
+		if s.find("tasks", c.int("task_id")) == nil || s.find("users", c.int("user_id")) == nil || c.str("content") == "" {
+			return false, nil
+		}
+		visibility := c.str("visibility")
+		if visibility == "" {
+			visibility = "app-user"
+		}
+		return s.insert("comments", record{"task_id": c.int("task_id"), "user_id": c.int("user_id"), "comment": c.str("content"),
+			"reference": c.str("reference"), "visibility": visibility, "date_creation": s.now, "date_modification": s.now}), nil
+	}, "task_id", "user_id", "content", "?reference", "?visibility"),
+	"updateComment": proc(func(s *Server, c *call) (any, error) {
+		comment := s.find("comments", c.int("id"))
+		if comment == nil || c.str("content") == "" {
+			return false, nil
+		}
+		comment["comment"], comment["date_modification"] = c.str("content"), s.now
+		return true, nil
+	}, "id", "content"),
+	"removeComment": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("comments", byID(c.int("comment_id"))) > 0, nil
+	}, "comment_id"),
+
+	"getSubtask": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("subtasks", c.int("subtask_id"))), nil
+	}, "subtask_id"),
+	"getAllSubtasks": proc(func(s *Server, c *call) (any, error) {
+		var out []map[string]any
+		for _, subtask := range s.list("subtasks", where("task_id", c.int("task_id"))) {
+			row := s.withUser(subtask.row(), subtask.int("user_id"))
+			row["status_name"] = []string{"Todo", "In progress", "Done"}[min(max(subtask.int("status"), 0), 2)]
+			out = append(out, row)
+		}
+		if out == nil {
+			return []any{}, nil
+		}
+		return out, nil
+	}, "task_id"),
+	"createSubtask": proc(func(s *Server, c *call) (any, error) {
+		if s.find("tasks", c.int("task_id")) == nil || c.str("title") == "" {
+			return false, nil
+		}
+		subtask := record{"task_id": c.int("task_id"), "status": 0, "user_id": 0, "time_estimated": 0, "time_spent": 0,
+			"position": len(s.list("subtasks", where("task_id", c.int("task_id")))) + 1}
+		c.set(subtask, "title", "user_id", "time_estimated", "time_spent", "status")
+		return s.insert("subtasks", subtask), nil
+	}, "task_id", "title", "?user_id", "?time_estimated", "?time_spent", "?status"),
+	"updateSubtask": proc(func(s *Server, c *call) (any, error) {
+		// Kanboard updates the subtask by its ID and moves it to task_id
+		subtask := s.find("subtasks", c.int("id"))
+		if subtask == nil || s.find("tasks", c.int("task_id")) == nil {
+			return false, nil
+		}
+		c.set(subtask, "task_id", "title", "user_id", "time_estimated", "time_spent", "status")
+		return true, nil
+	}, "id", "task_id", "?title", "?user_id", "?time_estimated", "?time_spent", "?status"),
+	"removeSubtask": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("subtasks", byID(c.int("subtask_id"))) > 0, nil
+	}, "subtask_id"),
+	"hasSubtaskTimer": proc(func(s *Server, c *call) (any, error) {
+		return s.runningTimer(c) != nil, nil
+	}, "subtask_id", "?user_id"),
+	"setSubtaskStartTime": proc(func(s *Server, c *call) (any, error) {
+		if s.find("subtasks", c.int("subtask_id")) == nil || s.runningTimer(c) != nil {
+			return false, nil
+		}
+		s.insert("subtask_time_tracking", record{"subtask_id": c.int("subtask_id"), "user_id": s.timerUser(c), "start": s.now, "end": 0})
+		return true, nil
+	}, "subtask_id", "?user_id"),
+	"setSubtaskEndTime": proc(func(s *Server, c *call) (any, error) {
+		timer := s.runningTimer(c)
+		if timer == nil {
+			return false, nil
+		}
+		timer["end"] = s.now
+		return true, nil
+	}, "subtask_id", "?user_id"),
+	"getSubtaskTimeSpent": proc(func(s *Server, c *call) (any, error) {
+		seconds := 0
+		for _, timer := range s.list("subtask_time_tracking", where("subtask_id", c.int("subtask_id"))) {
+			if timer.int("user_id") == s.timerUser(c) && timer.int("end") > 0 {
+				seconds += timer.int("end") - timer.int("start")
+			}
+		}
+		return float64(seconds) / 3600, nil
+	}, "subtask_id", "?user_id"),
+}
+
+func taskFlag(active int) procedure {
+	return proc(func(s *Server, c *call) (any, error) {
+		task := s.find("tasks", c.int("task_id"))
+		if task == nil {
+			return false, nil
+		}
+		task["is_active"] = active
+		task["date_completed"] = 0
+		if active == 0 {
+			task["date_completed"] = s.now
+		}
+		return true, nil
+	}, "task_id")
+}
+
+// placeTask moves task to the project of the call, into the given or the first column
+// and swimlane
+func (s *Server) placeTask(task record, c *call) bool {
+	if s.find("projects", c.int("project_id")) == nil {
+		return false
+	}
+	task["project_id"] = c.int("project_id")
+	task["column_id"], task["swimlane_id"] = 0, 0
+	c.set(task, "column_id", "swimlane_id", "category_id", "owner_id")
+	return s.validPlacement(task)
+}
+
+// validPlacement fills in the first column and swimlane of the task's project and
+// checks the given ones belong to it
+func (s *Server) validPlacement(task record) bool {
+	projectID := task.int("project_id")
+	for field, name := range map[string]string{"column_id": "columns", "swimlane_id": "swimlanes"} {
+		if task.int(field) == 0 {
+			if first := s.list(name, where("project_id", projectID)); len(first) > 0 {
+				task[field] = first[0].int("id")
+			}
+			continue
+		}
+		if row := s.find(name, task.int(field)); row == nil || row.int("project_id") != projectID {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) overdueRows(tasks []record) []map[string]any {
+	out := make([]map[string]any, 0, len(tasks))
+	for _, task := range tasks {
+		row := map[string]any{
+			"id": strconv.Itoa(task.int("id")), "title": task.str("title"), "date_due": strconv.Itoa(task.int("date_due")),
+			"project_id": strconv.Itoa(task.int("project_id")), "project_name": s.find("projects", task.int("project_id")).str("name"),
+			"assignee_username": nil, "assignee_name": nil,
+		}
+		if owner := s.find("users", task.int("owner_id")); owner != nil {
+			row["assignee_username"], row["assignee_name"] = owner.str("username"), owner.str("name")
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// matchQuery implements a subset of Kanboard's search syntax: status:open|closed,
+// assignee:<username>|nobody, ref:<reference> and free text matched against titles
+func (s *Server) matchQuery(task record, query string) bool {
+	for _, term := range strings.Fields(query) {
+		key, value, filter := strings.Cut(term, ":")
+		if !filter {
+			text := strings.ToLower(task.str("title") + " " + task.str("description"))
+			if !strings.Contains(text, strings.ToLower(term)) {
+				return false
+			}
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(key) {
+		case "status":
+			if (value == "open") != (task.int("is_active") == 1) {
+				return false
+			}
+		case "assignee":
+			owner := s.find("users", task.int("owner_id"))
+			if value == "nobody" && owner != nil || value != "nobody" && (owner == nil || owner.str("username") != value) {
+				return false
+			}
+		case "ref", "reference":
+			if task.str("reference") != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// setTaskTags replaces the tags of a task, creating the project tags that don't exist
+func (s *Server) setTaskTags(projectID, taskID int, names []any) {
+	s.remove("task_has_tags", where("task_id", taskID))
+	for _, value := range names {
+		name, _ := value.(string)
+		if name == "" {
+			continue
+		}
+		tag := s.findTag(projectID, name)
+		if tag == nil {
+			tag = s.findTag(0, name)
+		}
+		tagID := 0
+		if tag != nil {
+			tagID = tag.int("id")
+		} else {
+			tagID = s.insert("tags", record{"name": name, "project_id": projectID, "color_id": ""})
+		}
+		s.insert("task_has_tags", record{"task_id": taskID, "tag_id": tagID})
+	}
+}
+
+func (s *Server) findTag(projectID int, name string) record {
+	for _, tag := range s.list("tags", where("project_id", projectID)) {
+		if strings.EqualFold(tag.str("name"), name) {
+			return tag
+		}
+	}
+	return nil
+}
+
+// withUser adds the username, name and email of userID to a row
+func (s *Server) withUser(row map[string]any, userID int) map[string]any {
+	row["username"], row["name"], row["email"] = nil, nil, nil
+	if user := s.find("users", userID); user != nil {
+		row["username"], row["name"], row["email"] = user.str("username"), user.str("name"), user.str("email")
+	}
+	return row
+}
+
+// timerUser is the user_id argument, the calling user when it is not given
+func (s *Server) timerUser(c *call) int {
+	if c.has("user_id") || c.user == nil {
+		return c.int("user_id")
+	}
+	return c.user.int("id")
+}
+
+func (s *Server) runningTimer(c *call) record {
+	for _, timer := range s.list("subtask_time_tracking", where("subtask_id", c.int("subtask_id"))) {
+		if timer.int("user_id") == s.timerUser(c) && timer.int("end") == 0 {
+			return timer
+		}
+	}
+	return nil
+}
+
+// parseDate converts Kanboard's accepted date formats to a Unix timestamp
+func parseDate(value string) int {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02", time.RFC3339, "01/02/2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return int(t.Unix())
+		}
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package kanboardtest
+
+import "slices"
+
+var userRoles = []string{"app-admin", "app-manager", "app-user"}
+
+var userProcedures = map[string]procedure{
+	"getUser": proc(func(s *Server, c *call) (any, error) {
+		if user := s.find("users", c.int("user_id")); user != nil {
+			return user.row(), nil
+		}
+		return nil, nil
+	}, "user_id"),
+	"getUserByName": proc(func(s *Server, c *call) (any, error) {
+		if user := s.userByName(c.str("username")); user != nil {
+			return user.row(), nil
+		}
+		return nil, nil
+	}, "username"),
+	"getAllUsers": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("users", nil)), nil
+	}),
+	"createUser": proc(func(s *Server, c *call) (any, error) {
+		if c.str("username") == "" || len(c.str("password")) < 6 || s.userByName(c.str("username")) != nil ||
+			(c.has("role") && !slices.Contains(userRoles, c.str("role"))) {
+			return false, nil
+		}
+		user := record{"username": c.str("username"), "password": c.str("password"), "name": "", "email": "", "role": "app-user",
+			"is_active": 1, "is_ldap_user": 0, "notifications_enabled": 0, "timezone": "", "language": "", "avatar_path": ""}
+		c.set(user, "name", "email", "role")
+		return s.insert("users", user), nil
+	}, "username", "password", "?name", "?email", "?role"),
+	"createLdapUser": proc(func(s *Server, c *call) (any, error) {
+		// No LDAP server is configured, so Kanboard can't look the user up
+		return false, nil
+	}, "username"),
+	"updateUser": proc(func(s *Server, c *call) (any, error) {
+		user := s.find("users", c.int("id"))
+		if user == nil || (c.has("role") && !slices.Contains(userRoles, c.str("role"))) {
+			return false, nil
+		}
+		if c.has("username") {
+			if other := s.userByName(c.str("username")); other != nil && other.int("id") != user.int("id") {
+				return false, nil
+			}
+		}
+		c.set(user, "username", "name", "email", "role")
+		return true, nil
+	}, "id", "?username", "?name", "?email", "?role"),
+	"removeUser": proc(func(s *Server, c *call) (any, error) {
+		id := c.int("user_id")
+		if s.remove("users", byID(id)) == 0 {
+			return false, nil
+		}
+		s.remove("project_has_users", where("user_id", id))
+		s.remove("group_has_users", where("user_id", id))
+		for _, task := range s.list("tasks", where("owner_id", id)) {
+			task["owner_id"] = 0
+		}
+		return true, nil
+	}, "user_id"),
+	"enableUser":  userFlag(1),
+	"disableUser": userFlag(0),
+	"isActiveUser": proc(func(s *Server, c *call) (any, error) {
+		user := s.find("users", c.int("user_id"))
+		return user != nil && user.int("is_active") == 1, nil
+	}, "user_id"),
+
+	"getAllGroups": proc(func(s *Server, c *call) (any, error) {
+		return rows(s.list("groups", nil)), nil
+	}),
+	"getGroup": proc(func(s *Server, c *call) (any, error) {
+		return found(s.find("groups", c.int("group_id"))), nil
+	}, "group_id"),
+	"createGroup": proc(func(s *Server, c *call) (any, error) {
+		if c.str("name") == "" {
+			return false, nil
+		}
+		return s.insert("groups", record{"name": c.str("name"), "external_id": c.str("external_id")}), nil
+	}, "name", "?external_id"),
+	"updateGroup": proc(func(s *Server, c *call) (any, error) {
+		group := s.find("groups", c.int("group_id"))
+		if group == nil {
+			return false, nil
+		}
+		c.set(group, "name", "external_id")
+		return true, nil
+	}, "group_id", "?name", "?external_id"),
+	"removeGroup": proc(func(s *Server, c *call) (any, error) {
+		if s.remove("groups", byID(c.int("group_id"))) == 0 {
+			return false, nil
+		}
+		s.remove("group_has_users", where("group_id", c.int("group_id")))
+		s.remove("project_has_groups", where("group_id", c.int("group_id")))
+		return true, nil
+	}, "group_id"),
+	"getMemberGroups": proc(func(s *Server, c *call) (any, error) {
+		var groups []record
+		for _, member := range s.list("group_has_users", where("user_id", c.int("user_id"))) {
+			if group := s.find("groups", member.int("group_id")); group != nil {
+				groups = append(groups, group)
+			}
+		}
+		return rows(groups), nil
+	}, "user_id"),
+	"getGroupMembers": proc(func(s *Server, c *call) (any, error) {
+		var users []record
+		for _, member := range s.list("group_has_users", where("group_id", c.int("group_id"))) {
+			if user := s.find("users", member.int("user_id")); user != nil {
+				users = append(users, user)
+			}
+		}
+		return rows(users), nil
+	}, "group_id"),
+	"addGroupMember": proc(func(s *Server, c *call) (any, error) {
+		if s.find("groups", c.int("group_id")) == nil || s.find("users", c.int("user_id")) == nil || s.isGroupMember(c) {
+			return false, nil
+		}
+		s.insert("group_has_users", record{"group_id": c.int("group_id"), "user_id": c.int("user_id")})
+		return true, nil
+	}, "group_id", "user_id"),
+	"removeGroupMember": proc(func(s *Server, c *call) (any, error) {
+		return s.remove("group_has_users", func(member record) bool {
+			return member.int("group_id") == c.int("group_id") && member.int("user_id") == c.int("user_id")
+		}) > 0, nil
+	}, "group_id", "user_id"),
+	"isGroupMember": proc(func(s *Server, c *call) (any, error) {
+		return s.isGroupMember(c), nil
+	}, "group_id", "user_id"),
+}
+
+func userFlag(active int) procedure {
+	return proc(func(s *Server, c *call) (any, error) {
+		user := s.find("users", c.int("user_id"))
+		if user == nil {
+			return false, nil
+		}
+		user["is_active"] = active
+		return true, nil
+	}, "user_id")
+}
+
+func (s *Server) isGroupMember(c *call) bool {
+	return len(s.list("group_has_users", func(member record) bool {
+		return member.int("group_id") == c.int("group_id") && member.int("user_id") == c.int("user_id")
+	})) > 0
+}
@@ -0,0 +1,524 @@
+package kanboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kanboard returns numbers as JSON numbers or numeric strings depending on the version
+// and database driver, booleans as true/false, 0/1 or "0"/"1", and false or null for
+// objects that do not exist. The flex types below accept all of these variants and
+// always encode the same way, so tool output does not depend on the Kanboard backend.
+
+// flexInt is an integer that also decodes from numeric strings, booleans, "" and null
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(data []byte) error {
+	value, err := decodeScalar(data)
+	if err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*i = 0
+	case bool:
+		*i = 0
+		if v {
+			*i = 1
+		}
+	case json.Number:
+		n, err := parseFlexInt(string(v))
+		if err != nil {
+			return err
+		}
+		*i = flexInt(n)
+	case string:
+		n, err := parseFlexInt(v)
+		if err != nil {
+			return err
+		}
+		*i = flexInt(n)
+	}
+	return nil
+}
+
+func parseFlexInt(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+	return int(f), nil
+}
+
+// flexFloat is a number that also decodes from numeric strings, "" and null
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	value, err := decodeScalar(data)
+	if err != nil {
+		return err
+	}
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*f = 0
+		return nil
+	case bool:
+		*f = 0
+		if v {
+			*f = 1
+		}
+		return nil
+	case json.Number:
+		s = string(v)
+	case string:
+		s = strings.TrimSpace(v)
+	}
+	if s == "" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	*f = flexFloat(n)
+	return nil
+}
+
+// flexBool is a boolean that also decodes from 0/1, "0"/"1", "true"/"false", "" and null
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	value, err := decodeScalar(data)
+	if err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*b = false
+	case bool:
+		*b = flexBool(v)
+	case json.Number:
+		n, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %s", v)
+		}
+		*b = n != 0
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "", "0", "false", "no", "off":
+			*b = false
+		case "1", "true", "yes", "on":
+			*b = true
+		default:
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+	}
+	return nil
+}
+
+// flexString is a string that also decodes from numbers, booleans and null
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	value, err := decodeScalar(data)
+	if err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+	case bool:
+		*s = flexString(strconv.FormatBool(v))
+	case json.Number:
+		*s = flexString(v)
+	case string:
+		*s = flexString(v)
+	}
+	return nil
+}
+
+// decodeScalar decodes a JSON scalar, keeping numbers as json.Number
+func decodeScalar(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case nil, bool, json.Number, string:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("expected a scalar, got %s", data)
+	}
+}
+
+// Project is a Kanboard project
+type Project struct {
+	ID                      flexInt     `json:"id"`
+	Name                    flexString  `json:"name"`
+	Description             flexString  `json:"description"`
+	Identifier              flexString  `json:"identifier"`
+	IsActive                flexBool    `json:"is_active"`
+	IsPublic                flexBool    `json:"is_public"`
+	IsPrivate               flexBool    `json:"is_private"`
+	Token                   flexString  `json:"token"`
+	LastModified            flexInt     `json:"last_modified"`
+	StartDate               flexString  `json:"start_date"`
+	EndDate                 flexString  `json:"end_date"`
+	OwnerID                 flexInt     `json:"owner_id"`
+	PriorityDefault         flexInt     `json:"priority_default"`
+	PriorityStart           flexInt     `json:"priority_start"`
+	PriorityEnd             flexInt     `json:"priority_end"`
+	Email                   flexString  `json:"email"`
+	PredefinedEmailSubjects flexString  `json:"predefined_email_subjects"`
+	PerSwimlaneTaskLimits   flexBool    `json:"per_swimlane_task_limits"`
+	TaskLimit               flexInt     `json:"task_limit"`
+	EnableGlobalTags        flexBool    `json:"enable_global_tags"`
+	Role                    flexString  `json:"role,omitempty"` // set by getMyProjects on some versions
+	URL                     *ProjectURL `json:"url,omitempty"`
+}
+
+// ProjectURL holds the links Kanboard adds to project responses
+type ProjectURL struct {
+	Board    flexString `json:"board"`
+	Calendar flexString `json:"calendar,omitempty"`
+	List     flexString `json:"list"`
+}
+
+// Task is a Kanboard task. The project and assignee names are only set by the
+// overdue task lists.
+type Task struct {
+	ID                  flexInt    `json:"id"`
+	Title               flexString `json:"title"`
+	Description         flexString `json:"description"`
+	DateCreation        flexInt    `json:"date_creation"`
+	DateModification    flexInt    `json:"date_modification"`
+	DateCompleted       flexInt    `json:"date_completed"`
+	DateStarted         flexInt    `json:"date_started"`
+	DateDue             flexInt    `json:"date_due"`
+	DateMoved           flexInt    `json:"date_moved"`
+	ColorID             flexString `json:"color_id"`
+	ProjectID           flexInt    `json:"project_id"`
+	ColumnID            flexInt    `json:"column_id"`
+	SwimlaneID          flexInt    `json:"swimlane_id"`
+	OwnerID             flexInt    `json:"owner_id"`
+	CreatorID           flexInt    `json:"creator_id"`
+	CategoryID          flexInt    `json:"category_id"`
+	Position            flexInt    `json:"position"`
+	IsActive            flexBool   `json:"is_active"`
+	Score               flexInt    `json:"score"`
+	Priority            flexInt    `json:"priority"`
+	Reference           flexString `json:"reference"`
+	TimeSpent           flexFloat  `json:"time_spent"`
+	TimeEstimated       flexFloat  `json:"time_estimated"`
+	RecurrenceStatus    flexInt    `json:"recurrence_status"`
+	RecurrenceTrigger   flexInt    `json:"recurrence_trigger"`
+	RecurrenceFactor    flexInt    `json:"recurrence_factor"`
+	RecurrenceTimeframe flexInt    `json:"recurrence_timeframe"`
+	RecurrenceBasedate  flexInt    `json:"recurrence_basedate"`
+	RecurrenceParent    flexInt    `json:"recurrence_parent"`
+	RecurrenceChild     flexInt    `json:"recurrence_child"`
+	ExternalProvider    flexString `json:"external_provider,omitempty"`
+	ExternalURI         flexString `json:"external_uri,omitempty"`
+	URL                 flexString `json:"url,omitempty"`
+	Color               *TaskColor `json:"color,omitempty"`
+	ProjectName         flexString `json:"project_name,omitempty"`
+	AssigneeUsername    flexString `json:"assignee_username,omitempty"`
+	AssigneeName        flexString `json:"assignee_name,omitempty"`
+}
+
+// TaskColor is the color definition Kanboard embeds in task responses
+type TaskColor struct {
+	Name       flexString `json:"name"`
+	Background flexString `json:"background"`
+	Border     flexString `json:"border"`
+}
+
+// Column is a column of a project board
+type Column struct {
+	ID              flexInt    `json:"id"`
+	Title           flexString `json:"title"`
+	Position        flexInt    `json:"position"`
+	ProjectID       flexInt    `json:"project_id"`
+	TaskLimit       flexInt    `json:"task_limit"`
+	Description     flexString `json:"description"`
+	HideInDashboard flexBool   `json:"hide_in_dashboard"`
+}
+
+// Swimlane is a swimlane of a project board
+type Swimlane struct {
+	ID          flexInt    `json:"id"`
+	Name        flexString `json:"name"`
+	Position    flexInt    `json:"position"`
+	IsActive    flexBool   `json:"is_active"`
+	ProjectID   flexInt    `json:"project_id"`
+	Description flexString `json:"description"`
+	TaskLimit   flexInt    `json:"task_limit"`
+}
+
+// Category is a task category of a project
+type Category struct {
+	ID          flexInt    `json:"id"`
+	Name        flexString `json:"name"`
+	ProjectID   flexInt    `json:"project_id"`
+	Description flexString `json:"description"`
+	ColorID     flexString `json:"color_id"`
+}
+
+// Comment is a task comment, with its author's details
+type Comment struct {
+	ID               flexInt    `json:"id"`
+	TaskID           flexInt    `json:"task_id"`
+	UserID           flexInt    `json:"user_id"`
+	DateCreation     flexInt    `json:"date_creation"`
+	DateModification flexInt    `json:"date_modification"`
+	Comment          flexString `json:"comment"`
+	Reference        flexString `json:"reference"`
+	Visibility       flexString `json:"visibility,omitempty"`
+	Username         flexString `json:"username"`
+	Name             flexString `json:"name"`
+	Email            flexString `json:"email"`
+	AvatarPath       flexString `json:"avatar_path"`
+}
+
+// Subtask is a subtask of a task
+type Subtask struct {
+	ID             flexInt    `json:"id"`
+	Title          flexString `json:"title"`
+	Status         flexInt    `json:"status"`
+	StatusName     flexString `json:"status_name,omitempty"`
+	TimeEstimated  flexFloat  `json:"time_estimated"`
+	TimeSpent      flexFloat  `json:"time_spent"`
+	TaskID         flexInt    `json:"task_id"`
+	UserID         flexInt    `json:"user_id"`
+	Position       flexInt    `json:"position"`
+	Username       flexString `json:"username,omitempty"`
+	Name           flexString `json:"name,omitempty"`
+	TimerStartDate flexInt    `json:"timer_start_date,omitempty"`
+	IsTimerStarted flexBool   `json:"is_timer_started,omitempty"`
+}
+
+// User is a Kanboard user. Password hashes, two-factor secrets and API tokens
+// returned by Kanboard are deliberately not part of the model.
+type User struct {
+	ID                   flexInt    `json:"id"`
+	Username             flexString `json:"username"`
+	Name                 flexString `json:"name"`
+	Email                flexString `json:"email"`
+	Role                 flexString `json:"role"`
+	IsActive             flexBool   `json:"is_active"`
+	IsLdapUser           flexBool   `json:"is_ldap_user"`
+	NotificationsEnabled flexBool   `json:"notifications_enabled"`
+	NotificationsFilter  flexInt    `json:"notifications_filter"`
+	Timezone             flexString `json:"timezone"`
+	Language             flexString `json:"language"`
+	DisableLoginForm     flexBool   `json:"disable_login_form"`
+	TwofactorActivated   flexBool   `json:"twofactor_activated"`
+	NbFailedLogin        flexInt    `json:"nb_failed_login"`
+	LockExpirationDate   flexInt    `json:"lock_expiration_date"`
+	GoogleID             flexString `json:"google_id,omitempty"`
+	GithubID             flexString `json:"github_id,omitempty"`
+	GitlabID             flexString `json:"gitlab_id,omitempty"`
+	AvatarPath           flexString `json:"avatar_path"`
+	Filter               flexString `json:"filter,omitempty"`
+}
+
+// Group is a user group
+type Group struct {
+	ID         flexInt    `json:"id"`
+	ExternalID flexString `json:"external_id"`
+	Name       flexString `json:"name"`
+}
+
+// Tag is a project tag, or a global tag when ProjectID is 0
+type Tag struct {
+	ID        flexInt    `json:"id"`
+	Name      flexString `json:"name"`
+	ProjectID flexInt    `json:"project_id"`
+	ColorID   flexString `json:"color_id"`
+}
+
+// Link is a task link type such as "blocks" / "is blocked by"
+type Link struct {
+	ID         flexInt    `json:"id"`
+	Label      flexString `json:"label"`
+	OppositeID flexInt    `json:"opposite_id"`
+}
+
+// TaskLink links a task to another task with a link type
+type TaskLink struct {
+	ID             flexInt `json:"id"`
+	LinkID         flexInt `json:"link_id"`
+	TaskID         flexInt `json:"task_id"`
+	OppositeTaskID flexInt `json:"opposite_task_id"`
+}
+
+// LinkedTask is an entry of getAllTaskLinks: the link and the task at its other end.
+// TaskID is the ID of the opposite task.
+type LinkedTask struct {
+	ID                   flexInt    `json:"id"`
+	LinkID               flexInt    `json:"link_id"`
+	Label                flexString `json:"label"`
+	TaskID               flexInt    `json:"task_id"`
+	Title                flexString `json:"title"`
+	IsActive             flexBool   `json:"is_active"`
+	ProjectID            flexInt    `json:"project_id"`
+	ProjectName          flexString `json:"project_name,omitempty"`
+	ColumnID             flexInt    `json:"column_id,omitempty"`
+	ColumnTitle          flexString `json:"column_title"`
+	TaskTimeSpent        flexFloat  `json:"task_time_spent,omitempty"`
+	TaskTimeEstimated    flexFloat  `json:"task_time_estimated,omitempty"`
+	TaskAssigneeID       flexInt    `json:"task_assignee_id,omitempty"`
+	TaskAssigneeUsername flexString `json:"task_assignee_username,omitempty"`
+	TaskAssigneeName     flexString `json:"task_assignee_name,omitempty"`
+}
+
+// TaskFile is a file attached to a task
+type TaskFile struct {
+	ID       flexInt    `json:"id"`
+	Name     flexString `json:"name"`
+	Path     flexString `json:"path"`
+	IsImage  flexBool   `json:"is_image"`
+	TaskID   flexInt    `json:"task_id"`
+	Date     flexInt    `json:"date"`
+	UserID   flexInt    `json:"user_id"`
+	Size     flexInt    `json:"size"`
+	Username flexString `json:"username,omitempty"`
+	UserName flexString `json:"user_name,omitempty"`
+}
+
+// ProjectFile is a file attached to a project
+type ProjectFile struct {
+	ID        flexInt    `json:"id"`
+	Name      flexString `json:"name"`
+	Path      flexString `json:"path"`
+	IsImage   flexBool   `json:"is_image"`
+	ProjectID flexInt    `json:"project_id"`
+	Date      flexInt    `json:"date"`
+	UserID    flexInt    `json:"user_id"`
+	Size      flexInt    `json:"size"`
+}
+
+// ExternalTaskLink is a link from a task to an external resource such as a web page
+type ExternalTaskLink struct {
+	ID               flexInt    `json:"id"`
+	LinkType         flexString `json:"link_type"`
+	Dependency       flexString `json:"dependency"`
+	Title            flexString `json:"title"`
+	URL              flexString `json:"url"`
+	DateCreation     flexInt    `json:"date_creation"`
+	DateModification flexInt    `json:"date_modification"`
+	TaskID           flexInt    `json:"task_id"`
+	CreatorID        flexInt    `json:"creator_id"`
+}
+
+// Action is an automatic action of a project
+type Action struct {
+	ID         flexInt               `json:"id"`
+	ProjectID  flexInt               `json:"project_id"`
+	EventName  flexString            `json:"event_name"`
+	ActionName flexString            `json:"action_name"`
+	Params     map[string]flexString `json:"params"`
+}
+
+// Sprint is a sprint of the sprint plugin
+type Sprint struct {
+	ID          flexInt    `json:"id"`
+	ProjectID   flexInt    `json:"project_id"`
+	Name        flexString `json:"name"`
+	Description flexString `json:"description,omitempty"`
+	Goal        flexString `json:"goal,omitempty"`
+	StartDate   flexString `json:"start_date"`
+	EndDate     flexString `json:"end_date"`
+	IsActive    flexBool   `json:"is_active"`
+	IsCompleted flexBool   `json:"is_completed"`
+}
+
+// DecodeResult converts a JSON-RPC result into target through its JSON form
+func DecodeResult(result interface{}, target interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// DecodeObject decodes the result of a single-object lookup such as getTask. Kanboard
+// answers false, null or an empty object when the object does not exist, which is
+// reported as a not found error naming kind and ref.
+func DecodeObject[T any](result interface{}, kind string, ref interface{}) (*T, error) {
+	if isEmptyResult(result) {
+		return nil, &Error{Kind: ErrorKindNotFound, Message: fmt.Sprintf("%s %v not found", kind, ref)}
+	}
+	object := new(T)
+	if err := DecodeResult(result, object); err != nil {
+		return nil, fmt.Errorf("invalid %s %v: %w", kind, ref, err)
+	}
+	return object, nil
+}
+
+// DecodeList decodes the result of a list method. Empty results may come as false,
+// null or {}, and some methods return objects keyed by ID instead of arrays.
+func DecodeList[T any](result interface{}, method string) ([]T, error) {
+	if isEmptyResult(result) {
+		return []T{}, nil
+	}
+	if object, ok := result.(map[string]interface{}); ok {
+		keys := slices.Sorted(maps.Keys(object))
+		sort.SliceStable(keys, func(i, j int) bool {
+			a, errA := strconv.Atoi(keys[i])
+			b, errB := strconv.Atoi(keys[j])
+			return errA == nil && errB == nil && a < b
+		})
+		items := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			items = append(items, object[key])
+		}
+		result = items
+	}
+	list := []T{}
+	if err := DecodeResult(result, &list); err != nil {
+		return nil, fmt.Errorf("unexpected result for %s: %w", method, err)
+	}
+	return list, nil
+}
+
+// DecodeID reads the ID returned by a create method, which answers false on failure
+func DecodeID(result interface{}, method string) (int, error) {
+	var id flexInt
+	if result == false || DecodeResult(result, &id) != nil || id <= 0 {
+		return 0, fmt.Errorf("unexpected result for %s: %v", method, result)
+	}
+	return int(id), nil
+}
+
+func isEmptyResult(result interface{}) bool {
+	switch v := result.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
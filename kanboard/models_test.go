@@ -0,0 +1,129 @@
+package kanboard
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFlexScalars(t *testing.T) {
+	cases := []struct {
+		json    string
+		int     flexInt
+		float   flexFloat
+		bool    flexBool
+		string  flexString
+		invalid string // the flex types that refuse the value
+	}{
+		{json: `0`, string: "0"},
+		{json: `"0"`, string: "0"},
+		{json: `""`},
+		{json: `null`},
+		{json: `1`, int: 1, float: 1, bool: true, string: "1"},
+		{json: `"1"`, int: 1, float: 1, bool: true, string: "1"},
+		{json: `"42"`, int: 42, float: 42, string: "42", invalid: "bool"},
+		{json: `" 7 "`, int: 7, float: 7, string: " 7 ", invalid: "bool"},
+		{json: `"-3"`, int: -3, float: -3, string: "-3", invalid: "bool"},
+		{json: `"2.0"`, int: 2, float: 2, string: "2.0", invalid: "bool"},
+		{json: `"1.5"`, float: 1.5, string: "1.5", invalid: "int bool"},
+		{json: `1.5`, float: 1.5, bool: true, string: "1.5", invalid: "int"},
+		{json: `1700000000`, int: 1700000000, float: 1700000000, bool: true, string: "1700000000"},
+		{json: `true`, int: 1, float: 1, bool: true, string: "true"},
+		{json: `false`, string: "false"},
+		{json: `"true"`, bool: true, string: "true", invalid: "int float"},
+		{json: `"false"`, string: "false", invalid: "int float"},
+		{json: `"abc"`, string: "abc", invalid: "int float bool"},
+		{json: `[1]`, invalid: "int float bool string"},
+		{json: `{"id": 1}`, invalid: "int float bool string"},
+	}
+	for _, c := range cases {
+		t.Run(c.json, func(t *testing.T) {
+			var i flexInt
+			var f flexFloat
+			var b flexBool
+			var s flexString
+			targets := []struct {
+				name   string
+				target json.Unmarshaler
+				got    func() interface{}
+				want   interface{}
+			}{
+				{"int", &i, func() interface{} { return i }, c.int},
+				{"float", &f, func() interface{} { return f }, c.float},
+				{"bool", &b, func() interface{} { return b }, c.bool},
+				{"string", &s, func() interface{} { return s }, c.string},
+			}
+			for _, target := range targets {
+				err := json.Unmarshal([]byte(c.json), target.target)
+				if wantErr := strings.Contains(c.invalid, target.name); wantErr != (err != nil) {
+					t.Errorf("flex%s: error %v, want error %v", target.name, err, wantErr)
+					continue
+				}
+				if err == nil && target.got() != target.want {
+					t.Errorf("flex%s = %v, want %v", target.name, target.got(), target.want)
+				}
+			}
+		})
+	}
+}
+
+// Models encode the same way whatever variant Kanboard sent
+func TestModelsNormalizeKanboardVariants(t *testing.T) {
+	want := `{"id":1,"link_type":"weblink","dependency":"related","title":"Spec","url":"https://example.com","date_creation":0,"date_modification":0,"task_id":3,"creator_id":0}`
+	variants := []string{
+		`{"id": 1, "link_type": "weblink", "dependency": "related", "title": "Spec", "url": "https://example.com", "date_creation": 0, "date_modification": null, "task_id": 3, "creator_id": 0}`,
+		`{"id": "1", "link_type": "weblink", "dependency": "related", "title": "Spec", "url": "https://example.com", "date_creation": "0", "date_modification": "", "task_id": "3", "creator_id": null}`,
+	}
+	for _, variant := range variants {
+		var result interface{}
+		if err := json.Unmarshal([]byte(variant), &result); err != nil {
+			t.Fatal(err)
+		}
+		link, err := DecodeObject[ExternalTaskLink](result, "external task link", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := json.Marshal(link); string(got) != want {
+			t.Errorf("%s\nencodes as %s\nwant       %s", variant, got, want)
+		}
+	}
+}
+
+func TestDecodeResults(t *testing.T) {
+	decode := func(raw string) interface{} {
+		var result interface{}
+		if err := json.Unmarshal([]byte(raw), &result); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	for _, raw := range []string{`false`, `null`, `{}`} {
+		_, err := DecodeObject[Task](decode(raw), "task", 42)
+		var kbErr *Error
+		if !errors.As(err, &kbErr) || kbErr.Kind != ErrorKindNotFound || err.Error() != "task 42 not found" {
+			t.Errorf("DecodeObject(%s) error = %v, want task 42 not found", raw, err)
+		}
+		if list, err := DecodeList[Task](decode(raw), "getAllTasks"); err != nil || list == nil || len(list) != 0 {
+			t.Errorf("DecodeList(%s) = %v, %v, want an empty list", raw, list, err)
+		}
+	}
+
+	// Lists keyed by ID come back in ID order
+	tags, err := DecodeList[Tag](decode(`{"10": {"id": "10", "name": "b"}, "9": {"id": 9, "name": "a"}}`), "getTagsByProject")
+	if err != nil || len(tags) != 2 || tags[0].ID != 9 || tags[1].ID != 10 {
+		t.Errorf("DecodeList of an object = %+v, %v", tags, err)
+	}
+	if _, err := DecodeList[Tag](decode(`"tags"`), "getAllTags"); err == nil {
+		t.Error("DecodeList accepted a string")
+	}
+
+	ids := map[string]int{`5`: 5, `"5"`: 5, `false`: 0, `0`: 0, `"abc"`: 0, `null`: 0}
+	for raw, want := range ids {
+		id, err := DecodeID(decode(raw), "createTask")
+		if id != want || (err == nil) != (want > 0) {
+			t.Errorf("DecodeID(%s) = %d, %v, want %d", raw, id, err, want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package kanboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxListedNames caps how many valid values a "not found" error lists
+const maxListedNames = 30
+
+// NameResolver turns a name given in place of an ID into that ID
+type NameResolver func(ctx context.Context, ref string) (int, error)
+
+// namedCandidate is an object whose ID may be given by one of its names
+type namedCandidate struct {
+	ID    int
+	Names []string // matched against the reference, the first one is listed in errors
+	Note  string   // extra detail listed in errors, e.g. the full name of a user
+}
+
+func (c namedCandidate) String() string {
+	if c.Note == "" {
+		return fmt.Sprintf("%s (%d)", c.Names[0], c.ID)
+	}
+	return fmt.Sprintf("%s (%d, %s)", c.Names[0], c.ID, c.Note)
+}
+
+// FuzzyNamesFromEnv reads KANBOARD_NAME_MATCHING: "strict" (default) only accepts whole
+// names, "fuzzy" also accepts a part of a name as long as it matches a single object
+func FuzzyNamesFromEnv() (bool, error) {
+	switch value := strings.ToLower(strings.TrimSpace(os.Getenv("KANBOARD_NAME_MATCHING"))); value {
+	case "", "strict":
+		return false, nil
+	case "fuzzy":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid KANBOARD_NAME_MATCHING: %q (expected strict or fuzzy)", value)
+	}
+}
+
+type exactNamesContextKey struct{}
+
+// WithExactNames returns a context in which names given in place of IDs must match in
+// full, whatever KANBOARD_NAME_MATCHING says. Destructive tools run in such a context so
+// that a part of a name never picks the object to remove.
+func WithExactNames(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exactNamesContextKey{}, true)
+}
+
+// fuzzyNames reports whether a unique partial name is accepted for the calls made with ctx
+func (kc *Client) fuzzyNames(ctx context.Context) bool {
+	exact, _ := ctx.Value(exactNamesContextKey{}).(bool)
+	return kc.FuzzyNames && !exact
+}
+
+// matchName picks the candidate named ref, ignoring case unless that is ambiguous.
+// In fuzzy mode a unique partial match is accepted too. where describes the search
+// scope for errors, e.g. "in project 7".
+func matchName(kind, where, ref string, candidates []namedCandidate, fuzzy bool) (int, error) {
+	needle := strings.ToLower(strings.TrimSpace(ref))
+	var matches []namedCandidate
+	for _, candidate := range candidates {
+		for _, name := range candidate.Names {
+			if name != "" && strings.ToLower(name) == needle {
+				matches = append(matches, candidate)
+				break
+			}
+		}
+	}
+	if len(matches) > 1 {
+		// Names differing only in case: an exact match wins
+		var exact []namedCandidate
+		for _, candidate := range matches {
+			for _, name := range candidate.Names {
+				if name == strings.TrimSpace(ref) {
+					exact = append(exact, candidate)
+					break
+				}
+			}
+		}
+		if len(exact) == 1 {
+			matches = exact
+		}
+	}
+	if len(matches) == 0 && fuzzy {
+		for _, candidate := range candidates {
+			for _, name := range candidate.Names {
+				if name != "" && strings.Contains(strings.ToLower(name), needle) {
+					matches = append(matches, candidate)
+					break
+				}
+			}
+		}
+	}
+
+	if where != "" {
+		where = " " + where
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0].ID, nil
+	case 0:
+		if len(candidates) == 0 {
+			return 0, &Error{Kind: ErrorKindNotFound, Message: fmt.Sprintf("%s '%s' not found%s, there are no %s", kind, ref, where, plural(kind))}
+		}
+		return 0, &Error{
+			Kind:    ErrorKindNotFound,
+			Message: fmt.Sprintf("%s '%s' not found%s; valid %s: %s", kind, ref, where, plural(kind), listCandidates(candidates)),
+		}
+	default:
+		return 0, &Error{
+			Kind: ErrorKindValidation,
+			Message: fmt.Sprintf("%s '%s' is ambiguous%s, it matches %d %s: %s; use the ID",
+				kind, ref, where, len(matches), plural(kind), listCandidates(matches)),
+		}
+	}
+}
+
+func plural(kind string) string {
+	if strings.HasSuffix(kind, "y") {
+		return strings.TrimSuffix(kind, "y") + "ies"
+	}
+	return kind + "s"
+}
+
+// listCandidates formats candidates sorted by ID, at most maxListedNames of them
+func listCandidates(candidates []namedCandidate) string {
+	sorted := append([]namedCandidate(nil), candidates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	names := make([]string, 0, len(sorted))
+	for i, candidate := range sorted {
+		if i == maxListedNames {
+			names = append(names, fmt.Sprintf("and %d more", len(sorted)-maxListedNames))
+			break
+		}
+		names = append(names, candidate.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// newCandidates turns a list of Kanboard objects into name candidates, skipping objects without an ID
+func newCandidates[T any](objects []T, candidate func(T) namedCandidate) []namedCandidate {
+	candidates := make([]namedCandidate, 0, len(objects))
+	for _, object := range objects {
+		if c := candidate(object); c.ID > 0 {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// ResolveUser accepts a user ID, a username or a full name
+func (kc *Client) ResolveUser(ctx context.Context, ref string) (int, error) {
+	user, err := kc.GetUserByName(ctx, ref)
+	if err == nil && user.ID > 0 {
+		return int(user.ID), nil
+	}
+	if err != nil && errorKind(err) != ErrorKindNotFound {
+		return 0, fmt.Errorf("failed to resolve user '%s': %w", ref, err)
+	}
+
+	list, err := kc.GetAllUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve user '%s': getAllUsers failed: %w", ref, err)
+	}
+	users := newCandidates(list, func(user User) namedCandidate {
+		return namedCandidate{ID: int(user.ID), Names: []string{string(user.Username), string(user.Name)}, Note: string(user.Name)}
+	})
+	return matchName("user", "", ref, users, kc.fuzzyNames(ctx))
+}
+
+// ResolveColumn accepts a column ID or a column title within a project
+func (kc *Client) ResolveColumn(ctx context.Context, projectID int, ref string) (int, error) {
+	if projectID == 0 {
+		return 0, needsProjectError("column", ref)
+	}
+	list, err := kc.GetColumns(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve column '%s': getColumns failed: %w", ref, err)
+	}
+	columns := newCandidates(list, func(column Column) namedCandidate {
+		return namedCandidate{ID: int(column.ID), Names: []string{string(column.Title)}}
+	})
+	return matchName("column", fmt.Sprintf("in project %d", projectID), ref, columns, kc.fuzzyNames(ctx))
+}
+
+// ResolveSwimlane accepts a swimlane ID or a swimlane name within a project
+func (kc *Client) ResolveSwimlane(ctx context.Context, projectID int, ref string) (int, error) {
+	if projectID == 0 {
+		return 0, needsProjectError("swimlane", ref)
+	}
+	swimlane, err := kc.GetSwimlaneByName(ctx, projectID, ref)
+	if err == nil && swimlane.ID > 0 {
+		return int(swimlane.ID), nil
+	}
+	if err != nil && errorKind(err) != ErrorKindNotFound {
+		return 0, fmt.Errorf("failed to resolve swimlane '%s': %w", ref, err)
+	}
+
+	list, err := kc.GetAllSwimlanes(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve swimlane '%s': getAllSwimlanes failed: %w", ref, err)
+	}
+	swimlanes := newCandidates(list, func(swimlane Swimlane) namedCandidate {
+		return namedCandidate{ID: int(swimlane.ID), Names: []string{string(swimlane.Name)}}
+	})
+	return matchName("swimlane", fmt.Sprintf("in project %d", projectID), ref, swimlanes, kc.fuzzyNames(ctx))
+}
+
+// ResolveCategory accepts a category ID or a category name within a project
+func (kc *Client) ResolveCategory(ctx context.Context, projectID int, ref string) (int, error) {
+	if projectID == 0 {
+		return 0, needsProjectError("category", ref)
+	}
+	list, err := kc.GetAllCategories(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve category '%s': getAllCategories failed: %w", ref, err)
+	}
+	categories := newCandidates(list, func(category Category) namedCandidate {
+		return namedCandidate{ID: int(category.ID), Names: []string{string(category.Name)}}
+	})
+	return matchName("category", fmt.Sprintf("in project %d", projectID), ref, categories, kc.fuzzyNames(ctx))
+}
+
+// ResolveTag accepts a tag ID or a tag name, within a project or, when projectID is 0,
+// across all projects
+func (kc *Client) ResolveTag(ctx context.Context, projectID int, ref string) (int, error) {
+	var list []Tag
+	var err error
+	where := ""
+	if projectID != 0 {
+		list, err = kc.GetTagsByProject(ctx, projectID)
+		where = fmt.Sprintf("in project %d", projectID)
+	} else {
+		list, err = kc.GetAllTags(ctx)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve tag '%s': %w", ref, err)
+	}
+	tags := newCandidates(list, func(tag Tag) namedCandidate {
+		note := "global"
+		if tag.ProjectID != 0 {
+			note = fmt.Sprintf("project %d", tag.ProjectID)
+		}
+		return namedCandidate{ID: int(tag.ID), Names: []string{string(tag.Name)}, Note: note}
+	})
+	return matchName("tag", where, ref, tags, kc.fuzzyNames(ctx))
+}
+
+func needsProjectError(kind, ref string) error {
+	return &Error{
+		Kind:    ErrorKindValidation,
+		Message: fmt.Sprintf("%s '%s' can only be looked up by name within a project; pass project_id or the numeric %s ID", kind, ref, kind),
+	}
+}
+
+// The resolvers below bind the project a name is looked up in
+
+func (kc *Client) ColumnResolver(projectID int) NameResolver {
+	return func(ctx context.Context, ref string) (int, error) { return kc.ResolveColumn(ctx, projectID, ref) }
+}
+
+func (kc *Client) SwimlaneResolver(projectID int) NameResolver {
+	return func(ctx context.Context, ref string) (int, error) { return kc.ResolveSwimlane(ctx, projectID, ref) }
+}
+
+func (kc *Client) CategoryResolver(projectID int) NameResolver {
+	return func(ctx context.Context, ref string) (int, error) { return kc.ResolveCategory(ctx, projectID, ref) }
+}
+
+func (kc *Client) TagResolver(projectID int) NameResolver {
+	return func(ctx context.Context, ref string) (int, error) { return kc.ResolveTag(ctx, projectID, ref) }
+}
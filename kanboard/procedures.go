@@ -0,0 +1,86 @@
+package kanboard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// kanboardProcedures maps each Kanboard JSON-RPC method to the procedure class that implements it
+var kanboardProcedures = map[string][]string{
+	"actionprocedure":              {"getAvailableActions", "getAvailableActionEvents", "getCompatibleActionEvents", "getActions", "createAction", "removeAction"},
+	"appprocedure":                 {"getVersion", "getTimezone", "getDefaultTaskColors", "getDefaultTaskColor", "getColorList", "getApplicationRoles", "getProjectRoles"},
+	"boardprocedure":               {"getBoard"},
+	"categoryprocedure":            {"createCategory", "getCategory", "getAllCategories", "updateCategory", "removeCategory"},
+	"columnprocedure":              {"getColumns", "getColumn", "changeColumnPosition", "updateColumn", "addColumn", "removeColumn"},
+	"commentprocedure":             {"getComment", "getAllComments", "createComment", "updateComment", "removeComment"},
+	"groupprocedure":               {"createGroup", "updateGroup", "removeGroup", "getGroup", "getAllGroups"},
+	"groupmemberprocedure":         {"getMemberGroups", "getGroupMembers", "addGroupMember", "removeGroupMember", "isGroupMember"},
+	"linkprocedure":                {"getLinkById", "getLinkByLabel", "getOppositeLinkId", "getAllLinks", "createLink", "updateLink", "removeLink"},
+	"meprocedure":                  {"getMe", "getMyDashboard", "getMyActivityStream", "createMyPrivateProject", "getMyProjectsList", "getMyOverdueTasks", "getMyProjects"},
+	"projectprocedure":             {"getProjectById", "getProjectByName", "getProjectByIdentifier", "getProjectByEmail", "getAllProjects", "removeProject", "enableProject", "disableProject", "enableProjectPublicAccess", "disableProjectPublicAccess", "getProjectActivity", "getProjectActivities", "createProject", "updateProject"},
+	"projectfileprocedure":         {"getProjectFile", "getAllProjectFiles", "downloadProjectFile", "createProjectFile", "removeProjectFile", "removeAllProjectFiles"},
+	"projectmetadataprocedure":     {"getProjectMetadata", "getProjectMetadataByName", "saveProjectMetadata", "removeProjectMetadata"},
+	"projectpermissionprocedure":   {"getProjectUsers", "getAssignableUsers", "addProjectUser", "addProjectGroup", "removeProjectUser", "removeProjectGroup", "changeProjectUserRole", "changeProjectGroupRole", "getProjectUserRole"},
+	"subtaskprocedure":             {"getSubtask", "getAllSubtasks", "removeSubtask", "createSubtask", "updateSubtask"},
+	"subtasktimetrackingprocedure": {"hasSubtaskTimer", "setSubtaskStartTime", "setSubtaskEndTime", "getSubtaskTimeSpent"},
+	"swimlaneprocedure":            {"getActiveSwimlanes", "getAllSwimlanes", "getSwimlaneById", "getSwimlaneByName", "getSwimlane", "changeSwimlanePosition", "updateSwimlane", "addSwimlane", "removeSwimlane", "disableSwimlane", "enableSwimlane"},
+	"taskprocedure":                {"searchTasks", "getTask", "getTaskByReference", "getAllTasks", "getOverdueTasks", "getOverdueTasksByProject", "openTask", "closeTask", "removeTask", "moveTaskPosition", "moveTaskToProject", "duplicateTaskToProject", "createTask", "updateTask"},
+	"taskfileprocedure":            {"getTaskFile", "getAllTaskFiles", "downloadTaskFile", "createTaskFile", "removeTaskFile", "removeAllTaskFiles"},
+	"tasklinkprocedure":            {"getTaskLinkById", "getAllTaskLinks", "createTaskLink", "updateTaskLink", "removeTaskLink"},
+	"taskexternallinkprocedure":    {"getExternalTaskLinkTypes", "getExternalTaskLinkProviderDependencies", "getExternalTaskLinkById", "getAllExternalTaskLinks", "createExternalTaskLink", "updateExternalTaskLink", "removeExternalTaskLink"},
+	"taskmetadataprocedure":        {"getTaskMetadata", "getTaskMetadataByName", "saveTaskMetadata", "removeTaskMetadata"},
+	"tagprocedure":                 {"getAllTags", "getTagsByProject", "createTag", "updateTag", "removeTag"},
+	"tasktagprocedure":             {"setTaskTags", "getTaskTags"},
+	"userprocedure":                {"getUser", "getUserByName", "getAllUsers", "removeUser", "disableUser", "enableUser", "isActiveUser", "createUser", "createLdapUser", "updateUser"},
+	"sprintprocedure":              {"createSprint", "getSprintById", "updateSprint", "removeSprint", "getAllSprintsByProject"},
+}
+
+// procedureByMethod is the reverse index of kanboardProcedures
+var procedureByMethod = func() map[string]string {
+	index := make(map[string]string)
+	for procedure, methods := range kanboardProcedures {
+		for _, method := range methods {
+			index[method] = procedure
+		}
+	}
+	return index
+}()
+
+// ProcedureForMethod returns the procedure class of a Kanboard JSON-RPC method
+func ProcedureForMethod(method string) (string, bool) {
+	procedure, ok := procedureByMethod[method]
+	return procedure, ok
+}
+
+// readOnlyMethodPrefixes are the Kanboard method verbs that never change data
+var readOnlyMethodPrefixes = []string{"get", "is", "has", "search", "download"}
+
+// IsMutatingMethod reports whether a Kanboard JSON-RPC method changes data
+func IsMutatingMethod(method string) bool {
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToInt converts the number representations Kanboard and MCP clients use into an int
+func ToInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case string:
+		id, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, false
+		}
+		return id, true
+	default:
+		return 0, false
+	}
+}
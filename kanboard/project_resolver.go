@@ -0,0 +1,128 @@
+package kanboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kanboard-mcp/logging"
+)
+
+// projectCandidate is a project considered while resolving a reference
+type projectCandidate struct {
+	ID         int
+	Name       string
+	Identifier string
+}
+
+func (p projectCandidate) String() string {
+	if p.Identifier == "" {
+		return fmt.Sprintf("%d: %s", p.ID, p.Name)
+	}
+	return fmt.Sprintf("%d: %s (%s)", p.ID, p.Name, p.Identifier)
+}
+
+func newProjectCandidate(project Project) projectCandidate {
+	return projectCandidate{ID: int(project.ID), Name: string(project.Name), Identifier: string(project.Identifier)}
+}
+
+// ResolveProject turns a project reference into a project ID. The reference is tried as
+// a numeric ID, an exact name, a project identifier and then as a case-insensitive name
+// or identifier. In fuzzy mode (KANBOARD_NAME_MATCHING=fuzzy) a part of a name is
+// accepted last, except for destructive tools (see WithExactNames). A reference matching
+// several projects is rejected with the list of candidates.
+func (kc *Client) ResolveProject(ctx context.Context, ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return 0, &Error{Kind: ErrorKindValidation, Message: "project reference cannot be empty"}
+	}
+	if id, err := strconv.Atoi(ref); err == nil {
+		if id <= 0 {
+			return 0, &Error{Kind: ErrorKindValidation, Message: fmt.Sprintf("invalid project ID %d", id)}
+		}
+		return id, nil
+	}
+
+	// Exact name and identifier lookups go out together
+	results, err := kc.CallBatch(ctx, []BatchCall{
+		{Method: "getProjectByName", Params: map[string]string{"name": ref}},
+		{Method: "getProjectByIdentifier", Params: map[string]string{"identifier": strings.ToUpper(ref)}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve project '%s': %w", ref, err)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		if project, err := DecodeObject[Project](result.Result, "project", ref); err == nil && project.ID > 0 {
+			return int(project.ID), nil
+		}
+	}
+
+	projects, err := kc.listProjectCandidates(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve project '%s': %w", ref, err)
+	}
+	matches := matchProjects(projects, ref, kc.fuzzyNames(ctx))
+	switch len(matches) {
+	case 0:
+		return 0, &Error{Kind: ErrorKindNotFound, Message: fmt.Sprintf("project '%s' not found", ref)}
+	case 1:
+		logging.HTTP.DebugContext(ctx, "project resolved by name", "reference", ref, "project_id", matches[0].ID, "name", matches[0].Name)
+		return matches[0].ID, nil
+	}
+
+	names := make([]string, len(matches))
+	for i, project := range matches {
+		names[i] = project.String()
+	}
+	return 0, &Error{
+		Kind: ErrorKindValidation,
+		Message: fmt.Sprintf("project '%s' is ambiguous, it matches %d projects: %s; use the project ID or the full name",
+			ref, len(matches), strings.Join(names, ", ")),
+	}
+}
+
+// listProjectCandidates loads the projects visible to the configured credentials.
+// User credentials may not list all projects, their own projects are used instead.
+func (kc *Client) listProjectCandidates(ctx context.Context) ([]projectCandidate, error) {
+	list, err := kc.GetAllProjects(ctx)
+	if errorKind(err) == ErrorKindAuth {
+		list, err = kc.GetMyProjects(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]projectCandidate, 0, len(list))
+	for _, project := range list {
+		if project.ID > 0 {
+			projects = append(projects, newProjectCandidate(project))
+		}
+	}
+	return projects, nil
+}
+
+// matchProjects returns the projects whose name or identifier equals ref ignoring case,
+// or failing that and when fuzzy is set, whose name contains it. Results are sorted by ID.
+func matchProjects(projects []projectCandidate, ref string, fuzzy bool) []projectCandidate {
+	needle := strings.ToLower(ref)
+	var exact, partial []projectCandidate
+	for _, project := range projects {
+		name := strings.ToLower(project.Name)
+		switch {
+		case name == needle || strings.EqualFold(project.Identifier, ref):
+			exact = append(exact, project)
+		case fuzzy && strings.Contains(name, needle):
+			partial = append(partial, project)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
@@ -0,0 +1,178 @@
+package kanboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all requests to one Kanboard server
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now(), now: time.Now}
+}
+
+// wait blocks until a token is available or ctx is done. A nil limiter never blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token now, possibly going into debt, and returns how long to wait
+// until the debt is paid back
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens < 0 {
+		return time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	return 0
+}
+
+// methodLimiter caps the number of in-flight requests per Kanboard method
+type methodLimiter struct {
+	limit int
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newMethodLimiter(limit int) *methodLimiter {
+	return &methodLimiter{limit: limit, slots: make(map[string]chan struct{})}
+}
+
+func (m *methodLimiter) slot(method string) chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	slot, ok := m.slots[method]
+	if !ok {
+		slot = make(chan struct{}, m.limit)
+		m.slots[method] = slot
+	}
+	return slot
+}
+
+// acquire takes a slot for every given method and returns the function releasing them.
+// Methods are taken in sorted order so that concurrent batches cannot deadlock.
+// A nil limiter never blocks.
+func (m *methodLimiter) acquire(ctx context.Context, methods ...string) (func(), error) {
+	if m == nil {
+		return func() {}, nil
+	}
+
+	unique := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		unique[method] = true
+	}
+	sorted := make([]string, 0, len(unique))
+	for method := range unique {
+		sorted = append(sorted, method)
+	}
+	sort.Strings(sorted)
+
+	var held []chan struct{}
+	release := func() {
+		for _, slot := range held {
+			<-slot
+		}
+	}
+	for _, method := range sorted {
+		slot := m.slot(method)
+		select {
+		case slot <- struct{}{}:
+			held = append(held, slot)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// throttle waits for the rate limiter and the per-method slots before a request is sent
+func (kc *Client) throttle(ctx context.Context, methods ...string) (func(), error) {
+	release, err := kc.MethodLimiter.acquire(ctx, methods...)
+	if err != nil {
+		return nil, err
+	}
+	if err := kc.RateLimiter.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// NewRateLimitersFromEnv reads KANBOARD_RATE_LIMIT, KANBOARD_RATE_BURST and
+// KANBOARD_MAX_CONCURRENT_PER_METHOD; unset or zero values disable the limits
+func NewRateLimitersFromEnv() (*rateLimiter, *methodLimiter, error) {
+	var limiter *rateLimiter
+	if value := os.Getenv("KANBOARD_RATE_LIMIT"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return nil, nil, fmt.Errorf("invalid KANBOARD_RATE_LIMIT: %q (expected requests per second)", value)
+		}
+		if rate > 0 {
+			burst := int(rate)
+			if value := os.Getenv("KANBOARD_RATE_BURST"); value != "" {
+				burst, err = strconv.Atoi(value)
+				if err != nil || burst < 1 {
+					return nil, nil, fmt.Errorf("invalid KANBOARD_RATE_BURST: %q (expected a positive integer)", value)
+				}
+			}
+			limiter = newRateLimiter(rate, burst)
+		}
+	}
+
+	var perMethod *methodLimiter
+	if value := strings.TrimSpace(os.Getenv("KANBOARD_MAX_CONCURRENT_PER_METHOD")); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return nil, nil, fmt.Errorf("invalid KANBOARD_MAX_CONCURRENT_PER_METHOD: %q (expected a non-negative integer)", value)
+		}
+		if limit > 0 {
+			perMethod = newMethodLimiter(limit)
+		}
+	}
+
+	return limiter, perMethod, nil
+}
@@ -0,0 +1,114 @@
+package kanboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for the rate limiter and the circuit breaker
+type fakeClock struct {
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRateLimiter(2, 3)
+	limiter.now, limiter.last = clock.Now, clock.Now()
+
+	steps := []struct {
+		advance time.Duration
+		delay   time.Duration
+	}{
+		// The burst is available at once
+		{0, 0}, {0, 0}, {0, 0},
+		// Then requests go into debt at 2 per second
+		{0, 500 * time.Millisecond},
+		{0, time.Second},
+		// Waiting pays the debt back
+		{time.Second, 500 * time.Millisecond},
+		// A long idle period refills no more than the burst
+		{time.Minute, 0}, {0, 0}, {0, 0},
+		{0, 500 * time.Millisecond},
+	}
+	for i, step := range steps {
+		clock.Advance(step.advance)
+		if delay := limiter.reserve(); delay != step.delay {
+			t.Fatalf("step %d: delay %v, want %v", i, delay, step.delay)
+		}
+	}
+}
+
+func TestRateLimiterWaitCancelledReturnsToken(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRateLimiter(0.001, 1)
+	limiter.now, limiter.last = clock.Now, clock.Now()
+
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled wait to fail, got %v", err)
+	}
+	// The cancelled request gave its token back: the next one waits for one token, not two
+	if delay := limiter.reserve(); delay != 1000*time.Second {
+		t.Fatalf("delay %v, want 1000s", delay)
+	}
+
+	var disabled *rateLimiter
+	if err := disabled.wait(ctx); err != nil {
+		t.Fatalf("a nil limiter must not block: %v", err)
+	}
+}
+
+func TestMethodLimiterSlots(t *testing.T) {
+	limiter := newMethodLimiter(1)
+	blocked := func(methods ...string) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		release, err := limiter.acquire(ctx, methods...)
+		if err != nil {
+			return true
+		}
+		release()
+		return false
+	}
+
+	// Duplicates in a batch take a single slot
+	release, err := limiter.acquire(context.Background(), "getTask", "getTask", "getProjectById")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !blocked("getTask") || !blocked("getProjectById") {
+		t.Fatal("a held method slot must block the next request")
+	}
+	if blocked("getAllTasks") {
+		t.Fatal("other methods have their own slots")
+	}
+	// A batch that cannot get every slot gives back the ones it took
+	if !blocked("getAllTasks", "getTask") || blocked("getAllTasks") {
+		t.Fatal("a failed batch must release the slots it acquired")
+	}
+	release()
+	if blocked("getTask", "getProjectById") {
+		t.Fatal("released slots must be available again")
+	}
+
+	var disabled *methodLimiter
+	if release, err := disabled.acquire(context.Background(), "getTask"); err != nil {
+		t.Fatal(err)
+	} else {
+		release()
+	}
+}
@@ -0,0 +1,328 @@
+package kanboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kanboard-mcp/logging"
+	"kanboard-mcp/metrics"
+)
+
+// responseCacheTTLs are the default lifetimes of cached Kanboard read methods.
+// Only reference data that rarely changes is cached.
+var responseCacheTTLs = map[string]time.Duration{
+	"getColumns":             5 * time.Minute,
+	"getAllSwimlanes":        5 * time.Minute,
+	"getActiveSwimlanes":     5 * time.Minute,
+	"getSwimlaneByName":      5 * time.Minute,
+	"getAllCategories":       5 * time.Minute,
+	"getAllTags":             5 * time.Minute,
+	"getTagsByProject":       5 * time.Minute,
+	"getProjectByName":       5 * time.Minute,
+	"getProjectByIdentifier": 5 * time.Minute,
+	"getAllLinks":            30 * time.Minute,
+	"getColorList":           time.Hour,
+	"getApplicationRoles":    time.Hour,
+	"getProjectRoles":        time.Hour,
+	"getVersion":             time.Hour,
+	"getTimezone":            time.Hour,
+}
+
+// projectScopedCacheMethods take the project ID as their first parameter
+var projectScopedCacheMethods = map[string]bool{
+	"getColumns":         true,
+	"getAllSwimlanes":    true,
+	"getActiveSwimlanes": true,
+	"getAllCategories":   true,
+	"getTagsByProject":   true,
+}
+
+// responseCacheInvalidations lists the cached methods made stale by each Kanboard write
+var responseCacheInvalidations = map[string][]string{
+	"addColumn":            {"getColumns"},
+	"updateColumn":         {"getColumns"},
+	"removeColumn":         {"getColumns"},
+	"changeColumnPosition": {"getColumns"},
+
+	"addSwimlane":            {"getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName"},
+	"updateSwimlane":         {"getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName"},
+	"removeSwimlane":         {"getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName"},
+	"enableSwimlane":         {"getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName"},
+	"disableSwimlane":        {"getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName"},
+	"changeSwimlanePosition": {"getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName"},
+
+	"createCategory": {"getAllCategories"},
+	"updateCategory": {"getAllCategories"},
+	"removeCategory": {"getAllCategories"},
+
+	"createTag":   {"getAllTags", "getTagsByProject"},
+	"updateTag":   {"getAllTags", "getTagsByProject"},
+	"removeTag":   {"getAllTags", "getTagsByProject"},
+	"setTaskTags": {"getAllTags", "getTagsByProject"}, // creates tags that don't exist yet
+
+	"createLink": {"getAllLinks"},
+	"updateLink": {"getAllLinks"},
+	"removeLink": {"getAllLinks"},
+
+	"createProject":          {"getProjectByName", "getProjectByIdentifier"},
+	"createMyPrivateProject": {"getProjectByName", "getProjectByIdentifier"},
+	"updateProject":          {"getProjectByName", "getProjectByIdentifier"},
+	"enableProject":          {"getProjectByName", "getProjectByIdentifier"},
+	"disableProject":         {"getProjectByName", "getProjectByIdentifier"},
+	"removeProject": {"getProjectByName", "getProjectByIdentifier", "getColumns", "getAllSwimlanes", "getActiveSwimlanes", "getSwimlaneByName",
+		"getAllCategories", "getAllTags", "getTagsByProject"},
+}
+
+// ResponseCache is a read-through cache of Kanboard responses keyed by user, method and
+// params. The clients of an instance share it: what Kanboard returns depends on the user,
+// but a write made by any of them invalidates the entries of all.
+type ResponseCache struct {
+	ttls map[string]time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*responseCacheEntry
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	invalidations atomic.Int64
+}
+
+type responseCacheEntry struct {
+	method    string
+	projectID int // 0 for global entries and when the project is unknown
+	value     json.RawMessage
+	expiresAt time.Time
+}
+
+// ResponseCacheStats reports the cache counters for diagnostics
+type ResponseCacheStats struct {
+	Enabled       bool              `json:"enabled"`
+	Entries       int               `json:"entries"`
+	Hits          int64             `json:"hits"`
+	Misses        int64             `json:"misses"`
+	Invalidations int64             `json:"invalidations"`
+	TTLs          map[string]string `json:"ttls,omitempty"`
+}
+
+func newResponseCache(ttls map[string]time.Duration) *ResponseCache {
+	return &ResponseCache{ttls: ttls, now: time.Now, entries: make(map[string]*responseCacheEntry)}
+}
+
+// NewResponseCacheFromEnv applies KANBOARD_RESPONSE_CACHE=false and the per-method
+// KANBOARD_RESPONSE_CACHE_TTLS overrides ("getColumns=1m,getVersion=0") to the defaults
+func NewResponseCacheFromEnv() (*ResponseCache, error) {
+	if strings.EqualFold(os.Getenv("KANBOARD_RESPONSE_CACHE"), "false") {
+		return nil, nil
+	}
+
+	ttls := make(map[string]time.Duration, len(responseCacheTTLs))
+	for method, ttl := range responseCacheTTLs {
+		ttls[method] = ttl
+	}
+
+	var problems []string
+	for _, entry := range strings.Split(os.Getenv("KANBOARD_RESPONSE_CACHE_TTLS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if _, known := responseCacheTTLs[method]; !ok || !known {
+			problems = append(problems, fmt.Sprintf("invalid entry %q (expected <method>=<duration> for one of: %s)", entry, strings.Join(slices.Sorted(maps.Keys(responseCacheTTLs)), ", ")))
+			continue
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || ttl < 0 {
+			problems = append(problems, fmt.Sprintf("invalid duration %q for %s", value, method))
+			continue
+		}
+		ttls[method] = ttl
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid KANBOARD_RESPONSE_CACHE_TTLS: %s", strings.Join(problems, "; "))
+	}
+	return newResponseCache(ttls), nil
+}
+
+// Cacheable reports whether responses of method are cached. A nil cache caches nothing.
+func (c *ResponseCache) Cacheable(method string) bool {
+	return c != nil && c.ttls[method] > 0
+}
+
+func responseCacheKey(user, method string, params interface{}) (string, bool) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	return user + "\x00" + method + "\x00" + string(encoded), true
+}
+
+// cacheProjectID extracts the project of a call from named or positional params
+func cacheProjectID(method string, params interface{}) int {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return 0
+	}
+	var named map[string]interface{}
+	if json.Unmarshal(encoded, &named) == nil {
+		projectID, _ := ToInt(named["project_id"])
+		return projectID
+	}
+	var positional []interface{}
+	if projectScopedCacheMethods[method] && json.Unmarshal(encoded, &positional) == nil && len(positional) > 0 {
+		projectID, _ := ToInt(positional[0])
+		return projectID
+	}
+	return 0
+}
+
+// get returns a fresh copy of the response user got, so callers may modify it
+func (c *ResponseCache) get(user, method string, params interface{}) (interface{}, bool) {
+	if !c.Cacheable(method) {
+		return nil, false
+	}
+	key, ok := responseCacheKey(user, method, params)
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	if found && c.now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		found = false
+	}
+	c.mu.Unlock()
+
+	if !found {
+		c.misses.Add(1)
+		metrics.Default.ResponseCache.Inc(method, "miss")
+		return nil, false
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(entry.value, &value); err != nil {
+		return nil, false
+	}
+	c.hits.Add(1)
+	metrics.Default.ResponseCache.Inc(method, "hit")
+	return value, true
+}
+
+// set stores the response user got. "Not found" answers (false, null) are not cached.
+func (c *ResponseCache) set(user, method string, params interface{}, value interface{}) {
+	if !c.Cacheable(method) || value == nil || value == false {
+		return
+	}
+	key, ok := responseCacheKey(user, method, params)
+	if !ok {
+		return
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for key, entry := range c.entries {
+		// Entries of sessions that are gone are never read again
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[key] = &responseCacheEntry{
+		method:    method,
+		projectID: cacheProjectID(method, params),
+		value:     encoded,
+		expiresAt: now.Add(c.ttls[method]),
+	}
+}
+
+// invalidateFor drops the entries made stale by a Kanboard write. When the write names a
+// project, only that project's entries and the global ones are dropped.
+func (c *ResponseCache) invalidateFor(method string, params interface{}) {
+	if c == nil {
+		return
+	}
+	methods := responseCacheInvalidations[method]
+	if len(methods) == 0 {
+		return
+	}
+	if n := c.Clear(methods, cacheProjectID(method, params)); n > 0 {
+		logging.HTTP.Debug("response cache invalidated", "write", method, "entries", n)
+	}
+}
+
+// Clear drops the entries of the given methods (all methods when empty) for one project
+// (all projects when 0) and returns how many were dropped
+func (c *ResponseCache) Clear(methods []string, projectID int) int {
+	if c == nil {
+		return 0
+	}
+	selected := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		selected[method] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cleared := 0
+	for key, entry := range c.entries {
+		if len(selected) > 0 && !selected[entry.method] {
+			continue
+		}
+		if projectID != 0 && entry.projectID != 0 && entry.projectID != projectID {
+			continue
+		}
+		delete(c.entries, key)
+		cleared++
+	}
+	c.invalidations.Add(int64(cleared))
+	return cleared
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *ResponseCache) Stats() ResponseCacheStats {
+	if c == nil {
+		return ResponseCacheStats{}
+	}
+
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	ttls := make(map[string]string, len(c.ttls))
+	for method, ttl := range c.ttls {
+		ttls[method] = ttl.String()
+	}
+	return ResponseCacheStats{
+		Enabled:       true,
+		Entries:       entries,
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+		TTLs:          ttls,
+	}
+}
+
+// CachedMethods returns the Kanboard methods whose responses are cached
+func (c *ResponseCache) CachedMethods() []string {
+	var methods []string
+	for _, method := range slices.Sorted(maps.Keys(c.ttls)) {
+		if c.ttls[method] > 0 {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
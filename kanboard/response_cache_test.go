@@ -0,0 +1,228 @@
+package kanboard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"kanboard-mcp/kanboard/kanboardtest"
+)
+
+func TestResponseCacheTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache := newResponseCache(map[string]time.Duration{"getColumns": time.Minute, "getVersion": 0})
+	cache.now = clock.Now
+	params := map[string]int{"project_id": 1}
+
+	cache.set("", "getColumns", params, []interface{}{"Backlog"})
+	cache.set("", "getVersion", nil, "1.2.40")
+	cache.set("", "getTask", map[string]int{"task_id": 1}, map[string]interface{}{"id": "1"})
+
+	steps := []struct {
+		advance time.Duration
+		method  string
+		params  interface{}
+		want    bool
+	}{
+		{0, "getColumns", params, true},
+		{59 * time.Second, "getColumns", params, true},
+		{2 * time.Second, "getColumns", params, false},
+		// A zero TTL and methods without a TTL are never cached
+		{0, "getVersion", nil, false},
+		{0, "getTask", map[string]int{"task_id": 1}, false},
+	}
+	for i, step := range steps {
+		clock.Advance(step.advance)
+		if _, hit := cache.get("", step.method, step.params); hit != step.want {
+			t.Fatalf("step %d: %s hit = %v, want %v", i, step.method, hit, step.want)
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 0 {
+		t.Fatalf("stats %+v, want 2 hits, 1 miss and the expired entry dropped", stats)
+	}
+}
+
+func TestResponseCacheSkipsNotFound(t *testing.T) {
+	cache := newResponseCache(map[string]time.Duration{"getProjectByName": time.Minute})
+	for _, value := range []interface{}{false, nil} {
+		cache.set("", "getProjectByName", map[string]string{"name": "Nope"}, value)
+		if _, hit := cache.get("", "getProjectByName", map[string]string{"name": "Nope"}); hit {
+			t.Fatalf("the not found answer %v was cached", value)
+		}
+	}
+	// Empty lists are answers like any other
+	cache.set("", "getProjectByName", map[string]string{"name": "Empty"}, []interface{}{})
+	if _, hit := cache.get("", "getProjectByName", map[string]string{"name": "Empty"}); !hit {
+		t.Fatal("an empty list should be cached")
+	}
+
+	var disabled *ResponseCache
+	disabled.set("", "getProjectByName", map[string]string{"name": "Any"}, true)
+	if _, hit := disabled.get("", "getProjectByName", map[string]string{"name": "Any"}); hit || disabled.Cacheable("getProjectByName") {
+		t.Fatal("a nil cache caches nothing")
+	}
+}
+
+func TestResponseCacheInvalidation(t *testing.T) {
+	cache := newResponseCache(map[string]time.Duration{
+		"getColumns": time.Minute, "getAllCategories": time.Minute, "getAllTags": time.Minute, "getProjectByName": time.Minute,
+	})
+	fill := func() {
+		cache.Clear(nil, 0)
+		cache.set("", "getColumns", map[string]int{"project_id": 1}, []interface{}{"Backlog"})
+		cache.set("", "getColumns", []int{2}, []interface{}{"Todo"})
+		cache.set("", "getAllCategories", map[string]int{"project_id": 1}, []interface{}{"Bug"})
+		cache.set("", "getAllTags", nil, []interface{}{"urgent"})
+		cache.set("", "getProjectByName", map[string]string{"name": "Mobile App"}, map[string]interface{}{"id": "2"})
+	}
+	cached := func(method string, params interface{}) bool {
+		_, hit := cache.get("", method, params)
+		return hit
+	}
+
+	cases := []struct {
+		name     string
+		write    string
+		params   interface{}
+		dropped  int
+		kept     [][2]interface{}
+		notKept  [][2]interface{}
+		clear    bool
+		methods  []string
+		clearFor int
+	}{
+		{
+			name: "column of project 1", write: "updateColumn", params: map[string]int{"project_id": 1}, dropped: 1,
+			notKept: [][2]interface{}{{"getColumns", map[string]int{"project_id": 1}}},
+			kept:    [][2]interface{}{{"getColumns", []int{2}}, {"getAllCategories", map[string]int{"project_id": 1}}},
+		},
+		{
+			// Without a project, the write may concern any project
+			name: "column by ID only", write: "removeColumn", params: map[string]int{"column_id": 5}, dropped: 2,
+			notKept: [][2]interface{}{{"getColumns", map[string]int{"project_id": 1}}, {"getColumns", []int{2}}},
+		},
+		{
+			name: "removed project", write: "removeProject", params: map[string]int{"project_id": 2}, dropped: 3,
+			notKept: [][2]interface{}{{"getColumns", []int{2}}, {"getAllTags", nil}, {"getProjectByName", map[string]string{"name": "Mobile App"}}},
+			kept:    [][2]interface{}{{"getColumns", map[string]int{"project_id": 1}}, {"getAllCategories", map[string]int{"project_id": 1}}},
+		},
+		{
+			name: "read", write: "getTask", params: map[string]int{"task_id": 1}, dropped: 0,
+			kept: [][2]interface{}{{"getColumns", map[string]int{"project_id": 1}}, {"getAllTags", nil}},
+		},
+		{
+			// Clear drops the global entries along with those of the project
+			name: "clear project 1", clear: true, clearFor: 1, dropped: 4,
+			kept: [][2]interface{}{{"getColumns", []int{2}}},
+		},
+		{
+			name: "clear tags", clear: true, methods: []string{"getAllTags"}, dropped: 1,
+			kept: [][2]interface{}{{"getColumns", []int{2}}, {"getProjectByName", map[string]string{"name": "Mobile App"}}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fill()
+			before := cache.Stats()
+			if c.clear {
+				if n := cache.Clear(c.methods, c.clearFor); n != c.dropped {
+					t.Fatalf("Clear dropped %d entries, want %d", n, c.dropped)
+				}
+			} else {
+				cache.invalidateFor(c.write, c.params)
+			}
+			if stats := cache.Stats(); before.Entries-stats.Entries != c.dropped || stats.Invalidations-before.Invalidations != int64(c.dropped) {
+				t.Fatalf("dropped %d entries (%d invalidations), want %d", before.Entries-stats.Entries, stats.Invalidations-before.Invalidations, c.dropped)
+			}
+			for _, entry := range c.kept {
+				if !cached(entry[0].(string), entry[1]) {
+					t.Errorf("%s %v was dropped", entry[0], entry[1])
+				}
+			}
+			for _, entry := range c.notKept {
+				if cached(entry[0].(string), entry[1]) {
+					t.Errorf("%s %v was kept", entry[0], entry[1])
+				}
+			}
+		})
+	}
+}
+
+func TestNewResponseCacheFromEnv(t *testing.T) {
+	cases := []struct {
+		disabled string
+		ttls     string
+		want     map[string]time.Duration
+		wantErr  string
+	}{
+		{want: map[string]time.Duration{"getColumns": 5 * time.Minute, "getVersion": time.Hour}},
+		{ttls: "getColumns=1m, getVersion=0", want: map[string]time.Duration{"getColumns": time.Minute, "getVersion": 0, "getAllLinks": 30 * time.Minute}},
+		{ttls: "getAllTags=90s,", want: map[string]time.Duration{"getAllTags": 90 * time.Second}},
+		{ttls: "getTask=1m", wantErr: `invalid entry "getTask=1m"`},
+		{ttls: "getColumns", wantErr: `invalid entry "getColumns"`},
+		{ttls: "getColumns=soon", wantErr: `invalid duration "soon" for getColumns`},
+		{ttls: "getColumns=-1s,getVersion=x", wantErr: `invalid duration "-1s" for getColumns; invalid duration "x" for getVersion`},
+		{disabled: "false", ttls: "getTask=1m"},
+	}
+	for _, c := range cases {
+		t.Run(c.disabled+c.ttls, func(t *testing.T) {
+			t.Setenv("KANBOARD_RESPONSE_CACHE", c.disabled)
+			t.Setenv("KANBOARD_RESPONSE_CACHE_TTLS", c.ttls)
+			cache, err := NewResponseCacheFromEnv()
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("error = %v, want %q", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.want == nil {
+				if cache != nil {
+					t.Fatal("KANBOARD_RESPONSE_CACHE=false should disable the cache")
+				}
+				return
+			}
+			for method, ttl := range c.want {
+				if cache.ttls[method] != ttl || cache.Cacheable(method) != (ttl > 0) {
+					t.Errorf("%s: TTL %v, want %v", method, cache.ttls[method], ttl)
+				}
+			}
+		})
+	}
+}
+
+// Session clients share the cache of their instance: each user gets the answers Kanboard
+// gave them, and a write by any user invalidates the entries of all
+func TestResponseCacheSharedBySessionClients(t *testing.T) {
+	kb := kanboardtest.NewServer()
+	defer kb.Close()
+	admin := NewClient(kb.Endpoint(), "", "admin", "admin-secret")
+	admin.Responses = newResponseCache(map[string]time.Duration{"getColumns": time.Minute})
+	alice := admin.WithUserToken("alice", "alice-secret")
+	ctx := context.Background()
+
+	getColumns := func(kc *Client) int {
+		t.Helper()
+		columns, err := kc.GetColumns(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(columns)
+	}
+	columns := getColumns(admin)
+	getColumns(alice)
+	getColumns(alice)
+	if calls := len(kb.Calls()); calls != 2 {
+		t.Fatalf("Kanboard received %d calls, want one per user", calls)
+	}
+
+	if _, err := admin.Call(ctx, "addColumn", map[string]interface{}{"project_id": 1, "title": "Review"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := getColumns(alice); n != columns+1 {
+		t.Fatalf("alice sees %d columns after admin added one, want %d", n, columns+1)
+	}
+}
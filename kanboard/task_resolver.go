@@ -0,0 +1,78 @@
+package kanboard
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// taskNumberPattern matches "#123"
+	taskNumberPattern = regexp.MustCompile(`^#(\d+)$`)
+	// projectTaskPattern matches "IDENTIFIER-123"
+	projectTaskPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)-(\d+)$`)
+)
+
+// ResolveTask turns a task reference into a task ID. It accepts "#123", "IDENTIFIER-123"
+// for task 123 of the project with that identifier, and the external reference of a
+// task in projectID (see getTaskByReference).
+func (kc *Client) ResolveTask(ctx context.Context, projectID int, ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if match := taskNumberPattern.FindStringSubmatch(ref); match != nil {
+		return strconv.Atoi(match[1])
+	}
+
+	if match := projectTaskPattern.FindStringSubmatch(ref); match != nil {
+		identifier := strings.ToUpper(match[1])
+		taskID, _ := strconv.Atoi(match[2])
+		project, err := kc.GetProjectByIdentifier(ctx, identifier)
+		if err != nil && errorKind(err) != ErrorKindNotFound {
+			return 0, fmt.Errorf("failed to resolve task '%s': %w", ref, err)
+		}
+		if err == nil {
+			taskProject, err := kc.TaskProjectID(ctx, taskID)
+			if err != nil {
+				return 0, err
+			}
+			if taskProject == int(project.ID) {
+				return taskID, nil
+			}
+			if projectID == 0 {
+				return 0, &Error{
+					Kind:    ErrorKindNotFound,
+					Message: fmt.Sprintf("task '%s' not found: task #%d belongs to project %d, not to %s (project %d)", ref, taskID, taskProject, identifier, project.ID),
+				}
+			}
+			// Not a task of that project, it may still be an external reference
+		}
+	}
+
+	if projectID == 0 {
+		return 0, &Error{
+			Kind:    ErrorKindValidation,
+			Message: fmt.Sprintf("task '%s' is not a task ID, #ID or IDENTIFIER-ID, and no project_id was given to look it up as an external reference", ref),
+		}
+	}
+	task, err := kc.GetTaskByReference(ctx, projectID, ref)
+	if err != nil && errorKind(err) != ErrorKindNotFound {
+		return 0, fmt.Errorf("failed to resolve task '%s': %w", ref, err)
+	}
+	if err != nil || task.ID <= 0 {
+		return 0, &Error{Kind: ErrorKindNotFound, Message: fmt.Sprintf("no task with reference '%s' in project %d", ref, projectID)}
+	}
+	return int(task.ID), nil
+}
+
+// TaskProjectID returns the project a task belongs to
+func (kc *Client) TaskProjectID(ctx context.Context, taskID int) (int, error) {
+	task, err := kc.GetTask(ctx, taskID)
+	if err != nil {
+		return 0, err
+	}
+	if task.ProjectID <= 0 {
+		return 0, fmt.Errorf("task %d has no valid project_id", taskID)
+	}
+	return int(task.ProjectID), nil
+}
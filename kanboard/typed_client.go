@@ -0,0 +1,376 @@
+package kanboard
+
+import "context"
+
+// Typed client methods for the Kanboard entities in models.go. Single-object getters
+// return a not found Error when Kanboard answers false or null.
+
+// Projects
+
+func (kc *Client) GetAllProjects(ctx context.Context) ([]Project, error) {
+	result, err := kc.Call(ctx, "getAllProjects", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Project](result, "getAllProjects")
+}
+
+func (kc *Client) GetMyProjects(ctx context.Context) ([]Project, error) {
+	result, err := kc.Call(ctx, "getMyProjects", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Project](result, "getMyProjects")
+}
+
+func (kc *Client) GetProjectByID(ctx context.Context, projectID int) (*Project, error) {
+	result, err := kc.Call(ctx, "getProjectById", map[string]int{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Project](result, "project", projectID)
+}
+
+func (kc *Client) GetProjectByName(ctx context.Context, name string) (*Project, error) {
+	result, err := kc.Call(ctx, "getProjectByName", map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Project](result, "project", quoted(name))
+}
+
+func (kc *Client) GetProjectByIdentifier(ctx context.Context, identifier string) (*Project, error) {
+	result, err := kc.Call(ctx, "getProjectByIdentifier", map[string]string{"identifier": identifier})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Project](result, "project", quoted(identifier))
+}
+
+func (kc *Client) GetProjectByEmail(ctx context.Context, email string) (*Project, error) {
+	result, err := kc.Call(ctx, "getProjectByEmail", map[string]string{"email": email})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Project](result, "project with email", quoted(email))
+}
+
+// Tasks
+
+func (kc *Client) GetTask(ctx context.Context, taskID int) (*Task, error) {
+	result, err := kc.Call(ctx, "getTask", map[string]int{"task_id": taskID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Task](result, "task", taskID)
+}
+
+func (kc *Client) GetTaskByReference(ctx context.Context, projectID int, reference string) (*Task, error) {
+	result, err := kc.Call(ctx, "getTaskByReference", map[string]interface{}{"project_id": projectID, "reference": reference})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Task](result, "task with reference", quoted(reference))
+}
+
+// GetAllTasks lists the tasks of a project, statusID is 1 for open and 0 for closed tasks
+func (kc *Client) GetAllTasks(ctx context.Context, projectID, statusID int) ([]Task, error) {
+	result, err := kc.Call(ctx, "getAllTasks", map[string]interface{}{"project_id": projectID, "status_id": statusID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Task](result, "getAllTasks")
+}
+
+func (kc *Client) GetOverdueTasks(ctx context.Context) ([]Task, error) {
+	result, err := kc.Call(ctx, "getOverdueTasks", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Task](result, "getOverdueTasks")
+}
+
+func (kc *Client) GetOverdueTasksByProject(ctx context.Context, projectID int) ([]Task, error) {
+	result, err := kc.Call(ctx, "getOverdueTasksByProject", map[string]interface{}{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Task](result, "getOverdueTasksByProject")
+}
+
+func (kc *Client) GetMyOverdueTasks(ctx context.Context) ([]Task, error) {
+	result, err := kc.Call(ctx, "getMyOverdueTasks", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Task](result, "getMyOverdueTasks")
+}
+
+func (kc *Client) SearchTasks(ctx context.Context, projectID int, query string) ([]Task, error) {
+	result, err := kc.Call(ctx, "searchTasks", map[string]interface{}{"project_id": projectID, "query": query})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Task](result, "searchTasks")
+}
+
+// Columns
+
+func (kc *Client) GetColumns(ctx context.Context, projectID int) ([]Column, error) {
+	result, err := kc.Call(ctx, "getColumns", map[string]int{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Column](result, "getColumns")
+}
+
+func (kc *Client) GetColumn(ctx context.Context, columnID int) (*Column, error) {
+	result, err := kc.Call(ctx, "getColumn", map[string]int{"column_id": columnID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Column](result, "column", columnID)
+}
+
+// Categories
+
+func (kc *Client) GetAllCategories(ctx context.Context, projectID int) ([]Category, error) {
+	result, err := kc.Call(ctx, "getAllCategories", map[string]int{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Category](result, "getAllCategories")
+}
+
+func (kc *Client) GetCategory(ctx context.Context, categoryID int) (*Category, error) {
+	result, err := kc.Call(ctx, "getCategory", map[string]int{"category_id": categoryID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Category](result, "category", categoryID)
+}
+
+// Comments
+
+func (kc *Client) GetAllComments(ctx context.Context, taskID int) ([]Comment, error) {
+	result, err := kc.Call(ctx, "getAllComments", map[string]int{"task_id": taskID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Comment](result, "getAllComments")
+}
+
+func (kc *Client) GetComment(ctx context.Context, commentID int) (*Comment, error) {
+	result, err := kc.Call(ctx, "getComment", map[string]int{"comment_id": commentID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Comment](result, "comment", commentID)
+}
+
+// Subtasks
+
+func (kc *Client) GetAllSubtasks(ctx context.Context, taskID int) ([]Subtask, error) {
+	result, err := kc.Call(ctx, "getAllSubtasks", map[string]interface{}{"task_id": taskID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Subtask](result, "getAllSubtasks")
+}
+
+func (kc *Client) GetSubtask(ctx context.Context, subtaskID int) (*Subtask, error) {
+	result, err := kc.Call(ctx, "getSubtask", map[string]interface{}{"subtask_id": subtaskID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Subtask](result, "subtask", subtaskID)
+}
+
+// GetProjectFile loads a project file. Kanboard finds the file by its ID alone, whatever
+// project is given.
+func (kc *Client) GetProjectFile(ctx context.Context, projectID, fileID int) (*ProjectFile, error) {
+	result, err := kc.Call(ctx, "getProjectFile", map[string]int{"project_id": projectID, "file_id": fileID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[ProjectFile](result, "project file", fileID)
+}
+
+// GetExternalTaskLink loads an external task link. Kanboard finds the link by its ID
+// alone, whatever task is given.
+func (kc *Client) GetExternalTaskLink(ctx context.Context, taskID, linkID int) (*ExternalTaskLink, error) {
+	result, err := kc.Call(ctx, "getExternalTaskLinkById", map[string]int{"task_id": taskID, "link_id": linkID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[ExternalTaskLink](result, "external task link", linkID)
+}
+
+// GetAllProjectFiles lists the files attached to a project
+func (kc *Client) GetAllProjectFiles(ctx context.Context, projectID int) ([]ProjectFile, error) {
+	result, err := kc.Call(ctx, "getAllProjectFiles", map[string]int{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[ProjectFile](result, "getAllProjectFiles")
+}
+
+// GetAllExternalTaskLinks lists the external links of a task
+func (kc *Client) GetAllExternalTaskLinks(ctx context.Context, taskID int) ([]ExternalTaskLink, error) {
+	result, err := kc.Call(ctx, "getAllExternalTaskLinks", map[string]int{"task_id": taskID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[ExternalTaskLink](result, "getAllExternalTaskLinks")
+}
+
+// GetTaskLink loads a link between two tasks
+func (kc *Client) GetTaskLink(ctx context.Context, taskLinkID int) (*TaskLink, error) {
+	result, err := kc.Call(ctx, "getTaskLinkById", map[string]int{"task_link_id": taskLinkID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[TaskLink](result, "task link", taskLinkID)
+}
+
+// GetAllTaskLinks lists the links of a task with the tasks they point to
+func (kc *Client) GetAllTaskLinks(ctx context.Context, taskID int) ([]LinkedTask, error) {
+	result, err := kc.Call(ctx, "getAllTaskLinks", map[string]int{"task_id": taskID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[LinkedTask](result, "getAllTaskLinks")
+}
+
+// Users and groups
+
+func (kc *Client) GetMe(ctx context.Context) (*User, error) {
+	result, err := kc.Call(ctx, "getMe", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[User](result, "user", "me")
+}
+
+func (kc *Client) GetUser(ctx context.Context, userID int) (*User, error) {
+	result, err := kc.Call(ctx, "getUser", map[string]int{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[User](result, "user", userID)
+}
+
+func (kc *Client) GetUserByName(ctx context.Context, username string) (*User, error) {
+	result, err := kc.Call(ctx, "getUserByName", map[string]string{"username": username})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[User](result, "user", quoted(username))
+}
+
+func (kc *Client) GetAllUsers(ctx context.Context) ([]User, error) {
+	result, err := kc.Call(ctx, "getAllUsers", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[User](result, "getAllUsers")
+}
+
+func (kc *Client) GetGroup(ctx context.Context, groupID int) (*Group, error) {
+	result, err := kc.Call(ctx, "getGroup", map[string]int{"group_id": groupID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Group](result, "group", groupID)
+}
+
+func (kc *Client) GetAllGroups(ctx context.Context) ([]Group, error) {
+	result, err := kc.Call(ctx, "getAllGroups", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Group](result, "getAllGroups")
+}
+
+func (kc *Client) GetMemberGroups(ctx context.Context, userID int) ([]Group, error) {
+	result, err := kc.Call(ctx, "getMemberGroups", map[string]int{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Group](result, "getMemberGroups")
+}
+
+func (kc *Client) GetGroupMembers(ctx context.Context, groupID int) ([]User, error) {
+	result, err := kc.Call(ctx, "getGroupMembers", map[string]int{"group_id": groupID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[User](result, "getGroupMembers")
+}
+
+// Tags
+
+func (kc *Client) GetAllTags(ctx context.Context) ([]Tag, error) {
+	result, err := kc.Call(ctx, "getAllTags", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Tag](result, "getAllTags")
+}
+
+func (kc *Client) GetTagsByProject(ctx context.Context, projectID int) ([]Tag, error) {
+	result, err := kc.Call(ctx, "getTagsByProject", map[string]interface{}{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Tag](result, "getTagsByProject")
+}
+
+// Links
+
+func (kc *Client) GetAllLinks(ctx context.Context) ([]Link, error) {
+	result, err := kc.Call(ctx, "getAllLinks", nil)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Link](result, "getAllLinks")
+}
+
+func (kc *Client) GetLinkByID(ctx context.Context, linkID int) (*Link, error) {
+	result, err := kc.Call(ctx, "getLinkById", map[string]int{"link_id": linkID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Link](result, "link", linkID)
+}
+
+func (kc *Client) GetLinkByLabel(ctx context.Context, label string) (*Link, error) {
+	result, err := kc.Call(ctx, "getLinkByLabel", map[string]string{"label": label})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Link](result, "link", quoted(label))
+}
+
+// Sprints
+
+func (kc *Client) GetSprintByID(ctx context.Context, sprintID int) (*Sprint, error) {
+	result, err := kc.Call(ctx, "getSprintById", map[string]int{"sprint_id": sprintID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObject[Sprint](result, "sprint", sprintID)
+}
+
+func (kc *Client) GetAllSprintsByProject(ctx context.Context, projectID int) ([]Sprint, error) {
+	result, err := kc.Call(ctx, "getAllSprintsByProject", map[string]int{"project_id": projectID})
+	if err != nil {
+		return nil, err
+	}
+	return DecodeList[Sprint](result, "getAllSprintsByProject")
+}
+
+// quoted formats a name for not found errors, e.g. project 'Website'
+func quoted(name string) string {
+	return "'" + name + "'"
+}
@@ -0,0 +1,208 @@
+// Package logging provides the per-subsystem structured loggers and request IDs.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Logging subsystems, each with its own verbosity (MCP_LOG_LEVELS=rbac=debug,http=warn)
+const (
+	subsystemRBAC      = "rbac"
+	subsystemHTTP      = "http"
+	subsystemTools     = "tools"
+	subsystemTransport = "transport"
+)
+
+var logSubsystems = []string{subsystemRBAC, subsystemHTTP, subsystemTools, subsystemTransport}
+
+// Per-subsystem loggers; all output goes to stderr since stdout carries the stdio transport
+var (
+	RBAC      *slog.Logger
+	HTTP      *slog.Logger
+	Tools     *slog.Logger
+	Transport *slog.Logger
+)
+
+func init() {
+	if err := configureLogging(os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// configureLogging builds the subsystem loggers from MCP_LOG_LEVEL, MCP_LOG_LEVELS and MCP_LOG_FORMAT.
+// KANBOARD_DEBUG=true is still honoured as a shortcut for MCP_LOG_LEVEL=debug.
+func configureLogging(w io.Writer) error {
+	var problems []string
+
+	defaultLevel := slog.LevelInfo
+	if os.Getenv("KANBOARD_DEBUG") == "true" {
+		defaultLevel = slog.LevelDebug
+	}
+	if value := os.Getenv("MCP_LOG_LEVEL"); value != "" {
+		level, err := parseLogLevel(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("MCP_LOG_LEVEL: %v", err))
+		} else {
+			defaultLevel = level
+		}
+	}
+
+	levels := make(map[string]slog.Level, len(logSubsystems))
+	for _, subsystem := range logSubsystems {
+		levels[subsystem] = defaultLevel
+	}
+	for _, entry := range strings.Split(os.Getenv("MCP_LOG_LEVELS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		subsystem, value, ok := strings.Cut(entry, "=")
+		subsystem = strings.ToLower(strings.TrimSpace(subsystem))
+		if _, known := levels[subsystem]; !ok || !known {
+			problems = append(problems, fmt.Sprintf("MCP_LOG_LEVELS: invalid entry %q (expected <%s>=<level>)", entry, strings.Join(logSubsystems, "|")))
+			continue
+		}
+		level, err := parseLogLevel(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("MCP_LOG_LEVELS: %v", err))
+			continue
+		}
+		levels[subsystem] = level
+	}
+
+	// The base handler lets everything through, subsystem handlers apply their own level
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var base slog.Handler
+	switch format := strings.ToLower(os.Getenv("MCP_LOG_FORMAT")); format {
+	case "", "text":
+		base = slog.NewTextHandler(w, options)
+	case "json":
+		base = slog.NewJSONHandler(w, options)
+	default:
+		problems = append(problems, fmt.Sprintf("MCP_LOG_FORMAT: unsupported format %q (supported: text, json)", format))
+		base = slog.NewTextHandler(w, options)
+	}
+
+	newLogger := func(subsystem string) *slog.Logger {
+		return slog.New(&subsystemHandler{
+			next:  base.WithAttrs([]slog.Attr{slog.String("subsystem", subsystem)}),
+			level: levels[subsystem],
+		})
+	}
+	RBAC = newLogger(subsystemRBAC)
+	HTTP = newLogger(subsystemHTTP)
+	Tools = newLogger(subsystemTools)
+	Transport = newLogger(subsystemTransport)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid logging configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func parseLogLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return 0, fmt.Errorf("unknown level %q (supported: debug, info, warn, error)", value)
+	}
+	return level, nil
+}
+
+// subsystemHandler filters records by the subsystem's level and adds the request ID from the context
+type subsystemHandler struct {
+	next  slog.Handler
+	level slog.Level
+}
+
+func (h *subsystemHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *subsystemHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *subsystemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &subsystemHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *subsystemHandler) WithGroup(name string) slog.Handler {
+	return &subsystemHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+type requestIDContextKey struct{}
+
+// RequestIDFromContext returns the correlation ID of the tool call, if any
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// RequestIDMiddleware tags every tool call with a correlation ID carried by all its log lines
+func RequestIDMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx = context.WithValue(ctx, requestIDContextKey{}, newRequestID())
+		Tools.DebugContext(ctx, "tool call started", "tool", request.Params.Name)
+
+		result, err := next(ctx, request)
+
+		switch {
+		case err != nil:
+			Tools.WarnContext(ctx, "tool call failed", "tool", request.Params.Name, "error", err)
+		case result != nil && result.IsError:
+			Tools.DebugContext(ctx, "tool call returned an error", "tool", request.Params.Name)
+		default:
+			Tools.DebugContext(ctx, "tool call finished", "tool", request.Params.Name)
+		}
+		return result, err
+	}
+}
+
+// ResultText joins the text content of a tool result
+func ResultText(result *mcp.CallToolResult) string {
+	var text string
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			if text != "" {
+				text += "\n"
+			}
+			text += textContent.Text
+		}
+	}
+	return text
+}
+
+// Longest error message kept in audit records, spans and breaker status
+const maxErrorLength = 500
+
+// TruncateText shortens an error message to maxErrorLength
+func TruncateText(text string) string {
+	if len(text) <= maxErrorLength {
+		return text
+	}
+	return text[:maxErrorLength] + "..."
+}
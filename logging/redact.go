@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// secretKeys are argument names whose values never reach the audit log or the debug logs
+var secretKeys = map[string]bool{
+	"blob":          true,
+	"password":      true,
+	"api_key":       true,
+	"apikey":        true,
+	"token":         true,
+	"confirm_token": true,
+	"secret":        true,
+}
+
+// secretPositions are the secret params of the Kanboard methods taking any, by position,
+// for calls sent with positional params
+var secretPositions = map[string]map[int]string{
+	"createuser":        {1: "password"},
+	"createprojectfile": {2: "blob"},
+	"createtaskfile":    {3: "blob"},
+}
+
+// Longest string value kept when logging a JSON-RPC body
+const maxLoggedValueLength = 200
+
+// IsSecretKey reports whether the value of an argument or JSON field must be redacted
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if secretKeys[key] {
+		return true
+	}
+	return strings.HasSuffix(key, "_password") || strings.HasSuffix(key, "_token") || strings.HasSuffix(key, "_secret") || strings.HasSuffix(key, "_key")
+}
+
+// RedactJSON returns a JSON document for logging, with the values of secret keys
+// redacted and long strings truncated
+func RedactJSON(data []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return TruncateText(string(data))
+	}
+	redacted, err := json.Marshal(redactValue(value, true))
+	if err != nil {
+		return TruncateText(string(data))
+	}
+	return string(redacted)
+}
+
+// Redact returns a copy of a decoded JSON value with the values of secret keys redacted.
+// Unlike RedactJSON it keeps every other value as is, for recordings that are replayed.
+func Redact(value interface{}) interface{} {
+	return redactValue(value, false)
+}
+
+// RedactParams is Redact for the params of a call to a Kanboard method, named or positional
+func RedactParams(method string, params interface{}) interface{} {
+	return redactParams(method, params, false)
+}
+
+func redactParams(method string, params interface{}, truncate bool) interface{} {
+	positional, ok := params.([]interface{})
+	secrets := secretPositions[strings.ToLower(method)]
+	if !ok || secrets == nil {
+		return redactValue(params, truncate)
+	}
+	redacted := make([]interface{}, len(positional))
+	for i, item := range positional {
+		if key, ok := secrets[i]; ok {
+			redacted[i] = redactedSecret(key, item)
+		} else {
+			redacted[i] = redactValue(item, truncate)
+		}
+	}
+	return redacted
+}
+
+// redactedSecret replaces the value of a secret, file contents keep their size
+func redactedSecret(key string, value interface{}) interface{} {
+	if text, ok := value.(string); ok && key == "blob" {
+		return fmt.Sprintf("[REDACTED %d bytes]", len(text))
+	}
+	return "[REDACTED]"
+}
+
+func redactValue(value interface{}, truncate bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// The params of JSON-RPC requests may be positional
+		method, _ := v["method"].(string)
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			switch {
+			case IsSecretKey(key):
+				redacted[key] = redactedSecret(strings.ToLower(key), item)
+			case key == "params" && method != "":
+				redacted[key] = redactParams(method, item, truncate)
+			default:
+				redacted[key] = redactValue(item, truncate)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item, truncate)
+		}
+		return redacted
+	case string:
+		if truncate && len(v) > maxLoggedValueLength {
+			return fmt.Sprintf("%s... [%d bytes]", v[:maxLoggedValueLength], len(v))
+		}
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	blob := strings.Repeat("QUJD", 1000)
+	body := `[{"jsonrpc":"2.0","method":"createUser","params":{"username":"bob","password":"hunter22"}},` +
+		`{"jsonrpc":"2.0","method":"createTaskFile","params":[1,2,"notes.txt","` + blob + `"]},` +
+		`{"jsonrpc":"2.0","method":"createUser","params":["eve","eve-secret"]},` +
+		`{"jsonrpc":"2.0","method":"updateProject","params":{"description":"` + strings.Repeat("x", 500) + `","owner_api_key":"k"}}]`
+
+	logged := RedactJSON([]byte(body))
+	for _, secret := range []string{"hunter22", blob[:20], "eve-secret", strings.Repeat("x", maxLoggedValueLength+1), `"k"`} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("logged body leaks %.20q: %s", secret, logged)
+		}
+	}
+	for _, want := range []string{`"username":"bob"`, `"password":"[REDACTED]"`, `"owner_api_key":"[REDACTED]"`, `"notes.txt","[REDACTED 4000 bytes]"`, `["eve","[REDACTED]"]`, "[500 bytes]"} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("logged body misses %s: %s", want, logged)
+		}
+	}
+}
+
+func TestRedactParams(t *testing.T) {
+	cases := []struct {
+		method string
+		params interface{}
+		want   interface{}
+	}{
+		{"createTaskFile", []interface{}{1, 2, "a.txt", "QUJD"}, []interface{}{1, 2, "a.txt", "[REDACTED 4 bytes]"}},
+		{"createProjectFile", []interface{}{1, "a.txt", "QUJD"}, []interface{}{1, "a.txt", "[REDACTED 4 bytes]"}},
+		{"createUser", []interface{}{"eve", "eve-secret"}, []interface{}{"eve", "[REDACTED]"}},
+		{"createUser", map[string]interface{}{"username": "eve", "password": "eve-secret"}, map[string]interface{}{"username": "eve", "password": "[REDACTED]"}},
+		{"getTask", []interface{}{1}, []interface{}{1}},
+	}
+	for _, c := range cases {
+		got, _ := json.Marshal(RedactParams(c.method, c.params))
+		want, _ := json.Marshal(c.want)
+		if string(got) != string(want) {
+			t.Errorf("RedactParams(%s, %v) = %s, want %s", c.method, c.params, got, want)
+		}
+	}
+}
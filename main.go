@@ -1,8764 +1,349 @@
 package main
 
 import (
-	"bytes"
-	"context"
-	"encoding/base64"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
-	"math"
-	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"regexp"
-	"sort"
-	"strconv"
 	"strings"
-	"time"
+	"syscall"
 
-	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"gopkg.in/yaml.v3"
-)
 
-// Transport modes supported by the MCP server
-const (
-	TransportStdio          = "stdio"
-	TransportSSE            = "sse"
-	TransportStreamableHTTP = "streamablehttp"
+	"kanboard-mcp/audit"
+	"kanboard-mcp/auth"
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+	"kanboard-mcp/metrics"
+	"kanboard-mcp/rbac"
+	"kanboard-mcp/tools"
+	"kanboard-mcp/tools/catalog"
+	"kanboard-mcp/tracing"
+	"kanboard-mcp/transport"
 )
 
+//go:generate go run ./cmd/gentools
+
 // Version information (set via ldflags)
 var (
 	version   = "dev"
 	buildTime = ""
 )
 
-// RBAC Configuration embedded as JSON
-const rbacConfigJSON = `{
-    "roles": {
-        "application_roles": {
-            "app-admin": "Administrator",
-            "app-manager": "Manager",
-            "app-user": "User"
-        },
-        "project_roles": {
-            "project-manager": "Project Manager",
-            "project-member": "Project Member",
-            "project-viewer": "Project Viewer"
-        },
-        "all_roles": {
-            "app-admin": "Administrator",
-            "app-manager": "Manager",
-            "app-user": "User",
-            "project-manager": "Project Manager",
-            "project-member": "Project Member",
-            "project-viewer": "Project Viewer"
-        }
-    },
-    "hierarchies": {
-        "application": {
-            "app-admin": [
-                "app-manager",
-                "app-user",
-                "app-public"
-            ],
-            "app-manager": [
-                "app-user",
-                "app-public"
-            ],
-            "app-user": [
-                "app-public"
-            ]
-        },
-        "project": {
-            "project-manager": [
-                "project-member",
-                "project-viewer"
-            ],
-            "project-member": [
-                "project-viewer"
-            ]
-        }
-    },
-    "access_maps": {
-        "project": {
-            "default_role": "project-viewer",
-            "rules": {
-                "actioncontroller": {
-                    "*": "project-manager"
-                },
-                "projectactionduplicationcontroller": {
-                    "*": "project-manager"
-                },
-                "actioncreationcontroller": {
-                    "*": "project-manager"
-                },
-                "analyticcontroller": {
-                    "*": "project-manager"
-                },
-                "boardajaxcontroller": {
-                    "save": "project-member"
-                },
-                "boardpopovercontroller": {
-                    "*": "project-member"
-                },
-                "taskpopovercontroller": {
-                    "*": "project-member"
-                },
-                "calendarcontroller": {
-                    "save": "project-member"
-                },
-                "categorycontroller": {
-                    "*": "project-manager"
-                },
-                "columncontroller": {
-                    "*": "project-manager"
-                },
-                "commentcontroller": {
-                    "create": "project-member",
-                    "save": "project-member",
-                    "edit": "project-member",
-                    "update": "project-member",
-                    "confirm": "project-member",
-                    "remove": "project-member"
-                },
-                "commentlistcontroller": {
-                    "save": "project-member"
-                },
-                "commentmailcontroller": {
-                    "*": "project-member"
-                },
-                "customfiltercontroller": {
-                    "*": "project-member"
-                },
-                "exportcontroller": {
-                    "*": "project-manager"
-                },
-                "taskfilecontroller": {
-                    "screenshot": "project-member",
-                    "create": "project-member",
-                    "save": "project-member",
-                    "remove": "project-member",
-                    "confirm": "project-member"
-                },
-                "projectviewcontroller": {
-                    "share": "project-manager",
-                    "updatesharing": "project-manager",
-                    "integrations": "project-manager",
-                    "updateintegrations": "project-manager",
-                    "notifications": "project-manager",
-                    "updatenotifications": "project-manager",
-                    "duplicate": "project-manager",
-                    "doduplication": "project-manager"
-                },
-                "projectpermissioncontroller": {
-                    "*": "project-manager"
-                },
-                "projecteditcontroller": {
-                    "*": "project-manager"
-                },
-                "projectpredefinedcontentcontroller": {
-                    "*": "project-manager"
-                },
-                "predefinedtaskdescriptioncontroller": {
-                    "*": "project-manager"
-                },
-                "projectfilecontroller": {
-                    "*": "project-member"
-                },
-                "projectuseroverviewcontroller": {
-                    "*": "project-manager"
-                },
-                "projectstatuscontroller": {
-                    "*": "project-manager"
-                },
-                "projecttagcontroller": {
-                    "*": "project-manager"
-                },
-                "subtaskcontroller": {
-                    "*": "project-member"
-                },
-                "subtaskconvertercontroller": {
-                    "*": "project-member"
-                },
-                "subtaskrestrictioncontroller": {
-                    "*": "project-member"
-                },
-                "subtaskstatuscontroller": {
-                    "*": "project-member"
-                },
-                "swimlanecontroller": {
-                    "*": "project-manager"
-                },
-                "tasksuppressioncontroller": {
-                    "*": "project-member"
-                },
-                "taskcreationcontroller": {
-                    "*": "project-member"
-                },
-                "taskbulkcontroller": {
-                    "*": "project-member"
-                },
-                "taskbulkmovecolumncontroller": {
-                    "*": "project-member"
-                },
-                "taskbulkchangepropertycontroller": {
-                    "*": "project-member"
-                },
-                "taskduplicationcontroller": {
-                    "*": "project-member"
-                },
-                "taskrecurrencecontroller": {
-                    "*": "project-member"
-                },
-                "taskimportcontroller": {
-                    "*": "project-manager"
-                },
-                "taskinternallinkcontroller": {
-                    "*": "project-member"
-                },
-                "taskexternallinkcontroller": {
-                    "*": "project-member"
-                },
-                "taskmodificationcontroller": {
-                    "*": "project-member"
-                },
-                "taskstatuscontroller": {
-                    "*": "project-member"
-                },
-                "taskmailcontroller": {
-                    "*": "project-member"
-                },
-                "userajaxcontroller": {
-                    "mention": "project-member"
-                }
-            }
-        },
-        "application": {
-            "default_role": "app-user",
-            "rules": {
-                "authcontroller": {
-                    "login": "app-public",
-                    "check": "app-public"
-                },
-                "captchacontroller": {
-                    "*": "app-public"
-                },
-                "passwordresetcontroller": {
-                    "*": "app-public"
-                },
-                "taskviewcontroller": {
-                    "readonly": "app-public"
-                },
-                "boardviewcontroller": {
-                    "readonly": "app-public"
-                },
-                "icalendarcontroller": {
-                    "*": "app-public"
-                },
-                "feedcontroller": {
-                    "*": "app-public"
-                },
-                "avatarfilecontroller": {
-                    "show": "app-public",
-                    "image": "app-public"
-                },
-                "userinvitecontroller": {
-                    "signup": "app-public",
-                    "register": "app-public"
-                },
-                "cronjobcontroller": {
-                    "run": "app-public"
-                },
-                "configcontroller": {
-                    "*": "app-admin"
-                },
-                "tagcontroller": {
-                    "*": "app-admin"
-                },
-                "plugincontroller": {
-                    "*": "app-admin"
-                },
-                "currencycontroller": {
-                    "*": "app-admin"
-                },
-                "grouplistcontroller": {
-                    "*": "app-admin"
-                },
-                "groupcreationcontroller": {
-                    "*": "app-admin"
-                },
-                "groupmodificationcontroller": {
-                    "*": "app-admin"
-                },
-                "linkcontroller": {
-                    "*": "app-admin"
-                },
-                "projectcreationcontroller": {
-                    "create": "app-manager"
-                },
-                "projectuseroverviewcontroller": {
-                    "*": "app-manager"
-                },
-                "twofactorcontroller": {
-                    "disable": "app-admin"
-                },
-                "userimportcontroller": {
-                    "*": "app-admin"
-                },
-                "usercreationcontroller": {
-                    "*": "app-admin"
-                },
-                "userlistcontroller": {
-                    "*": "app-admin"
-                },
-                "userstatuscontroller": {
-                    "*": "app-admin"
-                },
-                "usercredentialcontroller": {
-                    "changeauthentication": "app-admin",
-                    "saveauthentication": "app-admin",
-                    "unlock": "app-admin"
-                }
-            }
-        },
-        "api": {
-            "default_role": "app-user",
-            "rules": {
-                "userprocedure": {
-                    "*": "app-admin"
-                },
-                "groupmemberprocedure": {
-                    "*": "app-admin"
-                },
-                "groupprocedure": {
-                    "*": "app-admin"
-                },
-                "linkprocedure": {
-                    "*": "app-admin"
-                },
-                "taskprocedure": {
-                    "getoverduetasks": "app-admin"
-                },
-                "projectprocedure": {
-                    "getallprojects": "app-admin",
-                    "createproject": "app-manager"
-                }
-            }
-        },
-        "api_project": {
-            "default_role": "project-viewer",
-            "rules": {
-                "actionprocedure": {
-                    "removeaction": "project-manager",
-                    "getactions": "project-manager",
-                    "createaction": "project-manager"
-                },
-                "categoryprocedure": {
-                    "removecategory": "project-manager",
-                    "createcategory": "project-manager",
-                    "updatecategory": "project-manager"
-                },
-                "columnprocedure": {
-                    "updatecolumn": "project-manager",
-                    "addcolumn": "project-manager",
-                    "removecolumn": "project-manager",
-                    "changecolumnposition": "project-manager"
-                },
-                "commentprocedure": {
-                    "removecomment": "project-member",
-                    "createcomment": "project-member",
-                    "updatecomment": "project-member"
-                },
-                "projectpermissionprocedure": {
-                    "addprojectuser": "project-manager",
-                    "addprojectgroup": "project-manager",
-                    "removeprojectuser": "project-manager",
-                    "removeprojectgroup": "project-manager",
-                    "changeprojectuserrole": "project-manager",
-                    "changeprojectgrouprole": "project-manager"
-                },
-                "projectprocedure": {
-                    "updateproject": "project-manager",
-                    "removeproject": "project-manager",
-                    "enableproject": "project-manager",
-                    "disableproject": "project-manager",
-                    "enableprojectpublicaccess": "project-manager",
-                    "disableprojectpublicaccess": "project-manager"
-                },
-                "subtaskprocedure": {
-                    "removesubtask": "project-member",
-                    "createsubtask": "project-member",
-                    "updatesubtask": "project-member"
-                },
-                "subtasktimetrackingprocedure": {
-                    "setsubtaskstarttime": "project-member",
-                    "setsubtaskendtime": "project-member"
-                },
-                "swimlaneprocedure": {
-                    "addswimlane": "project-manager",
-                    "updateswimlane": "project-manager",
-                    "removeswimlane": "project-manager",
-                    "disableswimlane": "project-manager",
-                    "enableswimlane": "project-manager",
-                    "changeswimlaneposition": "project-manager"
-                },
-                "projectfileprocedure": {
-                    "createprojectfile": "project-member",
-                    "removeprojectfile": "project-member",
-                    "removeallprojectfiles": "project-member"
-                },
-                "taskfileprocedure": {
-                    "createtaskfile": "project-member",
-                    "removetaskfile": "project-member",
-                    "removealltaskfiles": "project-member"
-                },
-                "tasklinkprocedure": {
-                    "createtasklink": "project-member",
-                    "updatetasklink": "project-member",
-                    "removetasklink": "project-member"
-                },
-                "taskexternallinkprocedure": {
-                    "createexternaltasklink": "project-member",
-                    "updateexternaltasklink": "project-member",
-                    "removeexternaltasklink": "project-member"
-                },
-                "taskprocedure": {
-                    "opentask": "project-member",
-                    "closetask": "project-member",
-                    "removetask": "project-member",
-                    "movetaskposition": "project-member",
-                    "movetasktoproject": "project-member",
-                    "duplicatetasktoproject": "project-member",
-                    "createtask": "project-member",
-                    "updatetask": "project-member"
-                },
-                "tasktagprocedure": {
-                    "settasktags": "project-member"
-                },
-                "tagprocedure": {
-                    "createtag": "project-member",
-                    "updatetag": "project-member",
-                    "removetag": "project-member"
-                }
-            }
-        }
-    }
-}`
-
-// RBAC Data Structures
-type RBACConfig struct {
-	Roles       RolesConfig       `json:"roles"`
-	Hierarchies HierarchiesConfig `json:"hierarchies"`
-	AccessMaps  AccessMapsConfig  `json:"access_maps"`
-}
-
-type RolesConfig struct {
-	ApplicationRoles map[string]string `json:"application_roles"`
-	ProjectRoles     map[string]string `json:"project_roles"`
-	AllRoles         map[string]string `json:"all_roles"`
-}
-
-type HierarchiesConfig struct {
-	Application map[string][]string `json:"application"`
-	Project     map[string][]string `json:"project"`
-}
-
-type AccessMapsConfig struct {
-	Project     AccessMapConfig `json:"project"`
-	Application AccessMapConfig `json:"application"`
-	API         AccessMapConfig `json:"api"`
-	APIProject  AccessMapConfig `json:"api_project"`
-}
-
-type AccessMapConfig struct {
-	DefaultRole string                       `json:"default_role"`
-	Rules       map[string]map[string]string `json:"rules"`
-}
-
-// UserContext holds information about the current user and their permissions
-type UserContext struct {
-	UserID       int            `json:"user_id"`
-	Username     string         `json:"username"`
-	AppRoles     []string       `json:"app_roles"`
-	ProjectRoles map[int]string `json:"project_roles"` // project_id -> role
-	IsAdmin      bool           `json:"is_admin"`
-}
-
-// PermissionCheck represents a permission check request
-type PermissionCheck struct {
-	UserID    int    `json:"user_id"`
-	ProjectID *int   `json:"project_id,omitempty"` // nil for application-level permissions
-	Procedure string `json:"procedure"`
-	Method    string `json:"method"`
-}
-
-// RBACManager handles role-based access control
-type RBACManager struct {
-	config *RBACConfig
-}
-
-// NewRBACManager creates a new RBAC manager with the embedded configuration
-func NewRBACManager() (*RBACManager, error) {
-	var config RBACConfig
-	if err := json.Unmarshal([]byte(rbacConfigJSON), &config); err != nil {
-		return nil, fmt.Errorf("failed to parse RBAC config: %w", err)
-	}
-	return &RBACManager{config: &config}, nil
-}
-
-// CheckPermission checks if a user has permission for a specific procedure/method
-func (rbac *RBACManager) CheckPermission(userCtx *UserContext, projectID *int, procedure, method string) bool {
-	// Get the appropriate access map
-	var accessMap AccessMapConfig
-	var userRole string
+func main() {
+	// Parse command-line flags
+	var (
+		_                  = flag.Bool("stdio", false, "Use stdio transport (default)")
+		flagSSE            = flag.Bool("sse", false, "Use SSE transport")
+		flagStreamableHTTP = flag.Bool("streamablehttp", false, "Use Streamable HTTP transport")
+		flagHTTP           = flag.Bool("http", false, "Alias for --streamablehttp")
+		flagPort           = flag.String("port", "", "Port for HTTP/SSE transport (default: 8080)")
+		flagVersion        = flag.Bool("version", false, "Show version information")
+		flagReadOnly       = flag.Bool("read-only", false, "Expose only tools that don't modify Kanboard")
+		flagDryRun         = flag.Bool("dry-run", false, "Preview mutating Kanboard calls instead of executing them")
+	)
+	flag.Parse()
 
-	if projectID != nil {
-		// Project-level permission check
-		accessMap = rbac.config.AccessMaps.APIProject
-		userRole = userCtx.ProjectRoles[*projectID]
-		if userRole == "" {
-			userRole = accessMap.DefaultRole
-		}
-	} else {
-		// Application-level permission check
-		accessMap = rbac.config.AccessMaps.API
-		// Use highest application role
-		userRole = rbac.getHighestAppRole(userCtx.AppRoles)
-		if userRole == "" {
-			userRole = accessMap.DefaultRole
+	// Handle version flag
+	if *flagVersion {
+		fmt.Printf("KanboardMCP version %s\n", version)
+		if buildTime != "" {
+			fmt.Printf("Build time: %s\n", buildTime)
 		}
+		os.Exit(0)
 	}
 
-	// Check if the procedure exists in the access map
-	procedureRules, exists := accessMap.Rules[procedure]
-	if !exists {
-		return false
-	}
-
-	// Check specific method or wildcard
-	requiredRole, hasMethod := procedureRules[method]
-	if !hasMethod {
-		requiredRole, hasMethod = procedureRules["*"]
-		if !hasMethod {
-			return false
+	// Determine transport mode from flags or environment
+	transportMode := transport.Stdio
+	if *flagSSE {
+		transportMode = transport.SSE
+	} else if *flagStreamableHTTP || *flagHTTP {
+		transportMode = transport.StreamableHTTP
+	} else if mode := os.Getenv("MCP_MODE"); mode != "" {
+		switch strings.ToLower(mode) {
+		case transport.SSE:
+			transportMode = transport.SSE
+		case transport.StreamableHTTP, "http":
+			transportMode = transport.StreamableHTTP
+		default:
+			transportMode = transport.Stdio
 		}
 	}
 
-	// Check if user has the required role (considering hierarchy)
-	return rbac.hasRole(userCtx, userRole, requiredRole, projectID != nil)
-}
-
-// getHighestAppRole returns the highest application role from user's roles
-func (rbac *RBACManager) getHighestAppRole(userRoles []string) string {
-	rolePriority := map[string]int{
-		"app-admin":   3,
-		"app-manager": 2,
-		"app-user":    1,
-	}
-
-	highestRole := ""
-	highestPriority := 0
-
-	for _, role := range userRoles {
-		if priority, exists := rolePriority[role]; exists && priority > highestPriority {
-			highestPriority = priority
-			highestRole = role
-		}
+	// Determine port from flags or environment
+	port := "8080"
+	if *flagPort != "" {
+		port = *flagPort
+	} else if envPort := os.Getenv("MCP_PORT"); envPort != "" {
+		port = envPort
 	}
 
-	return highestRole
-}
-
-// hasRole checks if user has the required role considering role hierarchies
-func (rbac *RBACManager) hasRole(userCtx *UserContext, userRole, requiredRole string, isProjectRole bool) bool {
-	if userRole == requiredRole {
-		return true
-	}
+	logging.Transport.Debug("transport configured", "mode", transportMode, "port", port)
 
-	// Check role hierarchy
-	var hierarchy map[string][]string
-	if isProjectRole {
-		hierarchy = rbac.config.Hierarchies.Project
-	} else {
-		hierarchy = rbac.config.Hierarchies.Application
+	// Initialize RBAC manager
+	rbacManager, err := rbac.NewManager(os.Getenv("RBAC_CONFIG"))
+	if err != nil {
+		logging.RBAC.Error("failed to initialize RBAC manager", "error", err)
+		os.Exit(1)
 	}
 
-	// If user has a higher role that includes the required role
-	if inheritedRoles, exists := hierarchy[userRole]; exists {
-		for _, inheritedRole := range inheritedRoles {
-			if inheritedRole == requiredRole {
-				return true
-			}
+	// Several Kanboard installations may be configured in a file, the KANBOARD_*
+	// variables configure a single one otherwise
+	var instancesConfig *tools.InstancesConfig
+	if path := os.Getenv("KANBOARD_INSTANCES_CONFIG"); path != "" {
+		instancesConfig, err = tools.LoadInstancesConfig(path)
+		if err != nil {
+			logging.HTTP.Error("failed to load instances config", "error", err)
+			os.Exit(1)
 		}
+		if os.Getenv("KANBOARD_RECORD") != "" || os.Getenv("KANBOARD_REPLAY") != "" {
+			logging.HTTP.Error("KANBOARD_RECORD and KANBOARD_REPLAY need a single Kanboard instance, unset KANBOARD_INSTANCES_CONFIG")
+			os.Exit(1)
+		}
+		logging.HTTP.Info("loaded instances config", "path", path, "instances", instancesConfig.Names(), "default", instancesConfig.Default)
 	}
 
-	return false
-}
+	// Optional append-only audit log of every tool call
+	auditLog, err := audit.NewLoggerFromEnv()
+	if err != nil {
+		logging.Tools.Error("failed to initialize audit log", "error", err)
+		os.Exit(1)
+	}
 
-// GetUserContext retrieves user context (this would typically call Kanboard API)
-func (rbac *RBACManager) GetUserContext(kc *kanboardClient, ctx context.Context) (*UserContext, error) {
-	// Get current user info
-	userInfo, err := kc.callKanboardAPI(ctx, "getMe", nil)
+	// Optional OTLP trace export, off unless OTEL_TRACES_EXPORTER=otlp
+	tracing.Default, err = tracing.NewFromEnv(version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
+		logging.Transport.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
 	}
 
-	userMap, ok := userInfo.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid user info format")
+	// Every tool the server knows, with the metadata RBAC, the config and the docs use
+	registry := catalog.New(rbacManager)
+
+	// Read-only mode hides mutating tools and blocks mutating API calls
+	registry.ReadOnly = *flagReadOnly || strings.EqualFold(os.Getenv("MCP_READ_ONLY"), "true")
+	if registry.ReadOnly {
+		logging.Tools.Info("running in read-only mode")
 	}
 
-	userIDFloat, ok := userMap["id"].(float64)
-	if !ok {
-		return nil, fmt.Errorf("invalid user ID format")
+	// Dry-run mode previews every mutating call instead of sending it
+	registry.DryRun = *flagDryRun || strings.EqualFold(os.Getenv("MCP_DRY_RUN"), "true")
+	if registry.DryRun {
+		logging.Tools.Info("running in dry-run mode")
 	}
-	userID := int(userIDFloat)
 
-	username, _ := userMap["username"].(string)
+	// Destructive tools that require a confirm token
+	registry.Confirm = tools.ConfirmToolsFromEnv(registry)
 
-	// Get the actual role from Kanboard API response
-	var appRoles []string
-	if roleFromAPI, exists := userMap["role"]; exists && roleFromAPI != nil {
-		if roleStr, ok := roleFromAPI.(string); ok && roleStr != "" {
-			appRoles = []string{roleStr}
-			if os.Getenv("KANBOARD_DEBUG") == "true" {
-				fmt.Fprintf(os.Stderr, "DEBUG: Got role from API: %s\n", roleStr)
-			}
-		}
-	} else if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: No 'role' field in getMe response, available fields: ")
-		for k := range userMap {
-			fmt.Fprintf(os.Stderr, "%s ", k)
+	// Load MCP tools configuration
+	configPath := os.Getenv("MCP_TOOLS_CONFIG")
+	if configPath == "" {
+		// Try to find config in the same directory as the binary
+		execPath, err := os.Executable()
+		if err == nil {
+			configPath = filepath.Join(filepath.Dir(execPath), "mcp-tools-config.yaml")
+		} else {
+			configPath = "mcp-tools-config.yaml"
 		}
-		fmt.Fprintf(os.Stderr, "\n")
 	}
 
-	// If no role from API, fall back to environment variables
-	if len(appRoles) == 0 {
-		appRoles = strings.Split(os.Getenv("KANBOARD_USER_APP_ROLES"), ",")
-		if len(appRoles) == 1 && appRoles[0] == "" {
-			appRoles = []string{"app-user"} // default role
-		}
+	var enabledTools map[string]bool
+	config, err := tools.LoadConfig(configPath)
+	if err != nil {
+		logging.Tools.Warn("failed to load MCP tools config, all tools will be enabled", "error", err)
+	} else if config != nil {
+		enabledTools = config.EnabledTools(registry)
+		logging.Tools.Debug("loaded MCP tools config", "path", configPath, "enabled_tools", len(enabledTools))
 	}
 
-	// Clean up roles
-	cleanRoles := make([]string, 0, len(appRoles))
-	for _, role := range appRoles {
-		role = strings.TrimSpace(role)
-		if role != "" {
-			cleanRoles = append(cleanRoles, role)
+	// One Kanboard client per instance, each with its own tool configuration
+	if instancesConfig == nil {
+		kbClient, err := newKanboardClient(kanboardEnv())
+		if err != nil {
+			logging.HTTP.Error("failed to initialize Kanboard client", "error", err)
+			os.Exit(1)
 		}
-	}
-
-	// Get project roles - try to get from API first, then environment
-	projectRoles := make(map[int]string)
-
-	// Try to get project roles from Kanboard API
-	if userProjects, err := kc.callKanboardAPI(ctx, "getMyProjects", nil); err == nil {
-		if projects, ok := userProjects.([]interface{}); ok {
-			if os.Getenv("KANBOARD_DEBUG") == "true" {
-				fmt.Fprintf(os.Stderr, "DEBUG: Found %d projects in getMyProjects response\n", len(projects))
+		kbClient.ReadOnly = registry.ReadOnly
+		registry.Instances = []*tools.Instance{{Name: "default", Client: kbClient, Enabled: enabledTools}}
+	} else {
+		for _, name := range instancesConfig.Names() {
+			instanceConfig := instancesConfig.Instances[name]
+			kbClient, err := newKanboardClient(instanceConfig)
+			if err != nil {
+				logging.HTTP.Error("failed to initialize Kanboard client", "instance", name, "error", err)
+				os.Exit(1)
 			}
-			for _, project := range projects {
-				if projectMap, ok := project.(map[string]interface{}); ok {
-					if projectIDFloat, exists := projectMap["id"]; exists {
-						if projectID, ok := projectIDFloat.(float64); ok {
-							// Try to get user role in this project
-							if role, exists := projectMap["role"]; exists {
-								if roleStr, ok := role.(string); ok {
-									projectRoles[int(projectID)] = roleStr
-									if os.Getenv("KANBOARD_DEBUG") == "true" {
-										fmt.Fprintf(os.Stderr, "DEBUG: Project %d has role: %s\n", int(projectID), roleStr)
-									}
-								}
-							} else if os.Getenv("KANBOARD_DEBUG") == "true" {
-								fmt.Fprintf(os.Stderr, "DEBUG: Project %d has no role field\n", int(projectID))
-							}
-						}
-					}
-				}
+			kbClient.ReadOnly = registry.ReadOnly || instanceConfig.ReadOnly
+			instance := &tools.Instance{Name: name, Description: instanceConfig.Description, Client: kbClient,
+				Enabled: enabledTools, ReadOnly: instanceConfig.ReadOnly}
+			if instanceConfig.Tools != nil {
+				instance.Enabled = instanceConfig.Tools.EnabledTools(registry)
 			}
-		}
-	} else if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Failed to get user projects: %v\n", err)
-	}
-
-	// Fall back to environment variables for project roles
-	projectRolesStr := os.Getenv("KANBOARD_USER_PROJECT_ROLES")
-	if projectRolesStr != "" {
-		// Format: "project_id:role,project_id:role"
-		pairs := strings.Split(projectRolesStr, ",")
-		for _, pair := range pairs {
-			parts := strings.Split(strings.TrimSpace(pair), ":")
-			if len(parts) == 2 {
-				if projectID, err := strconv.Atoi(parts[0]); err == nil {
-					// Only set if not already set from API
-					if _, exists := projectRoles[projectID]; !exists {
-						projectRoles[projectID] = parts[1]
-					}
-				}
+			if name == instancesConfig.Default {
+				registry.DefaultInstance = instance
 			}
+			registry.Instances = append(registry.Instances, instance)
 		}
 	}
 
-	return &UserContext{
-		UserID:       userID,
-		Username:     username,
-		AppRoles:     cleanRoles,
-		ProjectRoles: projectRoles,
-		IsAdmin:      rbac.hasRole(&UserContext{AppRoles: cleanRoles}, rbac.getHighestAppRole(cleanRoles), "app-admin", false),
-	}, nil
-}
-
-// requireID is a helper function that accepts ID parameters as either int or string
-func requireID(request mcp.CallToolRequest, paramName string) (int, error) {
-	// Try to get as int first
-	id, err := request.RequireInt(paramName)
-	if err == nil {
-		return id, nil
-	}
-	// If that fails, try as string and convert
-	idStr, err := request.RequireString(paramName)
-	if err != nil {
-		return 0, fmt.Errorf("parameter %s must be an integer or a string representation of an integer", paramName)
-	}
-	idInt, err := strconv.Atoi(idStr)
-	if err != nil {
-		return 0, fmt.Errorf("parameter %s must be a valid integer, got: %s", paramName, idStr)
-	}
-	return idInt, nil
-}
-
-// checkPermission is a helper method for kanboardClient to check permissions
-func (kc *kanboardClient) checkPermission(ctx context.Context, projectID *int, procedure, method string) error {
-	// Allow bypassing permission checks for debugging
-	if os.Getenv("KANBOARD_SKIP_RBAC") == "true" {
-		return nil
-	}
-
-	userCtx, err := kc.rbac.GetUserContext(kc, ctx)
+	// Kanboard credentials sent by each HTTP client, in place of the shared ones
+	registry.SessionCredentials, err = tools.SessionCredentialsModeFromEnv()
 	if err != nil {
-		return fmt.Errorf("failed to get user context: %w", err)
-	}
-
-	// Debug logging
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: User %s (ID: %d) roles: app=%v, project=%v\n",
-			userCtx.Username, userCtx.UserID, userCtx.AppRoles, userCtx.ProjectRoles)
-		fmt.Fprintf(os.Stderr, "DEBUG: Checking permission for %s.%s (projectID: %v)\n",
-			procedure, method, projectID)
+		logging.Transport.Error("failed to read session credentials mode", "error", err)
+		os.Exit(1)
 	}
-
-	if !kc.rbac.CheckPermission(userCtx, projectID, procedure, method) {
-		var contextMsg string
-		if projectID != nil {
-			if projectRole, exists := userCtx.ProjectRoles[*projectID]; exists {
-				contextMsg = fmt.Sprintf(" (project role: %s)", projectRole)
-			} else {
-				contextMsg = " (no project role assigned)"
-			}
+	registry.SessionClientEvicted = rbacManager.ForgetClient
+	if registry.SessionCredentials != tools.SessionCredentialsOff {
+		if transportMode == transport.Stdio {
+			logging.Transport.Warn("MCP_SESSION_CREDENTIALS is ignored with the stdio transport, which has no sessions to authenticate")
+			registry.SessionCredentials = tools.SessionCredentialsOff
+		} else {
+			logging.Transport.Info("using the Kanboard credentials of each session", "mode", registry.SessionCredentials)
 		}
-		return fmt.Errorf("access denied: insufficient permissions for %s.%s (user: %s, app roles: %v%s)",
-			procedure, method, userCtx.Username, userCtx.AppRoles, contextMsg)
-	}
-
-	return nil
-}
-
-// ToolConfig represents the configuration for a domain of tools
-type ToolConfig struct {
-	Enabled bool     `yaml:"enabled"`
-	Tools   []string `yaml:"tools"`
-}
-
-// MCPToolsConfig represents the entire MCP tools configuration
-type MCPToolsConfig struct {
-	Corerules     ToolConfig `yaml:"corerules"`
-	Tasks         ToolConfig `yaml:"tasks"`
-	Projects      ToolConfig `yaml:"projects"`
-	Comments      ToolConfig `yaml:"comments"`
-	Categories    ToolConfig `yaml:"categories"`
-	Columns       ToolConfig `yaml:"columns"`
-	Swimlanes     ToolConfig `yaml:"swimlanes"`
-	Subtasks      ToolConfig `yaml:"subtasks"`
-	Tags          ToolConfig `yaml:"tags"`
-	Users         ToolConfig `yaml:"users"`
-	Groups        ToolConfig `yaml:"groups"`
-	Links         ToolConfig `yaml:"links"`
-	Actions       ToolConfig `yaml:"actions"`
-	Board         ToolConfig `yaml:"board"`
-	Sprints       ToolConfig `yaml:"sprints"`
-	Search        ToolConfig `yaml:"search"`
-	Metadata      ToolConfig `yaml:"metadata"`
-	System        ToolConfig `yaml:"system"`
-	ExternalLinks ToolConfig `yaml:"external_links"`
-	Dashboard     ToolConfig `yaml:"dashboard"`
-}
-
-// loadMCPToolsConfig loads the MCP tools configuration from YAML file
-func loadMCPToolsConfig(configPath string) (*MCPToolsConfig, error) {
-	// Default config path if not specified
-	if configPath == "" {
-		configPath = "mcp-tools-config.yaml"
-	}
-
-	// Check if config file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		// If config doesn't exist, return default config with corerules enabled
-		return &MCPToolsConfig{
-			Corerules:     ToolConfig{Enabled: true, Tools: []string{}},
-			Tasks:         ToolConfig{Enabled: false, Tools: []string{}},
-			Projects:      ToolConfig{Enabled: false, Tools: []string{}},
-			Comments:      ToolConfig{Enabled: false, Tools: []string{}},
-			Categories:    ToolConfig{Enabled: false, Tools: []string{}},
-			Columns:       ToolConfig{Enabled: false, Tools: []string{}},
-			Swimlanes:     ToolConfig{Enabled: false, Tools: []string{}},
-			Subtasks:      ToolConfig{Enabled: false, Tools: []string{}},
-			Tags:          ToolConfig{Enabled: false, Tools: []string{}},
-			Users:         ToolConfig{Enabled: false, Tools: []string{}},
-			Groups:        ToolConfig{Enabled: false, Tools: []string{}},
-			Links:         ToolConfig{Enabled: false, Tools: []string{}},
-			Actions:       ToolConfig{Enabled: false, Tools: []string{}},
-			Board:         ToolConfig{Enabled: false, Tools: []string{}},
-			Sprints:       ToolConfig{Enabled: false, Tools: []string{}},
-			Search:        ToolConfig{Enabled: false, Tools: []string{}},
-			Metadata:      ToolConfig{Enabled: false, Tools: []string{}},
-			System:        ToolConfig{Enabled: false, Tools: []string{}},
-			ExternalLinks: ToolConfig{Enabled: false, Tools: []string{}},
-			Dashboard:     ToolConfig{Enabled: false, Tools: []string{}},
-		}, nil
 	}
 
-	data, err := os.ReadFile(configPath)
+	// Optional bearer token authentication of the HTTP endpoints, with per-token tool domains
+	authenticator, err := auth.NewFromEnv(registry)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	var config MCPToolsConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+		logging.Transport.Error("failed to initialize authentication", "error", err)
+		os.Exit(1)
 	}
-
-	return &config, nil
-}
-
-// getAllEnabledTools collects all enabled tools from all domains
-func (config *MCPToolsConfig) getAllEnabledTools() map[string]bool {
-	enabledTools := make(map[string]bool)
-
-	// Helper function to add tools from a domain
-	addTools := func(domain ToolConfig) {
-		if domain.Enabled {
-			for _, tool := range domain.Tools {
-				enabledTools[tool] = true
-			}
+	if authenticator != nil {
+		if transportMode == transport.Stdio {
+			logging.Transport.Warn("MCP_AUTH_* settings are ignored with the stdio transport")
+		} else {
+			logging.Transport.Info("MCP endpoints require a bearer token", "methods", authenticator.Methods())
 		}
+	} else if transportMode != transport.Stdio {
+		logging.Transport.Warn("MCP endpoints are open to anyone who can reach the port, set MCP_AUTH_TOKENS_FILE, MCP_AUTH_HMAC_SECRET or MCP_AUTH_JWKS_FILE")
 	}
 
-	// corerules are always enabled if specified
-	addTools(config.Corerules)
-	addTools(config.Tasks)
-	addTools(config.Projects)
-	addTools(config.Comments)
-	addTools(config.Categories)
-	addTools(config.Columns)
-	addTools(config.Swimlanes)
-	addTools(config.Subtasks)
-	addTools(config.Tags)
-	addTools(config.Users)
-	addTools(config.Groups)
-	addTools(config.Links)
-	addTools(config.Actions)
-	addTools(config.Board)
-	addTools(config.Sprints)
-	addTools(config.Search)
-	addTools(config.Metadata)
-	addTools(config.System)
-	addTools(config.ExternalLinks)
-	addTools(config.Dashboard)
-
-	return enabledTools
-}
-
-// ToolRegistryFunc is a function that registers a tool
-type ToolRegistryFunc func(s *server.MCPServer, kbClient *kanboardClient)
-
-// ToolRegistry maps tool names to their registration functions
-type ToolRegistry map[string]ToolRegistryFunc
-
-// ToolInfo stores metadata about a registered tool for tool search
-type ToolInfo struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-}
-
-// Global registry of all registered tools for tool search
-var registeredTools []ToolInfo
+	s := newServer(registry, rbacManager, auditLog, authenticator)
 
-// registerToolIfEnabled conditionally registers a tool if it's enabled in the config
-func registerToolIfEnabled(toolName string, enabledTools map[string]bool, tool mcp.Tool, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), s *server.MCPServer) {
-	// Extract description from tool for the registry
-	description := tool.Description
-
-	// If enabledTools is empty or nil, register all tools (backward compatibility)
-	if enabledTools == nil || len(enabledTools) == 0 {
-		s.AddTool(tool, handler)
-		registeredTools = append(registeredTools, ToolInfo{Name: toolName, Description: description})
-		return
-	}
-
-	// Check if tool is enabled
-	if enabled, exists := enabledTools[toolName]; exists && enabled {
-		s.AddTool(tool, handler)
-		registeredTools = append(registeredTools, ToolInfo{Name: toolName, Description: description})
-		if os.Getenv("KANBOARD_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "DEBUG: Registered tool: %s\n", toolName)
-		}
-	} else if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Skipped tool (not enabled): %s\n", toolName)
+	// The HTTP transports only return on errors, so the buffered spans are exported when
+	// the process is stopped. ServeStdio handles the signals itself and returns.
+	if transportMode != transport.Stdio {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			sig := <-signals
+			logging.Transport.Info("shutting down", "signal", sig.String())
+			tracing.Default.Shutdown()
+			os.Exit(0)
+		}()
 	}
-}
 
-// ToolSearchResult represents a search result with relevance score
-type ToolSearchResult struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Score       float64 `json:"score"`
+	// Start the server based on transport mode
+	transport.Start(s, registry.Instances, transport.Config{
+		Mode:      transportMode,
+		Port:      port,
+		Version:   version,
+		BuildTime: buildTime,
+		Auth:      authenticator,
+	})
+	tracing.Default.Shutdown()
 }
 
-// toolSearchHandler handles tool_search requests using regex or BM25 search
-func toolSearchHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	query, err := req.RequireString("query")
+// newServer creates the MCP server with the registry's tools installed. Tool calls are
+// routed to their Kanboard instance first, then RBAC is enforced by the middleware.
+// tools/list and tool_search only show the tools within the scopes of the authenticated caller.
+func newServer(registry *tools.Registry, rbacManager *rbac.Manager, auditLog *audit.Logger, authenticator *auth.Authenticator) *server.MCPServer {
+	s := server.NewMCPServer(
+		"KanboardMCP",
+		"1.0.0",
+		server.WithToolCapabilities(false),
+		server.WithHooks(metrics.Default.Hooks()),
+		server.WithToolFilter(authenticator.ToolFilter()),
+		server.WithToolHandlerMiddleware(logging.RequestIDMiddleware),
+		server.WithToolHandlerMiddleware(metrics.Middleware),
+		server.WithToolHandlerMiddleware(tracing.Middleware),
+		server.WithToolHandlerMiddleware(registry.InstanceMiddleware()),
+		server.WithToolHandlerMiddleware(auditLog.Middleware(registry)),
+		server.WithToolHandlerMiddleware(registry.BreakerMiddleware()),
+		server.WithToolHandlerMiddleware(rbacManager.Middleware(registry)),
+		server.WithToolHandlerMiddleware(registry.ConfirmMiddleware()),
+	)
+	registry.ToolFilter = authenticator.ToolFilter()
+	registry.Install(s)
+	return s
+}
+
+// kanboardEnv reads the Kanboard connection from the KANBOARD_* variables
+func kanboardEnv() tools.InstanceConfig {
+	config := tools.InstanceConfig{
+		Endpoint:   os.Getenv("KANBOARD_API_ENDPOINT"),
+		AuthMethod: os.Getenv("KANBOARD_AUTH_METHOD"),
+		APIKey:     os.Getenv("KANBOARD_API_KEY"),
+		Username:   os.Getenv("KANBOARD_USERNAME"),
+		Password:   os.Getenv("KANBOARD_PASSWORD"),
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://your-kanboard-url/jsonrpc.php"
+	}
+	if config.APIKey == "" {
+		config.APIKey = "your-kanboard-api-key"
+	}
+	if config.Username == "" {
+		config.Username = "your-kanboard-username" // Default or placeholder
+	}
+	if config.Password == "" {
+		config.Password = "your-kanboard-password" // Default or placeholder
+	}
+	return config
+}
+
+// newKanboardClient creates the client of a Kanboard instance, with the HTTP, rate limit,
+// circuit breaker, cache, name matching and cassette settings from the environment
+func newKanboardClient(config tools.InstanceConfig) (*kanboard.Client, error) {
+	logging.HTTP.Debug("Kanboard connection configured",
+		"endpoint", config.Endpoint,
+		"api_key", kanboard.MaskAPIKey(config.APIKey), "api_key_length", len(config.APIKey),
+		"username", config.Username,
+		"password", kanboard.MaskPassword(config.Password), "password_length", len(config.Password),
+		"auth_method", config.AuthMethod)
+
+	kbClient := kanboard.NewClient(config.Endpoint, config.APIKey, config.Username, config.Password)
+	kbClient.AuthMethod = config.AuthMethod
+
+	// Connection pool for all requests to this Kanboard
+	var err error
+	kbClient.HTTPClient, err = kanboard.NewHTTPClientFromEnv()
 	if err != nil {
-		return mcp.NewToolResultError("query parameter is required"), nil
-	}
-
-	searchType := req.GetString("search_type", "auto")
-	maxResults := req.GetInt("max_results", 10)
-
-	var results []ToolSearchResult
-
-	switch searchType {
-	case "regex":
-		results = searchToolsRegex(query, maxResults)
-	case "bm25":
-		results = searchToolsBM25(query, maxResults)
-	default: // "auto" - try regex first, fall back to BM25
-		results = searchToolsRegex(query, maxResults)
-		if len(results) == 0 {
-			results = searchToolsBM25(query, maxResults)
-		}
-	}
-
-	// Build response
-	response := map[string]interface{}{
-		"query":        query,
-		"search_type":  searchType,
-		"total_tools":  len(registeredTools),
-		"result_count": len(results),
-		"results":      results,
+		return nil, fmt.Errorf("failed to initialize HTTP client: %w", err)
 	}
 
-	jsonResponse, err := json.MarshalIndent(response, "", "  ")
+	// Protect Kanboard from bursts of tool calls and stop calling it while it is down
+	kbClient.RateLimiter, kbClient.MethodLimiter, err = kanboard.NewRateLimitersFromEnv()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+		return nil, fmt.Errorf("failed to initialize rate limits: %w", err)
 	}
-
-	return mcp.NewToolResultText(string(jsonResponse)), nil
-}
-
-// searchToolsRegex searches tools using regex pattern matching
-func searchToolsRegex(pattern string, maxResults int) []ToolSearchResult {
-	var results []ToolSearchResult
-
-	// Compile regex (case-insensitive)
-	re, err := regexp.Compile("(?i)" + pattern)
+	kbClient.Breaker, err = kanboard.NewCircuitBreakerFromEnv()
 	if err != nil {
-		// If pattern is invalid, treat it as a literal string search
-		pattern = regexp.QuoteMeta(pattern)
-		re, _ = regexp.Compile("(?i)" + pattern)
-	}
-
-	for _, tool := range registeredTools {
-		// Check if pattern matches name or description
-		nameMatch := re.MatchString(tool.Name)
-		descMatch := re.MatchString(tool.Description)
-
-		if nameMatch || descMatch {
-			score := 0.0
-			if nameMatch {
-				score += 1.0 // Higher score for name match
-			}
-			if descMatch {
-				score += 0.5 // Lower score for description match
-			}
-			results = append(results, ToolSearchResult{
-				Name:        tool.Name,
-				Description: tool.Description,
-				Score:       score,
-			})
-		}
-	}
-
-	// Sort by score descending
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
-
-	// Limit results
-	if len(results) > maxResults {
-		results = results[:maxResults]
-	}
-
-	return results
-}
-
-// searchToolsBM25 searches tools using BM25 algorithm for keyword relevance
-func searchToolsBM25(query string, maxResults int) []ToolSearchResult {
-	// Tokenize query
-	queryTerms := tokenize(query)
-	if len(queryTerms) == 0 {
-		return nil
-	}
-
-	// BM25 parameters
-	k1 := 1.2
-	b := 0.75
-
-	// Calculate average document length
-	totalLen := 0
-	for _, tool := range registeredTools {
-		totalLen += len(tokenize(tool.Name + " " + tool.Description))
-	}
-	avgDocLen := float64(totalLen) / float64(len(registeredTools))
-
-	// Calculate document frequencies for query terms
-	docFreq := make(map[string]int)
-	for _, term := range queryTerms {
-		for _, tool := range registeredTools {
-			docText := strings.ToLower(tool.Name + " " + tool.Description)
-			if strings.Contains(docText, term) {
-				docFreq[term]++
-			}
-		}
-	}
-
-	// Calculate BM25 scores
-	var results []ToolSearchResult
-	N := float64(len(registeredTools))
-
-	for _, tool := range registeredTools {
-		docText := tool.Name + " " + tool.Description
-		docTokens := tokenize(docText)
-		docLen := float64(len(docTokens))
-
-		// Count term frequencies in document
-		termFreq := make(map[string]int)
-		for _, token := range docTokens {
-			termFreq[token]++
-		}
-
-		// Calculate BM25 score
-		score := 0.0
-		for _, term := range queryTerms {
-			tf := float64(termFreq[term])
-			df := float64(docFreq[term])
-
-			if df == 0 {
-				continue
-			}
-
-			// IDF component
-			idf := math.Log((N - df + 0.5) / (df + 0.5))
-
-			// TF component with length normalization
-			tfNorm := (tf * (k1 + 1)) / (tf + k1*(1-b+b*(docLen/avgDocLen)))
-
-			score += idf * tfNorm
-		}
-
-		// Boost score if query matches tool name
-		nameLower := strings.ToLower(tool.Name)
-		for _, term := range queryTerms {
-			if strings.Contains(nameLower, term) {
-				score *= 1.5 // 50% boost for name matches
-				break
-			}
-		}
-
-		if score > 0 {
-			results = append(results, ToolSearchResult{
-				Name:        tool.Name,
-				Description: tool.Description,
-				Score:       score,
-			})
-		}
-	}
-
-	// Sort by score descending
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
-
-	// Limit results
-	if len(results) > maxResults {
-		results = results[:maxResults]
-	}
-
-	return results
-}
-
-// tokenize splits text into lowercase tokens
-func tokenize(text string) []string {
-	// Convert to lowercase and split on non-alphanumeric characters
-	text = strings.ToLower(text)
-	re := regexp.MustCompile(`[^a-z0-9]+`)
-	words := re.Split(text, -1)
-
-	// Filter empty strings and common stop words
-	stopWords := map[string]bool{
-		"a": true, "an": true, "the": true, "and": true, "or": true,
-		"is": true, "are": true, "was": true, "were": true, "be": true,
-		"to": true, "of": true, "in": true, "for": true, "on": true,
-		"with": true, "by": true, "from": true, "as": true, "at": true,
-	}
-
-	var tokens []string
-	for _, word := range words {
-		if word != "" && !stopWords[word] {
-			tokens = append(tokens, word)
-		}
+		return nil, fmt.Errorf("failed to initialize circuit breaker: %w", err)
 	}
 
-	return tokens
-}
-
-func main() {
-	// Parse command-line flags
-	var (
-		_                  = flag.Bool("stdio", false, "Use stdio transport (default)")
-		flagSSE            = flag.Bool("sse", false, "Use SSE transport")
-		flagStreamableHTTP = flag.Bool("streamablehttp", false, "Use Streamable HTTP transport")
-		flagHTTP           = flag.Bool("http", false, "Alias for --streamablehttp")
-		flagPort           = flag.String("port", "", "Port for HTTP/SSE transport (default: 8080)")
-		flagVersion        = flag.Bool("version", false, "Show version information")
-	)
-	flag.Parse()
-
-	// Handle version flag
-	if *flagVersion {
-		fmt.Printf("KanboardMCP version %s\n", version)
-		if buildTime != "" {
-			fmt.Printf("Build time: %s\n", buildTime)
-		}
-		os.Exit(0)
+	// Cache slow-changing reference data such as columns, swimlanes and tags
+	kbClient.Responses, err = kanboard.NewResponseCacheFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize response cache: %w", err)
 	}
 
-	// Determine transport mode from flags or environment
-	transportMode := TransportStdio
-	if *flagSSE {
-		transportMode = TransportSSE
-	} else if *flagStreamableHTTP || *flagHTTP {
-		transportMode = TransportStreamableHTTP
-	} else if mode := os.Getenv("MCP_MODE"); mode != "" {
-		switch strings.ToLower(mode) {
-		case TransportSSE:
-			transportMode = TransportSSE
-		case TransportStreamableHTTP, "http":
-			transportMode = TransportStreamableHTTP
-		default:
-			transportMode = TransportStdio
-		}
+	// Strict or fuzzy matching of names given in place of IDs
+	kbClient.FuzzyNames, err = kanboard.FuzzyNamesFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read name matching mode: %w", err)
 	}
 
-	// Determine port from flags or environment
-	port := "8080"
-	if *flagPort != "" {
-		port = *flagPort
-	} else if envPort := os.Getenv("MCP_PORT"); envPort != "" {
-		port = envPort
+	// Optional recording of the Kanboard traffic, or replay of a recording without Kanboard
+	kbClient.Cassette, err = kanboard.NewCassetteFromEnv(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cassette: %w", err)
 	}
-
-	// Debug transport configuration
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Transport mode: %s\n", transportMode)
-		if transportMode != TransportStdio {
-			fmt.Fprintf(os.Stderr, "DEBUG: Port: %s\n", port)
-		}
+	if kbClient.Cassette.Replaying() {
+		logging.HTTP.Info("replaying Kanboard API calls from a cassette", "cassette", os.Getenv("KANBOARD_REPLAY"))
+	} else if kbClient.Cassette != nil {
+		logging.HTTP.Info("recording Kanboard API calls to a cassette", "cassette", os.Getenv("KANBOARD_RECORD"))
 	}
-
-	// Load MCP tools configuration
-	configPath := os.Getenv("MCP_TOOLS_CONFIG")
-	if configPath == "" {
-		// Try to find config in the same directory as the binary
-		execPath, err := os.Executable()
-		if err == nil {
-			configPath = filepath.Join(filepath.Dir(execPath), "mcp-tools-config.yaml")
-		} else {
-			configPath = "mcp-tools-config.yaml"
-		}
-	}
-
-	config, err := loadMCPToolsConfig(configPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to load MCP tools config: %v. All tools will be enabled.\n", err)
-		config = nil
-	}
-
-	var enabledTools map[string]bool
-	if config != nil {
-		enabledTools = config.getAllEnabledTools()
-		if os.Getenv("KANBOARD_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "DEBUG: Loaded MCP tools config from: %s\n", configPath)
-			fmt.Fprintf(os.Stderr, "DEBUG: Enabled tools count: %d\n", len(enabledTools))
-		}
-	}
-
-	// Create a new MCP server
-	s := server.NewMCPServer(
-		"KanboardMCP",
-		"1.0.0",
-		server.WithToolCapabilities(false),
-	)
-
-	var tool mcp.Tool
-
-	// Initialize RBAC manager
-	rbacManager, err := NewRBACManager()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to initialize RBAC manager: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Initialize Kanboard API client
-	apiEndpoint := os.Getenv("KANBOARD_API_ENDPOINT")
-	if apiEndpoint == "" {
-		apiEndpoint = "https://your-kanboard-url/jsonrpc.php"
-	}
-
-	apiKey := os.Getenv("KANBOARD_API_KEY")
-	if apiKey == "" {
-		apiKey = "your-kanboard-api-key"
-	}
-
-	kbUsername := os.Getenv("KANBOARD_USERNAME")
-	if kbUsername == "" {
-		kbUsername = "your-kanboard-username" // Default or placeholder
-	}
-
-	kbPassword := os.Getenv("KANBOARD_PASSWORD")
-	if kbPassword == "" {
-		kbPassword = "your-kanboard-password" // Default or placeholder
-	}
-
-	// Debug environment variables
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Environment variables loaded:\n")
-		fmt.Fprintf(os.Stderr, "DEBUG: KANBOARD_API_ENDPOINT=%s\n", apiEndpoint)
-		fmt.Fprintf(os.Stderr, "DEBUG: KANBOARD_API_KEY=%s (length: %d)\n", maskAPIKey(apiKey), len(apiKey))
-		fmt.Fprintf(os.Stderr, "DEBUG: KANBOARD_USERNAME=%s\n", kbUsername)
-		fmt.Fprintf(os.Stderr, "DEBUG: KANBOARD_PASSWORD=%s (length: %d)\n", maskPassword(kbPassword), len(kbPassword))
-		fmt.Fprintf(os.Stderr, "DEBUG: KANBOARD_AUTH_METHOD=%s\n", os.Getenv("KANBOARD_AUTH_METHOD"))
-	}
-
-	kbClient := newKanboardClient(apiEndpoint, apiKey, kbUsername, kbPassword, rbacManager)
-
-	tool = mcp.NewTool("get_projects",
-		mcp.WithDescription("List all projects accessible to the current user with basic details (ID, name, status)"),
-	)
-	registerToolIfEnabled("get_projects", enabledTools, tool, kbClient.getProjectsHandler, s)
-
-	tool = mcp.NewTool("create_project",
-		mcp.WithDescription("Create a new project with name, identifier, description, and optional owner assignment"),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the project to create"),
-		),
-		mcp.WithString("description",
-			mcp.Description("Description of the project (optional)"),
-		),
-		mcp.WithNumber("owner_id",
-			mcp.Description("ID of the project owner (optional)"),
-		),
-		mcp.WithString("identifier",
-			mcp.Description("Alphanumeric project identifier (optional)"),
-		),
-		mcp.WithString("start_date",
-			mcp.Description("Project start date in ISO8601 format (optional)"),
-		),
-		mcp.WithString("end_date",
-			mcp.Description("Project end date in ISO8601 format (optional)"),
-		),
-		mcp.WithNumber("priority_default",
-			mcp.Description("Default task priority (optional)"),
-		),
-		mcp.WithNumber("priority_start",
-			mcp.Description("Start priority (optional)"),
-		),
-		mcp.WithNumber("priority_end",
-			mcp.Description("End priority (optional)"),
-		),
-		mcp.WithString("email",
-			mcp.Description("Project email address (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_project", enabledTools, tool, kbClient.createProjectHandler, s)
-
-	tool = mcp.NewTool("get_tasks",
-		mcp.WithDescription("Get all tasks for a project with optional status filter (open/closed/all)"),
-		mcp.WithString("project_name",
-			mcp.Required(),
-			mcp.Description("Name of the project to get tasks from"),
-		),
-	)
-	registerToolIfEnabled("get_tasks", enabledTools, tool, kbClient.getTasksHandler, s)
-
-	tool = mcp.NewTool("create_task",
-		mcp.WithDescription("Create a new task with title, description, assignee, due date, color, category, and column placement"),
-		mcp.WithString("project_name",
-			mcp.Required(),
-			mcp.Description("Name of the project to add the task to"),
-		),
-		mcp.WithString("title",
-			mcp.Required(),
-			mcp.Description("Title of the task to create"),
-		),
-		mcp.WithString("color_id",
-			mcp.Description("Color ID for the task (optional)"),
-		),
-		mcp.WithNumber("column_id",
-			mcp.Description("ID of the column to add the task to (optional)"),
-		),
-		mcp.WithNumber("owner_id",
-			mcp.Description("ID of the task owner (optional)"),
-		),
-		mcp.WithNumber("creator_id",
-			mcp.Description("ID of the task creator (optional)"),
-		),
-		mcp.WithString("date_due",
-			mcp.Description("Due date in YYYY-MM-DD HH:MM format (optional)"),
-		),
-		mcp.WithString("description",
-			mcp.Description("Markdown content for the task description (optional)"),
-		),
-		mcp.WithNumber("category_id",
-			mcp.Description("ID of the task category (optional)"),
-		),
-		mcp.WithNumber("score",
-			mcp.Description("Complexity score of the task (optional)"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Description("ID of the swimlane to add the task to (optional)"),
-		),
-		mcp.WithNumber("priority",
-			mcp.Description("Priority of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_status",
-			mcp.Description("Recurrence status of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_trigger",
-			mcp.Description("Recurrence trigger of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_factor",
-			mcp.Description("Recurrence factor of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_timeframe",
-			mcp.Description("Recurrence timeframe of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_basedate",
-			mcp.Description("Recurrence base date of the task (optional)"),
-		),
-		mcp.WithString("reference",
-			mcp.Description("External reference for the task (optional)"),
-		),
-		mcp.WithArray("tags",
-			mcp.WithStringItems(),
-			mcp.Description("List of tags (array of strings) (optional)"),
-		),
-		mcp.WithString("date_started",
-			mcp.Description("Start date in YYYY-MM-DD HH:MM format (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_task", enabledTools, tool, kbClient.createTaskHandler, s)
-
-	tool = mcp.NewTool("create_test_task",
-		mcp.WithDescription("Create test task bypassing RBAC checks (for debugging 403 errors)"),
-		mcp.WithString("project_name",
-			mcp.Required(),
-			mcp.Description("Name of the project to create the task in"),
-		),
-		mcp.WithString("title",
-			mcp.Required(),
-			mcp.Description("Title of the task to create"),
-		),
-		mcp.WithString("description",
-			mcp.Description("Description of the task (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_test_task", enabledTools, tool, kbClient.createTestTaskHandler, s)
-
-	tool = mcp.NewTool("update_task",
-		mcp.WithDescription("Update task properties: title, description, assignee, due date, color, category, priority, or column"),
-		mcp.WithNumber("id",
-			mcp.Required(),
-			mcp.Description("ID of the task to update"),
-		),
-		mcp.WithString("title",
-			mcp.Description("New title for the task (optional)"),
-		),
-		mcp.WithString("color_id",
-			mcp.Description("New color ID for the task (optional)"),
-		),
-		mcp.WithNumber("owner_id",
-			mcp.Description("New owner ID for the task (optional)"),
-		),
-		mcp.WithString("date_due",
-			mcp.Description("New due date in YYYY-MM-DD HH:MM format (optional)"),
-		),
-		mcp.WithString("description",
-			mcp.Description("New Markdown content for the task description (optional)"),
-		),
-		mcp.WithNumber("category_id",
-			mcp.Description("New ID of the task category (optional)"),
-		),
-		mcp.WithNumber("score",
-			mcp.Description("New complexity score of the task (optional)"),
-		),
-		mcp.WithNumber("priority",
-			mcp.Description("New priority of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_status",
-			mcp.Description("New recurrence status of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_trigger",
-			mcp.Description("New recurrence trigger of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_factor",
-			mcp.Description("New recurrence factor of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_timeframe",
-			mcp.Description("New recurrence timeframe of the task (optional)"),
-		),
-		mcp.WithNumber("recurrence_basedate",
-			mcp.Description("New recurrence base date of the task (optional)"),
-		),
-		mcp.WithString("reference",
-			mcp.Description("New external reference for the task (optional)"),
-		),
-		mcp.WithArray("tags",
-			mcp.WithStringItems(),
-			mcp.Description("New list of tags (array of strings) (optional)"),
-		),
-		mcp.WithString("date_started",
-			mcp.Description("New start date in YYYY-MM-DD HH:MM format (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_task", enabledTools, tool, kbClient.updateTaskHandler, s)
-
-	tool = mcp.NewTool("delete_task",
-		mcp.WithDescription("Permanently delete a task and all associated comments, files, and subtasks"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to delete"),
-		),
-	)
-	registerToolIfEnabled("delete_task", enabledTools, tool, kbClient.deleteTaskHandler, s)
-
-	tool = mcp.NewTool("get_task",
-		mcp.WithDescription("Get complete task details by ID including metadata, tags, and time tracking info"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get details for"),
-		),
-	)
-	registerToolIfEnabled("get_task", enabledTools, tool, kbClient.getTaskHandler, s)
-
-	tool = mcp.NewTool("move_task_position",
-		mcp.WithDescription("Move a task to another column, position or swimlane inside the same board"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project containing the task"),
-		),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to move"),
-		),
-		mcp.WithNumber("column_id",
-			mcp.Required(),
-			mcp.Description("ID of the column to move the task to"),
-		),
-		mcp.WithNumber("position",
-			mcp.Required(),
-			mcp.Description("New position for the task (must be >= 1)"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to move the task to"),
-		),
-	)
-	registerToolIfEnabled("move_task_position", enabledTools, tool, kbClient.moveTaskPositionHandler, s)
-
-	tool = mcp.NewTool("get_users",
-		mcp.WithDescription("List all users in the system including active and disabled accounts"),
-	)
-	registerToolIfEnabled("get_users", enabledTools, tool, kbClient.getUsersHandler, s)
-
-	tool = mcp.NewTool("create_user",
-		mcp.WithDescription("Create a new local user account with username, password, email, and role assignment"),
-		mcp.WithString("username",
-			mcp.Required(),
-			mcp.Description("Username for the new user (must be unique)"),
-		),
-		mcp.WithString("password",
-			mcp.Required(),
-			mcp.Description("Password for the new user (must have at least 6 characters)"),
-		),
-		mcp.WithString("name",
-			mcp.Description("Full name of the new user (optional)"),
-		),
-		mcp.WithString("email",
-			mcp.Description("Email address of the new user (optional)"),
-		),
-		mcp.WithString("role",
-			mcp.Description("Role for the user (app-admin, app-manager, app-user) (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_user", enabledTools, tool, kbClient.createUserHandler, s)
-
-	tool = mcp.NewTool("create_ldap_user",
-		mcp.WithDescription("Create a user account linked to LDAP/Active Directory authentication"),
-		mcp.WithString("username",
-			mcp.Required(),
-			mcp.Description("Username for the LDAP user"),
-		),
-	)
-	registerToolIfEnabled("create_ldap_user", enabledTools, tool, kbClient.createLdapUserHandler, s)
-
-	tool = mcp.NewTool("get_user",
-		mcp.WithDescription("Get user profile by ID including email, role, status, and group memberships"),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_user", enabledTools, tool, kbClient.getUserHandler, s)
-
-	tool = mcp.NewTool("get_user_by_name",
-		mcp.WithDescription("Get user profile by username including email, role, status, and group memberships"),
-		mcp.WithString("username",
-			mcp.Required(),
-			mcp.Description("Username of the user to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_user_by_name", enabledTools, tool, kbClient.getUserByNameHandler, s)
-
-	tool = mcp.NewTool("update_user",
-		mcp.WithDescription("Update user properties: name, email, password, role, or notification settings"),
-		mcp.WithNumber("id",
-			mcp.Required(),
-			mcp.Description("ID of the user to update"),
-		),
-		mcp.WithString("username",
-			mcp.Description("New username (optional)"),
-		),
-		mcp.WithString("name",
-			mcp.Description("New full name (optional)"),
-		),
-		mcp.WithString("email",
-			mcp.Description("New email address (optional)"),
-		),
-		mcp.WithString("role",
-			mcp.Description("New role (app-admin, app-manager, app-user) (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_user", enabledTools, tool, kbClient.updateUserHandler, s)
-
-	tool = mcp.NewTool("remove_user",
-		mcp.WithDescription("Permanently delete a user account (tasks will be unassigned)"),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_user", enabledTools, tool, kbClient.removeUserHandler, s)
-
-	tool = mcp.NewTool("disable_user",
-		mcp.WithDescription("Deactivate a user account, preventing login while preserving task history"),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to disable"),
-		),
-	)
-	registerToolIfEnabled("disable_user", enabledTools, tool, kbClient.disableUserHandler, s)
-
-	tool = mcp.NewTool("enable_user",
-		mcp.WithDescription("Reactivate a disabled user account, restoring login access"),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to enable"),
-		),
-	)
-	registerToolIfEnabled("enable_user", enabledTools, tool, kbClient.enableUserHandler, s)
-
-	tool = mcp.NewTool("is_active_user",
-		mcp.WithDescription("Check if a user account is active (returns boolean)"),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to check"),
-		),
-	)
-	registerToolIfEnabled("is_active_user", enabledTools, tool, kbClient.isActiveUserHandler, s)
-
-	tool = mcp.NewTool("assign_task",
-		mcp.WithDescription("Assign or reassign a task to a user by user ID"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to assign"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to assign the task to"),
-		),
-	)
-	registerToolIfEnabled("assign_task", enabledTools, tool, kbClient.assignTaskHandler, s)
-
-	tool = mcp.NewTool("set_task_due_date",
-		mcp.WithDescription("Set or update a task's due date/deadline (ISO format or timestamp)"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to set the due date for"),
-		),
-		mcp.WithString("due_date",
-			mcp.Required(),
-			mcp.Description("Due date in YYYY-MM-DD format"),
-		),
-	)
-	registerToolIfEnabled("set_task_due_date", enabledTools, tool, kbClient.setTaskDueDateHandler, s)
-
-	tool = mcp.NewTool("create_comment",
-		mcp.WithDescription("Add a comment to a task for discussion, updates, or notes"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to add a comment to"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user adding the comment"),
-		),
-		mcp.WithString("content",
-			mcp.Required(),
-			mcp.Description("Markdown content for the comment"),
-		),
-		mcp.WithString("reference",
-			mcp.Description("External reference for the comment"),
-		),
-		mcp.WithString("visibility",
-			mcp.Description("Visibility of the comment (app-user, app-manager, app-admin)"),
-		),
-	)
-	registerToolIfEnabled("create_comment", enabledTools, tool, kbClient.createCommentHandler, s)
-
-	tool = mcp.NewTool("get_task_comments",
-		mcp.WithDescription("Get all comments on a task ordered by creation date"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get comments for"),
-		),
-	)
-	registerToolIfEnabled("get_task_comments", enabledTools, tool, kbClient.getTaskCommentsHandler, s)
-
-	tool = mcp.NewTool("get_comment",
-		mcp.WithDescription("Get a single comment by ID including author and timestamp"),
-		mcp.WithNumber("comment_id",
-			mcp.Required(),
-			mcp.Description("ID of the comment to get details for"),
-		),
-	)
-	registerToolIfEnabled("get_comment", enabledTools, tool, kbClient.getCommentHandler, s)
-
-	tool = mcp.NewTool("update_comment",
-		mcp.WithDescription("Edit an existing comment's text content"),
-		mcp.WithNumber("id",
-			mcp.Required(),
-			mcp.Description("ID of the comment to update"),
-		),
-		mcp.WithString("content",
-			mcp.Required(),
-			mcp.Description("New Markdown content for the comment"),
-		),
-	)
-	registerToolIfEnabled("update_comment", enabledTools, tool, kbClient.updateCommentHandler, s)
-
-	tool = mcp.NewTool("remove_comment",
-		mcp.WithDescription("Delete a comment from a task"),
-		mcp.WithNumber("comment_id",
-			mcp.Required(),
-			mcp.Description("ID of the comment to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_comment", enabledTools, tool, kbClient.removeCommentHandler, s)
-
-	tool = mcp.NewTool("assign_user_to_project",
-		mcp.WithDescription("Assign a user to a project with a specific role"),
-		mcp.WithString("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to assign the user to"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to assign"),
-		),
-		mcp.WithString("role",
-			mcp.Description("Role to assign (e.g., project-member, project-manager)"),
-		),
-	)
-	registerToolIfEnabled("assign_user_to_project", enabledTools, tool, kbClient.assignUserToProjectHandler, s)
-
-	tool = mcp.NewTool("get_me",
-		mcp.WithDescription("Get current authenticated user's profile and session info"),
-	)
-	registerToolIfEnabled("get_me", enabledTools, tool, kbClient.getMeHandler, s)
-
-	tool = mcp.NewTool("get_my_dashboard",
-		mcp.WithDescription("Get current user's dashboard with assigned tasks, projects, and activities"),
-	)
-	registerToolIfEnabled("get_my_dashboard", enabledTools, tool, kbClient.getMyDashboardHandler, s)
-
-	tool = mcp.NewTool("get_my_activity_stream",
-		mcp.WithDescription("Get the last 100 events for the logged user"),
-	)
-	registerToolIfEnabled("get_my_activity_stream", enabledTools, tool, kbClient.getMyActivityStreamHandler, s)
-
-	tool = mcp.NewTool("create_my_private_project",
-		mcp.WithDescription("Create a private project for the logged user"),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the private project to create"),
-		),
-		mcp.WithString("description",
-			mcp.Description("Description of the private project (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_my_private_project", enabledTools, tool, kbClient.createMyPrivateProjectHandler, s)
-
-	tool = mcp.NewTool("get_my_projects_list",
-		mcp.WithDescription("Get list of projects the current user has access to (IDs and names only)"),
-	)
-	registerToolIfEnabled("get_my_projects_list", enabledTools, tool, kbClient.getMyProjectsListHandler, s)
-
-	tool = mcp.NewTool("get_my_overdue_tasks",
-		mcp.WithDescription("Get all overdue tasks assigned to the current user"),
-	)
-	registerToolIfEnabled("get_my_overdue_tasks", enabledTools, tool, kbClient.getMyOverdueTasksHandler, s)
-
-	tool = mcp.NewTool("get_my_projects",
-		mcp.WithDescription("Get projects of connected user with full details"),
-	)
-	registerToolIfEnabled("get_my_projects", enabledTools, tool, kbClient.getMyProjectsHandler, s)
-
-	tool = mcp.NewTool("get_external_task_link_types",
-		mcp.WithDescription("Get available external link providers (GitHub, GitLab, web URLs, etc.)"),
-	)
-	registerToolIfEnabled("get_external_task_link_types", enabledTools, tool, kbClient.getExternalTaskLinkTypesHandler, s)
-
-	tool = mcp.NewTool("get_ext_link_provider_deps",
-		mcp.WithDescription("Get available dependencies for a given provider"),
-		mcp.WithString("provider",
-			mcp.Required(),
-			mcp.Description("Provider name"),
-		),
-	)
-	registerToolIfEnabled("get_ext_link_provider_deps", enabledTools, tool, kbClient.getExternalTaskLinkProviderDependenciesHandler, s)
-
-	tool = mcp.NewTool("create_external_task_link",
-		mcp.WithDescription("Attach an external URL or reference to a task (GitHub issue, GitLab MR, web link)"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task"),
-		),
-		mcp.WithString("url",
-			mcp.Required(),
-			mcp.Description("URL of the external link"),
-		),
-		mcp.WithString("dependency",
-			mcp.Required(),
-			mcp.Description("Dependency of the external link"),
-		),
-		mcp.WithString("type",
-			mcp.Description("Type of the external link (optional)"),
-		),
-		mcp.WithString("title",
-			mcp.Description("Title of the external link (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_external_task_link", enabledTools, tool, kbClient.createExternalTaskLinkHandler, s)
-
-	tool = mcp.NewTool("update_external_task_link",
-		mcp.WithDescription("Update an external link's URL, title, or type"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task"),
-		),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the external link to update"),
-		),
-		mcp.WithString("title",
-			mcp.Description("New title for the external link"),
-		),
-		mcp.WithString("url",
-			mcp.Description("New URL for the external link")),
-		mcp.WithString("dependency",
-			mcp.Description("New dependency for the external link"),
-		),
-	)
-	registerToolIfEnabled("update_external_task_link", enabledTools, tool, kbClient.updateExternalTaskLinkHandler, s)
-
-	tool = mcp.NewTool("get_external_task_link_by_id",
-		mcp.WithDescription("Get external link details including URL, provider, and dependency info"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task"),
-		),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the external link to retrieve")),
-	)
-	registerToolIfEnabled("get_external_task_link_by_id", enabledTools, tool, kbClient.getExternalTaskLinkByIdHandler, s)
-
-	tool = mcp.NewTool("get_all_external_task_links",
-		mcp.WithDescription("Get all external URLs and references attached to a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get external links for"),
-		),
-	)
-	registerToolIfEnabled("get_all_external_task_links", enabledTools, tool, kbClient.getAllExternalTaskLinksHandler, s)
-
-	tool = mcp.NewTool("remove_external_task_link",
-		mcp.WithDescription("Remove an external URL or reference from a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task"),
-		),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the external link to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_external_task_link", enabledTools, tool, kbClient.removeExternalTaskLinkHandler, s)
-
-	tool = mcp.NewTool("get_columns",
-		mcp.WithDescription("Get all board columns for a project (e.g., Backlog, In Progress, Done)"),
-		mcp.WithString("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get columns from"),
-		),
-	)
-	registerToolIfEnabled("get_columns", enabledTools, tool, kbClient.getColumnsHandler, s)
-
-	tool = mcp.NewTool("get_column",
-		mcp.WithDescription("Get column details by ID including title, position, and task limit"),
-		mcp.WithNumber("column_id",
-			mcp.Required(),
-			mcp.Description("ID of the column to get details for"),
-		),
-	)
-	registerToolIfEnabled("get_column", enabledTools, tool, kbClient.getColumnHandler, s)
-
-	tool = mcp.NewTool("create_column",
-		mcp.WithDescription("Add a new board column to a project with title and optional task limit"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to add the column to"),
-		),
-		mcp.WithString("title",
-			mcp.Required(),
-			mcp.Description("Title of the column to create"),
-		),
-		mcp.WithNumber("task_limit",
-			mcp.Description("Task limit for the new column"),
-		),
-		mcp.WithString("description",
-			mcp.Description("Description for the new column"),
-		),
-	)
-	registerToolIfEnabled("create_column", enabledTools, tool, kbClient.createColumnHandler, s)
-
-	tool = mcp.NewTool("update_column",
-		mcp.WithDescription("Update column properties: title, task limit, or description"),
-		mcp.WithNumber("column_id",
-			mcp.Required(),
-			mcp.Description("ID of the column to update"),
-		),
-		mcp.WithString("title",
-			mcp.Required(),
-			mcp.Description("New title for the column"),
-		),
-		mcp.WithNumber("task_limit",
-			mcp.Description("New task limit for the column"),
-		),
-		mcp.WithString("description",
-			mcp.Description("New description for the column"),
-		),
-	)
-	registerToolIfEnabled("update_column", enabledTools, tool, kbClient.updateColumnHandler, s)
-
-	tool = mcp.NewTool("delete_column",
-		mcp.WithDescription("Delete a board column (tasks must be moved first)"),
-		mcp.WithNumber("column_id",
-			mcp.Required(),
-			mcp.Description("ID of the column to delete"),
-		),
-	)
-	registerToolIfEnabled("delete_column", enabledTools, tool, kbClient.deleteColumnHandler, s)
-
-	tool = mcp.NewTool("reorder_columns",
-		mcp.WithDescription("Reorder board columns by specifying new position for a column"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project containing the columns"),
-		),
-		mcp.WithNumber("column_id",
-			mcp.Required(),
-			mcp.Description("ID of the column to reorder"),
-		),
-		mcp.WithNumber("position",
-			mcp.Required(),
-			mcp.Description("New position for the column (must be >= 1)"),
-		),
-	)
-	registerToolIfEnabled("reorder_columns", enabledTools, tool, kbClient.reorderColumnsHandler, s)
-
-	tool = mcp.NewTool("get_categories",
-		mcp.WithDescription("Get all task categories defined for a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get categories from"),
-		),
-	)
-	registerToolIfEnabled("get_categories", enabledTools, tool, kbClient.getCategoriesHandler, s)
-
-	tool = mcp.NewTool("create_category",
-		mcp.WithDescription("Create a new task category for a project with name and optional color"),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the category to create"),
-		),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to add the category to"),
-		),
-		mcp.WithString("color_id",
-			mcp.Description("Color ID for the category (e.g., 'blue', 'green')"),
-		),
-	)
-	registerToolIfEnabled("create_category", enabledTools, tool, kbClient.createCategoryHandler, s)
-
-	tool = mcp.NewTool("get_category",
-		mcp.WithDescription("Get category details by ID including name, color, and project association"),
-		mcp.WithNumber("category_id",
-			mcp.Required(),
-			mcp.Description("ID of the category to get details for"),
-		),
-	)
-	registerToolIfEnabled("get_category", enabledTools, tool, kbClient.getCategoryHandler, s)
-
-	tool = mcp.NewTool("update_category",
-		mcp.WithDescription("Update category name or color"),
-		mcp.WithNumber("category_id",
-			mcp.Required(),
-			mcp.Description("ID of the category to update"),
-		),
-		mcp.WithString("name",
-			mcp.Description("New name for the category"),
-		),
-		mcp.WithString("color_id",
-			mcp.Description("Color ID for the category (e.g., 'blue', 'green')"),
-		),
-	)
-	registerToolIfEnabled("update_category", enabledTools, tool, kbClient.updateCategoryHandler, s)
-
-	tool = mcp.NewTool("delete_category",
-		mcp.WithDescription("Delete a category (tasks will be uncategorized)"),
-		mcp.WithNumber("category_id",
-			mcp.Required(),
-			mcp.Description("ID of the category to delete"),
-		),
-	)
-	registerToolIfEnabled("delete_category", enabledTools, tool, kbClient.deleteCategoryHandler, s)
-
-	tool = mcp.NewTool("get_swimlanes",
-		mcp.WithDescription("List all swimlanes of a project (enabled or disabled) and sorted by position"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get swimlanes from"),
-		),
-	)
-	registerToolIfEnabled("get_swimlanes", enabledTools, tool, kbClient.getAllSwimlanesHandler, s)
-
-	tool = mcp.NewTool("get_active_swimlanes",
-		mcp.WithDescription("Get the list of enabled swimlanes of a project (include default swimlane if enabled)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get active swimlanes from"),
-		),
-	)
-	registerToolIfEnabled("get_active_swimlanes", enabledTools, tool, kbClient.getActiveSwimlanesHandler, s)
-
-	tool = mcp.NewTool("get_swimlane",
-		mcp.WithDescription("Get swimlane details by ID including name, description, and position"),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_swimlane", enabledTools, tool, kbClient.getSwimlaneHandler, s)
-
-	tool = mcp.NewTool("get_swimlane_by_id",
-		mcp.WithDescription("Get swimlane details by ID including name, description, and position"),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_swimlane_by_id", enabledTools, tool, kbClient.getSwimlaneByIdHandler, s)
-
-	tool = mcp.NewTool("get_swimlane_by_name",
-		mcp.WithDescription("Get swimlane details by exact name match within a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project the swimlane belongs to"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the swimlane to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_swimlane_by_name", enabledTools, tool, kbClient.getSwimlaneByNameHandler, s)
-
-	tool = mcp.NewTool("change_swimlane_position",
-		mcp.WithDescription("Reorder swimlane by setting new position (affects board layout)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project containing the swimlane"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to reorder"),
-		),
-		mcp.WithNumber("position",
-			mcp.Required(),
-			mcp.Description("New position for the swimlane (must be >= 1)"),
-		),
-	)
-	registerToolIfEnabled("change_swimlane_position", enabledTools, tool, kbClient.changeSwimlanePositionHandler, s)
-
-	tool = mcp.NewTool("create_swimlane",
-		mcp.WithDescription("Create a new horizontal swimlane for task organization (e.g., by team, priority, or epic)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to add the swimlane to"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the swimlane to create"),
-		),
-		mcp.WithString("description",
-			mcp.Description("Description of the swimlane (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_swimlane", enabledTools, tool, kbClient.addSwimlaneHandler, s)
-
-	tool = mcp.NewTool("update_swimlane",
-		mcp.WithDescription("Update swimlane name or description"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project the swimlane belongs to"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to update"),
-		),
-		mcp.WithString("name",
-			mcp.Description("New name for the swimlane (optional)"),
-		),
-		mcp.WithString("description",
-			mcp.Description("New description for the swimlane (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_swimlane", enabledTools, tool, kbClient.updateSwimlaneHandler, s)
-
-	tool = mcp.NewTool("remove_swimlane",
-		mcp.WithDescription("Delete a swimlane (tasks will move to default swimlane)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project the swimlane belongs to"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_swimlane", enabledTools, tool, kbClient.removeSwimlaneHandler, s)
-
-	tool = mcp.NewTool("disable_swimlane",
-		mcp.WithDescription("Hide a swimlane from the board while preserving its tasks"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project the swimlane belongs to"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to disable"),
-		),
-	)
-	registerToolIfEnabled("disable_swimlane", enabledTools, tool, kbClient.disableSwimlaneHandler, s)
-
-	tool = mcp.NewTool("enable_swimlane",
-		mcp.WithDescription("Show a previously disabled swimlane on the board"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project the swimlane belongs to"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Required(),
-			mcp.Description("ID of the swimlane to enable"),
-		),
-	)
-	registerToolIfEnabled("enable_swimlane", enabledTools, tool, kbClient.enableSwimlaneHandler, s)
-
-	tool = mcp.NewTool("get_board",
-		mcp.WithDescription("Get all necessary information to display a board"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get board details for"),
-		),
-	)
-	registerToolIfEnabled("get_board", enabledTools, tool, kbClient.getBoardHandler, s)
-
-	// Task Metadata Management
-	tool = mcp.NewTool("get_task_metadata",
-		mcp.WithDescription("Get all metadata related to a task by task unique id"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get metadata from"),
-		),
-	)
-	registerToolIfEnabled("get_task_metadata", enabledTools, tool, kbClient.getTaskMetadataHandler, s)
-
-	tool = mcp.NewTool("get_task_metadata_by_name",
-		mcp.WithDescription("Get metadata related to a task by task unique id and metakey (name)"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get metadata from"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the metadata key"),
-		),
-	)
-	registerToolIfEnabled("get_task_metadata_by_name", enabledTools, tool, kbClient.getTaskMetadataByNameHandler, s)
-
-	tool = mcp.NewTool("save_task_metadata",
-		mcp.WithDescription("Save or update custom metadata key-value pair on a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to save/update metadata for"),
-		),
-		mcp.WithObject("values",
-			mcp.Required(),
-			mcp.Description("Dictionary of metadata values (key-value pairs)"),
-		),
-	)
-	registerToolIfEnabled("save_task_metadata", enabledTools, tool, kbClient.saveTaskMetadataHandler, s)
-
-	tool = mcp.NewTool("remove_task_metadata",
-		mcp.WithDescription("Delete a custom metadata field from a task by key name"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to remove metadata from"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the metadata key to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_task_metadata", enabledTools, tool, kbClient.removeTaskMetadataHandler, s)
-
-	tool = mcp.NewTool("create_group",
-		mcp.WithDescription("Create a new user group for bulk permission management"),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the group to create"),
-		),
-		mcp.WithString("external_id",
-			mcp.Description("External ID for the group (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_group", enabledTools, tool, kbClient.createGroupHandler, s)
-
-	tool = mcp.NewTool("update_group",
-		mcp.WithDescription("Update group name or external ID"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group to update"),
-		),
-		mcp.WithString("name",
-			mcp.Description("New name for the group (optional)"),
-		),
-		mcp.WithString("external_id",
-			mcp.Description("New external ID for the group (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_group", enabledTools, tool, kbClient.updateGroupHandler, s)
-
-	tool = mcp.NewTool("remove_group",
-		mcp.WithDescription("Delete a group (users remain but lose group-based permissions)"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_group", enabledTools, tool, kbClient.removeGroupHandler, s)
-
-	tool = mcp.NewTool("get_group",
-		mcp.WithDescription("Get group details by ID including name and member count"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_group", enabledTools, tool, kbClient.getGroupHandler, s)
-
-	tool = mcp.NewTool("get_all_groups",
-		mcp.WithDescription("Get all user groups in the system"),
-	)
-	registerToolIfEnabled("get_all_groups", enabledTools, tool, kbClient.getAllGroupsHandler, s)
-
-	tool = mcp.NewTool("get_member_groups",
-		mcp.WithDescription("Get all groups that a specific user belongs to"),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user"),
-		),
-	)
-	registerToolIfEnabled("get_member_groups", enabledTools, tool, kbClient.getMemberGroupsHandler, s)
-
-	tool = mcp.NewTool("get_group_members",
-		mcp.WithDescription("Get list of all users in a group"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group"),
-		),
-	)
-	registerToolIfEnabled("get_group_members", enabledTools, tool, kbClient.getGroupMembersHandler, s)
-
-	tool = mcp.NewTool("add_group_member",
-		mcp.WithDescription("Add a user to a group for shared permissions"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to add"),
-		),
-	)
-	registerToolIfEnabled("add_group_member", enabledTools, tool, kbClient.addGroupMemberHandler, s)
-
-	tool = mcp.NewTool("remove_group_member",
-		mcp.WithDescription("Remove a user from a group (revokes group-based permissions)"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group")),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_group_member", enabledTools, tool, kbClient.removeGroupMemberHandler, s)
-
-	tool = mcp.NewTool("is_group_member",
-		mcp.WithDescription("Check if a user belongs to a specific group (returns boolean)"),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user"),
-		),
-	)
-	registerToolIfEnabled("is_group_member", enabledTools, tool, kbClient.isGroupMemberHandler, s)
-
-	tool = mcp.NewTool("create_task_link",
-		mcp.WithDescription("Create a relationship between two tasks (blocks, is blocked by, relates to, etc.)"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the first task"),
-		),
-		mcp.WithNumber("opposite_task_id",
-			mcp.Required(),
-			mcp.Description("ID of the opposite task"),
-		),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the link type"),
-		),
-	)
-	registerToolIfEnabled("create_task_link", enabledTools, tool, kbClient.createTaskLinkHandler, s)
-
-	tool = mcp.NewTool("update_task_link",
-		mcp.WithDescription("Update the relationship type between two linked tasks"),
-		mcp.WithNumber("task_link_id",
-			mcp.Required(),
-			mcp.Description("ID of the task link to update"),
-		),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the first task"),
-		),
-		mcp.WithNumber("opposite_task_id",
-			mcp.Required(),
-			mcp.Description("ID of the opposite task"),
-		),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the link type"),
-		),
-	)
-	registerToolIfEnabled("update_task_link", enabledTools, tool, kbClient.updateTaskLinkHandler, s)
-
-	tool = mcp.NewTool("get_task_link_by_id",
-		mcp.WithDescription("Get task link details including source task, target task, and relationship type"),
-		mcp.WithNumber("task_link_id",
-			mcp.Required(),
-			mcp.Description("ID of the task link to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_task_link_by_id", enabledTools, tool, kbClient.getTaskLinkByIdHandler, s)
-
-	tool = mcp.NewTool("get_all_task_links",
-		mcp.WithDescription("Get all task relationships (dependencies, blockers, related tasks) for a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get links for"),
-		),
-	)
-	registerToolIfEnabled("get_all_task_links", enabledTools, tool, kbClient.getAllTaskLinksHandler, s)
-
-	tool = mcp.NewTool("remove_task_link",
-		mcp.WithDescription("Remove a link between two tasks"),
-		mcp.WithNumber("task_link_id",
-			mcp.Required(),
-			mcp.Description("ID of the task link to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_task_link", enabledTools, tool, kbClient.removeTaskLinkHandler, s)
-
-	// Link Management
-	tool = mcp.NewTool("get_all_links",
-		mcp.WithDescription("Get all available task relationship types (e.g., blocks/blocked by, parent/child)"),
-	)
-	registerToolIfEnabled("get_all_links", enabledTools, tool, kbClient.getAllLinksHandler, s)
-
-	tool = mcp.NewTool("get_opposite_link_id",
-		mcp.WithDescription("Get the reverse relationship ID (e.g., 'blocked by' for 'blocks')"),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the link to get the opposite ID for"),
-		),
-	)
-	registerToolIfEnabled("get_opposite_link_id", enabledTools, tool, kbClient.getOppositeLinkIdHandler, s)
-
-	tool = mcp.NewTool("get_link_by_label",
-		mcp.WithDescription("Get task relationship type by its label name (e.g., 'blocks', 'relates to')"),
-		mcp.WithString("label",
-			mcp.Required(),
-			mcp.Description("Label of the link to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_link_by_label", enabledTools, tool, kbClient.getLinkByLabelHandler, s)
-
-	tool = mcp.NewTool("get_link_by_id",
-		mcp.WithDescription("Get task relationship type definition by ID"),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the link to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_link_by_id", enabledTools, tool, kbClient.getLinkByIdHandler, s)
-
-	tool = mcp.NewTool("create_link",
-		mcp.WithDescription("Define a new task relationship type with label and opposite label"),
-		mcp.WithString("label",
-			mcp.Required(),
-			mcp.Description("Label of the new link"),
-		),
-		mcp.WithString("opposite_label",
-			mcp.Description("Label of the opposite link (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_link", enabledTools, tool, kbClient.createLinkHandler, s)
-
-	tool = mcp.NewTool("update_link",
-		mcp.WithDescription("Update a task relationship type definition"),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the link to update"),
-		),
-		mcp.WithNumber("opposite_link_id",
-			mcp.Required(),
-			mcp.Description("ID of the opposite link"),
-		),
-		mcp.WithString("label",
-			mcp.Required(),
-			mcp.Description("New label for the link"),
-		),
-	)
-	registerToolIfEnabled("update_link", enabledTools, tool, kbClient.updateLinkHandler, s)
-
-	tool = mcp.NewTool("remove_link",
-		mcp.WithDescription("Delete a task relationship type definition"),
-		mcp.WithNumber("link_id",
-			mcp.Required(),
-			mcp.Description("ID of the link to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_link", enabledTools, tool, kbClient.removeLinkHandler, s)
-
-	// Project Management
-
-	tool = mcp.NewTool("get_project_by_id",
-		mcp.WithDescription("Get detailed project information by ID including settings, members count, and configuration"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_project_by_id", enabledTools, tool, kbClient.getProjectByIdHandler, s)
-
-	tool = mcp.NewTool("get_project_by_name",
-		mcp.WithDescription("Get detailed project information by exact name match"),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the project to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_project_by_name", enabledTools, tool, kbClient.getProjectByNameHandler, s)
-
-	tool = mcp.NewTool("get_project_by_identifier",
-		mcp.WithDescription("Get detailed project information by unique project identifier/slug"),
-		mcp.WithString("identifier",
-			mcp.Required(),
-			mcp.Description("Identifier of the project to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_project_by_identifier", enabledTools, tool, kbClient.getProjectByIdentifierHandler, s)
-
-	tool = mcp.NewTool("get_project_by_email",
-		mcp.WithDescription("Get project information associated with a specific email address"),
-		mcp.WithString("email",
-			mcp.Required(),
-			mcp.Description("Email of the project to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_project_by_email", enabledTools, tool, kbClient.getProjectByEmailHandler, s)
-
-	tool = mcp.NewTool("get_all_projects",
-		mcp.WithDescription("Get all projects in the system (admin only) with full details"),
-	)
-	registerToolIfEnabled("get_all_projects", enabledTools, tool, kbClient.getAllProjectsHandler, s)
-
-	tool = mcp.NewTool("update_project",
-		mcp.WithDescription("Update project properties including name, description, identifier, owner, and settings"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to update"),
-		),
-		mcp.WithString("name",
-			mcp.Description("New name for the project (optional)"),
-		),
-		mcp.WithString("description",
-			mcp.Description("New description for the project (optional)"),
-		),
-		mcp.WithNumber("owner_id",
-			mcp.Description("New owner ID for the project (optional)"),
-		),
-		mcp.WithString("identifier",
-			mcp.Description("New alphanumeric identifier for the project (optional)"),
-		),
-		mcp.WithString("start_date",
-			mcp.Description("New start date in ISO8601 format (optional)"),
-		),
-		mcp.WithString("end_date",
-			mcp.Description("New end date in ISO8601 format (optional)"),
-		),
-		mcp.WithNumber("priority_default",
-			mcp.Description("New default task priority (optional)"),
-		),
-		mcp.WithNumber("priority_start",
-			mcp.Description("New start priority (optional)"),
-		),
-		mcp.WithNumber("priority_end",
-			mcp.Description("New end priority (optional)"),
-		),
-		mcp.WithString("email",
-			mcp.Description("New project email address (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_project", enabledTools, tool, kbClient.updateProjectHandler, s)
-
-	tool = mcp.NewTool("remove_project",
-		mcp.WithDescription("Permanently delete a project and all associated tasks, files, and history"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_project", enabledTools, tool, kbClient.removeProjectHandler, s)
-
-	tool = mcp.NewTool("enable_project",
-		mcp.WithDescription("Activate a disabled project, making it visible and accessible to members"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to enable"),
-		),
-	)
-	registerToolIfEnabled("enable_project", enabledTools, tool, kbClient.enableProjectHandler, s)
-
-	tool = mcp.NewTool("disable_project",
-		mcp.WithDescription("Deactivate a project, hiding it from users while preserving all data"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to disable"),
-		),
-	)
-	registerToolIfEnabled("disable_project", enabledTools, tool, kbClient.disableProjectHandler, s)
-
-	tool = mcp.NewTool("enable_project_public_access",
-		mcp.WithDescription("Enable public read-only access to a project board via shareable URL"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to enable public access for"),
-		),
-	)
-	registerToolIfEnabled("enable_project_public_access", enabledTools, tool, kbClient.enableProjectPublicAccessHandler, s)
-
-	tool = mcp.NewTool("disable_project_public_access",
-		mcp.WithDescription("Disable public URL access, making the project private to members only"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to disable public access for"),
-		),
-	)
-	registerToolIfEnabled("disable_project_public_access", enabledTools, tool, kbClient.disableProjectPublicAccessHandler, s)
-
-	tool = mcp.NewTool("get_project_activity",
-		mcp.WithDescription("Get recent activity events for a project (task changes, comments, file uploads)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get activity for"),
-		),
-	)
-	registerToolIfEnabled("get_project_activity", enabledTools, tool, kbClient.getProjectActivityHandler, s)
-
-	tool = mcp.NewTool("get_project_activities",
-		mcp.WithDescription("Get paginated activity feed for one or more projects with filtering options"),
-		mcp.WithArray("project_ids",
-			mcp.Required(),
-			mcp.WithNumberItems(),
-			mcp.Description("Array of project IDs to get activities for"),
-		),
-	)
-	registerToolIfEnabled("get_project_activities", enabledTools, tool, kbClient.getProjectActivitiesHandler, s)
-
-	// Project File Management
-	tool = mcp.NewTool("create_project_file",
-		mcp.WithDescription("Upload and attach a file to a project (base64 encoded content)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to attach the file to"),
-		),
-		mcp.WithString("filename",
-			mcp.Required(),
-			mcp.Description("Name of the file, or file path if blob is not provided"),
-		),
-		mcp.WithString("blob",
-			mcp.Description("File content encoded in base64. If not provided, filename will be treated as a file path and read from disk (relative paths will be resolved from current working directory)"),
-		),
-	)
-	registerToolIfEnabled("create_project_file", enabledTools, tool, kbClient.createProjectFileHandler, s)
-
-	tool = mcp.NewTool("get_all_project_files",
-		mcp.WithDescription("Get list of all file attachments on a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get files from"),
-		),
-	)
-	registerToolIfEnabled("get_all_project_files", enabledTools, tool, kbClient.getAllProjectFilesHandler, s)
-
-	tool = mcp.NewTool("get_project_file",
-		mcp.WithDescription("Get project file attachment metadata (name, size, upload date) by ID"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("file_id",
-			mcp.Required(),
-			mcp.Description("ID of the file to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_project_file", enabledTools, tool, kbClient.getProjectFileHandler, s)
-
-	tool = mcp.NewTool("download_project_file",
-		mcp.WithDescription("Download project file contents (encoded in base64). Optionally save to disk if save_path is provided."),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("file_id",
-			mcp.Required(),
-			mcp.Description("ID of the file to download"),
-		),
-		mcp.WithString("save_path",
-			mcp.Description("Optional path to save the file. If 'temp', saves to OS temp directory. If empty, returns base64 content only. If directory, saves with original filename. If full path, saves to that location."),
-		),
-	)
-	registerToolIfEnabled("download_project_file", enabledTools, tool, kbClient.downloadProjectFileHandler, s)
-
-	tool = mcp.NewTool("remove_project_file",
-		mcp.WithDescription("Delete a file attachment from a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("file_id",
-			mcp.Required(),
-			mcp.Description("ID of the file to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_project_file", enabledTools, tool, kbClient.removeProjectFileHandler, s)
-
-	tool = mcp.NewTool("remove_all_project_files",
-		mcp.WithDescription("Delete all file attachments from a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to remove all files from"),
-		),
-	)
-	registerToolIfEnabled("remove_all_project_files", enabledTools, tool, kbClient.removeAllProjectFilesHandler, s)
-
-	// Project Metadata Management
-	tool = mcp.NewTool("get_project_metadata",
-		mcp.WithDescription("Get all custom metadata key-value pairs for a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get metadata from"),
-		),
-	)
-	registerToolIfEnabled("get_project_metadata", enabledTools, tool, kbClient.getProjectMetadataHandler, s)
-
-	tool = mcp.NewTool("get_project_metadata_by_name",
-		mcp.WithDescription("Get a single project metadata value by key name"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the metadata key"),
-		),
-	)
-	registerToolIfEnabled("get_project_metadata_by_name", enabledTools, tool, kbClient.getProjectMetadataByNameHandler, s)
-
-	tool = mcp.NewTool("save_project_metadata",
-		mcp.WithDescription("Save or update custom metadata key-value pair on a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithObject("values",
-			mcp.Required(),
-			mcp.Description("Dictionary of metadata values (key-value pairs)"),
-		),
-	)
-	registerToolIfEnabled("save_project_metadata", enabledTools, tool, kbClient.saveProjectMetadataHandler, s)
-
-	tool = mcp.NewTool("remove_project_metadata",
-		mcp.WithDescription("Delete a custom metadata field from a project by key name"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the metadata key to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_project_metadata", enabledTools, tool, kbClient.removeProjectMetadataHandler, s)
-
-	// Project Permission Management
-	tool = mcp.NewTool("get_project_users",
-		mcp.WithDescription("Get all users with access to a project and their roles"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get users from"),
-		),
-	)
-	registerToolIfEnabled("get_project_users", enabledTools, tool, kbClient.getProjectUsersHandler, s)
-
-	tool = mcp.NewTool("get_assignable_users",
-		mcp.WithDescription("Get users that can be assigned to a task for a project (all members except viewers)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithBoolean("prepend_unassigned",
-			mcp.Description("Prepend the 'Unassigned' option (optional, default is false)"),
-		),
-	)
-	registerToolIfEnabled("get_assignable_users", enabledTools, tool, kbClient.getAssignableUsersHandler, s)
-
-	tool = mcp.NewTool("add_project_user",
-		mcp.WithDescription("Grant access to a project for a user"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user"),
-		),
-		mcp.WithString("role",
-			mcp.Description("Role to assign (optional)"),
-		),
-	)
-	registerToolIfEnabled("add_project_user", enabledTools, tool, kbClient.addProjectUserHandler, s)
-
-	tool = mcp.NewTool("add_project_group",
-		mcp.WithDescription("Grant access to a project for a group"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group"),
-		),
-		mcp.WithString("role",
-			mcp.Description("Role to assign (optional)"),
-		),
-	)
-	registerToolIfEnabled("add_project_group", enabledTools, tool, kbClient.addProjectGroupHandler, s)
-
-	tool = mcp.NewTool("remove_project_user",
-		mcp.WithDescription("Revoke user access to a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user"),
-		),
-	)
-	registerToolIfEnabled("remove_project_user", enabledTools, tool, kbClient.removeProjectUserHandler, s)
-
-	tool = mcp.NewTool("remove_project_group",
-		mcp.WithDescription("Revoke group access to a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group"),
-		),
-	)
-	registerToolIfEnabled("remove_project_group", enabledTools, tool, kbClient.removeProjectGroupHandler, s)
-
-	tool = mcp.NewTool("change_project_user_role",
-		mcp.WithDescription("Change a user's role in a project (manager, member, or viewer)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user"),
-		),
-		mcp.WithString("role",
-			mcp.Required(),
-			mcp.Description("New role to assign"),
-		),
-	)
-	registerToolIfEnabled("change_project_user_role", enabledTools, tool, kbClient.changeProjectUserRoleHandler, s)
-
-	tool = mcp.NewTool("change_project_group_role",
-		mcp.WithDescription("Change a group's role in a project (manager, member, or viewer)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("group_id",
-			mcp.Required(),
-			mcp.Description("ID of the group"),
-		),
-		mcp.WithString("role",
-			mcp.Required(),
-			mcp.Description("New role to assign"),
-		),
-	)
-	registerToolIfEnabled("change_project_group_role", enabledTools, tool, kbClient.changeProjectGroupRoleHandler, s)
-
-	tool = mcp.NewTool("get_project_user_role",
-		mcp.WithDescription("Get a user's current role (manager, member, viewer) in a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Required(),
-			mcp.Description("ID of the user"),
-		),
-	)
-	registerToolIfEnabled("get_project_user_role", enabledTools, tool, kbClient.getProjectUserRoleHandler, s)
-
-	// Subtask Management
-	tool = mcp.NewTool("create_subtask",
-		mcp.WithDescription("Create a subtask/checklist item within a task with title, assignee, and time estimate"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to associate the subtask with"),
-		),
-		mcp.WithString("title",
-			mcp.Required(),
-			mcp.Description("Title of the subtask"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Description("ID of the user assigned to the subtask (optional)"),
-		),
-		mcp.WithNumber("time_estimated",
-			mcp.Description("Estimated time for the subtask in hours (optional)"),
-		),
-		mcp.WithNumber("time_spent",
-			mcp.Description("Time spent on the subtask in hours (optional)"),
-		),
-		mcp.WithNumber("status",
-			mcp.Description("Status of the subtask (0: Todo, 1: In Progress, 2: Done) (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_subtask", enabledTools, tool, kbClient.createSubtaskHandler, s)
-
-	tool = mcp.NewTool("get_subtask",
-		mcp.WithDescription("Get subtask details including status, assignee, and time tracking"),
-		mcp.WithNumber("subtask_id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_subtask", enabledTools, tool, kbClient.getSubtaskHandler, s)
-
-	tool = mcp.NewTool("get_all_subtasks",
-		mcp.WithDescription("Get all subtasks/checklist items for a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to get subtasks for"),
-		),
-	)
-	registerToolIfEnabled("get_all_subtasks", enabledTools, tool, kbClient.getAllSubtasksHandler, s)
-
-	tool = mcp.NewTool("update_subtask",
-		mcp.WithDescription("Update subtask title, status, assignee, or time estimate"),
-		mcp.WithNumber("id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask to update"),
-		),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the associated task"),
-		),
-		mcp.WithString("title",
-			mcp.Description("New title for the subtask (optional)"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Description("New user ID assigned to the subtask (optional)"),
-		),
-		mcp.WithNumber("time_estimated",
-			mcp.Description("New estimated time for the subtask in hours (optional)"),
-		),
-		mcp.WithNumber("time_spent",
-			mcp.Description("New time spent on the subtask in hours (optional)"),
-		),
-		mcp.WithNumber("status",
-			mcp.Description("New status of the subtask (0: Todo, 1: In Progress, 2: Done) (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_subtask", enabledTools, tool, kbClient.updateSubtaskHandler, s)
-
-	tool = mcp.NewTool("remove_subtask",
-		mcp.WithDescription("Delete a subtask from a task"),
-		mcp.WithNumber("subtask_id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_subtask", enabledTools, tool, kbClient.removeSubtaskHandler, s)
-
-	// Subtask Time Tracking
-	tool = mcp.NewTool("has_subtask_timer",
-		mcp.WithDescription("Check if a timer is started for the given subtask and user"),
-		mcp.WithNumber("subtask_id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Description("ID of the user (optional)"),
-		),
-	)
-	registerToolIfEnabled("has_subtask_timer", enabledTools, tool, kbClient.hasSubtaskTimerHandler, s)
-
-	tool = mcp.NewTool("set_subtask_start_time",
-		mcp.WithDescription("Start time tracking timer for a subtask (for logging work hours)"),
-		mcp.WithNumber("subtask_id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Description("ID of the user (optional)"),
-		),
-	)
-	registerToolIfEnabled("set_subtask_start_time", enabledTools, tool, kbClient.setSubtaskStartTimeHandler, s)
-
-	tool = mcp.NewTool("set_subtask_end_time",
-		mcp.WithDescription("Stop time tracking timer for a subtask and log elapsed time"),
-		mcp.WithNumber("subtask_id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Description("ID of the user (optional)"),
-		),
-	)
-	registerToolIfEnabled("set_subtask_end_time", enabledTools, tool, kbClient.setSubtaskEndTimeHandler, s)
-
-	tool = mcp.NewTool("get_subtask_time_spent",
-		mcp.WithDescription("Get total time logged on a subtask by a specific user"),
-		mcp.WithNumber("subtask_id",
-			mcp.Required(),
-			mcp.Description("ID of the subtask"),
-		),
-		mcp.WithNumber("user_id",
-			mcp.Description("ID of the user (optional)"),
-		),
-	)
-	registerToolIfEnabled("get_subtask_time_spent", enabledTools, tool, kbClient.getSubtaskTimeSpentHandler, s)
-
-	// Tag Management
-	tool = mcp.NewTool("get_all_tags",
-		mcp.WithDescription("Get all tags defined in the system (global tags)"),
-	)
-	registerToolIfEnabled("get_all_tags", enabledTools, tool, kbClient.getAllTagsHandler, s)
-
-	tool = mcp.NewTool("get_tags_by_project",
-		mcp.WithDescription("Get all tags available for a specific project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get tags for"),
-		),
-	)
-	registerToolIfEnabled("get_tags_by_project", enabledTools, tool, kbClient.getTagsByProjectHandler, s)
-
-	tool = mcp.NewTool("create_tag",
-		mcp.WithDescription("Create a new tag for labeling and filtering tasks"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to associate the tag with"),
-		),
-		mcp.WithString("tag",
-			mcp.Required(),
-			mcp.Description("Name of the tag"),
-		),
-		mcp.WithNumber("color_id",
-			mcp.Description("ID of the color for the tag (optional)"),
-		),
-	)
-	registerToolIfEnabled("create_tag", enabledTools, tool, kbClient.createTagHandler, s)
-
-	tool = mcp.NewTool("update_tag",
-		mcp.WithDescription("Rename an existing tag (updates all tagged tasks)"),
-		mcp.WithNumber("tag_id",
-			mcp.Required(),
-			mcp.Description("ID of the tag to update"),
-		),
-		mcp.WithString("tag",
-			mcp.Required(),
-			mcp.Description("New name for the tag"),
-		),
-		mcp.WithNumber("color_id",
-			mcp.Description("New color ID for the tag (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_tag", enabledTools, tool, kbClient.updateTagHandler, s)
-
-	tool = mcp.NewTool("remove_tag",
-		mcp.WithDescription("Delete a tag (removes from all tasks)"),
-		mcp.WithNumber("tag_id",
-			mcp.Required(),
-			mcp.Description("ID of the tag to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_tag", enabledTools, tool, kbClient.removeTagHandler, s)
-
-	tool = mcp.NewTool("set_task_tags",
-		mcp.WithDescription("Assign/Create/Update tags for a task"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task"),
-		),
-		mcp.WithArray("tags",
-			mcp.Required(),
-			mcp.WithStringItems(),
-			mcp.Description("List of tags (array of strings)"),
-		),
-	)
-	registerToolIfEnabled("set_task_tags", enabledTools, tool, kbClient.setTaskTagsHandler, s)
-
-	tool = mcp.NewTool("get_task_tags",
-		mcp.WithDescription("Get all tags currently assigned to a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task"),
-		),
-	)
-	registerToolIfEnabled("get_task_tags", enabledTools, tool, kbClient.getTaskTagsHandler, s)
-
-	tool = mcp.NewTool("create_task_file",
-		mcp.WithDescription("Upload and attach a file to a task (base64 encoded content)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("The project ID"),
-		),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("The task ID"),
-		),
-		mcp.WithString("filename",
-			mcp.Required(),
-			mcp.Description("The filename, or file path if blob is not provided"),
-		),
-		mcp.WithString("blob",
-			mcp.Description("File content encoded in base64. If not provided, filename will be treated as a file path and read from disk (relative paths will be resolved from current working directory)"),
-		),
-	)
-	registerToolIfEnabled("create_task_file", enabledTools, tool, kbClient.createTaskFileHandler, s)
-
-	tool = mcp.NewTool("get_all_task_files",
-		mcp.WithDescription("Get list of all file attachments on a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("The task ID"),
-		),
-	)
-	registerToolIfEnabled("get_all_task_files", enabledTools, tool, kbClient.getAllTaskFilesHandler, s)
-
-	tool = mcp.NewTool("get_task_file",
-		mcp.WithDescription("Get file attachment metadata (name, size, upload date) by ID"),
-		mcp.WithNumber("file_id",
-			mcp.Required(),
-			mcp.Description("The file ID"),
-		),
-	)
-	registerToolIfEnabled("get_task_file", enabledTools, tool, kbClient.getTaskFileHandler, s)
-
-	tool = mcp.NewTool("download_task_file",
-		mcp.WithDescription("Download file contents (encoded in base64). Optionally save to disk if save_path is provided."),
-		mcp.WithNumber("file_id",
-			mcp.Required(),
-			mcp.Description("The file ID"),
-		),
-		mcp.WithString("save_path",
-			mcp.Description("Optional path to save the file. If 'temp', saves to OS temp directory. If empty, returns base64 content only. If directory, saves with original filename. If full path, saves to that location."),
-		),
-	)
-	registerToolIfEnabled("download_task_file", enabledTools, tool, kbClient.downloadTaskFileHandler, s)
-
-	tool = mcp.NewTool("remove_task_file",
-		mcp.WithDescription("Delete a file attachment from a task"),
-		mcp.WithNumber("file_id",
-			mcp.Required(),
-			mcp.Description("The file ID"),
-		),
-	)
-	registerToolIfEnabled("remove_task_file", enabledTools, tool, kbClient.removeTaskFileHandler, s)
-
-	tool = mcp.NewTool("remove_all_task_files",
-		mcp.WithDescription("Delete all file attachments from a task"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("The task ID"),
-		),
-	)
-	registerToolIfEnabled("remove_all_task_files", enabledTools, tool, kbClient.removeAllTaskFilesHandler, s)
-
-	// Application API Procedures
-	tool = mcp.NewTool("get_version",
-		mcp.WithDescription("Get Kanboard application version number"),
-	)
-	registerToolIfEnabled("get_version", enabledTools, tool, kbClient.getVersionHandler, s)
-
-	tool = mcp.NewTool("get_timezone",
-		mcp.WithDescription("Get the timezone setting for the current user's session"),
-	)
-	registerToolIfEnabled("get_timezone", enabledTools, tool, kbClient.getTimezoneHandler, s)
-
-	tool = mcp.NewTool("get_default_task_colors",
-		mcp.WithDescription("Get all available task color options with hex values and names"),
-	)
-	registerToolIfEnabled("get_default_task_colors", enabledTools, tool, kbClient.getDefaultTaskColorsHandler, s)
-
-	tool = mcp.NewTool("get_default_task_color",
-		mcp.WithDescription("Get the system default color applied to new tasks"),
-	)
-	registerToolIfEnabled("get_default_task_color", enabledTools, tool, kbClient.getDefaultTaskColorHandler, s)
-
-	tool = mcp.NewTool("get_color_list",
-		mcp.WithDescription("Get mapping of color IDs to color names for task coloring"),
-	)
-	registerToolIfEnabled("get_color_list", enabledTools, tool, kbClient.getColorListHandler, s)
-
-	tool = mcp.NewTool("get_application_roles",
-		mcp.WithDescription("Get available system-wide roles (admin, manager, user)"),
-	)
-	registerToolIfEnabled("get_application_roles", enabledTools, tool, kbClient.getApplicationRolesHandler, s)
-
-	tool = mcp.NewTool("get_project_roles",
-		mcp.WithDescription("Get available project-level roles (manager, member, viewer)"),
-	)
-	registerToolIfEnabled("get_project_roles", enabledTools, tool, kbClient.getProjectRolesHandler, s)
-
-	// Automatic Actions API Procedures
-	tool = mcp.NewTool("get_available_actions",
-		mcp.WithDescription("Get all automation actions available (auto-assign, auto-close, notifications, etc.)"),
-	)
-	registerToolIfEnabled("get_available_actions", enabledTools, tool, kbClient.getAvailableActionsHandler, s)
-
-	tool = mcp.NewTool("get_available_action_events",
-		mcp.WithDescription("Get all trigger events that can start automations (task created, moved, etc.)"),
-	)
-	registerToolIfEnabled("get_available_action_events", enabledTools, tool, kbClient.getAvailableActionEventsHandler, s)
-
-	tool = mcp.NewTool("get_compatible_action_events",
-		mcp.WithDescription("Get trigger events compatible with a specific automation action"),
-		mcp.WithString("action_name",
-			mcp.Required(),
-			mcp.Description("Action name"),
-		),
-	)
-	registerToolIfEnabled("get_compatible_action_events", enabledTools, tool, kbClient.getCompatibleActionEventsHandler, s)
-
-	tool = mcp.NewTool("get_actions",
-		mcp.WithDescription("Get all configured automation rules for a project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("Project ID"),
-		),
-	)
-	registerToolIfEnabled("get_actions", enabledTools, tool, kbClient.getActionsHandler, s)
-
-	tool = mcp.NewTool("create_action",
-		mcp.WithDescription("Create a new automation rule for a project (trigger + action + parameters)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("Project ID"),
-		),
-		mcp.WithString("event_name",
-			mcp.Required(),
-			mcp.Description("Event name"),
-		),
-		mcp.WithString("action_name",
-			mcp.Required(),
-			mcp.Description("Action name"),
-		),
-		mcp.WithObject("params",
-			mcp.Required(),
-			mcp.Description("Key/value parameters"),
-		),
-	)
-	registerToolIfEnabled("create_action", enabledTools, tool, kbClient.createActionHandler, s)
-
-	tool = mcp.NewTool("remove_action",
-		mcp.WithDescription("Delete an automation rule from a project"),
-		mcp.WithNumber("action_id",
-			mcp.Required(),
-			mcp.Description("Action ID"),
-		),
-	)
-	registerToolIfEnabled("remove_action", enabledTools, tool, kbClient.removeActionHandler, s)
-
-	tool = mcp.NewTool("get_task_by_reference",
-		mcp.WithDescription("Get task by external reference ID (for integration with external systems like GitHub/GitLab)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-		mcp.WithString("reference",
-			mcp.Required(),
-			mcp.Description("External reference for the task"),
-		),
-	)
-	registerToolIfEnabled("get_task_by_reference", enabledTools, tool, kbClient.getTaskByReferenceHandler, s)
-
-	tool = mcp.NewTool("get_all_tasks",
-		mcp.WithDescription("Get all tasks across all projects (admin only) with optional status and project filters"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to get tasks from"),
-		),
-		mcp.WithNumber("status_id",
-			mcp.Required(),
-			mcp.Description("The value 1 for active tasks and 0 for inactive"),
-		),
-	)
-	registerToolIfEnabled("get_all_tasks", enabledTools, tool, kbClient.getAllTasksHandler, s)
-
-	tool = mcp.NewTool("get_overdue_tasks",
-		mcp.WithDescription("Get all tasks past their due date across all accessible projects"),
-	)
-	registerToolIfEnabled("get_overdue_tasks", enabledTools, tool, kbClient.getOverdueTasksHandler, s)
-
-	tool = mcp.NewTool("get_overdue_tasks_by_project",
-		mcp.WithDescription("Get all tasks past their due date for a specific project"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project"),
-		),
-	)
-	registerToolIfEnabled("get_overdue_tasks_by_project", enabledTools, tool, kbClient.getOverdueTasksByProjectHandler, s)
-
-	tool = mcp.NewTool("open_task",
-		mcp.WithDescription("Reopen a closed task, setting status back to open/active"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to open"),
-		),
-	)
-	registerToolIfEnabled("open_task", enabledTools, tool, kbClient.openTaskHandler, s)
-
-	tool = mcp.NewTool("close_task",
-		mcp.WithDescription("Close/complete a task, marking it as done"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to close"),
-		),
-	)
-	registerToolIfEnabled("close_task", enabledTools, tool, kbClient.closeTaskHandler, s)
-
-	tool = mcp.NewTool("move_task_to_project",
-		mcp.WithDescription("Move a task to a different project, specifying target column and swimlane"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to move"),
-		),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to move the task to"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Description("ID of the swimlane (optional)"),
-		),
-		mcp.WithNumber("column_id",
-			mcp.Description("ID of the column (optional)"),
-		),
-		mcp.WithNumber("category_id",
-			mcp.Description("ID of the category (optional)"),
-		),
-		mcp.WithNumber("owner_id",
-			mcp.Description("ID of the owner (optional)"),
-		),
-	)
-	registerToolIfEnabled("move_task_to_project", enabledTools, tool, kbClient.moveTaskToProjectHandler, s)
-
-	tool = mcp.NewTool("duplicate_task_to_project",
-		mcp.WithDescription("Copy a task to another project with optional inclusion of subtasks, comments, and files"),
-		mcp.WithNumber("task_id",
-			mcp.Required(),
-			mcp.Description("ID of the task to duplicate"),
-		),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to duplicate the task to"),
-		),
-		mcp.WithNumber("swimlane_id",
-			mcp.Description("ID of the swimlane (optional)"),
-		),
-		mcp.WithNumber("column_id",
-			mcp.Description("ID of the column (optional)"),
-		),
-		mcp.WithNumber("category_id",
-			mcp.Description("ID of the category (optional)"),
-		),
-		mcp.WithNumber("owner_id",
-			mcp.Description("ID of the owner (optional)"),
-		),
-	)
-	registerToolIfEnabled("duplicate_task_to_project", enabledTools, tool, kbClient.duplicateTaskToProjectHandler, s)
-
-	tool = mcp.NewTool("search_tasks",
-		mcp.WithDescription("Search tasks using Kanboard query syntax (supports: assignee, status, due date, category, tag filters)"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to search tasks in"),
-		),
-		mcp.WithString("query",
-			mcp.Required(),
-			mcp.Description("Search query string"),
-		),
-	)
-	registerToolIfEnabled("search_tasks", enabledTools, tool, kbClient.searchTasksHandler, s)
-
-	// ScrumSprint Plugin API
-	tool = mcp.NewTool("create_sprint",
-		mcp.WithDescription("Create a new sprint/iteration for agile project management with start and end dates"),
-		mcp.WithNumber("project_id",
-			mcp.Required(),
-			mcp.Description("ID of the project to create the sprint in"),
-		),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Name of the new sprint"),
-		),
-		mcp.WithString("start_date",
-			mcp.Required(),
-			mcp.Description("Start date of the sprint (YYYY-MM-DD)"),
-		),
-		mcp.WithString("end_date",
-			mcp.Required(),
-			mcp.Description("End date of the sprint (YYYY-MM-DD)"),
-		),
-	)
-	registerToolIfEnabled("create_sprint", enabledTools, tool, kbClient.createSprintHandler, s)
-
-	tool = mcp.NewTool("get_sprint_by_id",
-		mcp.WithDescription("Get sprint details including dates, status, and associated tasks"),
-		mcp.WithNumber("sprint_id",
-			mcp.Required(),
-			mcp.Description("ID of the sprint to retrieve"),
-		),
-	)
-	registerToolIfEnabled("get_sprint_by_id", enabledTools, tool, kbClient.getSprintByIdHandler, s)
-
-	tool = mcp.NewTool("update_sprint",
-		mcp.WithDescription("Update sprint name, dates, or status"),
-		mcp.WithNumber("sprint_id",
-			mcp.Required(),
-			mcp.Description("ID of the sprint to update"),
-		),
-		mcp.WithString("name",
-			mcp.Description("New name for the sprint (optional)"),
-		),
-		mcp.WithString("start_date",
-			mcp.Description("New start date for the sprint (YYYY-MM-DD) (optional)"),
-		),
-		mcp.WithString("end_date",
-			mcp.Description("New end date for the sprint (YYYY-MM-DD) (optional)"),
-		),
-		mcp.WithString("sprint_goal",
-			mcp.Description("New goal for the sprint (optional)"),
-		),
-		mcp.WithBoolean("is_completed",
-			mcp.Description("Whether the sprint is completed (optional)"),
-		),
-		mcp.WithBoolean("is_active",
-			mcp.Description("Whether the sprint is active (optional)"),
-		),
-	)
-	registerToolIfEnabled("update_sprint", enabledTools, tool, kbClient.updateSprintHandler, s)
-
-	tool = mcp.NewTool("remove_sprint",
-		mcp.WithDescription("Delete a sprint (tasks remain but lose sprint association)"),
-		mcp.WithNumber("sprint_id",
-			mcp.Required(),
-			mcp.Description("ID of the sprint to remove"),
-		),
-	)
-	registerToolIfEnabled("remove_sprint", enabledTools, tool, kbClient.removeSprintHandler, s)
-
-	tool = mcp.NewTool("get_all_sprints_by_project",
-		mcp.WithDescription("Retrieve all sprints for a given project."),
-		mcp.WithString("project_name",
-			mcp.Required(),
-			mcp.Description("Name of the project to retrieve sprints from"),
-		),
-	)
-	registerToolIfEnabled("get_all_sprints_by_project", enabledTools, tool, kbClient.getAllSprintsByProjectHandler, s)
-
-	// Tool Search - always enabled as a core infrastructure tool
-	tool = mcp.NewTool("tool_search",
-		mcp.WithDescription("Search for available tools by name or description using regex or BM25 keyword matching"),
-		mcp.WithString("query",
-			mcp.Required(),
-			mcp.Description("Search query - can be a regex pattern or keywords to find relevant tools"),
-		),
-		mcp.WithString("search_type",
-			mcp.Description("Search algorithm: 'regex' for pattern matching, 'bm25' for keyword relevance, 'auto' tries regex first then BM25 (default: auto)"),
-		),
-		mcp.WithNumber("max_results",
-			mcp.Description("Maximum number of results to return (default: 10)"),
-		),
-	)
-	// Tool search is always registered (not subject to config) as it's infrastructure
-	s.AddTool(tool, toolSearchHandler)
-	registeredTools = append(registeredTools, ToolInfo{Name: "tool_search", Description: tool.Description})
-
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Total tools registered: %d\n", len(registeredTools))
-	}
-
-	// Start the server based on transport mode
-	startServer(s, transportMode, port)
-}
-
-// startServer starts the MCP server with the specified transport mode
-func startServer(s *server.MCPServer, transportMode string, port string) {
-	switch transportMode {
-	case TransportSSE:
-		startSSEServer(s, port)
-	case TransportStreamableHTTP:
-		startStreamableHTTPServer(s, port)
-	case TransportStdio:
-		fallthrough
-	default:
-		startStdioServer(s)
-	}
-}
-
-// startStdioServer starts the MCP server with stdio transport
-func startStdioServer(s *server.MCPServer) {
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Starting MCP server with stdio transport\n")
-	}
-	if err := server.ServeStdio(s); err != nil {
-		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
-		os.Exit(1)
-	}
-}
-
-// startSSEServer starts the MCP server with SSE (Server-Sent Events) transport
-func startSSEServer(s *server.MCPServer, port string) {
-	addr := ":" + port
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Starting MCP server with SSE transport on %s\n", addr)
-	}
-
-	sseServer := server.NewSSEServer(s)
-
-	// Create HTTP server with SSE handlers
-	mux := http.NewServeMux()
-	mux.HandleFunc("/sse", sseServer.ServeHTTP)
-	mux.HandleFunc("/message", sseServer.ServeHTTP)
-	mux.HandleFunc("/health", healthCheckHandler)
-
-	fmt.Fprintf(os.Stderr, "KanboardMCP SSE server listening on %s\n", addr)
-	fmt.Fprintf(os.Stderr, "  SSE endpoint: http://localhost%s/sse\n", addr)
-	fmt.Fprintf(os.Stderr, "  Message endpoint: http://localhost%s/message\n", addr)
-	fmt.Fprintf(os.Stderr, "  Health check: http://localhost%s/health\n", addr)
-
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		fmt.Fprintf(os.Stderr, "SSE server error: %v\n", err)
-		os.Exit(1)
-	}
-}
-
-// startStreamableHTTPServer starts the MCP server with Streamable HTTP transport
-func startStreamableHTTPServer(s *server.MCPServer, port string) {
-	addr := ":" + port
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Starting MCP server with Streamable HTTP transport on %s\n", addr)
-	}
-
-	httpServer := server.NewStreamableHTTPServer(s)
-
-	// Create HTTP server with Streamable HTTP handlers
-	mux := http.NewServeMux()
-	mux.HandleFunc("/mcp", httpServer.ServeHTTP)
-	mux.HandleFunc("/health", healthCheckHandler)
-
-	fmt.Fprintf(os.Stderr, "KanboardMCP Streamable HTTP server listening on %s\n", addr)
-	fmt.Fprintf(os.Stderr, "  MCP endpoint: http://localhost%s/mcp\n", addr)
-	fmt.Fprintf(os.Stderr, "  Health check: http://localhost%s/health\n", addr)
-
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP server error: %v\n", err)
-		os.Exit(1)
-	}
-}
-
-// healthCheckHandler provides a simple health check endpoint
-func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":    "healthy",
-		"service":   "kanboard-mcp",
-		"version":   version,
-		"buildTime": buildTime,
-	})
-}
-
-type kanboardClient struct {
-	apiEndpoint string
-	apiKey      string
-	username    string
-	password    string
-	rbac        *RBACManager
-}
-
-func newKanboardClient(apiEndpoint, apiKey, username, password string, rbac *RBACManager) *kanboardClient {
-	return &kanboardClient{
-		apiEndpoint: apiEndpoint,
-		apiKey:      apiKey,
-		username:    username,
-		password:    password,
-		rbac:        rbac,
-	}
-}
-
-// APIResponse represents the standard Kanboard JSON-RPC response structure
-type APIResponse struct {
-	Jsonrpc string      `json:"jsonrpc"`
-	ID      int         `json:"id"`
-	Result  interface{} `json:"result"`
-	Error   *APIError   `json:"error"`
-}
-
-// APIError represents a Kanboard API error response
-type APIError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
-
-// RequestConfig holds configuration for API requests
-type RequestConfig struct {
-	MaxRetries    int
-	RetryDelay    time.Duration
-	Timeout       time.Duration
-	EnableLogging bool
-}
-
-// DefaultRequestConfig returns default configuration for API requests
-func DefaultRequestConfig() *RequestConfig {
-	return &RequestConfig{
-		MaxRetries:    3,
-		RetryDelay:    time.Second * 2,
-		Timeout:       time.Second * 30,
-		EnableLogging: true,
-	}
-}
-
-func (kc *kanboardClient) callKanboardAPI(ctx context.Context, method string, params interface{}) (interface{}, error) {
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: Calling Kanboard API method: %s\n", method)
-		if params != nil {
-			fmt.Fprintf(os.Stderr, "DEBUG: Parameters: %+v\n", params)
-		}
-	}
-	return kc.callKanboardAPIWithConfig(ctx, method, params, DefaultRequestConfig())
-}
-
-func (kc *kanboardClient) callKanboardAPIWithConfig(ctx context.Context, method string, params interface{}, config *RequestConfig) (interface{}, error) {
-	if config == nil {
-		config = DefaultRequestConfig()
-	}
-
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: config.Timeout,
-	}
-
-	var lastErr error
-	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
-		if attempt > 0 {
-			if config.EnableLogging {
-				fmt.Fprintf(os.Stderr, "Retrying API call to %s (attempt %d/%d)\n", method, attempt+1, config.MaxRetries+1)
-			}
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(config.RetryDelay):
-			}
-		}
-
-		result, err := kc.executeAPIRequest(ctx, client, method, params, config)
-		if err == nil {
-			return result, nil
-		}
-
-		lastErr = err
-
-		// Don't retry on authentication or validation errors
-		if isNonRetryableError(err) {
-			break
-		}
-	}
-
-	return nil, fmt.Errorf("API call failed after %d attempts: %w", config.MaxRetries+1, lastErr)
-}
-
-func (kc *kanboardClient) executeAPIRequest(ctx context.Context, client *http.Client, method string, params interface{}, config *RequestConfig) (interface{}, error) {
-	// Validate inputs
-	if method == "" {
-		return nil, fmt.Errorf("method cannot be empty")
-	}
-
-	// Prepare request body
-	requestBody := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  method,
-		"id":      generateRequestID(),
-		"params":  params,
-	}
-
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
-	}
-
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: API Request to %s: %s\n", kc.apiEndpoint, string(jsonBody))
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", kc.apiEndpoint, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "KanboardMCP/1.0")
-
-	// Set authentication
-	if err := kc.setAuthentication(req); err != nil {
-		if os.Getenv("KANBOARD_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "DEBUG: Authentication setup failed: %v\n", err)
-		}
-		return nil, err
-	}
-
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		authHeader := req.Header.Get("Authorization")
-		if authHeader != "" {
-			fmt.Fprintf(os.Stderr, "DEBUG: Using authorization header: %s...\n", authHeader[:20])
-		} else {
-			fmt.Fprintf(os.Stderr, "DEBUG: No authorization header set\n")
-		}
-	}
-
-	// Execute request
-	if config.EnableLogging || os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "Making API call to %s\n", method)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		if os.Getenv("KANBOARD_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "DEBUG: HTTP request failed: %v\n", err)
-		}
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: HTTP response status: %s\n", resp.Status)
-		for key, values := range resp.Header {
-			fmt.Fprintf(os.Stderr, "DEBUG: Response header %s: %v\n", key, values)
-		}
-	}
-
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil && (config.EnableLogging || os.Getenv("KANBOARD_DEBUG") == "true") {
-			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
-		}
-	}()
-
-	// Handle HTTP status errors
-	if err := kc.handleHTTPStatus(resp); err != nil {
-		if os.Getenv("KANBOARD_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "DEBUG: HTTP status error: %v\n", err)
-		}
-		return nil, err
-	}
-
-	// Parse response
-	return kc.parseAPIResponse(resp.Body, config)
-}
-
-func (kc *kanboardClient) setAuthentication(req *http.Request) error {
-	// Determine authentication method based on available credentials
-	authMethod := strings.ToLower(strings.TrimSpace(os.Getenv("KANBOARD_AUTH_METHOD")))
-
-	// Priority: API key over username/password
-	if kc.isValidAPIKey() {
-		switch authMethod {
-		case "global_token", "":
-			// Default: Global API token (Kanboard application token)
-			// Format: jsonrpc:<global-token>
-			auth := "jsonrpc:" + kc.apiKey
-			basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-			req.Header.Set("Authorization", basicAuth)
-			if os.Getenv("KANBOARD_DEBUG") == "true" {
-				fmt.Fprintf(os.Stderr, "DEBUG: Using global API token auth (jsonrpc:token)\n")
-			}
-			return nil
-
-		case "user_token":
-			// User-specific API token (limited access)
-			// Format: <username>:<user-token>
-			if kc.username == "" || kc.username == "your-kanboard-username" {
-				if os.Getenv("KANBOARD_DEBUG") == "true" {
-					fmt.Fprintf(os.Stderr, "DEBUG: KANBOARD_AUTH_METHOD=user_token requires valid KANBOARD_USERNAME\n")
-				}
-				return fmt.Errorf("KANBOARD_AUTH_METHOD=user_token requires KANBOARD_USERNAME to be set")
-			}
-			auth := kc.username + ":" + kc.apiKey
-			basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-			req.Header.Set("Authorization", basicAuth)
-			if os.Getenv("KANBOARD_DEBUG") == "true" {
-				fmt.Fprintf(os.Stderr, "DEBUG: Using user-specific API token auth (%s:token)\n", kc.username)
-			}
-			return nil
-
-		case "bearer":
-			// Bearer token authentication
-			req.Header.Set("Authorization", "Bearer "+kc.apiKey)
-			if os.Getenv("KANBOARD_DEBUG") == "true" {
-				fmt.Fprintf(os.Stderr, "DEBUG: Using bearer token auth\n")
-			}
-			return nil
-
-		default:
-			return fmt.Errorf("unsupported KANBOARD_AUTH_METHOD: %s (supported: global_token, user_token, bearer)", authMethod)
-		}
-	}
-
-	// Fallback to username/password authentication
-	if kc.isValidCredentials() {
-		auth := kc.username + ":" + kc.password
-		basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-		req.Header.Set("Authorization", basicAuth)
-		if os.Getenv("KANBOARD_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "DEBUG: Using username/password auth (%s)\n", kc.username)
-		}
-		return nil
-	}
-
-	return fmt.Errorf("no valid authentication credentials provided")
-}
-
-func (kc *kanboardClient) isValidAPIKey() bool {
-	isValid := kc.apiKey != "" && kc.apiKey != "your-kanboard-api-key"
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: API key validation - key length: %d, isValid: %v\n", len(kc.apiKey), isValid)
-		if !isValid {
-			if kc.apiKey == "" {
-				fmt.Fprintf(os.Stderr, "DEBUG: API key is empty\n")
-			} else if kc.apiKey == "your-kanboard-api-key" {
-				fmt.Fprintf(os.Stderr, "DEBUG: API key is placeholder value\n")
-			}
-		}
-	}
-	return isValid
-}
-
-func (kc *kanboardClient) isValidCredentials() bool {
-	return kc.username != "" && kc.password != "" &&
-		kc.username != "your-kanboard-username" && kc.password != "your-kanboard-password"
-}
-
-func (kc *kanboardClient) handleHTTPStatus(resp *http.Response) error {
-	if resp.StatusCode == http.StatusOK {
-		return nil
-	}
-
-	// Read response body for error details
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("HTTP %d: %s (failed to read response body: %w)", resp.StatusCode, resp.Status, err)
-	}
-
-	errorMsg := string(bodyBytes)
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: HTTP Error Response Body: %s\n", errorMsg)
-		fmt.Fprintf(os.Stderr, "DEBUG: Response Headers:\n")
-		for key, values := range resp.Header {
-			fmt.Fprintf(os.Stderr, "DEBUG:   %s: %v\n", key, values)
-		}
-	}
-
-	// Provide specific guidance for 403 errors
-	if resp.StatusCode == 403 {
-		return fmt.Errorf("HTTP 403 Forbidden - %s\nPossible causes:\n1. Invalid API key or credentials\n2. API key doesn't have required permissions\n3. User account is disabled or doesn't have access\n4. Project access restrictions\n\nDebug: Enable KANBOARD_DEBUG=true to see detailed auth info", errorMsg)
-	}
-
-	return fmt.Errorf("HTTP %d: %s - %s", resp.StatusCode, resp.Status, errorMsg)
-}
-
-func (kc *kanboardClient) parseAPIResponse(body io.Reader, config *RequestConfig) (interface{}, error) {
-	var apiResponse APIResponse
-
-	if err := json.NewDecoder(body).Decode(&apiResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode API response: %w", err)
-	}
-
-	// Check for JSON-RPC protocol errors
-	if apiResponse.Error != nil {
-		return nil, fmt.Errorf("kanboard API error (code %d): %s", apiResponse.Error.Code, apiResponse.Error.Message)
-	}
-
-	// Validate JSON-RPC response
-	if apiResponse.Jsonrpc != "2.0" {
-		return nil, fmt.Errorf("invalid JSON-RPC version: %s", apiResponse.Jsonrpc)
-	}
-
-	return apiResponse.Result, nil
-}
-
-func generateRequestID() int {
-	return int(time.Now().UnixNano() % 1000000)
-}
-
-func isNonRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := err.Error()
-
-	// Authentication errors
-	if strings.Contains(errStr, "authentication") ||
-		strings.Contains(errStr, "unauthorized") ||
-		strings.Contains(errStr, "forbidden") {
-		return true
-	}
-
-	// Validation errors
-	if strings.Contains(errStr, "validation") ||
-		strings.Contains(errStr, "invalid") ||
-		strings.Contains(errStr, "not found") {
-		return true
-	}
-
-	// JSON-RPC protocol errors
-	if strings.Contains(errStr, "JSON-RPC") ||
-		strings.Contains(errStr, "jsonrpc") {
-		return true
-	}
-
-	return false
-}
-
-func (kc *kanboardClient) getProjectsHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Check permission for getting all projects (application-level)
-	if err := kc.checkPermission(ctx, nil, "projectprocedure", "getallprojects"); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "getAllProjects", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Check permission for creating projects (application-level)
-	if err := kc.checkPermission(ctx, nil, "projectprocedure", "createproject"); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"name": name}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	ownerId := request.GetInt("owner_id", 0)
-	if ownerId != 0 {
-		params["owner_id"] = ownerId
-	}
-
-	identifier := request.GetString("identifier", "")
-	if identifier != "" {
-		params["identifier"] = identifier
-	}
-
-	startDate := request.GetString("start_date", "")
-	if startDate != "" {
-		params["start_date"] = startDate
-	}
-
-	endDate := request.GetString("end_date", "")
-	if endDate != "" {
-		params["end_date"] = endDate
-	}
-
-	priorityDefault := request.GetInt("priority_default", 0)
-	if priorityDefault != 0 {
-		params["priority_default"] = priorityDefault
-	}
-
-	priorityStart := request.GetInt("priority_start", 0)
-	if priorityStart != 0 {
-		params["priority_start"] = priorityStart
-	}
-
-	priorityEnd := request.GetInt("priority_end", 0)
-	if priorityEnd != 0 {
-		params["priority_end"] = priorityEnd
-	}
-
-	email := request.GetString("email", "")
-	if email != "" {
-		params["email"] = email
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTasksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectName, err := request.RequireString("project_name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// First, get the project ID from the project name
-	result, err := kc.callKanboardAPI(ctx, "getProjectByName", map[string]string{"name": projectName})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project ID: %v", err)), nil
-	}
-
-	// Kanboard API sometimes returns a boolean false or true, or an empty array []
-	// instead of a project object when the project is not found.
-	// We need to handle these cases before attempting to unmarshal into a struct.
-	projectMap, ok := result.(map[string]interface{})
-	if !ok {
-		// If it's not a map, check if it's a boolean (false or true).
-		// Kanboard API might return `false` for not found, or `true` if it's a "success" response without data.
-		if b, isBool := result.(bool); isBool {
-			// If boolean false, it's definitively not found.
-			// If boolean true, and it's not a map, it's also not a valid project object.
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or API returned an unexpected boolean value: %v", projectName, b)), nil
-		}
-
-		// If it's not a map and not a boolean, check if it's nil or an empty array.
-		if result == nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned nil", projectName)), nil
-		}
-		if arr, isArray := result.([]interface{}); isArray && len(arr) == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty array", projectName)), nil
-		}
-
-		// For any other unexpected type
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned unexpected type %T", projectName, result)), nil
-	}
-
-	// If projectMap is empty, treat as not found. (e.g., Kanboard returns {} for not found)
-	if len(projectMap) == 0 {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty object", projectName)), nil
-	}
-
-	var projectInfo struct {
-		ID json.RawMessage `json:"id"`
-	}
-	tempBytes, err := json.Marshal(projectMap)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal project info for parsing: %v", err)), nil
-	}
-	if err := json.Unmarshal(tempBytes, &projectInfo); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse project info: %v", err)), nil
-	}
-
-	var projectID string
-	var idInt int
-	if err := json.Unmarshal(projectInfo.ID, &idInt); err == nil {
-		projectID = strconv.Itoa(idInt)
-	} else {
-		var idStr string
-		if err := json.Unmarshal(projectInfo.ID, &idStr); err == nil {
-			projectID = idStr
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' id is not a valid int or string: %v", projectName, err)), nil
-		}
-	}
-
-	if projectID == "" {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or ID is empty", projectName)), nil
-	}
-
-	params := map[string]interface{}{"project_id": projectID}
-	result, err = kc.callKanboardAPI(ctx, "getAllTasks", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get tasks: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectName, err := request.RequireString("project_name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	title, err := request.RequireString("title")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// First, get the project ID from the project name
-	result, err := kc.callKanboardAPI(ctx, "getProjectByName", map[string]string{"name": projectName})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project ID: %v", err)), nil
-	}
-
-	// Kanboard API sometimes returns a boolean false or true, or an empty array []
-	// instead of a project object when the project is not found.
-	// We need to handle these cases before attempting to unmarshal into a struct.
-	projectMap, ok := result.(map[string]interface{})
-	if !ok {
-		// If it's not a map, check if it's a boolean (false or true).
-		// Kanboard API might return `false` for not found, or `true` if it's a "success" response without data.
-		if b, isBool := result.(bool); isBool {
-			// If boolean false, it's definitively not found.
-			// If boolean true, and it's not a map, it's also not a valid project object.
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or API returned an unexpected boolean value: %v", projectName, b)), nil
-		}
-
-		// If it's not a map and not a boolean, check if it's nil or an empty array.
-		if result == nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned nil", projectName)), nil
-		}
-		if arr, isArray := result.([]interface{}); isArray && len(arr) == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty array", projectName)), nil
-		}
-
-		// For any other unexpected type
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned unexpected type %T", projectName, result)), nil
-	}
-
-	// If projectMap is empty, treat as not found. (e.g., Kanboard returns {} for not found)
-	if len(projectMap) == 0 {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty object", projectName)), nil
-	}
-
-	var projectInfo struct {
-		ID json.RawMessage `json:"id"`
-	}
-	tempBytes, err := json.Marshal(projectMap)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal project info for parsing: %v", err)), nil
-	}
-	if err := json.Unmarshal(tempBytes, &projectInfo); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse project info: %v", err)), nil
-	}
-
-	var projectID string
-	var idInt int
-	if err := json.Unmarshal(projectInfo.ID, &idInt); err == nil {
-		projectID = strconv.Itoa(idInt)
-	} else {
-		var idStr string
-		if err := json.Unmarshal(projectInfo.ID, &idStr); err == nil {
-			projectID = idStr
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' id is not a valid int or string: %v", projectName, err)), nil
-		}
-	}
-
-	if projectID == "" {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or ID is empty", projectName)), nil
-	}
-
-	// Convert projectID to int for permission check
-	projectIDInt, err := strconv.Atoi(projectID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid project ID format: %v", err)), nil
-	}
-
-	// Check permission for creating tasks (project-level)
-	if err := kc.checkPermission(ctx, &projectIDInt, "taskprocedure", "createtask"); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectID,
-		"title":      title,
-	}
-
-	colorId := request.GetString("color_id", "")
-	if colorId != "" {
-		params["color_id"] = colorId
-	}
-
-	columnId := request.GetInt("column_id", 0)
-	if columnId != 0 {
-		params["column_id"] = columnId
-	}
-
-	ownerId := request.GetInt("owner_id", 0)
-	if ownerId != 0 {
-		params["owner_id"] = ownerId
-	}
-
-	creatorId := request.GetInt("creator_id", 0)
-	if creatorId != 0 {
-		params["creator_id"] = creatorId
-	}
-
-	dateDue := request.GetString("date_due", "")
-	if dateDue != "" {
-		params["date_due"] = dateDue
-	}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	categoryId := request.GetInt("category_id", 0)
-	if categoryId != 0 {
-		params["category_id"] = categoryId
-	}
-
-	score := request.GetInt("score", 0)
-	if score != 0 {
-		params["score"] = score
-	}
-
-	swimlaneId := request.GetInt("swimlane_id", 0)
-	if swimlaneId != 0 {
-		params["swimlane_id"] = swimlaneId
-	}
-
-	priority := request.GetInt("priority", 0)
-	if priority != 0 {
-		params["priority"] = priority
-	}
-
-	recurrenceStatus := request.GetInt("recurrence_status", 0)
-	if recurrenceStatus != 0 {
-		params["recurrence_status"] = recurrenceStatus
-	}
-
-	recurrenceTrigger := request.GetInt("recurrence_trigger", 0)
-	if recurrenceTrigger != 0 {
-		params["recurrence_trigger"] = recurrenceTrigger
-	}
-
-	recurrenceFactor := request.GetInt("recurrence_factor", 0)
-	if recurrenceFactor != 0 {
-		params["recurrence_factor"] = recurrenceFactor
-	}
-
-	recurrenceTimeframe := request.GetInt("recurrence_timeframe", 0)
-	if recurrenceTimeframe != 0 {
-		params["recurrence_timeframe"] = recurrenceTimeframe
-	}
-
-	recurrenceBasedate := request.GetInt("recurrence_basedate", 0)
-	if recurrenceBasedate != 0 {
-		params["recurrence_basedate"] = recurrenceBasedate
-	}
-
-	reference := request.GetString("reference", "")
-	if reference != "" {
-		params["reference"] = reference
-	}
-
-	tags := request.GetStringSlice("tags", []string{})
-	if len(tags) > 0 {
-		params["tags"] = tags
-	}
-
-	dateStarted := request.GetString("date_started", "")
-	if dateStarted != "" {
-		params["date_started"] = dateStarted
-	}
-
-	result, err = kc.callKanboardAPI(ctx, "createTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createTestTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// This handler bypasses RBAC checks for debugging purposes
-	if os.Getenv("KANBOARD_DEBUG") == "true" {
-		fmt.Fprintf(os.Stderr, "DEBUG: createTestTaskHandler called (RBAC bypassed)\n")
-	}
-
-	projectName, err := request.RequireString("project_name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	title, err := request.RequireString("title")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// First, get the project ID from the project name
-	result, err := kc.callKanboardAPI(ctx, "getProjectByName", map[string]string{"name": projectName})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project ID: %v", err)), nil
-	}
-
-	// Kanboard API sometimes returns a boolean false or true, or an empty array []
-	// instead of a project object when the project is not found.
-	// We need to handle these cases before attempting to unmarshal into a struct.
-	projectMap, ok := result.(map[string]interface{})
-	if !ok {
-		// If it's not a map, check if it's a boolean (false or true).
-		// Kanboard API might return `false` for not found, or `true` if it's a "success" response without data.
-		if b, isBool := result.(bool); isBool {
-			// If boolean false, it's definitively not found.
-			// If boolean true, and it's not a map, it's also not a valid project object.
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or API returned an unexpected boolean value: %v", projectName, b)), nil
-		}
-
-		// If it's not a map and not a boolean, check if it's nil or an empty array.
-		if result == nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned nil", projectName)), nil
-		}
-		if arr, isArray := result.([]interface{}); isArray && len(arr) == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty array", projectName)), nil
-		}
-
-		// For any other unexpected type
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned unexpected type %T", projectName, result)), nil
-	}
-
-	// If projectMap is empty, treat as not found. (e.g., Kanboard returns {} for not found)
-	if len(projectMap) == 0 {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty object", projectName)), nil
-	}
-
-	var projectInfo struct {
-		ID json.RawMessage `json:"id"`
-	}
-	tempBytes, err := json.Marshal(projectMap)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal project info for parsing: %v", err)), nil
-	}
-	if err := json.Unmarshal(tempBytes, &projectInfo); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse project info: %v", err)), nil
-	}
-
-	var projectID string
-	var idInt int
-	if err := json.Unmarshal(projectInfo.ID, &idInt); err == nil {
-		projectID = strconv.Itoa(idInt)
-	} else {
-		var idStr string
-		if err := json.Unmarshal(projectInfo.ID, &idStr); err == nil {
-			projectID = idStr
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' id is not a valid int or string: %v", projectName, err)), nil
-		}
-	}
-
-	if projectID == "" {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or ID is empty", projectName)), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectID,
-		"title":      title,
-	}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	result, err = kc.callKanboardAPI(ctx, "createTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create test task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(fmt.Sprintf("Test task created successfully (RBAC bypassed):\n%s", string(resultBytes))), nil
-}
-
-// Helper functions for masking sensitive data in debug output
-func maskAPIKey(key string) string {
-	if key == "" || key == "your-kanboard-api-key" {
-		return key
-	}
-	if len(key) <= 8 {
-		return "****"
-	}
-	return key[:4] + "****" + key[len(key)-4:]
-}
-
-func maskPassword(password string) string {
-	if password == "" || password == "your-kanboard-password" {
-		return password
-	}
-	return strings.Repeat("*", len(password))
-}
-
-func (kc *kanboardClient) updateTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	id, err := request.RequireInt("id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": id}
-
-	title := request.GetString("title", "")
-	if title != "" {
-		params["title"] = title
-	}
-
-	colorId := request.GetString("color_id", "")
-	if colorId != "" {
-		params["color_id"] = colorId
-	}
-
-	ownerId := request.GetInt("owner_id", 0)
-	if ownerId != 0 {
-		params["owner_id"] = ownerId
-	}
-
-	dateDue := request.GetString("date_due", "")
-	if dateDue != "" {
-		params["date_due"] = dateDue
-	}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	categoryId := request.GetInt("category_id", 0)
-	if categoryId != 0 {
-		params["category_id"] = categoryId
-	}
-
-	score := request.GetInt("score", 0)
-	if score != 0 {
-		params["score"] = score
-	}
-
-	priority := request.GetInt("priority", 0)
-	if priority != 0 {
-		params["priority"] = priority
-	}
-
-	recurrenceStatus := request.GetInt("recurrence_status", 0)
-	if recurrenceStatus != 0 {
-		params["recurrence_status"] = recurrenceStatus
-	}
-
-	recurrenceTrigger := request.GetInt("recurrence_trigger", 0)
-	if recurrenceTrigger != 0 {
-		params["recurrence_trigger"] = recurrenceTrigger
-	}
-
-	recurrenceFactor := request.GetInt("recurrence_factor", 0)
-	if recurrenceFactor != 0 {
-		params["recurrence_factor"] = recurrenceFactor
-	}
-
-	recurrenceTimeframe := request.GetInt("recurrence_timeframe", 0)
-	if recurrenceTimeframe != 0 {
-		params["recurrence_timeframe"] = recurrenceTimeframe
-	}
-
-	recurrenceBasedate := request.GetInt("recurrence_basedate", 0)
-	if recurrenceBasedate != 0 {
-		params["recurrence_basedate"] = recurrenceBasedate
-	}
-
-	reference := request.GetString("reference", "")
-	if reference != "" {
-		params["reference"] = reference
-	}
-
-	tags := request.GetStringSlice("tags", []string{})
-	if len(tags) > 0 {
-		params["tags"] = tags
-	}
-
-	dateStarted := request.GetString("date_started", "")
-	if dateStarted != "" {
-		params["date_started"] = dateStarted
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) deleteTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "removeTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "getTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get task details: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) moveTaskPositionHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	columnId, err := request.RequireInt("column_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	position, err := request.RequireInt("position")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id":  projectId,
-		"task_id":     taskId,
-		"column_id":   columnId,
-		"position":    position,
-		"swimlane_id": swimlaneId,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "moveTaskPosition", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to move task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getUsersHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getAllUsers", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getUserByNameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	username, err := request.RequireString("username")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"username": username}
-	result, err := kc.callKanboardAPI(ctx, "getUserByName", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get user by name: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Check permission for creating users (application-level)
-	if err := kc.checkPermission(ctx, nil, "userprocedure", "createuser"); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	username, err := request.RequireString("username")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	password, err := request.RequireString("password")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"username": username,
-		"password": password,
-	}
-
-	name := request.GetString("name", "")
-	if name != "" {
-		params["name"] = name
-	}
-
-	email := request.GetString("email", "")
-	if email != "" {
-		params["email"] = email
-	}
-
-	role := request.GetString("role", "")
-	if role != "" {
-		params["role"] = role
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	id, err := request.RequireInt("id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": id}
-
-	username := request.GetString("username", "")
-	if username != "" {
-		params["username"] = username
-	}
-
-	name := request.GetString("name", "")
-	if name != "" {
-		params["name"] = name
-	}
-
-	email := request.GetString("email", "")
-	if email != "" {
-		params["email"] = email
-	}
-
-	role := request.GetString("role", "")
-	if role != "" {
-		params["role"] = role
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "removeUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMeHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getMe", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMyDashboardHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getMyDashboard", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMyActivityStreamHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getMyActivityStream", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createMyPrivateProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"name": name}
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-	result, err := kc.callKanboardAPI(ctx, "createMyPrivateProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMyProjectsListHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getMyProjectsList", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMyOverdueTasksHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getMyOverdueTasks", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMyProjectsHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getMyProjects", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getExternalTaskLinkTypesHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getExternalTaskLinkTypes", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getExternalTaskLinkProviderDependenciesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	providerName, err := request.RequireString("provider_name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"providerName": providerName}
-	result, err := kc.callKanboardAPI(ctx, "getExternalTaskLinkProviderDependencies", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createExternalTaskLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	url, err := request.RequireString("url")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	dependency, err := request.RequireString("dependency")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"task_id":    taskId,
-		"url":        url,
-		"dependency": dependency,
-	}
-
-	typeName := request.GetString("type", "")
-	if typeName != "" {
-		params["type"] = typeName
-	}
-
-	title := request.GetString("title", "")
-	if title != "" {
-		params["title"] = title
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createExternalTaskLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateExternalTaskLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"task_id": taskId, "link_id": linkId}
-
-	title := request.GetString("title", "")
-	if title != "" {
-		params["title"] = title
-	}
-	url := request.GetString("url", "")
-	if url != "" {
-		params["url"] = url
-	}
-	dependency := request.GetString("dependency", "")
-	if dependency != "" {
-		params["dependency"] = dependency
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateExternalTaskLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getExternalTaskLinkByIdHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId, "link_id": linkId}
-	result, err := kc.callKanboardAPI(ctx, "getExternalTaskLinkById", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllExternalTaskLinksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "getAllExternalTaskLinks", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeExternalTaskLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId, "link_id": linkId}
-	result, err := kc.callKanboardAPI(ctx, "removeExternalTaskLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getColumnsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := requireID(request, "project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getColumns", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get columns: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getColumnHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	columnId, err := request.RequireInt("column_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"column_id": columnId}
-	result, err := kc.callKanboardAPI(ctx, "getColumn", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get column details: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createColumnHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := requireID(request, "project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	title, err := request.RequireString("title")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectId,
-		"title":      title,
-	}
-
-	taskLimit := request.GetInt("task_limit", 0)
-	if taskLimit != 0 {
-		params["task_limit"] = taskLimit
-	}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "addColumn", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create column: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateColumnHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	columnId, err := request.RequireInt("column_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	title, err := request.RequireString("title")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": columnId, "title": title}
-
-	taskLimit := request.GetInt("task_limit", 0)
-	if taskLimit != 0 {
-		params["task_limit"] = taskLimit
-	}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateColumn", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update column: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) deleteColumnHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	columnId, err := request.RequireInt("column_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"column_id": columnId}
-	result, err := kc.callKanboardAPI(ctx, "removeColumn", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete column: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) reorderColumnsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := requireID(request, "project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	columnId, err := request.RequireInt("column_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	position, err := request.RequireInt("position")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectId,
-		"column_id":  columnId,
-		"position":   position,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "moveColumnPosition", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to reorder columns: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getCategoriesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := requireID(request, "project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getAllCategories", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get categories: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createCategoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := requireID(request, "project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Check permission for creating categories (project-level)
-	if err := kc.checkPermission(ctx, &projectId, "categoryprocedure", "createcategory"); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"name": name, "project_id": projectId}
-
-	colorId := request.GetString("color_id", "")
-	if colorId != "" {
-		params["color_id"] = colorId
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createCategory", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create category: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getCategoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	categoryId, err := request.RequireInt("category_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"category_id": categoryId}
-	result, err := kc.callKanboardAPI(ctx, "getCategory", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get category details: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateCategoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	categoryId, err := request.RequireInt("category_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": categoryId}
-	name := request.GetString("name", "")
-	if name != "" {
-		params["name"] = name
-	}
-
-	colorId := request.GetString("color_id", "")
-	if colorId != "" {
-		params["color_id"] = colorId
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateCategory", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update category: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) deleteCategoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	categoryId, err := request.RequireInt("category_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"category_id": categoryId}
-	result, err := kc.callKanboardAPI(ctx, "removeCategory", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete category: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getOldSwimlanesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId := request.GetString("project_id", "") // Swimlanes can be global or project-specific
-
-	var result interface{}
-	var err error
-	if projectId != "" {
-		params := map[string]string{"project_id": projectId}
-		result, err = kc.callKanboardAPI(ctx, "getAllSwimlanes", params)
-	} else {
-		result, err = kc.callKanboardAPI(ctx, "getAllSwimlanes", nil)
-	}
-
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get swimlanes: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getBoardHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := []int{projectId}
-	result, err := kc.callKanboardAPI(ctx, "getBoard", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get board details: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) assignTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": taskId, "owner_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "updateTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to assign task: %v", err)), nil
-	}
-
-	// Check if the result is false (Kanboard API returns false when update fails)
-	if resultBool, ok := result.(bool); ok && !resultBool {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to assign task: updateTask returned false. The user (ID: %d) may not be assigned to the project, or you may not have permission to assign tasks.", userId)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) setTaskDueDateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	dueDate, err := request.RequireString("due_date")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"task_id": taskId, "date_due": dueDate}
-	result, err := kc.callKanboardAPI(ctx, "updateTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to set task due date: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createCommentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Get task information to determine project ID
-	taskInfo, err := kc.callKanboardAPI(ctx, "getTask", map[string]int{"task_id": taskId})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get task info: %v", err)), nil
-	}
-
-	taskMap, ok := taskInfo.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid task info format"), nil
-	}
-
-	projectIdFloat, ok := taskMap["project_id"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("Invalid project_id in task info"), nil
-	}
-	projectId := int(projectIdFloat)
-
-	// Check permission for creating comments (project-level)
-	if err := kc.checkPermission(ctx, &projectId, "commentprocedure", "createcomment"); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	content, err := request.RequireString("content")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"task_id": taskId,
-		"user_id": userId,
-		"content": content,
-	}
-
-	reference := request.GetString("reference", "")
-	if reference != "" {
-		params["reference"] = reference
-	}
-
-	visibility := request.GetString("visibility", "")
-	if visibility != "" {
-		// Validate visibility
-		validVisibilities := []string{"app-user", "app-manager", "app-admin"}
-		visibilityValid := false
-		for _, validVisibility := range validVisibilities {
-			if visibility == validVisibility {
-				visibilityValid = true
-				break
-			}
-		}
-		if !visibilityValid {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid visibility '%s'. Valid visibilities are: %v", visibility, validVisibilities)), nil
-		}
-		params["visibility"] = visibility
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createComment", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create comment: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTaskCommentsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "getAllComments", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get task comments: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getCommentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	commentId, err := request.RequireInt("comment_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"comment_id": commentId}
-	result, err := kc.callKanboardAPI(ctx, "getComment", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get comment details: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateCommentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	id, err := request.RequireInt("id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	content, err := request.RequireString("content")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": id, "content": content}
-	result, err := kc.callKanboardAPI(ctx, "updateComment", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update comment: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeCommentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	commentId, err := request.RequireInt("comment_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"comment_id": commentId}
-	result, err := kc.callKanboardAPI(ctx, "removeComment", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove comment: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) assignUserToProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectIdStr, err := request.RequireString("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	projectId, err := strconv.Atoi(projectIdStr)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid project_id: %v", err)), nil
-	}
-
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	role := request.GetString("role", "project-member") // Default to project-member
-
-	// Validate role
-	validRoles := []string{"project-member", "project-manager", "project-viewer"}
-	roleValid := false
-	for _, validRole := range validRoles {
-		if role == validRole {
-			roleValid = true
-			break
-		}
-	}
-	if !roleValid {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid role '%s'. Valid roles are: %v", role, validRoles)), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectId,
-		"user_id":    userId,
-		"role":       role,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "addProjectUser", params)
-	if err != nil {
-		// Provide more helpful error message for 403 errors
-		if err.Error() == "API request failed with status code: 403, Response: 403 Forbidden" {
-			return mcp.NewToolResultError(fmt.Sprintf("Permission denied (403 Forbidden). The API user does not have sufficient privileges to assign users to projects. Please ensure the API user has 'app-admin' role in Kanboard. Project ID: %d, User ID: %d, Role: %s", projectId, userId, role)), nil
-		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to assign user to project: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createGroupHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"name": name}
-	externalId := request.GetString("external_id", "")
-	if externalId != "" {
-		params["external_id"] = externalId
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createGroup", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateGroupHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": groupId}
-	name := request.GetString("name", "")
-	if name != "" {
-		params["name"] = name
-	}
-	externalId := request.GetString("external_id", "")
-	if externalId != "" {
-		params["external_id"] = externalId
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateGroup", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeGroupHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"group_id": groupId}
-	result, err := kc.callKanboardAPI(ctx, "removeGroup", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getGroupHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"group_id": groupId}
-	result, err := kc.callKanboardAPI(ctx, "getGroup", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllGroupsHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getAllGroups", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getMemberGroupsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "getMemberGroups", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getGroupMembersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"group_id": groupId}
-	result, err := kc.callKanboardAPI(ctx, "getGroupMembers", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) addGroupMemberHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"group_id": groupId, "user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "addGroupMember", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeGroupMemberHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"group_id": groupId, "user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "removeGroupMember", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) isGroupMemberHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"group_id": groupId, "user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "isGroupMember", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createTaskLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	oppositeTaskId, err := request.RequireInt("opposite_task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{
-		"task_id":          taskId,
-		"opposite_task_id": oppositeTaskId,
-		"link_id":          linkId,
-	}
-	result, err := kc.callKanboardAPI(ctx, "createTaskLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateTaskLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskLinkId, err := request.RequireInt("task_link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	oppositeTaskId, err := request.RequireInt("opposite_task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{
-		"id":               taskLinkId,
-		"task_id":          taskId,
-		"opposite_task_id": oppositeTaskId,
-		"link_id":          linkId,
-	}
-	result, err := kc.callKanboardAPI(ctx, "updateTaskLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTaskLinkByIdHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskLinkId, err := request.RequireInt("task_link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_link_id": taskLinkId}
-	result, err := kc.callKanboardAPI(ctx, "getTaskLinkById", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllTaskLinksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "getAllTaskLinks", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeTaskLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskLinkId, err := request.RequireInt("task_link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"task_link_id": taskLinkId}
-	result, err := kc.callKanboardAPI(ctx, "removeTaskLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllLinksHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getAllLinks", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getOppositeLinkIdHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"link_id": linkId}
-	result, err := kc.callKanboardAPI(ctx, "getOppositeLinkId", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getLinkByLabelHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	label, err := request.RequireString("label")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"label": label}
-	result, err := kc.callKanboardAPI(ctx, "getLinkByLabel", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getLinkByIdHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"link_id": linkId}
-	result, err := kc.callKanboardAPI(ctx, "getLinkById", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	label, err := request.RequireString("label")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	oppositeLabel := request.GetString("opposite_label", "")
-
-	params := map[string]interface{}{
-		"label":          label,
-		"opposite_label": oppositeLabel,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	oppositeLinkId, err := request.RequireInt("opposite_link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	label, err := request.RequireString("label")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"id":               linkId,
-		"opposite_link_id": oppositeLinkId,
-		"label":            label,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	linkId, err := request.RequireInt("link_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"link_id": linkId}
-	result, err := kc.callKanboardAPI(ctx, "removeLink", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectByIdHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getProjectById", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectByNameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"name": name}
-	result, err := kc.callKanboardAPI(ctx, "getProjectByName", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project by name: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectByIdentifierHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	identifier, err := request.RequireString("identifier")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"identifier": identifier}
-	result, err := kc.callKanboardAPI(ctx, "getProjectByIdentifier", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project by identifier: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectByEmailHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	email, err := request.RequireString("email")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"email": email}
-	result, err := kc.callKanboardAPI(ctx, "getProjectByEmail", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project by email: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllProjectsHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getAllProjects", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"id": projectId}
-
-	name := request.GetString("name", "")
-	if name != "" {
-		params["name"] = name
-	}
-
-	description := request.GetString("description", "")
-	if description != "" {
-		params["description"] = description
-	}
-
-	ownerId := request.GetInt("owner_id", 0)
-	if ownerId != 0 {
-		params["owner_id"] = ownerId
-	}
-
-	identifier := request.GetString("identifier", "")
-	if identifier != "" {
-		params["identifier"] = identifier
-	}
-
-	startDate := request.GetString("start_date", "")
-	if startDate != "" {
-		params["start_date"] = startDate
-	}
-
-	endDate := request.GetString("end_date", "")
-	if endDate != "" {
-		params["end_date"] = endDate
-	}
-
-	priorityDefault := request.GetInt("priority_default", 0)
-	if priorityDefault != 0 {
-		params["priority_default"] = priorityDefault
-	}
-
-	priorityStart := request.GetInt("priority_start", 0)
-	if priorityStart != 0 {
-		params["priority_start"] = priorityStart
-	}
-
-	priorityEnd := request.GetInt("priority_end", 0)
-	if priorityEnd != 0 {
-		params["priority_end"] = priorityEnd
-	}
-
-	email := request.GetString("email", "")
-	if email != "" {
-		params["email"] = email
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update project: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "removeProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove project: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) enableProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "enableProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to enable project: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) disableProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "disableProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to disable project: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) enableProjectPublicAccessHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "enableProjectPublicAccess", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to enable project public access: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) disableProjectPublicAccessHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "disableProjectPublicAccess", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to disable project public access: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectActivityHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getProjectActivity", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project activity: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectActivitiesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectIds, err := request.RequireIntSlice("project_ids")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_ids": projectIds}
-	result, err := kc.callKanboardAPI(ctx, "getProjectActivities", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project activities: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-// readFileAsBase64 reads a file from the given path and returns its base64-encoded content.
-// If the path is not absolute, it will be resolved relative to the current working directory.
-func readFileAsBase64(filePath string) (string, error) {
-	// If path is not absolute, concatenate with current working directory
-	if !filepath.IsAbs(filePath) {
-		wd, err := os.Getwd()
-		if err != nil {
-			return "", fmt.Errorf("failed to get current working directory: %v", err)
-		}
-		filePath = filepath.Join(wd, filePath)
-	}
-
-	// Read the file
-	fileData, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file %s: %v", filePath, err)
-	}
-
-	// Encode to base64
-	encoded := base64.StdEncoding.EncodeToString(fileData)
-	return encoded, nil
-}
-
-// saveBase64ToFile saves base64-encoded content to a file at the specified path.
-// savePath can be:
-//   - "temp" or starts with "temp" -> saves to OS temp directory
-//   - Empty string -> saves to current directory with filename
-//   - Absolute path with filename -> uses as-is
-//   - Directory path -> concatenates with filename
-//   - Relative path -> resolves from current directory
-func saveBase64ToFile(base64Content, savePath, filename string) (string, error) {
-	// Decode base64 content
-	fileData, err := base64.StdEncoding.DecodeString(base64Content)
-	if err != nil {
-		return "", fmt.Errorf("failed to decode base64 content: %v", err)
-	}
-
-	var finalPath string
-
-	// Handle temp directory
-	savePathLower := strings.ToLower(strings.TrimSpace(savePath))
-	if savePathLower == "temp" || savePathLower == "tmp" {
-		tempDir := os.TempDir()
-		finalPath = filepath.Join(tempDir, filename)
-	} else if strings.HasPrefix(savePathLower, "temp/") || strings.HasPrefix(savePathLower, "tmp/") {
-		tempDir := os.TempDir()
-		// Extract subdirectory path after temp/ or tmp/
-		subPath := strings.TrimPrefix(savePathLower, "temp/")
-		subPath = strings.TrimPrefix(subPath, "tmp/")
-		// If subPath looks like a filename (has extension), use it; otherwise append filename
-		if filepath.Ext(subPath) != "" && !strings.Contains(subPath, string(filepath.Separator)) {
-			// It's a filename in temp dir
-			finalPath = filepath.Join(tempDir, subPath)
-		} else {
-			// It's a subdirectory, append filename
-			finalPath = filepath.Join(tempDir, subPath, filename)
-		}
-	} else if savePath == "" {
-		// No path specified, save to current directory
-		wd, err := os.Getwd()
-		if err != nil {
-			return "", fmt.Errorf("failed to get current working directory: %v", err)
-		}
-		finalPath = filepath.Join(wd, filename)
-	} else {
-		// Check if savePath is a directory or a full path
-		info, err := os.Stat(savePath)
-		if err == nil && info.IsDir() {
-			// It's a directory, concatenate with filename
-			finalPath = filepath.Join(savePath, filename)
-		} else if filepath.IsAbs(savePath) {
-			// It's an absolute path, use as-is
-			finalPath = savePath
-		} else {
-			// Relative path, resolve from current directory
-			wd, err := os.Getwd()
-			if err != nil {
-				return "", fmt.Errorf("failed to get current working directory: %v", err)
-			}
-			finalPath = filepath.Join(wd, savePath)
-		}
-	}
-
-	// Ensure directory exists
-	dir := filepath.Dir(finalPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create directory %s: %v", dir, err)
-	}
-
-	// Write file
-	if err := os.WriteFile(finalPath, fileData, 0644); err != nil {
-		return "", fmt.Errorf("failed to write file %s: %v", finalPath, err)
-	}
-
-	return finalPath, nil
-}
-
-func (kc *kanboardClient) createProjectFileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	filename, err := request.RequireString("filename")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Get blob, but make it optional
-	blob := request.GetString("blob", "")
-
-	// If blob is missing/empty, treat filename as a file path
-	if blob == "" {
-		var err error
-		blob, err = readFileAsBase64(filename)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to read file: %v", err)), nil
-		}
-		// Extract just the filename from the path for the API
-		filename = filepath.Base(filename)
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectId,
-		"filename":   filename,
-		"blob":       blob,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createProjectFile", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllProjectFilesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getAllProjectFiles", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectFileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	fileId, err := request.RequireInt("file_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId, "file_id": fileId}
-	result, err := kc.callKanboardAPI(ctx, "getProjectFile", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) downloadProjectFileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	fileId, err := request.RequireInt("file_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	savePath := request.GetString("save_path", "")
-
-	// Get file info first to extract filename
-	fileInfoParams := map[string]int{"project_id": projectId, "file_id": fileId}
-	fileInfo, err := kc.callKanboardAPI(ctx, "getProjectFile", fileInfoParams)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Extract filename from file info
-	var filename string
-	if fileInfoMap, ok := fileInfo.(map[string]interface{}); ok {
-		if name, exists := fileInfoMap["name"]; exists {
-			filename = fmt.Sprintf("%v", name)
-		} else {
-			filename = fmt.Sprintf("file_%d", fileId)
-		}
-	} else {
-		filename = fmt.Sprintf("file_%d", fileId)
-	}
-
-	// Download file content
-	params := map[string]int{"project_id": projectId, "file_id": fileId}
-	result, err := kc.callKanboardAPI(ctx, "downloadProjectFile", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Extract base64 content from result
-	var base64Content string
-	if resultMap, ok := result.(map[string]interface{}); ok {
-		if data, exists := resultMap["data"]; exists {
-			base64Content = fmt.Sprintf("%v", data)
-		} else {
-			// If result is a map but no "data" key, try to use the whole result as string
-			resultBytes, _ := json.Marshal(result)
-			base64Content = string(resultBytes)
-		}
-	} else if resultStr, ok := result.(string); ok {
-		base64Content = resultStr
-	} else {
-		return mcp.NewToolResultError(fmt.Sprintf("unexpected result type: %T", result)), nil
-	}
-
-	// If save_path is provided, decode base64 and save to file
-	if savePath != "" {
-		savedPath, err := saveBase64ToFile(base64Content, savePath, filename)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to save file: %v", err)), nil
-		}
-		return mcp.NewToolResultText(fmt.Sprintf("File saved to: %s", savedPath)), nil
-	}
-
-	// If save_path is not provided, decode base64 to string and return text content
-	decodedContent, err := base64.StdEncoding.DecodeString(base64Content)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to decode base64 content: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(decodedContent)), nil
-}
-
-func (kc *kanboardClient) removeProjectFileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	fileId, err := request.RequireInt("file_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId, "file_id": fileId}
-	result, err := kc.callKanboardAPI(ctx, "removeProjectFile", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeAllProjectFilesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "removeAllProjectFiles", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectMetadataHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getProjectMetadata", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectMetadataByNameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "name": name}
-	result, err := kc.callKanboardAPI(ctx, "getProjectMetadataByName", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) saveProjectMetadataHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	args := request.GetArguments()
-	values, ok := args["values"].(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Missing or invalid 'values' parameter"), nil
-	}
-
-	params := map[string]interface{}{"project_id": projectId, "values": values}
-	result, err := kc.callKanboardAPI(ctx, "saveProjectMetadata", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeProjectMetadataHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "name": name}
-	result, err := kc.callKanboardAPI(ctx, "removeProjectMetadata", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectUsersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getProjectUsers", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAssignableUsersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	prependUnassigned := request.GetBool("prepend_unassigned", false)
-
-	params := map[string]interface{}{"project_id": projectId, "prepend_unassigned": prependUnassigned}
-	result, err := kc.callKanboardAPI(ctx, "getAssignableUsers", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) addProjectUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	role := request.GetString("role", "")
-	params := map[string]interface{}{"project_id": projectId, "user_id": userId}
-	if role != "" {
-		params["role"] = role
-	}
-	result, err := kc.callKanboardAPI(ctx, "addProjectUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) addProjectGroupHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	role := request.GetString("role", "")
-	params := map[string]interface{}{"project_id": projectId, "group_id": groupId}
-	if role != "" {
-		params["role"] = role
-	}
-	result, err := kc.callKanboardAPI(ctx, "addProjectGroup", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeProjectUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "removeProjectUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeProjectGroupHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "group_id": groupId}
-	result, err := kc.callKanboardAPI(ctx, "removeProjectGroup", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) changeProjectUserRoleHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	role, err := request.RequireString("role")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "user_id": userId, "role": role}
-	result, err := kc.callKanboardAPI(ctx, "changeProjectUserRole", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) changeProjectGroupRoleHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	groupId, err := request.RequireInt("group_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	role, err := request.RequireString("role")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "group_id": groupId, "role": role}
-	result, err := kc.callKanboardAPI(ctx, "changeProjectGroupRole", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectUserRoleHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "getProjectUserRole", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-// Subtask Management
-func (kc *kanboardClient) createSubtaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	title, err := request.RequireString("title")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{"task_id": taskId, "title": title}
-
-	userId := request.GetInt("user_id", 0)
-	if userId != 0 {
-		params["user_id"] = userId
-	}
-	timeEstimated := request.GetInt("time_estimated", 0)
-	if timeEstimated != 0 {
-		params["time_estimated"] = timeEstimated
-	}
-	timeSpent := request.GetInt("time_spent", 0)
-	if timeSpent != 0 {
-		params["time_spent"] = timeSpent
-	}
-	status := request.GetInt("status", 0)
-	if status != 0 {
-		params["status"] = status
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "createSubtask", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getSubtaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	subtaskId, err := request.RequireInt("subtask_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"subtask_id": subtaskId}
-	result, err := kc.callKanboardAPI(ctx, "getSubtask", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllSubtasksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "getAllSubtasks", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateSubtaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	id, err := request.RequireInt("id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"id": id, "task_id": taskId}
-
-	title := request.GetString("title", "")
-	if title != "" {
-		params["title"] = title
-	}
-	userId := request.GetInt("user_id", 0)
-	if userId != 0 {
-		params["user_id"] = userId
-	}
-	timeEstimated := request.GetInt("time_estimated", 0)
-	if timeEstimated != 0 {
-		params["time_estimated"] = timeEstimated
-	}
-	timeSpent := request.GetInt("time_spent", 0)
-	if timeSpent != 0 {
-		params["time_spent"] = timeSpent
-	}
-	status := request.GetInt("status", 0)
-	if status != 0 {
-		params["status"] = status
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "updateSubtask", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeSubtaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	subtaskId, err := request.RequireInt("subtask_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"subtask_id": subtaskId}
-	result, err := kc.callKanboardAPI(ctx, "removeSubtask", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) hasSubtaskTimerHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	subtaskId, err := request.RequireInt("subtask_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"subtask_id": subtaskId}
-	userId := request.GetInt("user_id", 0)
-	if userId != 0 {
-		params["user_id"] = userId
-	}
-	result, err := kc.callKanboardAPI(ctx, "hasSubtaskTimer", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) setSubtaskStartTimeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	subtaskId, err := request.RequireInt("subtask_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"subtask_id": subtaskId}
-	userId := request.GetInt("user_id", 0)
-	if userId != 0 {
-		params["user_id"] = userId
-	}
-	result, err := kc.callKanboardAPI(ctx, "setSubtaskStartTime", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) setSubtaskEndTimeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	subtaskId, err := request.RequireInt("subtask_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"subtask_id": subtaskId}
-	userId := request.GetInt("user_id", 0)
-	if userId != 0 {
-		params["user_id"] = userId
-	}
-	result, err := kc.callKanboardAPI(ctx, "setSubtaskEndTime", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getSubtaskTimeSpentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	subtaskId, err := request.RequireInt("subtask_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"subtask_id": subtaskId}
-	userId := request.GetInt("user_id", 0)
-	if userId != 0 {
-		params["user_id"] = userId
-	}
-	result, err := kc.callKanboardAPI(ctx, "getSubtaskTimeSpent", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllTagsHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getAllTags", nil)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTagsByProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getTagsByProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createTagHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	tag, err := request.RequireString("tag")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "tag": tag}
-	colorId := request.GetInt("color_id", 0)
-	if colorId != 0 {
-		params["color_id"] = colorId
-	}
-	result, err := kc.callKanboardAPI(ctx, "createTag", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateTagHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tagId, err := request.RequireInt("tag_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	tag, err := request.RequireString("tag")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"id": tagId, "name": tag}
-	colorId := request.GetInt("color_id", 0)
-	if colorId != 0 {
-		params["color_id"] = colorId
-	}
-	result, err := kc.callKanboardAPI(ctx, "updateTag", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeTagHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tagId, err := request.RequireInt("tag_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"id": tagId}
-	result, err := kc.callKanboardAPI(ctx, "removeTag", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) setTaskTagsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	tags, err := request.RequireStringSlice("tags")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "task_id": taskId, "tags": tags}
-	result, err := kc.callKanboardAPI(ctx, "setTaskTags", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTaskTagsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"task_id": taskId}
-	result, err := kc.callKanboardAPI(ctx, "getTaskTags", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createTaskFileHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectID := request.GetInt("project_id", 0)
-	if projectID == 0 {
-		return mcp.NewToolResultError("project_id is required"), nil
-	}
-	taskID := request.GetInt("task_id", 0)
-	if taskID == 0 {
-		return mcp.NewToolResultError("task_id is required"), nil
-	}
-	filename := request.GetString("filename", "")
-	if filename == "" {
-		return mcp.NewToolResultError("filename is required"), nil
-	}
-
-	// Get blob, but make it optional
-	blob := request.GetString("blob", "")
-
-	// If blob is missing/empty, treat filename as a file path
-	if blob == "" {
-		var err error
-		blob, err = readFileAsBase64(filename)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to read file: %v", err)), nil
-		}
-		// Extract just the filename from the path for the API
-		filename = filepath.Base(filename)
-	}
-
-	result, err := kc.CreateTaskFile(
-		projectID,
-		taskID,
-		filename,
-		blob,
-	)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllTaskFilesHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskID := request.GetInt("task_id", 0)
-	if taskID == 0 {
-		return mcp.NewToolResultError("task_id is required"), nil
-	}
-
-	result, err := kc.GetAllTaskFiles(taskID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTaskFileHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	fileID := request.GetInt("file_id", 0)
-	if fileID == 0 {
-		return mcp.NewToolResultError("file_id is required"), nil
-	}
-
-	result, err := kc.GetTaskFile(fileID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) downloadTaskFileHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	fileID := request.GetInt("file_id", 0)
-	if fileID == 0 {
-		return mcp.NewToolResultError("file_id is required"), nil
-	}
-	savePath := request.GetString("save_path", "")
-
-	// Get file info first to extract filename
-	fileInfo, err := kc.GetTaskFile(fileID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Extract filename from file info
-	var filename string
-	if fileInfoMap, ok := fileInfo.(map[string]interface{}); ok {
-		if name, exists := fileInfoMap["name"]; exists {
-			filename = fmt.Sprintf("%v", name)
-		} else {
-			filename = fmt.Sprintf("file_%d", fileID)
-		}
-	} else {
-		filename = fmt.Sprintf("file_%d", fileID)
-	}
-
-	// Download file content
-	base64Content, err := kc.DownloadTaskFile(fileID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// If save_path is provided, decode base64 and save to file
-	if savePath != "" {
-		savedPath, err := saveBase64ToFile(base64Content, savePath, filename)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to save file: %v", err)), nil
-		}
-		return mcp.NewToolResultText(fmt.Sprintf("File saved to: %s", savedPath)), nil
-	}
-
-	// If save_path is not provided, decode base64 to string and return text content
-	decodedContent, err := base64.StdEncoding.DecodeString(base64Content)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to decode base64 content: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(decodedContent)), nil
-}
-
-func (kc *kanboardClient) removeTaskFileHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	fileID := request.GetInt("file_id", 0)
-	if fileID == 0 {
-		return mcp.NewToolResultError("file_id is required"), nil
-	}
-
-	result, err := kc.RemoveTaskFile(fileID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeAllTaskFilesHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskID := request.GetInt("task_id", 0)
-	if taskID == 0 {
-		return mcp.NewToolResultError("task_id is required"), nil
-	}
-
-	result, err := kc.RemoveAllTaskFiles(taskID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) CreateTaskFile(projectID, taskID int, filename, blob string) (int, error) {
-	params := []interface{}{projectID, taskID, filename, blob}
-	result, err := kc.callKanboardAPI(context.Background(), "createTaskFile", params)
-	if err != nil {
-		return 0, err
-	}
-
-	// Kanboard API returns 1 on success for some operations like creating a file
-	if fileID, ok := result.(float64); ok {
-		return int(fileID), nil
-	}
-	return 0, fmt.Errorf("unexpected result type for CreateTaskFile: %T", result)
-}
-
-func (kc *kanboardClient) GetAllTaskFiles(taskID int) ([]interface{}, error) {
-	params := map[string]interface{}{"task_id": taskID}
-	result, err := kc.callKanboardAPI(context.Background(), "getAllTaskFiles", params)
-	if err != nil {
-		return nil, err
-	}
-
-	if files, ok := result.([]interface{}); ok {
-		return files, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetAllTaskFiles: %T", result)
-}
-
-func (kc *kanboardClient) GetTaskFile(fileID int) (interface{}, error) {
-	params := []interface{}{fileID}
-	result, err := kc.callKanboardAPI(context.Background(), "getTaskFile", params)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (kc *kanboardClient) DownloadTaskFile(fileID int) (string, error) {
-	params := []interface{}{fileID}
-	result, err := kc.callKanboardAPI(context.Background(), "downloadTaskFile", params)
-	if err != nil {
-		return "", err
-	}
-
-	if content, ok := result.(string); ok {
-		return content, nil
-	}
-	return "", fmt.Errorf("unexpected result type for DownloadTaskFile: %T", result)
-}
-
-func (kc *kanboardClient) RemoveTaskFile(fileID int) (bool, error) {
-	params := []interface{}{fileID}
-	result, err := kc.callKanboardAPI(context.Background(), "removeTaskFile", params)
-	if err != nil {
-		return false, err
-	}
-
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for RemoveTaskFile: %T", result)
-}
-
-func (kc *kanboardClient) RemoveAllTaskFiles(taskID int) (bool, error) {
-	params := map[string]interface{}{"task_id": taskID}
-	result, err := kc.callKanboardAPI(context.Background(), "removeAllTaskFiles", params)
-	if err != nil {
-		return false, err
-	}
-
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for RemoveAllTaskFiles: %T", result)
-}
-
-func (kc *kanboardClient) GetVersion() (string, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getVersion", nil)
-	if err != nil {
-		return "", err
-	}
-
-	if version, ok := result.(string); ok {
-		return version, nil
-	}
-	return "", fmt.Errorf("unexpected result type for GetVersion: %T", result)
-}
-
-func (kc *kanboardClient) GetTimezone() (string, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getTimezone", nil)
-	if err != nil {
-		return "", err
-	}
-
-	if timezone, ok := result.(string); ok {
-		return timezone, nil
-	}
-	return "", fmt.Errorf("unexpected result type for GetTimezone: %T", result)
-}
-
-func (kc *kanboardClient) GetDefaultTaskColors() (map[string]interface{}, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getDefaultTaskColors", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if colors, ok := result.(map[string]interface{}); ok {
-		return colors, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetDefaultTaskColors: %T", result)
-}
-
-func (kc *kanboardClient) GetDefaultTaskColor() (string, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getDefaultTaskColor", nil)
-	if err != nil {
-		return "", err
-	}
-
-	if colorID, ok := result.(string); ok {
-		return colorID, nil
-	}
-	return "", fmt.Errorf("unexpected result type for GetDefaultTaskColor: %T", result)
-}
-
-func (kc *kanboardClient) GetColorList() (map[string]interface{}, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getColorList", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if colorList, ok := result.(map[string]interface{}); ok {
-		return colorList, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetColorList: %T", result)
-}
-
-func (kc *kanboardClient) GetApplicationRoles() (map[string]interface{}, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getApplicationRoles", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if roles, ok := result.(map[string]interface{}); ok {
-		return roles, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetApplicationRoles: %T", result)
-}
-
-func (kc *kanboardClient) GetProjectRoles() (map[string]interface{}, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getProjectRoles", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if roles, ok := result.(map[string]interface{}); ok {
-		return roles, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetProjectRoles: %T", result)
-}
-
-func (kc *kanboardClient) getVersionHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetVersion()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(result), nil
-}
-
-func (kc *kanboardClient) getTimezoneHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetTimezone()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(result), nil
-}
-
-func (kc *kanboardClient) getDefaultTaskColorsHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetDefaultTaskColors()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getDefaultTaskColorHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetDefaultTaskColor()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(result), nil
-}
-
-func (kc *kanboardClient) getColorListHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetColorList()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getApplicationRolesHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetApplicationRoles()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getProjectRolesHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetProjectRoles()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) GetAvailableActions() (map[string]interface{}, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getAvailableActions", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if actions, ok := result.(map[string]interface{}); ok {
-		return actions, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetAvailableActions: %T", result)
-}
-
-func (kc *kanboardClient) GetAvailableActionEvents() (map[string]interface{}, error) {
-	result, err := kc.callKanboardAPI(context.Background(), "getAvailableActionEvents", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if events, ok := result.(map[string]interface{}); ok {
-		return events, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetAvailableActionEvents: %T", result)
-}
-
-func (kc *kanboardClient) GetCompatibleActionEvents(actionName string) (map[string]interface{}, error) {
-	params := []interface{}{actionName}
-	result, err := kc.callKanboardAPI(context.Background(), "getCompatibleActionEvents", params)
-	if err != nil {
-		return nil, err
-	}
-
-	if events, ok := result.(map[string]interface{}); ok {
-		return events, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetCompatibleActionEvents: %T", result)
-}
-
-func (kc *kanboardClient) GetActions(projectID int) ([]interface{}, error) {
-	params := []interface{}{projectID}
-	result, err := kc.callKanboardAPI(context.Background(), "getActions", params)
-	if err != nil {
-		return nil, err
-	}
-
-	if actions, ok := result.([]interface{}); ok {
-		return actions, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetActions: %T", result)
-}
-
-func (kc *kanboardClient) CreateAction(projectID int, eventName, actionName string, params map[string]interface{}) (int, error) {
-	realParams := map[string]interface{}{
-		"project_id":  projectID,
-		"event_name":  eventName,
-		"action_name": actionName,
-		"params":      params,
-	}
-	result, err := kc.callKanboardAPI(context.Background(), "createAction", realParams)
-	if err != nil {
-		return 0, err
-	}
-
-	if actionID, ok := result.(float64); ok {
-		return int(actionID), nil
-	}
-	return 0, fmt.Errorf("unexpected result type for CreateAction: %T", result)
-}
-
-func (kc *kanboardClient) RemoveAction(actionID int) (bool, error) {
-	params := []interface{}{actionID}
-	result, err := kc.callKanboardAPI(context.Background(), "removeAction", params)
-	if err != nil {
-		return false, err
-	}
-
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for RemoveAction: %T", result)
-}
-
-func (kc *kanboardClient) getAvailableActionsHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetAvailableActions()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAvailableActionEventsHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.GetAvailableActionEvents()
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getCompatibleActionEventsHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	actionName := request.GetString("action_name", "")
-	if actionName == "" {
-		return mcp.NewToolResultError("action_name is required"), nil
-	}
-	result, err := kc.GetCompatibleActionEvents(actionName)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getActionsHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectID := request.GetInt("project_id", 0)
-	if projectID == 0 {
-		return mcp.NewToolResultError("project_id is required"), nil
-	}
-	result, err := kc.GetActions(projectID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createActionHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectID := request.GetInt("project_id", 0)
-	if projectID == 0 {
-		return mcp.NewToolResultError("project_id is required"), nil
-	}
-	eventName := request.GetString("event_name", "")
-	if eventName == "" {
-		return mcp.NewToolResultError("event_name is required"), nil
-	}
-	actionName := request.GetString("action_name", "")
-	if actionName == "" {
-		return mcp.NewToolResultError("action_name is required"), nil
-	}
-
-	// Retrieve all arguments and then extract 'params'
-	allArgs := request.GetArguments()
-	params, ok := allArgs["params"].(map[string]interface{})
-	if !ok {
-		// If 'params' is not provided or not a map, return an error or an empty map
-		return mcp.NewToolResultError("params must be a map or omitted"), nil
-	}
-
-	actionID, err := kc.CreateAction(projectID, eventName, actionName, params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.Itoa(actionID)), nil
-}
-
-func (kc *kanboardClient) removeActionHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	actionID := request.GetInt("action_id", 0)
-	if actionID == 0 {
-		return mcp.NewToolResultError("action_id is required"), nil
-	}
-	result, err := kc.RemoveAction(actionID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) GetActiveSwimlanes(projectID int) ([]interface{}, error) {
-	params := []interface{}{projectID}
-	result, err := kc.callKanboardAPI(context.Background(), "getActiveSwimlanes", params)
-	if err != nil {
-		return nil, err
-	}
-
-	if swimlanes, ok := result.([]interface{}); ok {
-		return swimlanes, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetActiveSwimlanes: %T", result)
-}
-
-func (kc *kanboardClient) GetAllSwimlanes(projectID int) ([]interface{}, error) {
-	params := []interface{}{projectID}
-	result, err := kc.callKanboardAPI(context.Background(), "getAllSwimlanes", params)
-	if err != nil {
-		return nil, err
-	}
-
-	if swimlanes, ok := result.([]interface{}); ok {
-		return swimlanes, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetAllSwimlanes: %T", result)
-}
-
-func (kc *kanboardClient) GetSwimlaneById(swimlaneID int) (interface{}, error) {
-	params := []interface{}{swimlaneID}
-	result, err := kc.callKanboardAPI(context.Background(), "getSwimlaneById", params)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (kc *kanboardClient) GetSwimlaneByName(projectID int, name string) (interface{}, error) {
-	params := []interface{}{projectID, name}
-	result, err := kc.callKanboardAPI(context.Background(), "getSwimlaneByName", params)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (kc *kanboardClient) ChangeSwimlanePosition(projectID, swimlaneID, position int) (bool, error) {
-	params := []interface{}{projectID, swimlaneID, position}
-	result, err := kc.callKanboardAPI(context.Background(), "changeSwimlanePosition", params)
-	if err != nil {
-		return false, err
-	}
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for ChangeSwimlanePosition: %T", result)
-}
-
-func (kc *kanboardClient) UpdateSwimlane(projectID, swimlaneID int, name, description string) (bool, error) {
-	params := map[string]interface{}{
-		"project_id": projectID,
-		"id":         swimlaneID,
-		"name":       name,
-	}
-	if description != "" {
-		params["description"] = description
-	}
-	result, err := kc.callKanboardAPI(context.Background(), "updateSwimlane", params)
-	if err != nil {
-		return false, err
-	}
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for UpdateSwimlane: %T", result)
-}
-
-func (kc *kanboardClient) AddSwimlane(projectID int, name, description string) (int, error) {
-	params := map[string]interface{}{
-		"project_id": projectID,
-		"name":       name,
-	}
-	if description != "" {
-		params["description"] = description
-	}
-	result, err := kc.callKanboardAPI(context.Background(), "addSwimlane", params)
-	if err != nil {
-		return 0, err
-	}
-	if swimlaneID, ok := result.(float64); ok {
-		return int(swimlaneID), nil
-	}
-	return 0, fmt.Errorf("unexpected result type for AddSwimlane: %T", result)
-}
-
-func (kc *kanboardClient) RemoveSwimlane(projectID, swimlaneID int) (bool, error) {
-	params := []interface{}{projectID, swimlaneID}
-	result, err := kc.callKanboardAPI(context.Background(), "removeSwimlane", params)
-	if err != nil {
-		return false, err
-	}
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for RemoveSwimlane: %T", result)
-}
-
-func (kc *kanboardClient) DisableSwimlane(projectID, swimlaneID int) (bool, error) {
-	params := []interface{}{projectID, swimlaneID}
-	result, err := kc.callKanboardAPI(context.Background(), "disableSwimlane", params)
-	if err != nil {
-		return false, err
-	}
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for DisableSwimlane: %T", result)
-}
-
-func (kc *kanboardClient) EnableSwimlane(projectID, swimlaneID int) (bool, error) {
-	params := []interface{}{projectID, swimlaneID}
-	result, err := kc.callKanboardAPI(context.Background(), "enableSwimlane", params)
-	if err != nil {
-		return false, err
-	}
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for EnableSwimlane: %T", result)
-}
-
-func (kc *kanboardClient) GetSwimlane(swimlaneID int) (interface{}, error) {
-	params := []interface{}{swimlaneID}
-	result, err := kc.callKanboardAPI(context.Background(), "getSwimlane", params)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (kc *kanboardClient) getSwimlaneHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetSwimlane(swimlaneId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getSwimlaneByIdHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetSwimlaneById(swimlaneId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getActiveSwimlanesHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetActiveSwimlanes(projectId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllSwimlanesHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetAllSwimlanes(projectId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getSwimlaneByNameHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetSwimlaneByName(projectId, name)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) changeSwimlanePositionHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	position, err := request.RequireInt("position")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.ChangeSwimlanePosition(projectId, swimlaneId, position)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) addSwimlaneHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	description := request.GetString("description", "")
-	result, err := kc.AddSwimlane(projectId, name, description)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.Itoa(result)), nil
-}
-
-func (kc *kanboardClient) updateSwimlaneHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name := request.GetString("name", "")
-	description := request.GetString("description", "")
-	result, err := kc.UpdateSwimlane(projectId, swimlaneId, name, description)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) removeSwimlaneHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.RemoveSwimlane(projectId, swimlaneId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) disableSwimlaneHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.DisableSwimlane(projectId, swimlaneId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) enableSwimlaneHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId, err := request.RequireInt("swimlane_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.EnableSwimlane(projectId, swimlaneId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-// GetTaskMetadata Task Metadata API Procedures
-func (kc *kanboardClient) GetTaskMetadata(taskID int) (map[string]interface{}, error) {
-	params := []interface{}{taskID}
-	result, err := kc.callKanboardAPI(context.Background(), "getTaskMetadata", params)
-	if err != nil {
-		return nil, err
-	}
-
-	if metadata, ok := result.(map[string]interface{}); ok {
-		return metadata, nil
-	}
-
-	if result == nil {
-		return map[string]interface{}{}, nil
-	}
-	return nil, fmt.Errorf("unexpected result type for GetTaskMetadata: %T", result)
-}
-
-func (kc *kanboardClient) GetTaskMetadataByName(taskID int, name string) (string, error) {
-	params := []interface{}{taskID, name}
-	result, err := kc.callKanboardAPI(context.Background(), "getTaskMetadataByName", params)
-	if err != nil {
-		return "", err
-	}
-
-	if value, ok := result.(string); ok {
-		return value, nil
-	}
-
-	if result == nil {
-		return "", nil // Kanboard returns null for empty string
-	}
-	return "", fmt.Errorf("unexpected result type for GetTaskMetadataByName: %T", result)
-}
-
-func (kc *kanboardClient) SaveTaskMetadata(taskID int, values map[string]string) (bool, error) {
-	params := map[string]interface{}{
-		"task_id": taskID,
-		"values":  values,
-	}
-	result, err := kc.callKanboardAPI(context.Background(), "saveTaskMetadata", params)
-	if err != nil {
-		return false, err
-	}
-
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for SaveTaskMetadata: %T", result)
-}
-
-func (kc *kanboardClient) RemoveTaskMetadata(taskID int, name string) (bool, error) {
-	params := []interface{}{taskID, name}
-	result, err := kc.callKanboardAPI(context.Background(), "removeTaskMetadata", params)
-	if err != nil {
-		return false, err
-	}
-
-	if success, ok := result.(bool); ok {
-		return success, nil
-	}
-	return false, fmt.Errorf("unexpected result type for RemoveTaskMetadata: %T", result)
-}
-
-// Task Metadata Handlers
-func (kc *kanboardClient) getTaskMetadataHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetTaskMetadata(taskId)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getTaskMetadataByNameHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.GetTaskMetadataByName(taskId, name)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(result), nil
-}
-
-func (kc *kanboardClient) saveTaskMetadataHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	// Extract 'values' as a map[string]string
-	args := request.GetArguments()
-	valuesMap, ok := args["values"].(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Missing or invalid 'values' parameter. Expected a map."), nil
-	}
-
-	stringValues := make(map[string]string)
-	for key, val := range valuesMap {
-		strVal, isString := val.(string)
-		if !isString {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid value for metadata key '%s'. Expected string.", key)), nil
-		}
-		stringValues[key] = strVal
-	}
-
-	result, err := kc.SaveTaskMetadata(taskId, stringValues)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) removeTaskMetadataHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	result, err := kc.RemoveTaskMetadata(taskId, name)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
-}
-
-func (kc *kanboardClient) getTaskByReferenceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	reference, err := request.RequireString("reference")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "reference": reference}
-	result, err := kc.callKanboardAPI(ctx, "getTaskByReference", params)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllTasksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	statusId, err := request.RequireInt("status_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId, "status_id": statusId}
-	result, err := kc.callKanboardAPI(ctx, "getAllTasks", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get tasks: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getOverdueTasksHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := kc.callKanboardAPI(ctx, "getOverdueTasks", nil)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get overdue tasks: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getOverdueTasksByProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"project_id": projectId}
-	result, err := kc.callKanboardAPI(ctx, "getOverdueTasksByProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get overdue tasks: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) openTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"task_id": taskId, "status": "open"}
-	result, err := kc.callKanboardAPI(ctx, "updateTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to open task: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) closeTaskHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]interface{}{"task_id": taskId, "status": "closed"}
-	result, err := kc.callKanboardAPI(ctx, "updateTask", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to close task: %v", err)), nil
-	}
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) moveTaskToProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId := request.GetInt("swimlane_id", 0)
-	columnId := request.GetInt("column_id", 0)
-	categoryId := request.GetInt("category_id", 0)
-	ownerId := request.GetInt("owner_id", 0)
-
-	params := map[string]interface{}{
-		"task_id":    taskId,
-		"project_id": projectId,
-	}
-	if swimlaneId != 0 {
-		params["swimlane_id"] = swimlaneId
-	}
-	if columnId != 0 {
-		params["column_id"] = columnId
-	}
-	if categoryId != 0 {
-		params["category_id"] = categoryId
-	}
-	if ownerId != 0 {
-		params["owner_id"] = ownerId
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "moveTaskToProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to move task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) duplicateTaskToProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	taskId, err := request.RequireInt("task_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	swimlaneId := request.GetInt("swimlane_id", 0)
-	columnId := request.GetInt("column_id", 0)
-	categoryId := request.GetInt("category_id", 0)
-	ownerId := request.GetInt("owner_id", 0)
-
-	params := map[string]interface{}{
-		"task_id":    taskId,
-		"project_id": projectId,
-	}
-	if swimlaneId != 0 {
-		params["swimlane_id"] = swimlaneId
-	}
-	if columnId != 0 {
-		params["column_id"] = columnId
-	}
-	if categoryId != 0 {
-		params["category_id"] = categoryId
-	}
-	if ownerId != 0 {
-		params["owner_id"] = ownerId
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "duplicateTaskToProject", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to duplicate task: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) searchTasksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectId, err := request.RequireInt("project_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	query, err := request.RequireString("query")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectId,
-		"query":      query,
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "searchTasks", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search tasks: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createLdapUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	username, err := request.RequireString("username")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]string{"username": username}
-	result, err := kc.callKanboardAPI(ctx, "createLdapUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create LDAP user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "getUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) disableUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "disableUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to disable user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) enableUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "enableUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to enable user: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) isActiveUserHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	userId, err := request.RequireInt("user_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	params := map[string]int{"user_id": userId}
-	result, err := kc.callKanboardAPI(ctx, "isActiveUser", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to check if user is active: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) createSprintHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Prefer project_id if provided
-	projectID := request.GetInt("project_id", 0)
-	if projectID == 0 {
-		// Fallback: project_name
-		projectName := request.GetString("project_name", "")
-		if projectName == "" {
-			return mcp.NewToolResultError("Project ID or name is required for createSprint"), nil
-		}
-		// Lookup project_id by name
-		result, err := kc.callKanboardAPI(ctx, "getProjectByName", map[string]string{"name": projectName})
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to get project ID: %v", err)), nil
-		}
-		projectMap, ok := result.(map[string]interface{})
-		if !ok {
-			if b, isBool := result.(bool); isBool && !b {
-				return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or API returned false", projectName)), nil
-			}
-			if result == nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned nil", projectName)), nil
-			}
-			if arr, isArray := result.([]interface{}); isArray && len(arr) == 0 {
-				return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty array", projectName)), nil
-			}
-			return mcp.NewToolResultError(fmt.Sprintf("Unexpected response type for getProjectByName: %T", result)), nil
-		}
-		if len(projectMap) == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty object", projectName)), nil
-		}
-		var projectInfo struct {
-			ID json.RawMessage `json:"id"`
-		}
-		tempBytes, err := json.Marshal(projectMap)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal project info for parsing: %v", err)), nil
-		}
-		if err := json.Unmarshal(tempBytes, &projectInfo); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to unmarshal project info: %v", err)), nil
-		}
-		var idInt int
-		if err := json.Unmarshal(projectInfo.ID, &idInt); err == nil {
-			projectID = idInt
-		} else {
-			var idStr string
-			if err := json.Unmarshal(projectInfo.ID, &idStr); err == nil {
-				idInt, err = strconv.Atoi(idStr)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("Project '%s' id string is not a valid int: %v", projectName, err)), nil
-				}
-				projectID = idInt
-			} else {
-				return mcp.NewToolResultError(fmt.Sprintf("Project '%s' id is not a valid int or string: %v", projectName, err)), nil
-			}
-		}
-		if projectID == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or ID is empty", projectName)), nil
-		}
-	}
-
-	name := request.GetString("name", "")
-	if name == "" {
-		return mcp.NewToolResultError("Sprint name is required"), nil
-	}
-	startDate := request.GetString("start_date", "")
-	if startDate == "" {
-		return mcp.NewToolResultError("Start date is required"), nil
-	}
-	endDate := request.GetString("end_date", "")
-	if endDate == "" {
-		return mcp.NewToolResultError("End date is required"), nil
-	}
-
-	params := map[string]interface{}{
-		"project_id": projectID,
-		"name":       name,
-		"start_date": startDate,
-		"end_date":   endDate,
-	}
-
-	sprintResult, err := kc.callKanboardAPI(ctx, "createSprint", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create sprint: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(sprintResult, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getSprintByIdHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	sprintID := request.GetInt("sprint_id", 0)
-	if sprintID == 0 {
-		return mcp.NewToolResultError("Sprint ID is required"), nil
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "getSprintById", map[string]int{"sprint_id": sprintID})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get sprint by ID: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) updateSprintHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	sprintID := request.GetInt("sprint_id", 0)
-	if sprintID == 0 {
-		return mcp.NewToolResultError("Sprint ID is required"), nil
-	}
-
-	params := make(map[string]interface{})
-	params["sprint_id"] = sprintID
-
-	if name := request.GetString("name", ""); name != "" {
-		params["name"] = name
-	}
-	if startDate := request.GetString("start_date", ""); startDate != "" {
-		params["start_date"] = startDate
-	}
-	if endDate := request.GetString("end_date", ""); endDate != "" {
-		params["end_date"] = endDate
-	}
-
-	isCompleted := request.GetBool("is_completed", false)
-	isActive := request.GetBool("is_active", false)
-
-	params["is_completed"] = isCompleted
-	params["is_active"] = isActive
-
-	result, err := kc.callKanboardAPI(ctx, "updateSprint", params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update sprint: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) removeSprintHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	sprintID := request.GetInt("sprint_id", 0)
-	if sprintID == 0 {
-		return mcp.NewToolResultError("Sprint ID is required"), nil
-	}
-
-	result, err := kc.callKanboardAPI(ctx, "removeSprint", map[string]int{"sprint_id": sprintID})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove sprint: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
-}
-
-func (kc *kanboardClient) getAllSprintsByProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectName := request.GetString("project_name", "")
-	if projectName == "" {
-		return mcp.NewToolResultError("Project name is required for getAllSprintsByProject"), nil
-	}
-
-	// First, get the project ID from the project name
-	result, err := kc.callKanboardAPI(ctx, "getProjectByName", map[string]string{"name": projectName})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project ID: %v", err)), nil
-	}
-
-	projectMap, ok := result.(map[string]interface{})
-	if !ok {
-		if b, isBool := result.(bool); isBool && !b {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or API returned false", projectName)), nil
-		}
-		if result == nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned nil", projectName)), nil
-		}
-		if arr, isArray := result.([]interface{}); isArray && len(arr) == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty array", projectName)), nil
-		}
-		return mcp.NewToolResultError(fmt.Sprintf("Unexpected response type for getProjectByName: %T", result)), nil
-	}
-
-	if len(projectMap) == 0 {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found: API returned empty object", projectName)), nil
-	}
-
-	var projectInfo struct {
-		ID json.RawMessage `json:"id"`
-	}
-	tempBytes, err := json.Marshal(projectMap)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal project info for parsing: %v", err)), nil
-	}
-	if err := json.Unmarshal(tempBytes, &projectInfo); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to unmarshal project info: %v", err)), nil
-	}
-
-	var projectID string
-	var idInt int
-	if err := json.Unmarshal(projectInfo.ID, &idInt); err == nil {
-		projectID = strconv.Itoa(idInt)
-	} else {
-		var idStr string
-		if err := json.Unmarshal(projectInfo.ID, &idStr); err == nil {
-			projectID = idStr
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Project '%s' id is not a valid int or string: %v", projectName, err)), nil
-		}
-	}
-
-	if projectID == "" {
-		return mcp.NewToolResultError(fmt.Sprintf("Project '%s' not found or ID is empty", projectName)), nil
-	}
-
-	sprintsResult, err := kc.callKanboardAPI(ctx, "getAllSprintsByProject", map[string]string{"project_id": projectID})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get all sprints by project: %v", err)), nil
-	}
-
-	resultBytes, err := json.MarshalIndent(sprintsResult, "", "  ")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprints result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(resultBytes)), nil
+	return kbClient, nil
 }
@@ -0,0 +1,887 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/audit"
+	"kanboard-mcp/auth"
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/kanboard/kanboardtest"
+	"kanboard-mcp/rbac"
+	"kanboard-mcp/tools"
+	"kanboard-mcp/tools/catalog"
+	"kanboard-mcp/transport"
+)
+
+// toolCall is one tools/call request
+type toolCall struct {
+	tool string
+	args map[string]any
+}
+
+// toolCase calls a tool against a freshly seeded fake Kanboard, after the setup calls
+type toolCase struct {
+	name  string
+	setup []toolCall
+	toolCall
+	// want is a substring of the result text, wantErr one of the error text
+	want    string
+	wantErr string
+}
+
+type args = map[string]any
+
+var blob = base64.StdEncoding.EncodeToString([]byte("hello"))
+
+// toolCases has at least one case per registered tool, TestToolCasesCoverRegistry checks it.
+// The fixtures are described in the kanboardtest package documentation.
+var toolCases = []toolCase{
+	// projects
+	{toolCall: toolCall{"get_all_projects", args{}}, want: "Website Redesign"},
+	{toolCall: toolCall{"get_projects", args{}}, want: "Mobile App"},
+	{toolCall: toolCall{"get_project_by_id", args{"project_id": "1"}}, want: "Website Redesign"},
+	{name: "by identifier", toolCall: toolCall{"get_project_by_id", args{"project_id": "APP"}}, want: "Mobile App"},
+	{toolCall: toolCall{"get_project_by_name", args{"name": "Mobile App"}}, want: `"identifier": "APP"`},
+	{name: "missing", toolCall: toolCall{"get_project_by_name", args{"name": "Nope"}}, wantErr: "not found"},
+	{toolCall: toolCall{"get_project_by_identifier", args{"identifier": "WEB"}}, want: "Website Redesign"},
+	{toolCall: toolCall{"get_project_by_email", args{"email": "web@example.com"}}, want: "Website Redesign"},
+	{toolCall: toolCall{"create_project", args{"name": "Intranet", "identifier": "INT"}}, want: "3"},
+	{toolCall: toolCall{"update_project", args{"project_id": "WEB", "description": "New site"}}, want: "true"},
+	{toolCall: toolCall{"remove_project", args{"project_id": "2"}}, want: "true"},
+	{toolCall: toolCall{"enable_project", args{"project_id": "1"}}, want: "true"},
+	{toolCall: toolCall{"disable_project", args{"project_id": "1"}}, want: "true"},
+	{toolCall: toolCall{"enable_project_public_access", args{"project_id": "1"}}, want: "true"},
+	{toolCall: toolCall{"disable_project_public_access", args{"project_id": "1"}}, want: "true"},
+	{toolCall: toolCall{"get_project_activity", args{"project_id": "1"}}, want: "task.create"},
+	{toolCall: toolCall{"get_project_activities", args{"project_ids": []any{1, "Mobile App"}}}, want: "task.create"},
+	{toolCall: toolCall{"create_my_private_project", args{"name": "Scratch"}}, want: "3"},
+
+	// project members
+	{toolCall: toolCall{"get_project_users", args{"project_id": "1"}}, want: "Vera Viewer"},
+	{toolCall: toolCall{"get_assignable_users", args{"project_id": "1"}}, want: "Alice Martin"},
+	{toolCall: toolCall{"add_project_user", args{"project_id": "2", "user_id": "alice", "role": "project-member"}}, want: "true"},
+	{toolCall: toolCall{"assign_user_to_project", args{"project_id": "APP", "user_id": "2"}}, want: "true"},
+	{toolCall: toolCall{"remove_project_user", args{"project_id": "1", "user_id": "viewer"}}, want: "true"},
+	{toolCall: toolCall{"change_project_user_role", args{"project_id": "1", "user_id": "alice", "role": "project-manager"}}, want: "true"},
+	{toolCall: toolCall{"get_project_user_role", args{"project_id": "1", "user_id": "alice"}}, want: "project-member"},
+	{toolCall: toolCall{"add_project_group", args{"project_id": "2", "group_id": 1, "role": "project-member"}}, want: "true"},
+	{
+		setup:    []toolCall{{"add_project_group", args{"project_id": "2", "group_id": 1}}},
+		toolCall: toolCall{"remove_project_group", args{"project_id": "2", "group_id": 1}}, want: "true",
+	},
+	{
+		setup:    []toolCall{{"add_project_group", args{"project_id": "2", "group_id": 1}}},
+		toolCall: toolCall{"change_project_group_role", args{"project_id": "2", "group_id": 1, "role": "project-viewer"}}, want: "true",
+	},
+
+	// project files and metadata
+	{toolCall: toolCall{"get_all_project_files", args{"project_id": "1"}}, want: "brief.txt"},
+	{toolCall: toolCall{"get_project_file", args{"project_id": "1", "file_id": 1}}, want: "brief.txt"},
+	{toolCall: toolCall{"download_project_file", args{"project_id": "1", "file_id": 1}}, want: "project brief"},
+	{toolCall: toolCall{"create_project_file", args{"project_id": "1", "filename": "notes.txt", "blob": blob}}, want: "2"},
+	{toolCall: toolCall{"remove_project_file", args{"project_id": "1", "file_id": 1}}, want: "true"},
+	{toolCall: toolCall{"remove_all_project_files", args{"project_id": "1"}}, want: "true"},
+	{toolCall: toolCall{"get_project_metadata", args{"project_id": "1"}}, want: "ACME"},
+	{toolCall: toolCall{"get_project_metadata_by_name", args{"project_id": "1", "name": "client"}}, want: "ACME"},
+	{toolCall: toolCall{"save_project_metadata", args{"project_id": "1", "values": args{"owner": "ops"}}}, want: "true"},
+	{toolCall: toolCall{"remove_project_metadata", args{"project_id": "1", "name": "client"}}, want: "true"},
+
+	// automatic actions
+	{toolCall: toolCall{"get_actions", args{"project_id": "1"}}, want: "TaskClose"},
+	{toolCall: toolCall{"create_action", args{"project_id": "1", "event_name": "task.move.column",
+		"action_name": `\Kanboard\Action\TaskClose`, "params": args{"column_id": "3"}}}, want: "2"},
+	{toolCall: toolCall{"remove_action", args{"action_id": 1}}, want: "true"},
+	{toolCall: toolCall{"get_available_actions", args{}}, want: "TaskClose"},
+	{toolCall: toolCall{"get_available_action_events", args{}}, want: "task.move.column"},
+	{toolCall: toolCall{"get_compatible_action_events", args{"action_name": `\Kanboard\Action\TaskClose`}}, want: "task.move.column"},
+
+	// sprints
+	{toolCall: toolCall{"create_sprint", args{"project_id": "1", "name": "Sprint 2", "start_date": "2024-01-15", "end_date": "2024-01-28"}}, want: "2"},
+	{toolCall: toolCall{"get_sprint_by_id", args{"sprint_id": 1}}, want: "Sprint 1"},
+	{toolCall: toolCall{"get_all_sprints_by_project", args{"project_name": "Website Redesign"}}, want: "Sprint 1"},
+	{toolCall: toolCall{"update_sprint", args{"sprint_id": 1, "name": "Sprint One"}}, want: "true"},
+	{toolCall: toolCall{"remove_sprint", args{"sprint_id": 1}}, want: "true"},
+
+	// tasks
+	{toolCall: toolCall{"get_task", args{"task_id": "1"}}, want: "Design landing page"},
+	{name: "hash reference", toolCall: toolCall{"get_task", args{"task_id": "#2"}}, want: "Fix login bug"},
+	{name: "identifier reference", toolCall: toolCall{"get_task", args{"task_id": "APP-4"}}, want: "Set up CI"},
+	{name: "external reference", toolCall: toolCall{"get_task", args{"task_id": "JIRA-102", "project_id": "WEB"}}, want: "Fix login bug"},
+	{name: "missing", toolCall: toolCall{"get_task", args{"task_id": "99"}}, wantErr: "99"},
+	{toolCall: toolCall{"get_tasks", args{"project_name": "Website Redesign"}}, want: "Fix login bug"},
+	{toolCall: toolCall{"get_all_tasks", args{"project_id": "1", "status_id": 0}}, want: "Write release notes"},
+	{toolCall: toolCall{"get_task_by_reference", args{"project_id": "1", "reference": "JIRA-102"}}, want: "Fix login bug"},
+	{toolCall: toolCall{"get_overdue_tasks", args{}}, want: "Design landing page"},
+	{toolCall: toolCall{"get_overdue_tasks_by_project", args{"project_id": "WEB"}}, want: "Design landing page"},
+	{toolCall: toolCall{"search_tasks", args{"project_id": "1", "query": "login"}}, want: "Fix login bug"},
+	{toolCall: toolCall{"create_task", args{"project_name": "Website Redesign", "title": "Write copy", "column_id": "Ready",
+		"owner_id": "alice", "swimlane_id": "Expedite", "category_id": "Feature", "tags": []any{"frontend", "copy"}}}, want: "5"},
+	{name: "unknown column", toolCall: toolCall{"create_task", args{"project_name": "Website Redesign", "title": "Write copy",
+		"column_id": "Nowhere"}}, wantErr: "Nowhere"},
+	{toolCall: toolCall{"create_test_task", args{"project_name": "Mobile App", "title": "Smoke test"}}, want: "5"},
+	{toolCall: toolCall{"update_task", args{"id": "1", "title": "Redesign landing page", "priority": 2}}, want: "true"},
+	{toolCall: toolCall{"open_task", args{"task_id": "3"}}, want: "true"},
+	{toolCall: toolCall{"close_task", args{"task_id": "WEB-1"}}, want: "true"},
+	{toolCall: toolCall{"delete_task", args{"task_id": "3"}}, want: "true"},
+	{toolCall: toolCall{"assign_task", args{"task_id": "2", "user_id": "alice"}}, want: "true"},
+	{toolCall: toolCall{"set_task_due_date", args{"task_id": "2", "due_date": "2030-01-31"}}, want: "true"},
+	{toolCall: toolCall{"move_task_position", args{"project_id": "1", "task_id": "1", "column_id": "Done", "position": 1,
+		"swimlane_id": "Expedite"}}, want: "true"},
+	{toolCall: toolCall{"move_task_to_project", args{"task_id": "1", "project_id": "APP"}}, want: "true"},
+	{toolCall: toolCall{"duplicate_task_to_project", args{"task_id": "1", "project_id": "2"}}, want: "5"},
+	// project_id is where the task goes, not where its external reference is looked up
+	{name: "reference", toolCall: toolCall{"move_task_to_project", args{"task_id": "JIRA-101", "project_id": "2"}}, wantErr: "no project_id was given"},
+
+	// task files
+	{toolCall: toolCall{"get_all_task_files", args{"task_id": "1"}}, want: "spec.txt"},
+	{toolCall: toolCall{"get_task_file", args{"file_id": 1}}, want: "spec.txt"},
+	{toolCall: toolCall{"download_task_file", args{"file_id": 1}}, want: "landing page spec"},
+	{toolCall: toolCall{"create_task_file", args{"project_id": "1", "task_id": "1", "filename": "notes.txt", "blob": blob}}, want: "2"},
+	{toolCall: toolCall{"remove_task_file", args{"file_id": 1}}, want: "true"},
+	{toolCall: toolCall{"remove_all_task_files", args{"task_id": "1"}}, want: "true"},
+
+	// task links
+	{toolCall: toolCall{"get_all_task_links", args{"task_id": "1"}}, want: "blocks"},
+	{toolCall: toolCall{"get_task_link_by_id", args{"task_link_id": 1}}, want: `"opposite_task_id": 2`},
+	{toolCall: toolCall{"create_task_link", args{"task_id": "1", "opposite_task_id": "3", "link_id": 1}}, want: "3"},
+	{toolCall: toolCall{"update_task_link", args{"task_link_id": 1, "task_id": "1", "opposite_task_id": "3", "link_id": 1}}, want: "true"},
+	{toolCall: toolCall{"remove_task_link", args{"task_link_id": 1}}, want: "true"},
+
+	// external links
+	{toolCall: toolCall{"get_external_task_link_types", args{}}, want: "weblink"},
+	{toolCall: toolCall{"get_ext_link_provider_deps", args{"provider": "weblink"}}, want: "related"},
+	{toolCall: toolCall{"get_all_external_task_links", args{"task_id": "1"}}, want: "Specification"},
+	{toolCall: toolCall{"get_external_task_link_by_id", args{"task_id": "1", "link_id": 1}}, want: "Specification"},
+	{toolCall: toolCall{"create_external_task_link", args{"task_id": "1", "url": "https://example.com/design", "dependency": "related"}}, want: "2"},
+	{toolCall: toolCall{"update_external_task_link", args{"task_id": "1", "link_id": 1, "title": "Spec v2"}}, want: "true"},
+	{toolCall: toolCall{"remove_external_task_link", args{"task_id": "1", "link_id": 1}}, want: "true"},
+
+	// task metadata and tags
+	{toolCall: toolCall{"get_task_metadata", args{"task_id": "1"}}, want: "3d"},
+	{toolCall: toolCall{"get_task_metadata_by_name", args{"task_id": "1", "name": "estimate"}}, want: "3d"},
+	{toolCall: toolCall{"save_task_metadata", args{"task_id": "1", "values": args{"estimate": "5d"}}}, want: "true"},
+	{toolCall: toolCall{"remove_task_metadata", args{"task_id": "1", "name": "estimate"}}, want: "true"},
+	{toolCall: toolCall{"get_all_tags", args{}}, want: "urgent"},
+	{toolCall: toolCall{"get_tags_by_project", args{"project_id": "1"}}, want: "frontend"},
+	{toolCall: toolCall{"create_tag", args{"project_id": "1", "tag": "backend"}}, want: "4"},
+	{toolCall: toolCall{"update_tag", args{"tag_id": "2", "tag": "web"}}, want: "true"},
+	{toolCall: toolCall{"remove_tag", args{"tag_id": "2"}}, want: "true"},
+	{toolCall: toolCall{"set_task_tags", args{"project_id": "1", "task_id": "1", "tags": []any{"urgent", "design"}}}, want: "true"},
+	{toolCall: toolCall{"get_task_tags", args{"task_id": "1"}}, want: "frontend"},
+
+	// comments
+	{toolCall: toolCall{"get_task_comments", args{"task_id": "1"}}, want: "Looks good"},
+	{toolCall: toolCall{"get_comment", args{"comment_id": 1}}, want: "Looks good"},
+	{toolCall: toolCall{"create_comment", args{"task_id": "1", "user_id": "alice", "content": "Ship it"}}, want: "2"},
+	{toolCall: toolCall{"update_comment", args{"id": 1, "content": "Looks great"}}, want: "true"},
+	{toolCall: toolCall{"remove_comment", args{"comment_id": 1}}, want: "true"},
+
+	// subtasks
+	{toolCall: toolCall{"get_all_subtasks", args{"task_id": "1"}}, want: "Draft wireframes"},
+	{toolCall: toolCall{"get_subtask", args{"subtask_id": 1}}, want: "Draft wireframes"},
+	{toolCall: toolCall{"create_subtask", args{"task_id": "1", "title": "Review wireframes", "user_id": "bob"}}, want: "2"},
+	{toolCall: toolCall{"update_subtask", args{"id": 1, "task_id": "1", "status": 2}}, want: "true"},
+	{toolCall: toolCall{"remove_subtask", args{"subtask_id": 1}}, want: "true"},
+	{toolCall: toolCall{"has_subtask_timer", args{"subtask_id": 1, "user_id": "alice"}}, want: "false"},
+	{toolCall: toolCall{"set_subtask_start_time", args{"subtask_id": 1, "user_id": "alice"}}, want: "true"},
+	{
+		setup:    []toolCall{{"set_subtask_start_time", args{"subtask_id": 1, "user_id": "alice"}}},
+		toolCall: toolCall{"set_subtask_end_time", args{"subtask_id": 1, "user_id": "alice"}}, want: "true",
+	},
+	{toolCall: toolCall{"get_subtask_time_spent", args{"subtask_id": 1, "user_id": "alice"}}, want: "0"},
+
+	// board, columns, swimlanes and categories
+	{toolCall: toolCall{"get_board", args{"project_id": "1"}}, want: "Design landing page"},
+	{toolCall: toolCall{"get_columns", args{"project_id": "1"}}, want: "Work in progress"},
+	{toolCall: toolCall{"get_column", args{"column_id": "Ready", "project_id": "1"}}, want: `"title": "Ready"`},
+	{toolCall: toolCall{"create_column", args{"project_id": "1", "title": "Review", "task_limit": 3}}, want: "9"},
+	{toolCall: toolCall{"update_column", args{"column_id": "2", "title": "Todo"}}, want: "true"},
+	{toolCall: toolCall{"reorder_columns", args{"project_id": "1", "column_id": "Done", "position": 1}}, want: "true"},
+	{toolCall: toolCall{"delete_column", args{"column_id": "2"}}, want: "true"},
+	{toolCall: toolCall{"get_swimlanes", args{"project_id": "1"}}, want: "Expedite"},
+	{toolCall: toolCall{"get_active_swimlanes", args{"project_id": "1"}}, want: "Expedite"},
+	{toolCall: toolCall{"get_swimlane", args{"swimlane_id": "3"}}, want: "Expedite"},
+	{toolCall: toolCall{"get_swimlane_by_id", args{"swimlane_id": "3"}}, want: "Expedite"},
+	{toolCall: toolCall{"get_swimlane_by_name", args{"project_id": "1", "name": "Expedite"}}, want: `"id": 3`},
+	{toolCall: toolCall{"create_swimlane", args{"project_id": "1", "name": "Support"}}, want: "4"},
+	{toolCall: toolCall{"update_swimlane", args{"project_id": "1", "swimlane_id": "Expedite", "name": "Urgent"}}, want: "true"},
+	{toolCall: toolCall{"change_swimlane_position", args{"project_id": "1", "swimlane_id": "Expedite", "position": 1}}, want: "true"},
+	{toolCall: toolCall{"remove_swimlane", args{"project_id": "1", "swimlane_id": "Expedite"}}, want: "true"},
+	{toolCall: toolCall{"disable_swimlane", args{"project_id": "1", "swimlane_id": "3"}}, want: "true"},
+	{toolCall: toolCall{"enable_swimlane", args{"project_id": "1", "swimlane_id": "3"}}, want: "true"},
+	{toolCall: toolCall{"get_categories", args{"project_id": "1"}}, want: "Feature"},
+	{toolCall: toolCall{"get_category", args{"category_id": "1"}}, want: "Bug"},
+	{toolCall: toolCall{"create_category", args{"project_id": "1", "name": "Chore"}}, want: "4"},
+	{toolCall: toolCall{"update_category", args{"category_id": "1", "name": "Defect"}}, want: "true"},
+	{toolCall: toolCall{"delete_category", args{"category_id": "1"}}, want: "true"},
+
+	// link types
+	{toolCall: toolCall{"get_all_links", args{}}, want: "is blocked by"},
+	{toolCall: toolCall{"get_link_by_id", args{"link_id": 2}}, want: "blocks"},
+	{toolCall: toolCall{"get_link_by_label", args{"label": "fixes"}}, want: `"id": 10`},
+	{toolCall: toolCall{"get_opposite_link_id", args{"link_id": 2}}, want: "3"},
+	{toolCall: toolCall{"create_link", args{"label": "causes", "opposite_label": "is caused by"}}, want: "12"},
+	{toolCall: toolCall{"update_link", args{"link_id": 1, "opposite_link_id": 0, "label": "is related to"}}, want: "true"},
+	{toolCall: toolCall{"remove_link", args{"link_id": 10}}, want: "true"},
+
+	// users and groups
+	{toolCall: toolCall{"get_users", args{}}, want: "Vera Viewer"},
+	{toolCall: toolCall{"get_user", args{"user_id": "alice"}}, want: "Alice Martin"},
+	{toolCall: toolCall{"get_user_by_name", args{"username": "bob"}}, want: "Bob Stone"},
+	{toolCall: toolCall{"create_user", args{"username": "carol", "password": "carol-secret", "role": "app-user"}}, want: "5"},
+	{toolCall: toolCall{"create_ldap_user", args{"username": "dave"}}, want: "false"},
+	{toolCall: toolCall{"update_user", args{"id": 2, "name": "Alice M."}}, want: "true"},
+	{toolCall: toolCall{"remove_user", args{"user_id": "viewer"}}, want: "true"},
+	{toolCall: toolCall{"disable_user", args{"user_id": "alice"}}, want: "true"},
+	{toolCall: toolCall{"enable_user", args{"user_id": "alice"}}, want: "true"},
+	{toolCall: toolCall{"is_active_user", args{"user_id": "alice"}}, want: "true"},
+	{toolCall: toolCall{"get_all_groups", args{}}, want: "Developers"},
+	{toolCall: toolCall{"get_group", args{"group_id": 1}}, want: "Developers"},
+	{toolCall: toolCall{"create_group", args{"name": "QA"}}, want: "2"},
+	{toolCall: toolCall{"update_group", args{"group_id": 1, "name": "Devs"}}, want: "true"},
+	{toolCall: toolCall{"remove_group", args{"group_id": 1}}, want: "true"},
+	{toolCall: toolCall{"get_group_members", args{"group_id": 1}}, want: "alice"},
+	{toolCall: toolCall{"get_member_groups", args{"user_id": "alice"}}, want: "Developers"},
+	{toolCall: toolCall{"add_group_member", args{"group_id": 1, "user_id": "bob"}}, want: "true"},
+	{toolCall: toolCall{"remove_group_member", args{"group_id": 1, "user_id": "alice"}}, want: "true"},
+	{toolCall: toolCall{"is_group_member", args{"group_id": 1, "user_id": "alice"}}, want: "true"},
+
+	// the current user
+	{toolCall: toolCall{"get_me", args{}}, want: "Administrator"},
+	{toolCall: toolCall{"get_my_dashboard", args{}}, want: "["},
+	{toolCall: toolCall{"get_my_activity_stream", args{}}, want: "task.create"},
+	{toolCall: toolCall{"get_my_projects", args{}}, want: "Mobile App"},
+	{toolCall: toolCall{"get_my_projects_list", args{}}, want: "Mobile App"},
+	{toolCall: toolCall{"get_my_overdue_tasks", args{}}, want: "["},
+
+	// application
+	{toolCall: toolCall{"get_version", args{}}, want: "1.2.35"},
+	{toolCall: toolCall{"get_timezone", args{}}, want: "UTC"},
+	{toolCall: toolCall{"get_default_task_colors", args{}}, want: "yellow"},
+	{toolCall: toolCall{"get_default_task_color", args{}}, want: "yellow"},
+	{toolCall: toolCall{"get_color_list", args{}}, want: "Yellow"},
+	{toolCall: toolCall{"get_application_roles", args{}}, want: "app-manager"},
+	{toolCall: toolCall{"get_project_roles", args{}}, want: "project-viewer"},
+	{toolCall: toolCall{"tool_search", args{"query": "subtask timer"}}, want: "has_subtask_timer"},
+	{toolCall: toolCall{"list_instances", args{}}, want: `"name": "default"`},
+	{toolCall: toolCall{"cache_clear", args{}}, wantErr: "disabled"},
+	{toolCall: toolCall{"get_rbac_cache_stats", args{}}, want: "{"},
+}
+
+// testServer is the MCP server wired like main does, in front of a fake Kanboard
+type testServer struct {
+	*server.MCPServer
+	kanboard *kanboardtest.Server
+	client   *kanboard.Client
+	registry *tools.Registry
+}
+
+// newTestServer starts a fake Kanboard and an MCP server calling it as username
+func newTestServer(t *testing.T, username string) *testServer {
+	t.Helper()
+	kb := kanboardtest.NewServer()
+	t.Cleanup(kb.Close)
+
+	manager, err := rbac.NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := catalog.New(manager)
+	client := kanboard.NewClient(kb.Endpoint(), "", username, username+"-secret")
+	registry.Instances = []*tools.Instance{{Name: "default", Client: client}}
+	return &testServer{
+		MCPServer: newServer(registry, manager, nil, nil),
+		kanboard:  kb,
+		client:    client,
+		registry:  registry,
+	}
+}
+
+// call sends a tools/call request through the MCP server and returns the result text
+func (s *testServer) call(t *testing.T, tool string, arguments map[string]any) (string, bool) {
+	t.Helper()
+	raw, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool, "arguments": arguments},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := s.HandleMessage(context.Background(), raw)
+	reply, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("%s: unexpected response %#v", tool, response)
+	}
+	var result mcp.CallToolResult
+	switch r := reply.Result.(type) {
+	case mcp.CallToolResult:
+		result = r
+	case *mcp.CallToolResult:
+		result = *r
+	default:
+		t.Fatalf("%s: unexpected result %#v", tool, reply.Result)
+	}
+
+	var text strings.Builder
+	for _, content := range result.Content {
+		if content, ok := content.(mcp.TextContent); ok {
+			text.WriteString(content.Text)
+		}
+	}
+	return text.String(), result.IsError
+}
+
+// run runs the setup calls and the tool call of c and checks the outcome
+func (s *testServer) run(t *testing.T, c toolCase) {
+	t.Helper()
+	for _, setup := range c.setup {
+		if text, isError := s.call(t, setup.tool, setup.args); isError {
+			t.Fatalf("setup %s failed: %s", setup.tool, text)
+		}
+	}
+
+	text, isError := s.call(t, c.tool, c.args)
+	defer func() {
+		if t.Failed() {
+			for _, call := range s.kanboard.Calls() {
+				t.Logf("Kanboard received %s %s", call.Method, call.Params)
+			}
+		}
+	}()
+	switch {
+	case c.wantErr != "" && !isError:
+		t.Fatalf("expected an error containing %q, got %s", c.wantErr, text)
+	case c.wantErr != "" && !strings.Contains(text, c.wantErr):
+		t.Fatalf("expected an error containing %q, got %s", c.wantErr, text)
+	case c.wantErr == "" && isError:
+		t.Fatalf("unexpected error: %s", text)
+	case c.wantErr == "" && !strings.Contains(text, c.want):
+		t.Fatalf("expected %q in the result, got %s", c.want, text)
+	}
+}
+
+func (c toolCase) testName() string {
+	if c.name != "" {
+		return c.tool + "/" + c.name
+	}
+	return c.tool
+}
+
+func TestToolCasesCoverRegistry(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, c := range toolCases {
+		covered[c.tool] = true
+	}
+	for _, tool := range catalog.New(nil).Tools() {
+		if !covered[tool.Name()] {
+			t.Errorf("no test case for tool %s", tool.Name())
+		}
+	}
+}
+
+func TestToolsWithoutRBAC(t *testing.T) {
+	t.Setenv("KANBOARD_SKIP_RBAC", "true")
+	for _, c := range toolCases {
+		t.Run(c.testName(), func(t *testing.T) {
+			newTestServer(t, "admin").run(t, c)
+		})
+	}
+}
+
+// An administrator may call every tool, so RBAC must not change any outcome
+func TestToolsWithRBACAsAdmin(t *testing.T) {
+	for _, c := range toolCases {
+		t.Run(c.testName(), func(t *testing.T) {
+			newTestServer(t, "admin").run(t, c)
+		})
+	}
+}
+
+// The viewer is a plain user with the project-viewer role in project 1: whatever they
+// call, nothing may change in Kanboard apart from their own private projects.
+func TestToolsWithRBACAsViewer(t *testing.T) {
+	for _, c := range toolCases {
+		if len(c.setup) > 0 {
+			continue
+		}
+		t.Run(c.testName(), func(t *testing.T) {
+			s := newTestServer(t, "viewer")
+			s.call(t, c.tool, c.args)
+			for _, method := range s.kanboard.Methods() {
+				if kanboard.IsMutatingMethod(method) && method != "createMyPrivateProject" {
+					t.Fatalf("viewer reached Kanboard method %s", method)
+				}
+			}
+		})
+	}
+}
+
+func TestRBACByRole(t *testing.T) {
+	cases := []struct {
+		user string
+		toolCase
+	}{
+		{"viewer", toolCase{toolCall: toolCall{"create_comment", args{"task_id": "1", "user_id": "viewer", "content": "Hi"}}, wantErr: "denied"}},
+		{"viewer", toolCase{toolCall: toolCall{"get_task", args{"task_id": "4"}}, wantErr: "denied"}},
+		{"viewer", toolCase{toolCall: toolCall{"get_task", args{"task_id": "1"}}, want: "Design landing page"}},
+		{"viewer", toolCase{toolCall: toolCall{"create_test_task", args{"project_name": "WEB", "title": "Smoke test"}}, wantErr: "denied"}},
+		{"viewer", toolCase{toolCall: toolCall{"create_user", args{"username": "eve", "password": "eve-secret"}}, wantErr: "denied"}},
+		{"alice", toolCase{toolCall: toolCall{"create_comment", args{"task_id": "1", "user_id": "alice", "content": "Hi"}}, want: "2"}},
+		{"alice", toolCase{toolCall: toolCall{"remove_project", args{"project_id": "1"}}, wantErr: "denied"}},
+		{"bob", toolCase{toolCall: toolCall{"update_project", args{"project_id": "APP", "description": "Apps"}}, want: "true"}},
+		{"viewer", toolCase{toolCall: toolCall{"save_task_metadata", args{"task_id": "1", "values": args{"estimate": "1d"}}}, wantErr: "denied"}},
+		{"alice", toolCase{toolCall: toolCall{"save_task_metadata", args{"task_id": "1", "values": args{"estimate": "1d"}}}, want: "true"}},
+		{"alice", toolCase{toolCall: toolCall{"remove_action", args{"action_id": 1}}, wantErr: "denied"}},
+		{"bob", toolCase{toolCall: toolCall{"remove_action", args{"action_id": 1}}, want: "true"}},
+		// Moving or copying a task needs a role in the project it goes to as well
+		{"alice", toolCase{toolCall: toolCall{"move_task_to_project", args{"task_id": "1", "project_id": "2"}}, wantErr: "denied"}},
+		{"alice", toolCase{toolCall: toolCall{"duplicate_task_to_project", args{"task_id": "1", "project_id": "2"}}, wantErr: "denied"}},
+		{"bob", toolCase{toolCall: toolCall{"move_task_to_project", args{"task_id": "1", "project_id": "2"}}, want: "true"}},
+		// The task must belong to the project the call is checked against
+		{"alice", toolCase{toolCall: toolCall{"set_task_tags", args{"project_id": "1", "task_id": "4", "tags": []any{"urgent"}}}, wantErr: "belongs to project 2"}},
+		{"alice", toolCase{toolCall: toolCall{"move_task_position", args{"project_id": "1", "task_id": "4", "column_id": "1", "position": 1, "swimlane_id": "1"}}, wantErr: "belongs to project 2"}},
+		{"alice", toolCase{toolCall: toolCall{"create_task_file", args{"project_id": "1", "task_id": "4", "filename": "a.txt", "blob": "aGk="}}, wantErr: "belongs to project 2"}},
+		{"alice", toolCase{toolCall: toolCall{"get_project_activities", args{"project_ids": []any{"WEB", "APP"}}}, wantErr: "denied"}},
+		// Kanboard loads files, subtasks and links by their ID alone, they must belong to the
+		// project or task the call is checked against
+		{"alice", toolCase{toolCall: toolCall{"get_project_file", args{"project_id": "1", "file_id": 1}}, want: "brief.txt"}},
+		{"bob", toolCase{toolCall: toolCall{"get_project_file", args{"project_id": "2", "file_id": 1}}, wantErr: "project file 1 belongs to project 1"}},
+		{"bob", toolCase{toolCall: toolCall{"download_project_file", args{"project_id": "2", "file_id": 1}}, wantErr: "project file 1 belongs to project 1"}},
+		{"bob", toolCase{toolCall: toolCall{"remove_project_file", args{"project_id": "2", "file_id": 1}}, wantErr: "project file 1 belongs to project 1"}},
+		{"alice", toolCase{toolCall: toolCall{"update_subtask", args{"id": 1, "task_id": "1", "title": "Wireframes"}}, want: "true"}},
+		{"bob", toolCase{toolCall: toolCall{"update_subtask", args{"id": 1, "task_id": "4", "title": "Wireframes"}}, wantErr: "subtask 1 belongs to task 1"}},
+		{"bob", toolCase{toolCall: toolCall{"update_task_link", args{"task_link_id": 1, "task_id": "4", "opposite_task_id": "2", "link_id": 1}}, wantErr: "task link 1 belongs to task 1"}},
+		{"bob", toolCase{toolCall: toolCall{"get_external_task_link_by_id", args{"task_id": "4", "link_id": 1}}, wantErr: "external task link 1 belongs to task 1"}},
+		{"bob", toolCase{toolCall: toolCall{"update_external_task_link", args{"task_id": "4", "link_id": 1, "title": "Spec"}}, wantErr: "external task link 1 belongs to task 1"}},
+		{"bob", toolCase{toolCall: toolCall{"remove_external_task_link", args{"task_id": "4", "link_id": 1}}, wantErr: "external task link 1 belongs to task 1"}},
+	}
+	for _, c := range cases {
+		t.Run(c.user+"/"+c.testName(), func(t *testing.T) {
+			s := newTestServer(t, c.user)
+			s.run(t, c.toolCase)
+			for _, method := range s.kanboard.Methods() {
+				if c.wantErr != "" && kanboard.IsMutatingMethod(method) {
+					t.Fatalf("denied call reached Kanboard method %s", method)
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyProjectNames(t *testing.T) {
+	cases := []struct {
+		fuzzy bool
+		toolCase
+	}{
+		{false, toolCase{toolCall: toolCall{"get_project_by_id", args{"project_id": "mobile app"}}, want: "Mobile App"}},
+		{false, toolCase{toolCall: toolCall{"get_project_by_id", args{"project_id": "Mobile"}}, wantErr: "not found"}},
+		{true, toolCase{toolCall: toolCall{"get_project_by_id", args{"project_id": "Mobile"}}, want: "Mobile App"}},
+		{true, toolCase{toolCall: toolCall{"get_project_activities", args{"project_ids": []any{"Redesign"}}}, want: "task.create"}},
+		// A part of a name never selects the project to delete
+		{true, toolCase{toolCall: toolCall{"remove_project", args{"project_id": "Mobile"}}, wantErr: "not found"}},
+		{true, toolCase{toolCall: toolCall{"remove_project", args{"project_id": "mobile app"}}, want: "true"}},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("fuzzy=%v/%s", c.fuzzy, c.testName()), func(t *testing.T) {
+			s := newTestServer(t, "bob")
+			s.client.FuzzyNames = c.fuzzy
+			s.run(t, c.toolCase)
+			if c.wantErr != "" && slices.ContainsFunc(s.kanboard.Methods(), kanboard.IsMutatingMethod) {
+				t.Fatalf("rejected call reached Kanboard: %v", s.kanboard.Methods())
+			}
+		})
+	}
+}
+
+func TestConfirmToken(t *testing.T) {
+	t.Setenv("KANBOARD_SKIP_RBAC", "true")
+	s := newTestServer(t, "admin")
+	s.registry.Confirm = map[string]bool{"remove_project": true}
+	arguments := args{"project_id": "2"}
+
+	text, isError := s.call(t, "remove_project", arguments)
+	if isError || !strings.Contains(text, "confirmation_required") {
+		t.Fatalf("expected a confirmation request, got %s", text)
+	}
+	for _, method := range s.kanboard.Methods() {
+		if method == "removeProject" {
+			t.Fatal("removeProject was called before confirmation")
+		}
+	}
+	token := regexp.MustCompile(`"confirm_token": "([0-9a-f]+)"`).FindStringSubmatch(text)
+	if token == nil {
+		t.Fatalf("no confirm token in %s", text)
+	}
+
+	arguments["confirm_token"] = token[1]
+	if text, isError := s.call(t, "remove_project", arguments); isError || !strings.Contains(text, "true") {
+		t.Fatalf("confirmed call failed: %s", text)
+	}
+	if text, isError := s.call(t, "remove_project", arguments); !isError {
+		t.Fatalf("a confirm token must not be reusable, got %s", text)
+	}
+}
+
+func TestDownloadToSavePath(t *testing.T) {
+	t.Setenv("KANBOARD_SKIP_RBAC", "true")
+	s := newTestServer(t, "admin")
+	path := filepath.Join(t.TempDir(), "spec.txt")
+
+	if text, isError := s.call(t, "download_task_file", args{"file_id": 1, "save_path": path}); isError {
+		t.Fatal(text)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "landing page spec" {
+		t.Fatalf("saved %q", content)
+	}
+}
+
+// A session recorded against Kanboard replays with the same results and no Kanboard calls
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	session := []toolCall{
+		{"get_task", args{"task_id": "#1"}},
+		{"create_comment", args{"task_id": "1", "user_id": "alice", "content": "Ship it"}},
+		{"get_task_comments", args{"task_id": "1"}},
+		{"create_user", args{"username": "eve", "password": "eve-secret"}},
+	}
+
+	recording := newTestServer(t, "admin")
+	var err error
+	recording.client.Cassette, err = kanboard.RecordCassette(path, recording.kanboard.Endpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded []string
+	for _, c := range session {
+		text, isError := recording.call(t, c.tool, c.args)
+		if isError {
+			t.Fatalf("%s failed: %s", c.tool, text)
+		}
+		recorded = append(recorded, text)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "eve-secret") {
+		t.Fatalf("the cassette holds a password:\n%s", content)
+	}
+
+	replaying := newTestServer(t, "admin")
+	replaying.client.Cassette, err = kanboard.ReplayCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range session {
+		if text, isError := replaying.call(t, c.tool, c.args); isError || text != recorded[i] {
+			t.Fatalf("%s replayed %s, recorded %s", c.tool, text, recorded[i])
+		}
+	}
+	if calls := replaying.kanboard.Methods(); len(calls) > 0 {
+		t.Fatalf("replay called Kanboard: %v", calls)
+	}
+
+	text, isError := replaying.call(t, "get_task", args{"task_id": "2"})
+	if !isError || !strings.Contains(text, `no recorded response for getTask{"task_id":2}`) {
+		t.Fatalf("expected an unmatched call error, got %s", text)
+	}
+	if unmatched := replaying.client.Cassette.Unmatched(); len(unmatched) != 1 || unmatched[0].Method != "getTask" {
+		t.Fatalf("unmatched calls: %+v", unmatched)
+	}
+}
+
+// Tool calls go to the instance they name, each with its own user, tools and read-only setting
+func TestInstances(t *testing.T) {
+	engineering, operations := kanboardtest.NewServer(), kanboardtest.NewServer()
+	t.Cleanup(engineering.Close)
+	t.Cleanup(operations.Close)
+
+	manager, err := rbac.NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := catalog.New(manager)
+	registry.Instances = []*tools.Instance{
+		{Name: "eng", Client: kanboard.NewClient(engineering.Endpoint(), "", "admin", "admin-secret")},
+		{Name: "ops", Client: kanboard.NewClient(operations.Endpoint(), "", "viewer", "viewer-secret"), ReadOnly: true,
+			Enabled: map[string]bool{"get_task": true, "create_task": true}},
+	}
+	registry.DefaultInstance = registry.Instances[0]
+	s := &testServer{MCPServer: newServer(registry, manager, nil, nil), kanboard: engineering, registry: registry}
+
+	cases := []struct {
+		toolCase
+		reached *kanboardtest.Server
+	}{
+		{toolCase{toolCall: toolCall{"get_task", args{"task_id": "4"}}, want: "Set up CI"}, engineering},
+		{toolCase{toolCall: toolCall{"get_task", args{"task_id": "4", "instance": "eng"}}, want: "Set up CI"}, engineering},
+		{toolCase{toolCall: toolCall{"get_task", args{"task_id": "1", "instance": "ops"}}, want: "Design landing page"}, operations},
+		// The viewer is not a member of project 2 on ops
+		{toolCase{toolCall: toolCall{"get_task", args{"task_id": "4", "instance": "ops"}}, wantErr: "denied"}, operations},
+		{toolCase{toolCall: toolCall{"create_task", args{"project_id": "1", "title": "New", "instance": "ops"}}, wantErr: "instance ops is read-only"}, nil},
+		{toolCase{toolCall: toolCall{"get_all_projects", args{"instance": "ops"}}, wantErr: "not enabled on instance ops"}, nil},
+		{toolCase{toolCall: toolCall{"get_all_projects", args{"instance": "qa"}}, wantErr: `unknown instance "qa" (instances: eng, ops)`}, nil},
+		{toolCase{toolCall: toolCall{"list_instances", args{}}, want: `"endpoint": "` + operations.Endpoint()}, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.testName(), func(t *testing.T) {
+			engineering.ResetCalls()
+			operations.ResetCalls()
+			s.run(t, c.toolCase)
+			for _, kb := range []*kanboardtest.Server{engineering, operations} {
+				if reached := len(kb.Calls()) > 0; reached != (kb == c.reached) {
+					t.Fatalf("unexpected Kanboard calls: %v", kb.Methods())
+				}
+			}
+		})
+	}
+
+	registry.DefaultInstance = nil
+	if text, _ := s.call(t, "get_version", args{}); !strings.Contains(text, "needs the instance argument, one of: eng, ops") {
+		t.Fatalf("expected the instance to be required, got %s", text)
+	}
+}
+
+// Over HTTP every MCP client may send its own Kanboard credentials, so that RBAC and
+// Kanboard see the real person rather than the identity of the server
+func TestSessionCredentials(t *testing.T) {
+	s := newTestServer(t, "admin")
+	s.registry.SessionCredentials = tools.SessionCredentialsOptional
+	endpoint := httptest.NewServer(server.NewStreamableHTTPServer(s.MCPServer, server.WithHTTPContextFunc(transport.ContextFromRequest)))
+	t.Cleanup(endpoint.Close)
+
+	post := func(t *testing.T, header http.Header, message map[string]any) (string, http.Header) {
+		t.Helper()
+		body, _ := json.Marshal(message)
+		request, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		request.Header = header
+		request.Header.Set("Content-Type", "application/json")
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		reply, _ := io.ReadAll(response.Body)
+		return string(reply), response.Header
+	}
+	_, initialized := post(t, http.Header{}, map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params":  map[string]any{"protocolVersion": mcp.LATEST_PROTOCOL_VERSION, "clientInfo": map[string]any{"name": "test", "version": "1"}},
+	})
+	sessionID := initialized.Get("Mcp-Session-Id")
+	call := func(t *testing.T, header http.Header, tool string, arguments map[string]any) string {
+		t.Helper()
+		header.Set("Mcp-Session-Id", sessionID)
+		reply, _ := post(t, header, map[string]any{
+			"jsonrpc": "2.0",
+			"id":      2,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool, "arguments": arguments},
+		})
+		return reply
+	}
+	basic := func(username, token string) http.Header {
+		return http.Header{"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+token))}}
+	}
+
+	cases := []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{"server identity", http.Header{}, "Set up CI"},
+		{"basic auth", basic("viewer", "viewer-secret"), "access denied"},
+		{"kanboard headers", http.Header{"X-Kanboard-Username": {"viewer"}, "X-Kanboard-Token": {"viewer-secret"}}, "access denied"},
+		{"other user", basic("bob", "bob-secret"), "Set up CI"},
+		{"wrong token", basic("viewer", "nope"), "401"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if reply := call(t, c.header, "get_task", args{"task_id": "4"}); !strings.Contains(reply, c.want) {
+				t.Fatalf("expected %q, got %s", c.want, reply)
+			}
+		})
+	}
+
+	// A role change made by one client reloads the cached roles of the other clients
+	if reply := call(t, http.Header{}, "add_project_user", args{"project_id": "2", "user_id": "viewer", "role": "project-viewer"}); !strings.Contains(reply, "true") {
+		t.Fatalf("add_project_user failed: %s", reply)
+	}
+	if reply := call(t, basic("viewer", "viewer-secret"), "get_task", args{"task_id": "4"}); !strings.Contains(reply, "Set up CI") {
+		t.Fatalf("expected the viewer to see the new project, got %s", reply)
+	}
+
+	s.registry.SessionCredentials = tools.SessionCredentialsRequired
+	if reply := call(t, http.Header{}, "get_task", args{"task_id": "4"}); !strings.Contains(reply, "authentication required") {
+		t.Fatalf("expected the credentials to be required, got %s", reply)
+	}
+	if reply := call(t, http.Header{}, "tool_search", args{"query": "task"}); strings.Contains(reply, "authentication required") {
+		t.Fatalf("local tools need no credentials, got %s", reply)
+	}
+}
+
+// The HTTP endpoints need a bearer token, whose scopes limit the tool domains
+func TestAuthentication(t *testing.T) {
+	tokens := filepath.Join(t.TempDir(), "tokens.yaml")
+	content := "tokens:\n" +
+		"  - name: triage-bot\n    token: triage-bot-token-0001\n    scopes: [tasks]\n" +
+		"  - name: admin\n    token: ${TEST_ADMIN_TOKEN}\n    scopes: [\"*\"]\n" +
+		"  - name: unscoped\n    token: unscoped-token-000001\n"
+	if err := os.WriteFile(tokens, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MCP_AUTH_TOKENS_FILE", tokens)
+	t.Setenv("TEST_ADMIN_TOKEN", "admin-token-000000001")
+
+	s := newTestServer(t, "admin")
+	authenticator, err := auth.NewFromEnv(s.registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := rbac.NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mcpServer := newServer(s.registry, manager, nil, authenticator)
+	endpoint := httptest.NewServer(authenticator.Handler(server.NewStreamableHTTPServer(mcpServer, server.WithStateLess(true))))
+	t.Cleanup(endpoint.Close)
+
+	post := func(t *testing.T, token, method string, params map[string]any) (int, string) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+		request, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		reply, _ := io.ReadAll(response.Body)
+		return response.StatusCode, string(reply)
+	}
+
+	cases := []struct {
+		name       string
+		token      string
+		method     string
+		params     map[string]any
+		wantStatus int
+		want       string
+		notWant    string
+	}{
+		{"no token", "", "tools/list", nil, http.StatusUnauthorized, "send a bearer token", ""},
+		{"unknown token", "guessed-token-0000001", "tools/list", nil, http.StatusUnauthorized, "invalid_token", ""},
+		{"scoped list", "triage-bot-token-0001", "tools/list", nil, http.StatusOK, `"get_task"`, `"create_comment"`},
+		{"scoped call", "triage-bot-token-0001", "tools/call", map[string]any{"name": "get_task", "arguments": args{"task_id": "4"}}, http.StatusOK, "Set up CI", ""},
+		{"always enabled", "triage-bot-token-0001", "tools/call", map[string]any{"name": "tool_search", "arguments": args{"query": "task"}}, http.StatusOK, "get_task", ""},
+		{"scoped search", "triage-bot-token-0001", "tools/call", map[string]any{"name": "tool_search", "arguments": args{"query": "comment"}}, http.StatusOK, "total_tools", "create_comment"},
+		{"out of scope", "triage-bot-token-0001", "tools/call", map[string]any{"name": "get_task_comments", "arguments": args{"task_id": "1"}}, http.StatusForbidden, "insufficient_scope", ""},
+		{"every domain", "admin-token-000000001", "tools/call", map[string]any{"name": "get_task_comments", "arguments": args{"task_id": "1"}}, http.StatusOK, "Looks good", ""},
+		{"no scopes", "unscoped-token-000001", "tools/call", map[string]any{"name": "get_task", "arguments": args{"task_id": "4"}}, http.StatusForbidden, "insufficient_scope", ""},
+		{"no scopes list", "unscoped-token-000001", "tools/list", nil, http.StatusOK, `"tool_search"`, `"get_task"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status, reply := post(t, c.token, c.method, c.params)
+			if status != c.wantStatus || !strings.Contains(reply, c.want) || (c.notWant != "" && strings.Contains(reply, c.notWant)) {
+				t.Fatalf("got %d %s", status, reply)
+			}
+		})
+	}
+
+	// Kanboard credentials can't share the Authorization header with the bearer token
+	request, _ := http.NewRequest(http.MethodPost, endpoint.URL, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	request.SetBasicAuth("viewer", "viewer-secret")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	reply, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusUnauthorized || !strings.Contains(string(reply), "X-Kanboard-Username") {
+		t.Fatalf("expected Basic credentials to be rejected, got %d %s", response.StatusCode, reply)
+	}
+}
+
+// Every tool call is audited with the user of its permission check, without extra
+// Kanboard calls, and with the secrets of its arguments redacted wherever they are
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	t.Setenv("MCP_AUDIT_LOG", path)
+	auditLog, err := audit.NewLoggerFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestServer(t, "alice")
+	manager, err := rbac.NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.MCPServer = newServer(s.registry, manager, auditLog, nil)
+
+	s.call(t, "get_task", args{"task_id": "1", "accounts": []any{args{"username": "eve", "password": "eve-secret"}}})
+	t.Setenv("KANBOARD_SKIP_RBAC", "true")
+	manager.InvalidateUserContext(s.client)
+	s.kanboard.ResetCalls()
+	s.call(t, "get_task", args{"task_id": "1"})
+	if methods := s.kanboard.Methods(); len(methods) != 1 || methods[0] != "getTask" {
+		t.Fatalf("expected only getTask without RBAC, Kanboard received %v", methods)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "eve-secret") {
+		t.Fatalf("the audit log holds a password:\n%s", content)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 audit records, got:\n%s", content)
+	}
+	var checked, skipped struct {
+		User  string `json:"user"`
+		Calls []struct {
+			Method string `json:"method"`
+		} `json:"calls"`
+	}
+	json.Unmarshal([]byte(lines[0]), &checked)
+	json.Unmarshal([]byte(lines[1]), &skipped)
+	if checked.User != "alice" || skipped.User != "" || len(skipped.Calls) != 1 {
+		t.Fatalf("unexpected audit records:\n%s", content)
+	}
+}
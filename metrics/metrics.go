@@ -0,0 +1,256 @@
+// Package metrics collects the Prometheus metrics exposed on /metrics.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Latency buckets in seconds, the Prometheus client defaults
+var defaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics holds every metric exposed on /metrics
+type Metrics struct {
+	toolCalls         *CounterVec
+	toolDuration      *histogramVec
+	jsonrpcCalls      *CounterVec
+	jsonrpcDuration   *histogramVec
+	JSONRPCRetries    *CounterVec
+	RBACDenials       *CounterVec
+	ResponseCache     *CounterVec
+	activeSessions    atomic.Int64
+	sessionsInitTotal atomic.Int64
+}
+
+func newServerMetrics() *Metrics {
+	return &Metrics{
+		toolCalls: newCounterVec("kanboard_mcp_tool_calls_total",
+			"MCP tool calls by tool and status (ok, error).", "tool", "status"),
+		toolDuration: newHistogramVec("kanboard_mcp_tool_call_duration_seconds",
+			"MCP tool call latency by tool.", defaultLatencyBuckets, "tool"),
+		jsonrpcCalls: newCounterVec("kanboard_mcp_jsonrpc_requests_total",
+			"Kanboard JSON-RPC calls by method and status (ok, error), after retries.", "method", "status"),
+		jsonrpcDuration: newHistogramVec("kanboard_mcp_jsonrpc_request_duration_seconds",
+			"Kanboard JSON-RPC call latency by method, including retries.", defaultLatencyBuckets, "method"),
+		JSONRPCRetries: newCounterVec("kanboard_mcp_jsonrpc_retries_total",
+			"Kanboard JSON-RPC retry attempts by method.", "method"),
+		RBACDenials: newCounterVec("kanboard_mcp_rbac_denials_total",
+			"Tool calls rejected by the permission layer by tool and reason (rbac, read_only).", "tool", "reason"),
+		ResponseCache: newCounterVec("kanboard_mcp_response_cache_lookups_total",
+			"Response cache lookups for cached Kanboard methods by method and result (hit, miss).", "method", "result"),
+	}
+}
+
+// Default is the process-wide metrics registry
+var Default = newServerMetrics()
+
+// ObserveJSONRPC records the outcome of a Kanboard API call
+func (m *Metrics) ObserveJSONRPC(method string, err error, started time.Time) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	m.jsonrpcCalls.Inc(method, status)
+	m.jsonrpcDuration.observe(time.Since(started).Seconds(), method)
+}
+
+// write renders all metrics in the Prometheus text exposition format
+func (m *Metrics) write(w io.Writer) {
+	m.toolCalls.write(w)
+	m.toolDuration.write(w)
+	m.jsonrpcCalls.write(w)
+	m.jsonrpcDuration.write(w)
+	m.JSONRPCRetries.write(w)
+	m.RBACDenials.write(w)
+	m.ResponseCache.write(w)
+
+	fmt.Fprintf(w, "# HELP kanboard_mcp_active_sessions MCP sessions with an open SSE or streamable HTTP stream.\n")
+	fmt.Fprintf(w, "# TYPE kanboard_mcp_active_sessions gauge\n")
+	fmt.Fprintf(w, "kanboard_mcp_active_sessions %d\n", m.activeSessions.Load())
+	fmt.Fprintf(w, "# HELP kanboard_mcp_sessions_initialized_total MCP sessions initialized since start.\n")
+	fmt.Fprintf(w, "# TYPE kanboard_mcp_sessions_initialized_total counter\n")
+	fmt.Fprintf(w, "kanboard_mcp_sessions_initialized_total %d\n", m.sessionsInitTotal.Load())
+}
+
+// Hooks tracks MCP sessions for the session metrics
+func (m *Metrics) Hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddOnRegisterSession(func(_ context.Context, _ server.ClientSession) {
+		m.activeSessions.Add(1)
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, _ server.ClientSession) {
+		m.activeSessions.Add(-1)
+	})
+	hooks.AddAfterInitialize(func(_ context.Context, _ any, _ *mcp.InitializeRequest, _ *mcp.InitializeResult) {
+		m.sessionsInitTotal.Add(1)
+	})
+	return hooks
+}
+
+// Middleware counts tool calls and measures their latency
+func Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		started := time.Now()
+		result, err := next(ctx, request)
+
+		status := "ok"
+		if err != nil || (result != nil && result.IsError) {
+			status = "error"
+		}
+		Default.toolCalls.Inc(request.Params.Name, status)
+		Default.toolDuration.observe(time.Since(started).Seconds(), request.Params.Name)
+		return result, err
+	}
+}
+
+// Handler serves /metrics for Prometheus
+func Handler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	Default.write(w)
+}
+
+// CounterVec is a counter partitioned by label values
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	labels map[string][]string
+}
+
+func newCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]float64),
+		labels:     make(map[string][]string),
+	}
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.labels[key]; !ok {
+		c.labels[key] = labelValues
+	}
+	c.values[key]++
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", c.name, c.help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", c.name)
+	for _, key := range slices.Sorted(maps.Keys(c.values)) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labelNames, c.labels[key], "", ""), formatFloat(c.values[key]))
+	}
+}
+
+// histogramVec is a histogram partitioned by label values
+type histogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*histogram
+}
+
+type histogram struct {
+	labels []string
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogramVec(name, help string, buckets []float64, labelNames ...string) *histogramVec {
+	return &histogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     make(map[string]*histogram),
+	}
+}
+
+func (h *histogramVec) observe(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogram{labels: labelValues, counts: make([]uint64, len(h.buckets))}
+		h.series[key] = series
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.sum += value
+	series.count++
+}
+
+func (h *histogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", h.name)
+	for _, key := range slices.Sorted(maps.Keys(h.series)) {
+		series := h.series[key]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labelNames, series.labels, "le", formatFloat(bound)), series.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labelNames, series.labels, "le", "+Inf"), series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labelNames, series.labels, "", ""), formatFloat(series.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labelNames, series.labels, "", ""), series.count)
+	}
+}
+
+// formatLabels renders {name="value",...}, with an optional extra label such as le
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escapeLabelValue(values[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer("\\", `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
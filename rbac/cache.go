@@ -0,0 +1,201 @@
+package rbac
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+	"kanboard-mcp/tracing"
+)
+
+// Default lifetime of a cached UserContext when KANBOARD_RBAC_CACHE_TTL is not set
+const defaultUserContextCacheTTL = 60 * time.Second
+
+// userContextCache keeps the last resolved UserContext so that permission checks
+// don't have to call getMe and getMyProjects before every tool call
+type userContextCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	userCtx   *UserContext
+	expiresAt time.Time
+	now       func() time.Time
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	invalidations atomic.Int64
+}
+
+// UserContextCacheStats reports the cache counters for diagnostics
+type UserContextCacheStats struct {
+	Enabled       bool   `json:"enabled"`
+	TTL           string `json:"ttl"`
+	Cached        bool   `json:"cached"`
+	ExpiresIn     string `json:"expires_in,omitempty"`
+	Hits          int64  `json:"hits"`
+	Misses        int64  `json:"misses"`
+	Invalidations int64  `json:"invalidations"`
+}
+
+// newUserContextCache creates a cache with the given TTL (a TTL <= 0 disables caching)
+func newUserContextCache(ttl time.Duration) *userContextCache {
+	return &userContextCache{ttl: ttl, now: time.Now}
+}
+
+// userContextCacheTTLFromEnv reads KANBOARD_RBAC_CACHE_TTL as a Go duration ("30s", "5m", "0" to disable)
+func userContextCacheTTLFromEnv() time.Duration {
+	value := os.Getenv("KANBOARD_RBAC_CACHE_TTL")
+	if value == "" {
+		return defaultUserContextCacheTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		// Accept a bare number of seconds as well
+		seconds, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			logging.RBAC.Warn("invalid KANBOARD_RBAC_CACHE_TTL, using default", "value", value, "default", defaultUserContextCacheTTL)
+			return defaultUserContextCacheTTL
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	return ttl
+}
+
+// get returns the cached user context if it is still fresh
+func (c *userContextCache) get() (*UserContext, bool) {
+	if c.ttl <= 0 {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.userCtx != nil && c.now().Before(c.expiresAt) {
+		c.hits.Add(1)
+		return c.userCtx, true
+	}
+	c.misses.Add(1)
+	return nil, false
+}
+
+// set stores a freshly resolved user context
+func (c *userContextCache) set(userCtx *UserContext) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.userCtx = userCtx
+	c.expiresAt = c.now().Add(c.ttl)
+}
+
+// invalidate drops the cached user context so the next check reloads it
+func (c *userContextCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.userCtx != nil {
+		c.invalidations.Add(1)
+	}
+	c.userCtx = nil
+	c.expiresAt = time.Time{}
+}
+
+// stats returns a snapshot of the cache counters
+func (c *userContextCache) stats() UserContextCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := UserContextCacheStats{
+		Enabled:       c.ttl > 0,
+		TTL:           c.ttl.String(),
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+	if c.userCtx != nil && c.now().Before(c.expiresAt) {
+		stats.Cached = true
+		stats.ExpiresIn = c.expiresAt.Sub(c.now()).Round(time.Second).String()
+	}
+	return stats
+}
+
+// UserContext returns the user behind the Kanboard client, served from the cache when possible
+func (rbac *Manager) UserContext(ctx context.Context, kc *kanboard.Client) (*UserContext, error) {
+	ctx, s := tracing.Default.Start(ctx, "rbac.getUserContext", tracing.KindInternal)
+	defer s.End()
+
+	cache := rbac.userContextCache(kc)
+	if userCtx, ok := cache.get(); ok {
+		s.SetAttribute("rbac.cache_hit", true)
+		logging.RBAC.DebugContext(ctx, "user context cache hit", "user", userCtx.Username)
+		return userCtx, nil
+	}
+
+	s.SetAttribute("rbac.cache_hit", false)
+	userCtx, err := rbac.loadUserContext(ctx, kc)
+	if err != nil {
+		s.RecordError(err)
+		return nil, err
+	}
+	cache.set(userCtx)
+
+	logging.RBAC.DebugContext(ctx, "user context cache miss", "user", userCtx.Username)
+	return userCtx, nil
+}
+
+// userContextCache returns the cache of the user behind a Kanboard client
+func (rbac *Manager) userContextCache(kc *kanboard.Client) *userContextCache {
+	rbac.mu.Lock()
+	defer rbac.mu.Unlock()
+
+	cache, ok := rbac.userCtxCaches[kc]
+	if !ok {
+		cache = newUserContextCache(rbac.cacheTTL)
+		rbac.userCtxCaches[kc] = cache
+	}
+	return cache
+}
+
+// InvalidateUserContext forces the next permission check through the client to reload roles from Kanboard
+func (rbac *Manager) InvalidateUserContext(kc *kanboard.Client) {
+	rbac.userContextCache(kc).invalidate()
+	logging.RBAC.Debug("user context cache invalidated")
+}
+
+// InvalidateInstance drops the cached user contexts of every client of the Kanboard
+// server kc talks to, the session clients included, after role assignments changed there
+func (rbac *Manager) InvalidateInstance(kc *kanboard.Client) {
+	rbac.mu.Lock()
+	var caches []*userContextCache
+	for client, cache := range rbac.userCtxCaches {
+		if client == kc || client.Endpoint() == kc.Endpoint() {
+			caches = append(caches, cache)
+		}
+	}
+	rbac.mu.Unlock()
+
+	for _, cache := range caches {
+		cache.invalidate()
+	}
+	logging.RBAC.Debug("user context caches invalidated", "endpoint", kc.Endpoint(), "clients", len(caches))
+}
+
+// CacheStats reports the counters of the user context cache of a Kanboard client
+func (rbac *Manager) CacheStats(kc *kanboard.Client) UserContextCacheStats {
+	return rbac.userContextCache(kc).stats()
+}
+
+// ForgetClient drops the user context cache of a Kanboard client that is no longer used
+func (rbac *Manager) ForgetClient(kc *kanboard.Client) {
+	rbac.mu.Lock()
+	defer rbac.mu.Unlock()
+	delete(rbac.userCtxCaches, kc)
+}
@@ -0,0 +1,122 @@
+package rbac
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/kanboard/kanboardtest"
+)
+
+// fakeClock is a manually advanced clock for the user context cache
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestUserContextCache(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := newUserContextCache(time.Minute)
+	cache.now = clock.Now
+	alice := &UserContext{Username: "alice"}
+
+	steps := []struct {
+		name      string
+		do        func()
+		wantHit   bool
+		wantStats UserContextCacheStats
+	}{
+		{"empty", func() {}, false, UserContextCacheStats{Misses: 1}},
+		{"stored", func() { cache.set(alice) }, true, UserContextCacheStats{Cached: true, ExpiresIn: "1m0s", Hits: 1, Misses: 1}},
+		{"fresh", func() { clock.Advance(59 * time.Second) }, true, UserContextCacheStats{Cached: true, ExpiresIn: "1s", Hits: 2, Misses: 1}},
+		{"expired", func() { clock.Advance(time.Second) }, false, UserContextCacheStats{Hits: 2, Misses: 2}},
+		{"reloaded", func() { cache.set(alice) }, true, UserContextCacheStats{Cached: true, ExpiresIn: "1m0s", Hits: 3, Misses: 2}},
+		{"invalidated", cache.invalidate, false, UserContextCacheStats{Hits: 3, Misses: 3, Invalidations: 1}},
+		// Invalidating an empty cache is not counted
+		{"invalidated again", cache.invalidate, false, UserContextCacheStats{Hits: 3, Misses: 4, Invalidations: 1}},
+	}
+	for _, step := range steps {
+		step.do()
+		userCtx, hit := cache.get()
+		if hit != step.wantHit || hit && userCtx != alice {
+			t.Fatalf("%s: get() = %v, %v, want hit %v", step.name, userCtx, hit, step.wantHit)
+		}
+		want := step.wantStats
+		want.Enabled, want.TTL = true, "1m0s"
+		if stats := cache.stats(); stats != want {
+			t.Fatalf("%s: stats %+v, want %+v", step.name, stats, want)
+		}
+	}
+}
+
+func TestUserContextCacheDisabled(t *testing.T) {
+	cache := newUserContextCache(0)
+	cache.set(&UserContext{Username: "alice"})
+	if _, hit := cache.get(); hit {
+		t.Fatal("a cache with a zero TTL must not keep user contexts")
+	}
+	if stats := cache.stats(); stats.Enabled || stats.Misses != 1 {
+		t.Fatalf("stats %+v", stats)
+	}
+}
+
+func TestUserContextCacheTTLFromEnv(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":     defaultUserContextCacheTTL,
+		"5m":   5 * time.Minute,
+		"30":   30 * time.Second,
+		"0":    0,
+		"soon": defaultUserContextCacheTTL,
+	}
+	for value, want := range cases {
+		t.Setenv("KANBOARD_RBAC_CACHE_TTL", value)
+		if ttl := userContextCacheTTLFromEnv(); ttl != want {
+			t.Errorf("KANBOARD_RBAC_CACHE_TTL=%q gives %v, want %v", value, ttl, want)
+		}
+	}
+}
+
+// Group memberships grant project roles, changing them reloads the roles of every
+// client of the instance
+func TestGroupMembershipInvalidatesUserContexts(t *testing.T) {
+	kb := kanboardtest.NewServer()
+	defer kb.Close()
+	manager, err := NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := newTestRegistry()
+	admin, viewer := userClient(kb, "admin"), userClient(kb, "viewer")
+	if _, err := admin.Call(context.Background(), "addProjectGroup", map[string]any{"project_id": 2, "group_id": 1, "role": "project-viewer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		kc   *kanboard.Client
+		tool string
+		args map[string]any
+		want string
+	}{
+		{viewer, "get_task", map[string]any{"task_id": "4"}, "access denied"},
+		{admin, "add_group_member", map[string]any{"group_id": 1, "user_id": "viewer"}, "true"},
+		{viewer, "get_task", map[string]any{"task_id": "4"}, "Set up CI"},
+		{admin, "remove_group_member", map[string]any{"group_id": 1, "user_id": "viewer"}, "true"},
+		{viewer, "get_task", map[string]any{"task_id": "4"}, "access denied"},
+	}
+	for i, step := range steps {
+		result := callTool(t, manager, registry, step.kc, step.tool, step.args)
+		if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, step.want) {
+			t.Fatalf("step %d %s: expected %q, got %s", i, step.tool, step.want, text)
+		}
+	}
+	if stats := manager.CacheStats(viewer); stats.Invalidations != 2 {
+		t.Errorf("the viewer's roles were invalidated %d times, want 2", stats.Invalidations)
+	}
+}
@@ -0,0 +1,240 @@
+package rbac
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in application role that is granted to unauthenticated users
+const rolePublic = "app-public"
+
+// knownAPIProcedures lists the JSON-RPC procedures shipped with Kanboard.
+// Procedures added by plugins must be declared in the "procedures" section of RBAC_CONFIG.
+var knownAPIProcedures = []string{
+	"actionprocedure",
+	"appprocedure",
+	"boardprocedure",
+	"categoryprocedure",
+	"columnprocedure",
+	"commentprocedure",
+	"groupmemberprocedure",
+	"groupprocedure",
+	"linkprocedure",
+	"meprocedure",
+	"projectfileprocedure",
+	"projectmetadataprocedure",
+	"projectpermissionprocedure",
+	"projectprocedure",
+	"subtaskprocedure",
+	"subtasktimetrackingprocedure",
+	"swimlaneprocedure",
+	"tagprocedure",
+	"taskexternallinkprocedure",
+	"taskfileprocedure",
+	"tasklinkprocedure",
+	"taskmetadataprocedure",
+	"taskprocedure",
+	"tasktagprocedure",
+	"userprocedure",
+}
+
+// rbacConfigMode reads RBAC_CONFIG_MODE: "merge" (default) or "replace"
+func rbacConfigMode() (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("RBAC_CONFIG_MODE")))
+	switch mode {
+	case "", "merge":
+		return "merge", nil
+	case "replace":
+		return "replace", nil
+	default:
+		return "", fmt.Errorf("unsupported RBAC_CONFIG_MODE: %s (supported: merge, replace)", mode)
+	}
+}
+
+// loadRBACConfigFile reads an RBAC policy from a JSON or YAML file.
+// Unknown fields are rejected so that typos don't silently weaken the policy.
+func loadRBACConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RBAC config file: %w", err)
+	}
+
+	var config Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&config); err != nil {
+			return nil, fmt.Errorf("failed to parse RBAC config file %s: %w", path, err)
+		}
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&config); err != nil {
+			return nil, fmt.Errorf("failed to parse RBAC config file %s: %w", path, err)
+		}
+	}
+
+	return &config, nil
+}
+
+// merge overlays another policy on top of this one.
+// Roles, hierarchy entries and individual access rules from other win; nothing is removed.
+func (config *Config) merge(other *Config) {
+	config.Roles.ApplicationRoles = mergeStringMap(config.Roles.ApplicationRoles, other.Roles.ApplicationRoles)
+	config.Roles.ProjectRoles = mergeStringMap(config.Roles.ProjectRoles, other.Roles.ProjectRoles)
+	config.Roles.AllRoles = mergeStringMap(config.Roles.AllRoles, other.Roles.AllRoles)
+
+	config.Hierarchies.Application = mergeHierarchy(config.Hierarchies.Application, other.Hierarchies.Application)
+	config.Hierarchies.Project = mergeHierarchy(config.Hierarchies.Project, other.Hierarchies.Project)
+
+	config.AccessMaps.Project.merge(other.AccessMaps.Project)
+	config.AccessMaps.Application.merge(other.AccessMaps.Application)
+	config.AccessMaps.API.merge(other.AccessMaps.API)
+	config.AccessMaps.APIProject.merge(other.AccessMaps.APIProject)
+
+	for _, procedure := range other.Procedures {
+		if !slices.Contains(config.Procedures, procedure) {
+			config.Procedures = append(config.Procedures, procedure)
+		}
+	}
+}
+
+func (accessMap *AccessMapConfig) merge(other AccessMapConfig) {
+	if other.DefaultRole != "" {
+		accessMap.DefaultRole = other.DefaultRole
+	}
+	if len(other.Rules) == 0 {
+		return
+	}
+	if accessMap.Rules == nil {
+		accessMap.Rules = make(map[string]map[string]string)
+	}
+	for procedure, methods := range other.Rules {
+		if accessMap.Rules[procedure] == nil {
+			accessMap.Rules[procedure] = make(map[string]string)
+		}
+		for method, role := range methods {
+			accessMap.Rules[procedure][method] = role
+		}
+	}
+}
+
+func mergeStringMap(base, overlay map[string]string) map[string]string {
+	if base == nil {
+		base = make(map[string]string)
+	}
+	for key, value := range overlay {
+		base[key] = value
+	}
+	return base
+}
+
+func mergeHierarchy(base, overlay map[string][]string) map[string][]string {
+	if base == nil {
+		base = make(map[string][]string)
+	}
+	for role, inherited := range overlay {
+		base[role] = inherited
+	}
+	return base
+}
+
+// validate checks that every role and procedure referenced by the policy is known
+func (config *Config) validate() error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	appRoles := map[string]bool{rolePublic: true}
+	for role := range config.Roles.ApplicationRoles {
+		appRoles[role] = true
+	}
+	projectRoles := make(map[string]bool)
+	for role := range config.Roles.ProjectRoles {
+		projectRoles[role] = true
+	}
+
+	if len(config.Roles.ApplicationRoles) == 0 {
+		addProblem("roles.application_roles: at least one application role is required")
+	}
+	if len(config.Roles.ProjectRoles) == 0 {
+		addProblem("roles.project_roles: at least one project role is required")
+	}
+	for role := range config.Roles.AllRoles {
+		if !appRoles[role] && !projectRoles[role] {
+			addProblem("roles.all_roles: unknown role %q (not declared in application_roles or project_roles)", role)
+		}
+	}
+
+	checkHierarchy := func(path string, hierarchy map[string][]string, known map[string]bool) {
+		for _, role := range slices.Sorted(maps.Keys(hierarchy)) {
+			if !known[role] {
+				addProblem("%s: unknown role %q", path, role)
+			}
+			for _, inherited := range hierarchy[role] {
+				if !known[inherited] {
+					addProblem("%s.%s: unknown inherited role %q", path, role, inherited)
+				}
+			}
+		}
+	}
+	checkHierarchy("hierarchies.application", config.Hierarchies.Application, appRoles)
+	checkHierarchy("hierarchies.project", config.Hierarchies.Project, projectRoles)
+
+	procedures := make(map[string]bool)
+	for _, procedure := range knownAPIProcedures {
+		procedures[procedure] = true
+	}
+	for _, procedure := range config.Procedures {
+		procedures[strings.ToLower(procedure)] = true
+	}
+
+	checkAccessMap := func(path string, accessMap AccessMapConfig, known map[string]bool, checkProcedures bool) {
+		if accessMap.DefaultRole == "" {
+			addProblem("%s.default_role: must not be empty", path)
+		} else if !known[accessMap.DefaultRole] {
+			addProblem("%s.default_role: unknown role %q", path, accessMap.DefaultRole)
+		}
+		for _, procedure := range slices.Sorted(maps.Keys(accessMap.Rules)) {
+			if procedure != strings.ToLower(procedure) {
+				addProblem("%s.rules.%s: procedure names must be lowercase", path, procedure)
+			} else if checkProcedures && !procedures[procedure] {
+				addProblem("%s.rules.%s: unknown procedure (declare plugin procedures under \"procedures\")", path, procedure)
+			}
+			methods := accessMap.Rules[procedure]
+			for _, method := range slices.Sorted(maps.Keys(methods)) {
+				if method != strings.ToLower(method) {
+					addProblem("%s.rules.%s.%s: method names must be lowercase", path, procedure, method)
+				}
+				if role := methods[method]; !known[role] {
+					addProblem("%s.rules.%s.%s: unknown role %q", path, procedure, method, role)
+				}
+			}
+		}
+	}
+	checkAccessMap("access_maps.project", config.AccessMaps.Project, projectRoles, false)
+	checkAccessMap("access_maps.application", config.AccessMaps.Application, appRoles, false)
+	checkAccessMap("access_maps.api", config.AccessMaps.API, appRoles, true)
+	checkAccessMap("access_maps.api_project", config.AccessMaps.APIProject, projectRoles, true)
+
+	if len(problems) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(problems)+1)
+	errs = append(errs, fmt.Errorf("invalid RBAC config (%d problems):", len(problems)))
+	for _, problem := range problems {
+		errs = append(errs, errors.New("  - "+problem))
+	}
+	return errors.Join(errs...)
+}
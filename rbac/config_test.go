@@ -0,0 +1,162 @@
+package rbac
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// minimalConfig is a complete policy on its own, for RBAC_CONFIG_MODE=replace
+const minimalConfig = `
+roles:
+  application_roles: {app-admin: Administrator, app-user: User}
+  project_roles: {project-member: Member}
+hierarchies:
+  application: {app-admin: [app-user, app-public]}
+access_maps:
+  project: {default_role: project-member}
+  application: {default_role: app-user}
+  api: {default_role: app-user, rules: {userprocedure: {createuser: app-admin}}}
+  api_project: {default_role: project-member}
+`
+
+// writeConfig stores an RBAC config file in a temporary directory
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConfigMergeAndReplace(t *testing.T) {
+	t.Run("merge", func(t *testing.T) {
+		t.Setenv("RBAC_CONFIG_MODE", "")
+		manager, err := NewManager(writeConfig(t, "rbac.yaml", `
+access_maps:
+  api_project:
+    rules:
+      taskprocedure: {removetask: project-manager}
+      myplugin: {run: project-member}
+procedures: [myplugin]
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules := manager.config.AccessMaps.APIProject.Rules
+		if rules["taskprocedure"]["removetask"] != "project-manager" || rules["myplugin"]["run"] != "project-member" {
+			t.Errorf("overlay rules missing: %v", rules)
+		}
+		// Nothing the overlay doesn't mention is removed
+		if rules["taskprocedure"]["createtask"] == "" || manager.config.Roles.ApplicationRoles["app-manager"] == "" {
+			t.Errorf("merge dropped built-in rules or roles")
+		}
+		if manager.config.AccessMaps.APIProject.DefaultRole == "" {
+			t.Errorf("merge cleared the default role")
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		t.Setenv("RBAC_CONFIG_MODE", "replace")
+		manager, err := NewManager(writeConfig(t, "rbac.yml", minimalConfig))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := manager.config.Roles.ApplicationRoles["app-manager"]; ok {
+			t.Errorf("replace kept the built-in roles: %v", manager.config.Roles.ApplicationRoles)
+		}
+		if rules := manager.config.AccessMaps.API.Rules; len(rules) != 1 || rules["userprocedure"]["createuser"] != "app-admin" {
+			t.Errorf("replace kept the built-in rules: %v", rules)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Setenv("RBAC_CONFIG_MODE", "merge")
+		manager, err := NewManager(writeConfig(t, "rbac.json", `{"access_maps": {"api": {"rules": {"userprocedure": {"createuser": "app-manager"}}}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if role := manager.config.AccessMaps.API.Rules["userprocedure"]["createuser"]; role != "app-manager" {
+			t.Errorf("createuser needs %q, want app-manager", role)
+		}
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		t.Setenv("RBAC_CONFIG_MODE", "append")
+		if _, err := NewManager(writeConfig(t, "rbac.yaml", minimalConfig)); err == nil || !strings.Contains(err.Error(), "RBAC_CONFIG_MODE") {
+			t.Errorf("error = %v, want unsupported RBAC_CONFIG_MODE", err)
+		}
+	})
+}
+
+func TestConfigValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		mode    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "unknown application role",
+			config:  `access_maps: {api: {rules: {taskprocedure: {createtask: app-root}}}}`,
+			wantErr: `access_maps.api.rules.taskprocedure.createtask: unknown role "app-root"`,
+		},
+		{
+			name:    "project role in an application map",
+			config:  `access_maps: {api: {rules: {taskprocedure: {createtask: project-member}}}}`,
+			wantErr: `unknown role "project-member"`,
+		},
+		{
+			name:    "unknown project role",
+			config:  `access_maps: {api_project: {default_role: project-owner}}`,
+			wantErr: `access_maps.api_project.default_role: unknown role "project-owner"`,
+		},
+		{
+			name:    "unknown inherited role",
+			config:  `hierarchies: {project: {project-manager: [project-member, project-guest]}}`,
+			wantErr: `hierarchies.project.project-manager: unknown inherited role "project-guest"`,
+		},
+		{
+			name:    "role outside application_roles and project_roles",
+			config:  `roles: {all_roles: {app-auditor: Auditor}}`,
+			wantErr: `roles.all_roles: unknown role "app-auditor"`,
+		},
+		{
+			name:    "undeclared plugin procedure",
+			config:  `access_maps: {api: {rules: {myplugin: {run: app-user}}}}`,
+			wantErr: "access_maps.api.rules.myplugin: unknown procedure",
+		},
+		{
+			name:    "uppercase method",
+			config:  `access_maps: {api: {rules: {taskprocedure: {createTask: app-user}}}}`,
+			wantErr: "method names must be lowercase",
+		},
+		{
+			name:    "misspelled field",
+			config:  `access_map: {api: {default_role: app-user}}`,
+			wantErr: "field access_map not found",
+		},
+		{
+			name:    "replace without roles",
+			mode:    "replace",
+			config:  `access_maps: {api: {default_role: app-user}}`,
+			wantErr: "roles.application_roles: at least one application role is required",
+		},
+		{name: "declared plugin procedure", config: `{procedures: [myplugin], access_maps: {api: {rules: {myplugin: {run: app-user}}}}}`},
+		{name: "complete replacement", mode: "replace", config: minimalConfig},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("RBAC_CONFIG_MODE", c.mode)
+			_, err := NewManager(writeConfig(t, "rbac.yaml", c.config))
+			switch {
+			case c.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+				t.Fatalf("error = %v, want %q", err, c.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+)
+
+// dryRunPreview is returned to the client instead of the tool result
+type dryRunPreview struct {
+	DryRun       bool                  `json:"dry_run"`
+	Tool         string                `json:"tool"`
+	WouldExecute bool                  `json:"would_execute"`
+	RBAC         *Decision             `json:"rbac"`
+	Resolved     resolvedIDs           `json:"resolved"`
+	Calls        []kanboard.DryRunCall `json:"calls"`
+	HandlerNote  string                `json:"handler_note,omitempty"`
+}
+
+type resolvedIDs struct {
+	ProjectIDs []int `json:"project_ids,omitempty"`
+}
+
+// dryRun runs the tool handler with mutating calls recorded and returns the preview.
+// Read calls still reach Kanboard so that names and IDs are validated and resolved.
+func (rbac *Manager) dryRun(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest, next server.ToolHandlerFunc, decision *Decision) (*mcp.CallToolResult, error) {
+	preview := dryRunPreview{
+		DryRun:   true,
+		Tool:     request.Params.Name,
+		RBAC:     decision,
+		Resolved: resolvedIDs{ProjectIDs: decision.ProjectIDs},
+		Calls:    []kanboard.DryRunCall{},
+	}
+	if decision.Procedure != "" {
+		if userCtx, err := rbac.UserContext(ctx, kc); err == nil {
+			decision.User = userCtx.Username
+		}
+	}
+
+	if decision.Allowed {
+		dryRunCtx, recorder := kanboard.WithDryRunRecorder(ctx)
+		result, err := next(dryRunCtx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, call := range recorder.Calls() {
+			// Passwords and file contents are not echoed back
+			preview.Calls = append(preview.Calls, call.Redacted())
+		}
+
+		if result != nil && result.IsError {
+			// Validation failed before anything would have been written
+			if len(preview.Calls) == 0 {
+				return result, nil
+			}
+			// The handler couldn't interpret the stand-in response of a recorded call
+			preview.HandlerNote = "handler stopped after the recorded calls: " + logging.ResultText(result)
+		}
+		preview.WouldExecute = true
+	}
+
+	resultBytes, err := json.MarshalIndent(preview, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal dry-run preview: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,128 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/kanboard/kanboardtest"
+	"kanboard-mcp/tools"
+	"kanboard-mcp/tools/groups"
+	"kanboard-mcp/tools/tasks"
+	"kanboard-mcp/tools/users"
+)
+
+// newTestRegistry registers the tool domains the rbac tests call
+func newTestRegistry() *tools.Registry {
+	registry := tools.NewRegistry()
+	users.Register(registry)
+	groups.Register(registry)
+	tasks.Register(registry)
+	return registry
+}
+
+// userClient is a Kanboard client of the fake Kanboard logged in as username
+func userClient(kb *kanboardtest.Server, username string) *kanboard.Client {
+	return kanboard.NewClient(kb.Endpoint(), "", username, username+"-secret")
+}
+
+// callTool runs a tool call through the instance and RBAC middlewares with the client kc
+func callTool(t *testing.T, manager *Manager, registry *tools.Registry, kc *kanboard.Client, name string, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	registry.Instances = []*tools.Instance{{Name: "default", Client: kc}}
+	tool, _ := registry.Lookup(name)
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return tool.Handler(ctx, tools.ClientFromContext(ctx), request)
+	}
+	var request mcp.CallToolRequest
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := registry.InstanceMiddleware()(manager.Middleware(registry)(handler))(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestDryRunPreview(t *testing.T) {
+	cases := []struct {
+		name         string
+		user         string
+		tool         string
+		args         map[string]any
+		wouldExecute bool
+		methods      []string
+		want         []string
+		notWant      []string
+	}{
+		{
+			name: "create user", user: "admin", tool: "create_user",
+			args:         map[string]any{"username": "eve", "password": "eve-secret", "dry_run": true},
+			wouldExecute: true, methods: []string{"createUser"},
+			want: []string{`"username": "eve"`, `"password": "[REDACTED]"`}, notWant: []string{"eve-secret"},
+		},
+		{
+			name: "denied", user: "alice", tool: "create_user",
+			args:         map[string]any{"username": "eve", "password": "eve-secret", "dry_run": true},
+			wouldExecute: false, want: []string{`"allowed": false`}, notWant: []string{"eve-secret"},
+		},
+		{
+			// The handler reads the placeholder ID of the file that would be created
+			name: "create task file", user: "alice", tool: "create_task_file",
+			args:         map[string]any{"project_id": "1", "task_id": "1", "filename": "notes.txt", "blob": "c2VjcmV0IG5vdGVz", "dry_run": true},
+			wouldExecute: true, methods: []string{"createTaskFile"},
+			want: []string{`"notes.txt"`, `"[REDACTED 16 bytes]"`}, notWant: []string{"c2VjcmV0IG5vdGVz", "handler_note"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kb := kanboardtest.NewServer()
+			defer kb.Close()
+			manager, err := NewManager("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := callTool(t, manager, newTestRegistry(), userClient(kb, c.user), c.tool, c.args)
+			if result.IsError {
+				t.Fatalf("dry run failed: %v", result.Content)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+
+			var preview struct {
+				WouldExecute bool                  `json:"would_execute"`
+				Calls        []kanboard.DryRunCall `json:"calls"`
+			}
+			if err := json.Unmarshal([]byte(text), &preview); err != nil {
+				t.Fatalf("invalid preview: %v\n%s", err, text)
+			}
+			if preview.WouldExecute != c.wouldExecute {
+				t.Errorf("would_execute = %v, want %v", preview.WouldExecute, c.wouldExecute)
+			}
+			var methods []string
+			for _, call := range preview.Calls {
+				methods = append(methods, call.Method)
+			}
+			if !slices.Equal(methods, c.methods) {
+				t.Errorf("previewed calls %v, want %v", methods, c.methods)
+			}
+			for _, want := range c.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("preview misses %s:\n%s", want, text)
+				}
+			}
+			for _, notWant := range c.notWant {
+				if strings.Contains(text, notWant) {
+					t.Errorf("preview contains %s:\n%s", notWant, text)
+				}
+			}
+			if slices.ContainsFunc(kb.Methods(), kanboard.IsMutatingMethod) {
+				t.Errorf("a dry run reached Kanboard: %v", kb.Methods())
+			}
+		})
+	}
+}
@@ -0,0 +1,794 @@
+// Package rbac enforces role-based access control on tool calls.
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+)
+
+// RBAC Configuration embedded as JSON
+const rbacConfigJSON = `{
+    "roles": {
+        "application_roles": {
+            "app-admin": "Administrator",
+            "app-manager": "Manager",
+            "app-user": "User"
+        },
+        "project_roles": {
+            "project-manager": "Project Manager",
+            "project-member": "Project Member",
+            "project-viewer": "Project Viewer"
+        },
+        "all_roles": {
+            "app-admin": "Administrator",
+            "app-manager": "Manager",
+            "app-user": "User",
+            "project-manager": "Project Manager",
+            "project-member": "Project Member",
+            "project-viewer": "Project Viewer"
+        }
+    },
+    "hierarchies": {
+        "application": {
+            "app-admin": [
+                "app-manager",
+                "app-user",
+                "app-public"
+            ],
+            "app-manager": [
+                "app-user",
+                "app-public"
+            ],
+            "app-user": [
+                "app-public"
+            ]
+        },
+        "project": {
+            "project-manager": [
+                "project-member",
+                "project-viewer"
+            ],
+            "project-member": [
+                "project-viewer"
+            ]
+        }
+    },
+    "access_maps": {
+        "project": {
+            "default_role": "project-viewer",
+            "rules": {
+                "actioncontroller": {
+                    "*": "project-manager"
+                },
+                "projectactionduplicationcontroller": {
+                    "*": "project-manager"
+                },
+                "actioncreationcontroller": {
+                    "*": "project-manager"
+                },
+                "analyticcontroller": {
+                    "*": "project-manager"
+                },
+                "boardajaxcontroller": {
+                    "save": "project-member"
+                },
+                "boardpopovercontroller": {
+                    "*": "project-member"
+                },
+                "taskpopovercontroller": {
+                    "*": "project-member"
+                },
+                "calendarcontroller": {
+                    "save": "project-member"
+                },
+                "categorycontroller": {
+                    "*": "project-manager"
+                },
+                "columncontroller": {
+                    "*": "project-manager"
+                },
+                "commentcontroller": {
+                    "create": "project-member",
+                    "save": "project-member",
+                    "edit": "project-member",
+                    "update": "project-member",
+                    "confirm": "project-member",
+                    "remove": "project-member"
+                },
+                "commentlistcontroller": {
+                    "save": "project-member"
+                },
+                "commentmailcontroller": {
+                    "*": "project-member"
+                },
+                "customfiltercontroller": {
+                    "*": "project-member"
+                },
+                "exportcontroller": {
+                    "*": "project-manager"
+                },
+                "taskfilecontroller": {
+                    "screenshot": "project-member",
+                    "create": "project-member",
+                    "save": "project-member",
+                    "remove": "project-member",
+                    "confirm": "project-member"
+                },
+                "projectviewcontroller": {
+                    "share": "project-manager",
+                    "updatesharing": "project-manager",
+                    "integrations": "project-manager",
+                    "updateintegrations": "project-manager",
+                    "notifications": "project-manager",
+                    "updatenotifications": "project-manager",
+                    "duplicate": "project-manager",
+                    "doduplication": "project-manager"
+                },
+                "projectpermissioncontroller": {
+                    "*": "project-manager"
+                },
+                "projecteditcontroller": {
+                    "*": "project-manager"
+                },
+                "projectpredefinedcontentcontroller": {
+                    "*": "project-manager"
+                },
+                "predefinedtaskdescriptioncontroller": {
+                    "*": "project-manager"
+                },
+                "projectfilecontroller": {
+                    "*": "project-member"
+                },
+                "projectuseroverviewcontroller": {
+                    "*": "project-manager"
+                },
+                "projectstatuscontroller": {
+                    "*": "project-manager"
+                },
+                "projecttagcontroller": {
+                    "*": "project-manager"
+                },
+                "subtaskcontroller": {
+                    "*": "project-member"
+                },
+                "subtaskconvertercontroller": {
+                    "*": "project-member"
+                },
+                "subtaskrestrictioncontroller": {
+                    "*": "project-member"
+                },
+                "subtaskstatuscontroller": {
+                    "*": "project-member"
+                },
+                "swimlanecontroller": {
+                    "*": "project-manager"
+                },
+                "tasksuppressioncontroller": {
+                    "*": "project-member"
+                },
+                "taskcreationcontroller": {
+                    "*": "project-member"
+                },
+                "taskbulkcontroller": {
+                    "*": "project-member"
+                },
+                "taskbulkmovecolumncontroller": {
+                    "*": "project-member"
+                },
+                "taskbulkchangepropertycontroller": {
+                    "*": "project-member"
+                },
+                "taskduplicationcontroller": {
+                    "*": "project-member"
+                },
+                "taskrecurrencecontroller": {
+                    "*": "project-member"
+                },
+                "taskimportcontroller": {
+                    "*": "project-manager"
+                },
+                "taskinternallinkcontroller": {
+                    "*": "project-member"
+                },
+                "taskexternallinkcontroller": {
+                    "*": "project-member"
+                },
+                "taskmodificationcontroller": {
+                    "*": "project-member"
+                },
+                "taskstatuscontroller": {
+                    "*": "project-member"
+                },
+                "taskmailcontroller": {
+                    "*": "project-member"
+                },
+                "userajaxcontroller": {
+                    "mention": "project-member"
+                }
+            }
+        },
+        "application": {
+            "default_role": "app-user",
+            "rules": {
+                "authcontroller": {
+                    "login": "app-public",
+                    "check": "app-public"
+                },
+                "captchacontroller": {
+                    "*": "app-public"
+                },
+                "passwordresetcontroller": {
+                    "*": "app-public"
+                },
+                "taskviewcontroller": {
+                    "readonly": "app-public"
+                },
+                "boardviewcontroller": {
+                    "readonly": "app-public"
+                },
+                "icalendarcontroller": {
+                    "*": "app-public"
+                },
+                "feedcontroller": {
+                    "*": "app-public"
+                },
+                "avatarfilecontroller": {
+                    "show": "app-public",
+                    "image": "app-public"
+                },
+                "userinvitecontroller": {
+                    "signup": "app-public",
+                    "register": "app-public"
+                },
+                "cronjobcontroller": {
+                    "run": "app-public"
+                },
+                "configcontroller": {
+                    "*": "app-admin"
+                },
+                "tagcontroller": {
+                    "*": "app-admin"
+                },
+                "plugincontroller": {
+                    "*": "app-admin"
+                },
+                "currencycontroller": {
+                    "*": "app-admin"
+                },
+                "grouplistcontroller": {
+                    "*": "app-admin"
+                },
+                "groupcreationcontroller": {
+                    "*": "app-admin"
+                },
+                "groupmodificationcontroller": {
+                    "*": "app-admin"
+                },
+                "linkcontroller": {
+                    "*": "app-admin"
+                },
+                "projectcreationcontroller": {
+                    "create": "app-manager"
+                },
+                "projectuseroverviewcontroller": {
+                    "*": "app-manager"
+                },
+                "twofactorcontroller": {
+                    "disable": "app-admin"
+                },
+                "userimportcontroller": {
+                    "*": "app-admin"
+                },
+                "usercreationcontroller": {
+                    "*": "app-admin"
+                },
+                "userlistcontroller": {
+                    "*": "app-admin"
+                },
+                "userstatuscontroller": {
+                    "*": "app-admin"
+                },
+                "usercredentialcontroller": {
+                    "changeauthentication": "app-admin",
+                    "saveauthentication": "app-admin",
+                    "unlock": "app-admin"
+                }
+            }
+        },
+        "api": {
+            "default_role": "app-user",
+            "rules": {
+                "userprocedure": {
+                    "*": "app-admin"
+                },
+                "groupmemberprocedure": {
+                    "*": "app-admin"
+                },
+                "groupprocedure": {
+                    "*": "app-admin"
+                },
+                "linkprocedure": {
+                    "*": "app-admin"
+                },
+                "taskprocedure": {
+                    "getoverduetasks": "app-admin"
+                },
+                "projectprocedure": {
+                    "getallprojects": "app-admin",
+                    "createproject": "app-manager"
+                }
+            }
+        },
+        "api_project": {
+            "default_role": "project-viewer",
+            "rules": {
+                "actionprocedure": {
+                    "removeaction": "project-manager",
+                    "getactions": "project-manager",
+                    "createaction": "project-manager"
+                },
+                "categoryprocedure": {
+                    "removecategory": "project-manager",
+                    "createcategory": "project-manager",
+                    "updatecategory": "project-manager"
+                },
+                "columnprocedure": {
+                    "updatecolumn": "project-manager",
+                    "addcolumn": "project-manager",
+                    "removecolumn": "project-manager",
+                    "changecolumnposition": "project-manager"
+                },
+                "commentprocedure": {
+                    "removecomment": "project-member",
+                    "createcomment": "project-member",
+                    "updatecomment": "project-member"
+                },
+                "projectpermissionprocedure": {
+                    "addprojectuser": "project-manager",
+                    "addprojectgroup": "project-manager",
+                    "removeprojectuser": "project-manager",
+                    "removeprojectgroup": "project-manager",
+                    "changeprojectuserrole": "project-manager",
+                    "changeprojectgrouprole": "project-manager"
+                },
+                "projectprocedure": {
+                    "updateproject": "project-manager",
+                    "removeproject": "project-manager",
+                    "enableproject": "project-manager",
+                    "disableproject": "project-manager",
+                    "enableprojectpublicaccess": "project-manager",
+                    "disableprojectpublicaccess": "project-manager"
+                },
+                "subtaskprocedure": {
+                    "removesubtask": "project-member",
+                    "createsubtask": "project-member",
+                    "updatesubtask": "project-member"
+                },
+                "subtasktimetrackingprocedure": {
+                    "setsubtaskstarttime": "project-member",
+                    "setsubtaskendtime": "project-member"
+                },
+                "swimlaneprocedure": {
+                    "addswimlane": "project-manager",
+                    "updateswimlane": "project-manager",
+                    "removeswimlane": "project-manager",
+                    "disableswimlane": "project-manager",
+                    "enableswimlane": "project-manager",
+                    "changeswimlaneposition": "project-manager"
+                },
+                "projectfileprocedure": {
+                    "createprojectfile": "project-member",
+                    "removeprojectfile": "project-member",
+                    "removeallprojectfiles": "project-member"
+                },
+                "taskfileprocedure": {
+                    "createtaskfile": "project-member",
+                    "removetaskfile": "project-member",
+                    "removealltaskfiles": "project-member"
+                },
+                "tasklinkprocedure": {
+                    "createtasklink": "project-member",
+                    "updatetasklink": "project-member",
+                    "removetasklink": "project-member"
+                },
+                "taskexternallinkprocedure": {
+                    "createexternaltasklink": "project-member",
+                    "updateexternaltasklink": "project-member",
+                    "removeexternaltasklink": "project-member"
+                },
+                "taskprocedure": {
+                    "opentask": "project-member",
+                    "closetask": "project-member",
+                    "removetask": "project-member",
+                    "movetaskposition": "project-member",
+                    "movetasktoproject": "project-member",
+                    "duplicatetasktoproject": "project-member",
+                    "createtask": "project-member",
+                    "updatetask": "project-member"
+                },
+                "tasktagprocedure": {
+                    "settasktags": "project-member"
+                },
+                "tagprocedure": {
+                    "createtag": "project-member",
+                    "updatetag": "project-member",
+                    "removetag": "project-member"
+                },
+                "projectmetadataprocedure": {
+                    "saveprojectmetadata": "project-manager",
+                    "removeprojectmetadata": "project-manager"
+                },
+                "taskmetadataprocedure": {
+                    "savetaskmetadata": "project-member",
+                    "removetaskmetadata": "project-member"
+                },
+                "sprintprocedure": {
+                    "createsprint": "project-manager",
+                    "updatesprint": "project-manager",
+                    "removesprint": "project-manager"
+                }
+            }
+        }
+    },
+    "procedures": [
+        "sprintprocedure"
+    ]
+}`
+
+// RBAC Data Structures
+type Config struct {
+	Roles       RolesConfig       `json:"roles" yaml:"roles"`
+	Hierarchies HierarchiesConfig `json:"hierarchies" yaml:"hierarchies"`
+	AccessMaps  AccessMapsConfig  `json:"access_maps" yaml:"access_maps"`
+	// Procedures lists additional JSON-RPC procedures (e.g. from plugins) that may appear in the API access maps
+	Procedures []string `json:"procedures,omitempty" yaml:"procedures,omitempty"`
+}
+
+type RolesConfig struct {
+	ApplicationRoles map[string]string `json:"application_roles" yaml:"application_roles"`
+	ProjectRoles     map[string]string `json:"project_roles" yaml:"project_roles"`
+	AllRoles         map[string]string `json:"all_roles" yaml:"all_roles"`
+}
+
+type HierarchiesConfig struct {
+	Application map[string][]string `json:"application" yaml:"application"`
+	Project     map[string][]string `json:"project" yaml:"project"`
+}
+
+type AccessMapsConfig struct {
+	Project     AccessMapConfig `json:"project" yaml:"project"`
+	Application AccessMapConfig `json:"application" yaml:"application"`
+	API         AccessMapConfig `json:"api" yaml:"api"`
+	APIProject  AccessMapConfig `json:"api_project" yaml:"api_project"`
+}
+
+type AccessMapConfig struct {
+	DefaultRole string                       `json:"default_role" yaml:"default_role"`
+	Rules       map[string]map[string]string `json:"rules" yaml:"rules"`
+}
+
+// UserContext holds information about the current user and their permissions
+type UserContext struct {
+	UserID       int            `json:"user_id"`
+	Username     string         `json:"username"`
+	AppRoles     []string       `json:"app_roles"`
+	ProjectRoles map[int]string `json:"project_roles"` // project_id -> role
+	IsAdmin      bool           `json:"is_admin"`
+}
+
+// PermissionCheck represents a permission check request
+type PermissionCheck struct {
+	UserID    int    `json:"user_id"`
+	ProjectID *int   `json:"project_id,omitempty"` // nil for application-level permissions
+	Procedure string `json:"procedure"`
+	Method    string `json:"method"`
+}
+
+// Manager handles role-based access control
+type Manager struct {
+	config *Config
+
+	cacheTTL time.Duration
+	mu       sync.Mutex
+	// userCtxCaches holds one cache per Kanboard client, each instance having its own users
+	userCtxCaches map[*kanboard.Client]*userContextCache
+}
+
+// NewManager creates a new RBAC manager with the embedded configuration.
+// If configPath is set, the policy in that file is merged over (or replaces) the embedded default.
+func NewManager(configPath string) (*Manager, error) {
+	var config Config
+	if err := json.Unmarshal([]byte(rbacConfigJSON), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse RBAC config: %w", err)
+	}
+
+	if configPath != "" {
+		mode, err := rbacConfigMode()
+		if err != nil {
+			return nil, err
+		}
+		external, err := loadRBACConfigFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		if mode == "replace" {
+			config = *external
+		} else {
+			config.merge(external)
+		}
+		logging.RBAC.Info("loaded RBAC config", "path", configPath, "mode", mode)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &Manager{
+		config:        &config,
+		cacheTTL:      userContextCacheTTLFromEnv(),
+		userCtxCaches: make(map[*kanboard.Client]*userContextCache),
+	}, nil
+}
+
+// CheckPermission checks if a user has permission for a specific procedure/method
+func (rbac *Manager) CheckPermission(userCtx *UserContext, projectID *int, procedure, method string) bool {
+	// Administrators have access to every project, as in Kanboard
+	if userCtx.IsAdmin {
+		return true
+	}
+
+	// Get the appropriate access map
+	var accessMap AccessMapConfig
+	var userRole string
+
+	if projectID != nil {
+		// Project-level permission check
+		accessMap = rbac.config.AccessMaps.APIProject
+		userRole = userCtx.ProjectRoles[*projectID]
+		if userRole == "" {
+			// Users who are not members of a project can't access it, as in Kanboard
+			return false
+		}
+	} else {
+		// Application-level permission check
+		accessMap = rbac.config.AccessMaps.API
+		// Use highest application role
+		userRole = rbac.getHighestAppRole(userCtx.AppRoles)
+		if userRole == "" {
+			userRole = accessMap.DefaultRole
+		}
+	}
+
+	// Check specific method or wildcard; procedures without a rule require the
+	// access map's default role, like Kanboard's AccessMap does
+	requiredRole := accessMap.DefaultRole
+	if procedureRules, exists := accessMap.Rules[procedure]; exists {
+		if role, hasMethod := procedureRules[method]; hasMethod {
+			requiredRole = role
+		} else if role, hasWildcard := procedureRules["*"]; hasWildcard {
+			requiredRole = role
+		}
+	}
+
+	// Check if user has the required role (considering hierarchy)
+	return rbac.hasRole(userCtx, userRole, requiredRole, projectID != nil)
+}
+
+// getHighestAppRole returns the highest application role from user's roles
+func (rbac *Manager) getHighestAppRole(userRoles []string) string {
+	rolePriority := map[string]int{
+		"app-admin":   3,
+		"app-manager": 2,
+		"app-user":    1,
+	}
+
+	highestRole := ""
+	highestPriority := 0
+
+	for _, role := range userRoles {
+		if priority, exists := rolePriority[role]; exists && priority > highestPriority {
+			highestPriority = priority
+			highestRole = role
+		}
+	}
+
+	return highestRole
+}
+
+// hasRole checks if user has the required role considering role hierarchies
+func (rbac *Manager) hasRole(userCtx *UserContext, userRole, requiredRole string, isProjectRole bool) bool {
+	if userRole == requiredRole {
+		return true
+	}
+
+	// Check role hierarchy
+	var hierarchy map[string][]string
+	if isProjectRole {
+		hierarchy = rbac.config.Hierarchies.Project
+	} else {
+		hierarchy = rbac.config.Hierarchies.Application
+	}
+
+	// If user has a higher role that includes the required role
+	if inheritedRoles, exists := hierarchy[userRole]; exists {
+		for _, inheritedRole := range inheritedRoles {
+			if inheritedRole == requiredRole {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// loadUserContext asks Kanboard for the current user and their project roles
+func (rbac *Manager) loadUserContext(ctx context.Context, kc *kanboard.Client) (*UserContext, error) {
+	// The global application token has no user behind it, so getMe and getMyProjects
+	// are rejected by Kanboard. Kanboard grants that token full access; roles can
+	// still be narrowed through KANBOARD_USER_APP_ROLES / KANBOARD_USER_PROJECT_ROLES.
+	if kc.UsesApplicationToken() {
+		appRoles := envAppRoles()
+		if len(appRoles) == 0 {
+			appRoles = []string{"app-admin"}
+		}
+		projectRoles := make(map[int]string)
+		addEnvProjectRoles(projectRoles)
+		return &UserContext{
+			Username:     "jsonrpc",
+			AppRoles:     appRoles,
+			ProjectRoles: projectRoles,
+			IsAdmin:      rbac.hasRole(&UserContext{AppRoles: appRoles}, rbac.getHighestAppRole(appRoles), "app-admin", false),
+		}, nil
+	}
+
+	// Get current user info and projects in one round trip
+	results, err := kc.CallBatch(ctx, []kanboard.BatchCall{{Method: "getMe"}, {Method: "getMyProjects"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	if results[0].Err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", results[0].Err)
+	}
+	user, err := kanboard.DecodeObject[kanboard.User](results[0].Result, "user", "me")
+	if err != nil {
+		return nil, fmt.Errorf("invalid user info: %w", err)
+	}
+	if user.ID <= 0 {
+		return nil, fmt.Errorf("invalid user ID in getMe response")
+	}
+
+	// Get the actual role from Kanboard API response
+	var appRoles []string
+	if user.Role != "" {
+		appRoles = []string{string(user.Role)}
+		logging.RBAC.DebugContext(ctx, "got application role from API", "role", user.Role)
+	} else {
+		logging.RBAC.DebugContext(ctx, "no role field in getMe response")
+	}
+
+	// If no role from API, fall back to environment variables
+	if len(appRoles) == 0 {
+		appRoles = envAppRoles()
+		if len(appRoles) == 0 {
+			appRoles = []string{"app-user"} // default role
+		}
+	}
+
+	// Clean up roles
+	cleanRoles := make([]string, 0, len(appRoles))
+	for _, role := range appRoles {
+		role = strings.TrimSpace(role)
+		if role != "" {
+			cleanRoles = append(cleanRoles, role)
+		}
+	}
+
+	// Get project roles - try to get from API first, then environment
+	projectRoles := make(map[int]string)
+
+	// Try to get project roles from Kanboard API
+	var projects []kanboard.Project
+	err = results[1].Err
+	if err == nil {
+		projects, err = kanboard.DecodeList[kanboard.Project](results[1].Result, "getMyProjects")
+	}
+	if err == nil {
+		logging.RBAC.DebugContext(ctx, "loaded projects from getMyProjects", "count", len(projects))
+		for _, project := range projects {
+			if project.ID <= 0 {
+				continue
+			}
+			// Try to get user role in this project
+			if project.Role != "" {
+				projectRoles[int(project.ID)] = string(project.Role)
+				logging.RBAC.DebugContext(ctx, "got project role from API", "project_id", int(project.ID), "role", project.Role)
+			} else {
+				logging.RBAC.DebugContext(ctx, "project has no role field", "project_id", int(project.ID))
+			}
+		}
+	} else {
+		logging.RBAC.WarnContext(ctx, "failed to get user projects", "error", err)
+	}
+
+	// Fall back to environment variables for project roles
+	addEnvProjectRoles(projectRoles)
+
+	return &UserContext{
+		UserID:       int(user.ID),
+		Username:     string(user.Username),
+		AppRoles:     cleanRoles,
+		ProjectRoles: projectRoles,
+		IsAdmin:      rbac.hasRole(&UserContext{AppRoles: cleanRoles}, rbac.getHighestAppRole(cleanRoles), "app-admin", false),
+	}, nil
+}
+
+// envAppRoles returns the application roles configured in KANBOARD_USER_APP_ROLES
+func envAppRoles() []string {
+	var roles []string
+	for _, role := range strings.Split(os.Getenv("KANBOARD_USER_APP_ROLES"), ",") {
+		role = strings.TrimSpace(role)
+		if role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// addEnvProjectRoles adds the roles from KANBOARD_USER_PROJECT_ROLES that are not already set
+func addEnvProjectRoles(projectRoles map[int]string) {
+	projectRolesStr := os.Getenv("KANBOARD_USER_PROJECT_ROLES")
+	if projectRolesStr == "" {
+		return
+	}
+	// Format: "project_id:role,project_id:role"
+	pairs := strings.Split(projectRolesStr, ",")
+	for _, pair := range pairs {
+		parts := strings.Split(strings.TrimSpace(pair), ":")
+		if len(parts) == 2 {
+			if projectID, err := strconv.Atoi(parts[0]); err == nil {
+				// Only set if not already set from API
+				if _, exists := projectRoles[projectID]; !exists {
+					projectRoles[projectID] = parts[1]
+				}
+			}
+		}
+	}
+}
+
+// checkPermission checks the permissions of the user behind the Kanboard client
+func (rbac *Manager) checkPermission(ctx context.Context, userCtx *UserContext, projectID *int, procedure, method string) error {
+	if logging.RBAC.Enabled(ctx, slog.LevelDebug) {
+		var projectIDValue interface{}
+		if projectID != nil {
+			projectIDValue = *projectID
+		}
+		logging.RBAC.DebugContext(ctx, "checking permission",
+			"user", userCtx.Username, "user_id", userCtx.UserID,
+			"app_roles", userCtx.AppRoles, "project_roles", userCtx.ProjectRoles,
+			"procedure", procedure, "method", method, "project_id", projectIDValue)
+	}
+
+	if !rbac.CheckPermission(userCtx, projectID, procedure, method) {
+		var contextMsg string
+		if projectID != nil {
+			if projectRole, exists := userCtx.ProjectRoles[*projectID]; exists {
+				contextMsg = fmt.Sprintf(" (project role: %s)", projectRole)
+			} else {
+				contextMsg = " (no project role assigned)"
+			}
+		}
+		return fmt.Errorf("access denied: insufficient permissions for %s.%s (user: %s, app roles: %v%s)",
+			procedure, method, userCtx.Username, userCtx.AppRoles, contextMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,442 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/metrics"
+	"kanboard-mcp/tools"
+)
+
+// Middleware enforces the access maps for every tool call before the handler runs.
+// How the project of a call is found comes from the tool's registry metadata, the
+// user is the one behind the client of the instance the call is routed to.
+func (rbac *Manager) Middleware(r *tools.Registry) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			kc := tools.ClientFromContext(ctx)
+			toolName := request.Params.Name
+			tool, ok := r.Lookup(toolName)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("access denied: tool %s is not registered", toolName)), nil
+			}
+			mutating := r.Mutating(toolName)
+			if r.ReadOnly && mutating {
+				metrics.Default.RBACDenials.Inc(toolName, "read_only")
+				return mcp.NewToolResultError(fmt.Sprintf("access denied: %s modifies Kanboard and the server is in read-only mode", toolName)), nil
+			}
+
+			decision, err := rbac.authorizeToolCall(ctx, kc, request, tool)
+			storeDecision(ctx, decision)
+			if mutating && r.IsDryRunRequest(request) {
+				return rbac.dryRun(ctx, kc, request, next, decision)
+			}
+			if err != nil {
+				metrics.Default.RBACDenials.Inc(toolName, "rbac")
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result, err := next(ctx, request)
+			if tool.ChangesRoles && err == nil && result != nil && !result.IsError {
+				// Role assignments changed, every user of the instance reloads them on the
+				// next permission check
+				rbac.InvalidateInstance(kc)
+			}
+			return result, err
+		}
+	}
+}
+
+type decisionContextKey struct{}
+
+// decisionSlot receives the decision the middleware takes for a tool call
+type decisionSlot struct {
+	mu       sync.Mutex
+	decision *Decision
+}
+
+// TrackDecision returns a context in which the middleware leaves its decision, and a
+// function returning that decision once the call is done (nil when no check ran)
+func TrackDecision(ctx context.Context) (context.Context, func() *Decision) {
+	slot := &decisionSlot{}
+	return context.WithValue(ctx, decisionContextKey{}, slot), func() *Decision {
+		slot.mu.Lock()
+		defer slot.mu.Unlock()
+		return slot.decision
+	}
+}
+
+// storeDecision hands the decision over to the TrackDecision caller, if any
+func storeDecision(ctx context.Context, decision *Decision) {
+	if slot, ok := ctx.Value(decisionContextKey{}).(*decisionSlot); ok {
+		slot.mu.Lock()
+		defer slot.mu.Unlock()
+		slot.decision = decision
+	}
+}
+
+// Decision describes the outcome of the permission check for a tool call
+type Decision struct {
+	Allowed    bool   `json:"allowed"`
+	Reason     string `json:"reason,omitempty"`
+	User       string `json:"user,omitempty"`
+	Procedure  string `json:"procedure,omitempty"`
+	Method     string `json:"method,omitempty"`
+	ProjectIDs []int  `json:"project_ids,omitempty"`
+}
+
+// authorizeToolCall runs the RBAC check for a tool call and returns the decision.
+// The error is the message returned to the client when the call is denied.
+func (rbac *Manager) authorizeToolCall(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest, tool *tools.Tool) (*Decision, error) {
+	toolName := request.Params.Name
+	switch {
+	case tool.Local:
+		return &Decision{Allowed: true, Reason: "local tool, no Kanboard access"}, nil
+	case os.Getenv("KANBOARD_SKIP_RBAC") == "true":
+		return &Decision{Allowed: true, Reason: "RBAC disabled by KANBOARD_SKIP_RBAC"}, nil
+	}
+
+	procedure, _ := kanboard.ProcedureForMethod(tool.Method)
+	method := strings.ToLower(tool.Method)
+	decision := &Decision{Procedure: procedure, Method: method}
+
+	projectIDs, err := rbac.resolveToolProjects(ctx, kc, request, tool)
+	if err != nil {
+		err = fmt.Errorf("permission check failed for %s: %v", toolName, err)
+		decision.Reason = err.Error()
+		return decision, err
+	}
+	decision.ProjectIDs = projectIDs
+
+	userCtx, err := rbac.UserContext(ctx, kc)
+	if err != nil {
+		err = fmt.Errorf("failed to get user context: %w", err)
+		decision.Reason = err.Error()
+		return decision, err
+	}
+	decision.User = userCtx.Username
+
+	if len(projectIDs) == 0 {
+		if err := rbac.checkPermission(ctx, userCtx, nil, procedure, method); err != nil {
+			decision.Reason = err.Error()
+			return decision, err
+		}
+	}
+	for _, projectID := range projectIDs {
+		projectID := projectID
+		if err := rbac.checkPermission(ctx, userCtx, &projectID, procedure, method); err != nil {
+			decision.Reason = err.Error()
+			return decision, err
+		}
+	}
+
+	decision.Allowed = true
+	return decision, nil
+}
+
+// resolveToolProjects returns the project IDs a tool call touches (none for application-level tools)
+func (rbac *Manager) resolveToolProjects(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest, tool *tools.Tool) ([]int, error) {
+	args := request.GetArguments()
+
+	switch tool.Scope {
+	case tools.ScopeApplication:
+		return nil, nil
+
+	case tools.ScopeProject, tools.ScopeProjectName:
+		id, ok, err := tools.ProjectArgument(ctx, kc, args, tool.Arg)
+		if err == nil && !ok && tool.Arg != "project_name" {
+			id, ok, err = tools.ProjectArgument(ctx, kc, args, "project_name")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("parameter %s is required", tool.Arg)
+		}
+		if tool.ObjectArg != "" {
+			if err := checkObjectOwner(ctx, kc, tool, args, id, 0); err != nil {
+				return nil, err
+			}
+		}
+		return []int{id}, nil
+
+	case tools.ScopeProjectList:
+		return tools.RequireProjectList(ctx, kc, args, tool.Arg)
+	}
+
+	id, err := scopedObjectID(ctx, kc, tool.Scope, tool.Arg, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectID int
+	switch tool.Scope {
+	case tools.ScopeTask:
+		projectID, err = kc.TaskProjectID(ctx, id)
+	case tools.ScopeColumn:
+		var column *kanboard.Column
+		if column, err = kc.GetColumn(ctx, id); err == nil {
+			projectID = int(column.ProjectID)
+		}
+	case tools.ScopeCategory:
+		var category *kanboard.Category
+		if category, err = kc.GetCategory(ctx, id); err == nil {
+			projectID = int(category.ProjectID)
+		}
+	case tools.ScopeSwimlane:
+		var swimlane *kanboard.Swimlane
+		if swimlane, err = kc.GetSwimlane(ctx, id); err == nil {
+			projectID = int(swimlane.ProjectID)
+		}
+	case tools.ScopeSprint:
+		var sprint *kanboard.Sprint
+		if sprint, err = kc.GetSprintByID(ctx, id); err == nil {
+			projectID = int(sprint.ProjectID)
+		}
+	case tools.ScopeComment:
+		var comment *kanboard.Comment
+		if comment, err = kc.GetComment(ctx, id); err == nil {
+			projectID, err = kc.TaskProjectID(ctx, int(comment.TaskID))
+		}
+	case tools.ScopeSubtask:
+		var subtask *kanboard.Subtask
+		if subtask, err = kc.GetSubtask(ctx, id); err == nil {
+			projectID, err = kc.TaskProjectID(ctx, int(subtask.TaskID))
+		}
+	case tools.ScopeTaskLink:
+		projectID, err = lookupProjectViaTask(ctx, kc, "getTaskLinkById", map[string]int{"task_link_id": id}, "task link", id)
+	case tools.ScopeTaskFile:
+		var file *kanboard.TaskFile
+		if file, err = kc.GetTaskFile(ctx, id); err == nil {
+			projectID, err = kc.TaskProjectID(ctx, int(file.TaskID))
+		}
+	case tools.ScopeTag:
+		projectID, err = lookupTagProjectID(ctx, kc, id)
+	case tools.ScopeAction:
+		projectID, err = rbac.lookupActionProjectID(ctx, kc, id)
+	default:
+		return nil, fmt.Errorf("unsupported permission scope %d", tool.Scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if projectID == 0 {
+		// Global objects (e.g. tags without a project) fall back to application-level checks
+		return nil, nil
+	}
+	if tool.ObjectArg != "" && tool.Scope == tools.ScopeTask {
+		if err := checkObjectOwner(ctx, kc, tool, args, projectID, id); err != nil {
+			return nil, err
+		}
+	}
+	projectIDs := []int{projectID}
+	if tool.DestinationArg != "" {
+		// Moving or copying a task needs the same permission in the project it goes to
+		destinationID, ok, err := tools.ProjectArgument(ctx, kc, args, tool.DestinationArg)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("parameter %s is required", tool.DestinationArg)
+		}
+		if destinationID != projectID {
+			projectIDs = append(projectIDs, destinationID)
+		}
+	}
+	return projectIDs, nil
+}
+
+// objectKinds name the objects a tool's ObjectArg can refer to in error messages
+var objectKinds = map[tools.Scope]string{
+	tools.ScopeTask:         "task",
+	tools.ScopeSubtask:      "subtask",
+	tools.ScopeTaskLink:     "task link",
+	tools.ScopeExternalLink: "external task link",
+	tools.ScopeProjectFile:  "project file",
+}
+
+// checkObjectOwner makes sure the object in the tool's ObjectArg belongs to the project
+// the call is checked against, or to the task for ScopeTask tools (taskID is then set).
+// Kanboard loads these objects by their ID alone and would act on an object of a
+// project the user has no role in.
+func checkObjectOwner(ctx context.Context, kc *kanboard.Client, tool *tools.Tool, args map[string]interface{}, projectID, taskID int) error {
+	objectID, err := scopedObjectID(ctx, kc, tool.ObjectScope, tool.ObjectArg, args)
+	if err != nil {
+		return err
+	}
+	kind := objectKinds[tool.ObjectScope]
+
+	var ownerProjectID, ownerTaskID int
+	switch tool.ObjectScope {
+	case tools.ScopeTask:
+		ownerTaskID = objectID
+	case tools.ScopeSubtask:
+		var subtask *kanboard.Subtask
+		if subtask, err = kc.GetSubtask(ctx, objectID); err == nil {
+			ownerTaskID = int(subtask.TaskID)
+		}
+	case tools.ScopeTaskLink:
+		var link map[string]interface{}
+		if link, err = lookupObject(ctx, kc, "getTaskLinkById", map[string]int{"task_link_id": objectID}, kind, objectID); err == nil {
+			ownerTaskID, _ = kanboard.ToInt(link["task_id"])
+		}
+	case tools.ScopeExternalLink:
+		var link *kanboard.ExternalTaskLink
+		if link, err = kc.GetExternalTaskLink(ctx, taskID, objectID); err == nil {
+			ownerTaskID = int(link.TaskID)
+		}
+	case tools.ScopeProjectFile:
+		var file *kanboard.ProjectFile
+		if file, err = kc.GetProjectFile(ctx, projectID, objectID); err == nil {
+			ownerProjectID = int(file.ProjectID)
+		}
+	default:
+		return fmt.Errorf("unsupported object scope %d", tool.ObjectScope)
+	}
+	if err != nil {
+		return err
+	}
+
+	if taskID != 0 {
+		if ownerTaskID != taskID {
+			return fmt.Errorf("%s %d belongs to task %d, not to task %d given in %s", kind, objectID, ownerTaskID, taskID, tool.Arg)
+		}
+		return nil
+	}
+	if ownerProjectID == 0 {
+		if ownerProjectID, err = kc.TaskProjectID(ctx, ownerTaskID); err != nil {
+			return err
+		}
+	}
+	if ownerProjectID != projectID {
+		return fmt.Errorf("%s %d belongs to project %d, not to project %d given in %s", kind, objectID, ownerProjectID, projectID, tool.Arg)
+	}
+	return nil
+}
+
+// scopedObjectID reads the ID of the object of the given scope in argument arg. Task
+// references and names of columns, swimlanes, categories and tags are looked up the
+// same way the handlers do.
+func scopedObjectID(ctx context.Context, kc *kanboard.Client, scope tools.Scope, arg string, args map[string]interface{}) (int, error) {
+	resolve := func(_ context.Context, ref string) (int, error) {
+		return 0, fmt.Errorf("parameter %s must be an integer ID, got '%s'", arg, ref)
+	}
+	switch scope {
+	case tools.ScopeTask:
+		return tools.TaskArgument(ctx, kc, args, arg)
+	case tools.ScopeColumn, tools.ScopeSwimlane, tools.ScopeCategory, tools.ScopeTag:
+		projectID, _, err := tools.ProjectArgument(ctx, kc, args, "project_id")
+		if err != nil {
+			return 0, err
+		}
+		switch scope {
+		case tools.ScopeColumn:
+			resolve = kc.ColumnResolver(projectID)
+		case tools.ScopeSwimlane:
+			resolve = kc.SwimlaneResolver(projectID)
+		case tools.ScopeCategory:
+			resolve = kc.CategoryResolver(projectID)
+		case tools.ScopeTag:
+			resolve = kc.TagResolver(projectID)
+		}
+	}
+
+	id, err := tools.NamedArgument(ctx, args, arg, resolve)
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("parameter %s is required", arg)
+	}
+	return id, nil
+}
+
+// lookupObject fetches a Kanboard object and rejects the "false"/empty responses used for not found
+func lookupObject(ctx context.Context, kc *kanboard.Client, method string, params interface{}, kind string, id int) (map[string]interface{}, error) {
+	result, err := kc.Call(ctx, method, params)
+	return apiObject(result, err, kind, id)
+}
+
+// apiObject interprets the result of a single-object lookup such as getTask or getColumn
+func apiObject(result interface{}, err error, kind string, id int) (map[string]interface{}, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s %d: %w", kind, id, err)
+	}
+	object, ok := result.(map[string]interface{})
+	if !ok || len(object) == 0 {
+		return nil, &kanboard.Error{Kind: kanboard.ErrorKindNotFound, Message: fmt.Sprintf("%s %d not found", kind, id)}
+	}
+	return object, nil
+}
+
+func lookupProjectViaTask(ctx context.Context, kc *kanboard.Client, method string, params interface{}, kind string, id int) (int, error) {
+	object, err := lookupObject(ctx, kc, method, params, kind, id)
+	if err != nil {
+		return 0, err
+	}
+	taskID, ok := kanboard.ToInt(object["task_id"])
+	if !ok {
+		return 0, fmt.Errorf("%s %d has no valid task_id", kind, id)
+	}
+	return kc.TaskProjectID(ctx, taskID)
+}
+
+func lookupTagProjectID(ctx context.Context, kc *kanboard.Client, tagID int) (int, error) {
+	tags, err := kc.GetAllTags(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load tags: %w", err)
+	}
+	for _, tag := range tags {
+		if int(tag.ID) == tagID {
+			return int(tag.ProjectID), nil
+		}
+	}
+	return 0, fmt.Errorf("tag %d not found", tagID)
+}
+
+// lookupActionProjectID finds the project of an automatic action. Kanboard can only list
+// the actions of a project, so the projects the user has a role in are searched.
+func (rbac *Manager) lookupActionProjectID(ctx context.Context, kc *kanboard.Client, actionID int) (int, error) {
+	userCtx, err := rbac.UserContext(ctx, kc)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user context: %w", err)
+	}
+	if userCtx.IsAdmin {
+		// Administrators may change the actions of every project
+		return 0, nil
+	}
+
+	projectIDs := slices.Sorted(maps.Keys(userCtx.ProjectRoles))
+	calls := make([]kanboard.BatchCall, len(projectIDs))
+	for i, projectID := range projectIDs {
+		calls[i] = kanboard.BatchCall{Method: "getActions", Params: map[string]int{"project_id": projectID}}
+	}
+	results, err := kc.CallBatch(ctx, calls)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load actions: %w", err)
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		actions, err := kanboard.DecodeList[kanboard.Action](result.Result, "getActions")
+		if err != nil {
+			return 0, err
+		}
+		for _, action := range actions {
+			if int(action.ID) == actionID {
+				return projectIDs[i], nil
+			}
+		}
+	}
+	return 0, &kanboard.Error{Kind: kanboard.ErrorKindNotFound, Message: fmt.Sprintf("action %d not found in the projects of %s", actionID, userCtx.Username)}
+}
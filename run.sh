@@ -41,7 +41,9 @@ show_help() {
     echo "  KANBOARD_USERNAME      Kanboard username (for user_token auth)"
     echo "  KANBOARD_PASSWORD      Kanboard password (for user_token auth)"
     echo "  KANBOARD_AUTH_METHOD   Authentication method (global_token, user_token, bearer)"
-    echo "  KANBOARD_DEBUG         Enable debug logging (true/false)"
+    echo "  MCP_LOG_LEVEL          Log level (debug, info, warn, error)"
+    echo "  MCP_LOG_LEVELS         Per-subsystem levels (e.g. rbac=debug,http=warn)"
+    echo "  MCP_LOG_FORMAT         Log format (text, json)"
     echo ""
     echo "Examples:"
     echo "  $0                      # Run in stdio mode (interactive)"
@@ -123,7 +125,9 @@ DOCKER_CMD+=" --name ${CONTAINER_NAME}"
 [ -n "$KANBOARD_USERNAME" ] && DOCKER_CMD+=" -e KANBOARD_USERNAME=${KANBOARD_USERNAME}"
 [ -n "$KANBOARD_PASSWORD" ] && DOCKER_CMD+=" -e KANBOARD_PASSWORD=${KANBOARD_PASSWORD}"
 [ -n "$KANBOARD_AUTH_METHOD" ] && DOCKER_CMD+=" -e KANBOARD_AUTH_METHOD=${KANBOARD_AUTH_METHOD}"
-[ -n "$KANBOARD_DEBUG" ] && DOCKER_CMD+=" -e KANBOARD_DEBUG=${KANBOARD_DEBUG}"
+[ -n "$MCP_LOG_LEVEL" ] && DOCKER_CMD+=" -e MCP_LOG_LEVEL=${MCP_LOG_LEVEL}"
+[ -n "$MCP_LOG_LEVELS" ] && DOCKER_CMD+=" -e MCP_LOG_LEVELS=${MCP_LOG_LEVELS}"
+[ -n "$MCP_LOG_FORMAT" ] && DOCKER_CMD+=" -e MCP_LOG_FORMAT=${MCP_LOG_FORMAT}"
 
 # Mode-specific options
 case $MODE in
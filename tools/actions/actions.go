@@ -0,0 +1,209 @@
+// Package actions registers the tools of the actions domain (automated actions).
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "actions", Description: "Automated actions"}
+
+// Register adds the automatic action tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	// Automatic Actions API Procedures
+	{
+		Definition: mcp.NewTool("get_available_actions",
+			mcp.WithDescription("Get all automation actions available (auto-assign, auto-close, notifications, etc.)"),
+		),
+		Handler:   getAvailableActionsHandler,
+		Method:    "getAvailableActions",
+		Procedure: "actionprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_available_action_events",
+			mcp.WithDescription("Get all trigger events that can start automations (task created, moved, etc.)"),
+		),
+		Handler:   getAvailableActionEventsHandler,
+		Method:    "getAvailableActionEvents",
+		Procedure: "actionprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_compatible_action_events",
+			mcp.WithDescription("Get trigger events compatible with a specific automation action"),
+			mcp.WithString("action_name",
+				mcp.Required(),
+				mcp.Description("Action name"),
+			),
+		),
+		Handler:   getCompatibleActionEventsHandler,
+		Method:    "getCompatibleActionEvents",
+		Procedure: "actionprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_actions",
+			mcp.WithDescription("Get all configured automation rules for a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("Project ID, name or identifier"),
+			),
+		),
+		Handler:   getActionsHandler,
+		Method:    "getActions",
+		Procedure: "actionprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_action",
+			mcp.WithDescription("Create a new automation rule for a project (trigger + action + parameters)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("Project ID, name or identifier"),
+			),
+			mcp.WithString("event_name",
+				mcp.Required(),
+				mcp.Description("Event name"),
+			),
+			mcp.WithString("action_name",
+				mcp.Required(),
+				mcp.Description("Action name"),
+			),
+			mcp.WithObject("params",
+				mcp.Required(),
+				mcp.Description("Key/value parameters"),
+			),
+		),
+		Handler:   createActionHandler,
+		Method:    "createAction",
+		Procedure: "actionprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_action",
+			mcp.WithDescription("Delete an automation rule from a project"),
+			mcp.WithNumber("action_id",
+				mcp.Required(),
+				mcp.Description("Action ID"),
+			),
+		),
+		Handler:     removeActionHandler,
+		Method:      "removeAction",
+		Procedure:   "actionprocedure",
+		Scope:       tools.ScopeAction,
+		Arg:         "action_id",
+		Destructive: true,
+	},
+}
+
+func getAvailableActionsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAvailableActions(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAvailableActionEventsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAvailableActionEvents(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getCompatibleActionEventsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	actionName := request.GetString("action_name", "")
+	if actionName == "" {
+		return mcp.NewToolResultError("action_name is required"), nil
+	}
+	result, err := kc.GetCompatibleActionEvents(ctx, actionName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getActionsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetActions(ctx, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createActionHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	eventName := request.GetString("event_name", "")
+	if eventName == "" {
+		return mcp.NewToolResultError("event_name is required"), nil
+	}
+	actionName := request.GetString("action_name", "")
+	if actionName == "" {
+		return mcp.NewToolResultError("action_name is required"), nil
+	}
+
+	// Retrieve all arguments and then extract 'params'
+	allArgs := request.GetArguments()
+	params, ok := allArgs["params"].(map[string]interface{})
+	if !ok {
+		// If 'params' is not provided or not a map, return an error or an empty map
+		return mcp.NewToolResultError("params must be a map or omitted"), nil
+	}
+
+	actionID, err := kc.CreateAction(ctx, projectID, eventName, actionName, params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.Itoa(actionID)), nil
+}
+
+func removeActionHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	actionID := request.GetInt("action_id", 0)
+	if actionID == 0 {
+		return mcp.NewToolResultError("action_id is required"), nil
+	}
+	result, err := kc.RemoveAction(ctx, actionID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+)
+
+// ProjectArgument resolves an optional project argument given as a number or a string.
+// ok is false when the argument is absent or empty.
+func ProjectArgument(ctx context.Context, kc *kanboard.Client, args map[string]interface{}, name string) (id int, ok bool, err error) {
+	id, err = NamedArgument(ctx, args, name, kc.ResolveProject)
+	if err != nil {
+		return 0, false, err
+	}
+	if id < 0 {
+		return 0, false, fmt.Errorf("parameter %s must be a project ID, name or identifier", name)
+	}
+	return id, id != 0, nil
+}
+
+// RequireProjectList resolves a required array of project IDs, names or identifiers
+func RequireProjectList(ctx context.Context, kc *kanboard.Client, args map[string]interface{}, name string) ([]int, error) {
+	values, ok := args[name].([]interface{})
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("required argument %q not found", name)
+	}
+	ids := make([]int, 0, len(values))
+	for _, value := range values {
+		id, ok, err := ProjectArgument(ctx, kc, map[string]interface{}{name: value}, name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("parameter %s must contain project IDs, names or identifiers", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// RequireProject resolves a required project argument of a tool call
+func RequireProject(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest, name string) (int, error) {
+	return RequireProjectArgument(ctx, kc, request.GetArguments(), name)
+}
+
+// RequireProjectArgument resolves a required project argument
+func RequireProjectArgument(ctx context.Context, kc *kanboard.Client, args map[string]interface{}, name string) (int, error) {
+	id, ok, err := ProjectArgument(ctx, kc, args, name)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("required argument %q not found", name)
+	}
+	return id, nil
+}
+
+// TaskArgument resolves a required task argument of a tool call. External references
+// are looked up in the call's project_id, which is only resolved when needed. Tools whose
+// DestinationArg is project_id move or copy the task there, so it cannot scope the lookup.
+func TaskArgument(ctx context.Context, kc *kanboard.Client, args map[string]interface{}, name string) (int, error) {
+	return RequireNamedArgument(ctx, args, name, func(ctx context.Context, ref string) (int, error) {
+		projectID := 0
+		if tool := ToolFromContext(ctx); tool == nil || tool.DestinationArg != "project_id" {
+			var err error
+			if projectID, _, err = ProjectArgument(ctx, kc, args, "project_id"); err != nil {
+				return 0, err
+			}
+		}
+		return kc.ResolveTask(ctx, projectID, ref)
+	})
+}
+
+// RequireTaskID reads a required task ID argument given as a number, a numeric string,
+// "#123", "IDENTIFIER-123" or an external reference within the call's project_id
+func RequireTaskID(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest, paramName string) (int, error) {
+	return TaskArgument(ctx, kc, request.GetArguments(), paramName)
+}
+
+// NamedArgument reads an ID argument that may also be given as a name. It returns 0
+// when the argument is absent or empty.
+func NamedArgument(ctx context.Context, args map[string]interface{}, name string, resolve kanboard.NameResolver) (int, error) {
+	value, exists := args[name]
+	if !exists || value == nil {
+		return 0, nil
+	}
+	if ref, ok := value.(string); ok {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return 0, nil
+		}
+		if id, err := strconv.Atoi(ref); err == nil {
+			return id, nil
+		}
+		return resolve(ctx, ref)
+	}
+	id, ok := kanboard.ToInt(value)
+	if !ok {
+		return 0, fmt.Errorf("parameter %s must be an ID or a name", name)
+	}
+	return id, nil
+}
+
+// OptionalNamed reads an optional ID-or-name argument of a tool call, 0 when absent
+func OptionalNamed(ctx context.Context, request mcp.CallToolRequest, name string, resolve kanboard.NameResolver) (int, error) {
+	return NamedArgument(ctx, request.GetArguments(), name, resolve)
+}
+
+// RequireNamed reads a required ID-or-name argument of a tool call
+func RequireNamed(ctx context.Context, request mcp.CallToolRequest, name string, resolve kanboard.NameResolver) (int, error) {
+	return RequireNamedArgument(ctx, request.GetArguments(), name, resolve)
+}
+
+// RequireNamedArgument reads a required ID argument that may also be given as a name
+func RequireNamedArgument(ctx context.Context, args map[string]interface{}, name string, resolve kanboard.NameResolver) (int, error) {
+	id, err := NamedArgument(ctx, args, name, resolve)
+	if err == nil && id == 0 {
+		return 0, fmt.Errorf("required argument %q not found", name)
+	}
+	return id, err
+}
+
+// ReadFileAsBase64 reads a file from the given path and returns its base64-encoded content.
+// If the path is not absolute, it will be resolved relative to the current working directory.
+func ReadFileAsBase64(filePath string) (string, error) {
+	// If path is not absolute, concatenate with current working directory
+	if !filepath.IsAbs(filePath) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current working directory: %v", err)
+		}
+		filePath = filepath.Join(wd, filePath)
+	}
+
+	// Read the file
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %v", filePath, err)
+	}
+
+	// Encode to base64
+	encoded := base64.StdEncoding.EncodeToString(fileData)
+	return encoded, nil
+}
+
+// SaveBase64ToFile saves base64-encoded content to a file at the specified path.
+// savePath can be:
+//   - "temp" or starts with "temp" -> saves to OS temp directory
+//   - Empty string -> saves to current directory with filename
+//   - Absolute path with filename -> uses as-is
+//   - Directory path -> concatenates with filename
+//   - Relative path -> resolves from current directory
+func SaveBase64ToFile(base64Content, savePath, filename string) (string, error) {
+	// Decode base64 content
+	fileData, err := base64.StdEncoding.DecodeString(base64Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 content: %v", err)
+	}
+
+	var finalPath string
+
+	// Handle temp directory
+	savePathLower := strings.ToLower(strings.TrimSpace(savePath))
+	if savePathLower == "temp" || savePathLower == "tmp" {
+		tempDir := os.TempDir()
+		finalPath = filepath.Join(tempDir, filename)
+	} else if strings.HasPrefix(savePathLower, "temp/") || strings.HasPrefix(savePathLower, "tmp/") {
+		tempDir := os.TempDir()
+		// Extract subdirectory path after temp/ or tmp/
+		subPath := strings.TrimPrefix(savePathLower, "temp/")
+		subPath = strings.TrimPrefix(subPath, "tmp/")
+		// If subPath looks like a filename (has extension), use it; otherwise append filename
+		if filepath.Ext(subPath) != "" && !strings.Contains(subPath, string(filepath.Separator)) {
+			// It's a filename in temp dir
+			finalPath = filepath.Join(tempDir, subPath)
+		} else {
+			// It's a subdirectory, append filename
+			finalPath = filepath.Join(tempDir, subPath, filename)
+		}
+	} else if savePath == "" {
+		// No path specified, save to current directory
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current working directory: %v", err)
+		}
+		finalPath = filepath.Join(wd, filename)
+	} else {
+		// Check if savePath is a directory or a full path
+		info, err := os.Stat(savePath)
+		if err == nil && info.IsDir() {
+			// It's a directory, concatenate with filename
+			finalPath = filepath.Join(savePath, filename)
+		} else if filepath.IsAbs(savePath) {
+			// It's an absolute path, use as-is
+			finalPath = savePath
+		} else {
+			// Relative path, resolve from current directory
+			wd, err := os.Getwd()
+			if err != nil {
+				return "", fmt.Errorf("failed to get current working directory: %v", err)
+			}
+			finalPath = filepath.Join(wd, savePath)
+		}
+	}
+
+	// Ensure directory exists
+	dir := filepath.Dir(finalPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+
+	// Write file
+	if err := os.WriteFile(finalPath, fileData, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file %s: %v", finalPath, err)
+	}
+
+	return finalPath, nil
+}
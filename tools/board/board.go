@@ -0,0 +1,58 @@
+// Package board registers the tools of the board domain (board operations).
+package board
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "board", Description: "Board operations"}
+
+// Register adds the board tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_board",
+			mcp.WithDescription("Get all necessary information to display a board"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get board details for"),
+			),
+		),
+		Handler:   getBoardHandler,
+		Method:    "getBoard",
+		Procedure: "boardprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+}
+
+func getBoardHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := []int{projectId}
+	result, err := kc.Call(ctx, "getBoard", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get board details: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
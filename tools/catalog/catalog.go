@@ -0,0 +1,51 @@
+// Package catalog assembles the registry from every tool domain.
+package catalog
+
+import (
+	"kanboard-mcp/rbac"
+	"kanboard-mcp/tools"
+	"kanboard-mcp/tools/actions"
+	"kanboard-mcp/tools/board"
+	"kanboard-mcp/tools/categories"
+	"kanboard-mcp/tools/columns"
+	"kanboard-mcp/tools/comments"
+	"kanboard-mcp/tools/dashboard"
+	"kanboard-mcp/tools/externallinks"
+	"kanboard-mcp/tools/groups"
+	"kanboard-mcp/tools/links"
+	"kanboard-mcp/tools/metadata"
+	"kanboard-mcp/tools/projects"
+	"kanboard-mcp/tools/search"
+	"kanboard-mcp/tools/sprints"
+	"kanboard-mcp/tools/subtasks"
+	"kanboard-mcp/tools/swimlanes"
+	"kanboard-mcp/tools/system"
+	"kanboard-mcp/tools/tags"
+	"kanboard-mcp/tools/tasks"
+	"kanboard-mcp/tools/users"
+)
+
+// New returns a registry holding every tool of every domain
+func New(manager *rbac.Manager) *tools.Registry {
+	r := tools.NewRegistry()
+	projects.Register(r)
+	tasks.Register(r)
+	comments.Register(r)
+	subtasks.Register(r)
+	columns.Register(r)
+	swimlanes.Register(r)
+	categories.Register(r)
+	tags.Register(r)
+	board.Register(r)
+	sprints.Register(r)
+	search.Register(r)
+	links.Register(r)
+	externallinks.Register(r)
+	metadata.Register(r)
+	actions.Register(r)
+	users.Register(r)
+	groups.Register(r)
+	dashboard.Register(r)
+	system.Register(r, manager)
+	return r
+}
@@ -0,0 +1,250 @@
+// Package categories registers the tools of the categories domain (category management).
+package categories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "categories", Description: "Category management"}
+
+// Register adds the category tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_categories",
+			mcp.WithDescription("Get all task categories defined for a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get categories from"),
+			),
+		),
+		Handler:   getCategoriesHandler,
+		Method:    "getAllCategories",
+		Procedure: "categoryprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_category",
+			mcp.WithDescription("Create a new task category for a project with name and optional color"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the category to create"),
+			),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to add the category to"),
+			),
+			mcp.WithString("color_id",
+				mcp.Description("Color ID for the category (e.g., 'blue', 'green')"),
+			),
+		),
+		Handler:   createCategoryHandler,
+		Method:    "createCategory",
+		Procedure: "categoryprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("get_category",
+			mcp.WithDescription("Get category details by ID including name, color, and project association"),
+			mcp.WithString("category_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the category to get details for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the category belongs to, required when category_id is a name (optional)"),
+			),
+		),
+		Handler:   getCategoryHandler,
+		Method:    "getCategory",
+		Procedure: "categoryprocedure",
+		Scope:     tools.ScopeCategory,
+		Arg:       "category_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("update_category",
+			mcp.WithDescription("Update category name or color"),
+			mcp.WithString("category_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the category to update"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the category belongs to, required when category_id is a name (optional)"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name for the category"),
+			),
+			mcp.WithString("color_id",
+				mcp.Description("Color ID for the category (e.g., 'blue', 'green')"),
+			),
+		),
+		Handler:   updateCategoryHandler,
+		Method:    "updateCategory",
+		Procedure: "categoryprocedure",
+		Scope:     tools.ScopeCategory,
+		Arg:       "category_id",
+	},
+	{
+		Definition: mcp.NewTool("delete_category",
+			mcp.WithDescription("Delete a category (tasks will be uncategorized)"),
+			mcp.WithString("category_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the category to delete"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the category belongs to, required when category_id is a name (optional)"),
+			),
+		),
+		Handler:     deleteCategoryHandler,
+		Method:      "removeCategory",
+		Procedure:   "categoryprocedure",
+		Scope:       tools.ScopeCategory,
+		Arg:         "category_id",
+		Destructive: true,
+	},
+}
+
+func getCategoriesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := kc.GetAllCategories(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get categories: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createCategoryHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"name": name, "project_id": projectId}
+
+	colorId := request.GetString("color_id", "")
+	if colorId != "" {
+		params["color_id"] = colorId
+	}
+
+	result, err := kc.Call(ctx, "createCategory", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create category: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getCategoryHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the category by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	categoryId, err := tools.RequireNamed(ctx, request, "category_id", kc.CategoryResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetCategory(ctx, categoryId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get category details: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateCategoryHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the category by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	categoryId, err := tools.RequireNamed(ctx, request, "category_id", kc.CategoryResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"id": categoryId}
+	name := request.GetString("name", "")
+	if name != "" {
+		params["name"] = name
+	}
+
+	colorId := request.GetString("color_id", "")
+	if colorId != "" {
+		params["color_id"] = colorId
+	}
+
+	result, err := kc.Call(ctx, "updateCategory", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update category: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func deleteCategoryHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the category by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	categoryId, err := tools.RequireNamed(ctx, request, "category_id", kc.CategoryResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"category_id": categoryId}
+	result, err := kc.Call(ctx, "removeCategory", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete category: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
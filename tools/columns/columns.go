@@ -0,0 +1,326 @@
+// Package columns registers the tools of the columns domain (column management).
+package columns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "columns", Description: "Column management"}
+
+// Register adds the column tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_columns",
+			mcp.WithDescription("Get all board columns for a project (e.g., Backlog, In Progress, Done)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get columns from"),
+			),
+		),
+		Handler:   getColumnsHandler,
+		Method:    "getColumns",
+		Procedure: "columnprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("get_column",
+			mcp.WithDescription("Get column details by ID including title, position, and task limit"),
+			mcp.WithString("column_id",
+				mcp.Required(),
+				mcp.Description("ID or title of the column to get details for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the column belongs to, required when column_id is a title (optional)"),
+			),
+		),
+		Handler:   getColumnHandler,
+		Method:    "getColumn",
+		Procedure: "columnprocedure",
+		Scope:     tools.ScopeColumn,
+		Arg:       "column_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_column",
+			mcp.WithDescription("Add a new board column to a project with title and optional task limit"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to add the column to"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the column to create"),
+			),
+			mcp.WithNumber("task_limit",
+				mcp.Description("Task limit for the new column"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description for the new column"),
+			),
+		),
+		Handler:   createColumnHandler,
+		Method:    "addColumn",
+		Procedure: "columnprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("update_column",
+			mcp.WithDescription("Update column properties: title, task limit, or description"),
+			mcp.WithString("column_id",
+				mcp.Required(),
+				mcp.Description("ID or title of the column to update"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the column belongs to, required when column_id is a title (optional)"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("New title for the column"),
+			),
+			mcp.WithNumber("task_limit",
+				mcp.Description("New task limit for the column"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description for the column"),
+			),
+		),
+		Handler:   updateColumnHandler,
+		Method:    "updateColumn",
+		Procedure: "columnprocedure",
+		Scope:     tools.ScopeColumn,
+		Arg:       "column_id",
+	},
+	{
+		Definition: mcp.NewTool("delete_column",
+			mcp.WithDescription("Delete a board column (tasks must be moved first)"),
+			mcp.WithString("column_id",
+				mcp.Required(),
+				mcp.Description("ID or title of the column to delete"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the column belongs to, required when column_id is a title (optional)"),
+			),
+		),
+		Handler:     deleteColumnHandler,
+		Method:      "removeColumn",
+		Procedure:   "columnprocedure",
+		Scope:       tools.ScopeColumn,
+		Arg:         "column_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("reorder_columns",
+			mcp.WithDescription("Reorder board columns by specifying new position for a column"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project containing the columns"),
+			),
+			mcp.WithString("column_id",
+				mcp.Required(),
+				mcp.Description("ID or title of the column to reorder"),
+			),
+			mcp.WithNumber("position",
+				mcp.Required(),
+				mcp.Description("New position for the column (must be >= 1)"),
+			),
+		),
+		Handler:   reorderColumnsHandler,
+		Method:    "changeColumnPosition",
+		Procedure: "columnprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+}
+
+func getColumnsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := kc.GetColumns(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get columns: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getColumnHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the column by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.RequireNamed(ctx, request, "column_id", kc.ColumnResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetColumn(ctx, columnId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get column details: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createColumnHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectId,
+		"title":      title,
+	}
+
+	taskLimit := request.GetInt("task_limit", 0)
+	if taskLimit != 0 {
+		params["task_limit"] = taskLimit
+	}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	result, err := kc.Call(ctx, "addColumn", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create column: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateColumnHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the column by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.RequireNamed(ctx, request, "column_id", kc.ColumnResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"column_id": columnId, "title": title}
+
+	taskLimit := request.GetInt("task_limit", 0)
+	if taskLimit != 0 {
+		params["task_limit"] = taskLimit
+	}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	result, err := kc.Call(ctx, "updateColumn", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update column: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func deleteColumnHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the column by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.RequireNamed(ctx, request, "column_id", kc.ColumnResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"column_id": columnId}
+	result, err := kc.Call(ctx, "removeColumn", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete column: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func reorderColumnsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.RequireNamed(ctx, request, "column_id", kc.ColumnResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	position, err := request.RequireInt("position")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectId,
+		"column_id":  columnId,
+		"position":   position,
+	}
+
+	result, err := kc.Call(ctx, "changeColumnPosition", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to reorder columns: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
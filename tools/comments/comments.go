@@ -0,0 +1,260 @@
+// Package comments registers the tools of the comments domain (comment management).
+package comments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "comments", Description: "Comment management"}
+
+// Register adds the comment tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("create_comment",
+			mcp.WithDescription("Add a comment to a task for discussion, updates, or notes"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to add a comment to"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user adding the comment"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Markdown content for the comment"),
+			),
+			mcp.WithString("reference",
+				mcp.Description("External reference for the comment"),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Visibility of the comment (app-user, app-manager, app-admin)"),
+			),
+		),
+		Handler:   createCommentHandler,
+		Method:    "createComment",
+		Procedure: "commentprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("get_task_comments",
+			mcp.WithDescription("Get all comments on a task ordered by creation date"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get comments for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getTaskCommentsHandler,
+		Method:    "getAllComments",
+		Procedure: "commentprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("get_comment",
+			mcp.WithDescription("Get a single comment by ID including author and timestamp"),
+			mcp.WithNumber("comment_id",
+				mcp.Required(),
+				mcp.Description("ID of the comment to get details for"),
+			),
+		),
+		Handler:   getCommentHandler,
+		Method:    "getComment",
+		Procedure: "commentprocedure",
+		Scope:     tools.ScopeComment,
+		Arg:       "comment_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("update_comment",
+			mcp.WithDescription("Edit an existing comment's text content"),
+			mcp.WithNumber("id",
+				mcp.Required(),
+				mcp.Description("ID of the comment to update"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("New Markdown content for the comment"),
+			),
+		),
+		Handler:   updateCommentHandler,
+		Method:    "updateComment",
+		Procedure: "commentprocedure",
+		Scope:     tools.ScopeComment,
+		Arg:       "id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("remove_comment",
+			mcp.WithDescription("Delete a comment from a task"),
+			mcp.WithNumber("comment_id",
+				mcp.Required(),
+				mcp.Description("ID of the comment to remove"),
+			),
+		),
+		Handler:     removeCommentHandler,
+		Method:      "removeComment",
+		Procedure:   "commentprocedure",
+		Scope:       tools.ScopeComment,
+		Arg:         "comment_id",
+		Destructive: true,
+		Core:        true,
+	},
+}
+
+func createCommentHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	content, err := request.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"task_id": taskId,
+		"user_id": userId,
+		"content": content,
+	}
+
+	reference := request.GetString("reference", "")
+	if reference != "" {
+		params["reference"] = reference
+	}
+
+	visibility := request.GetString("visibility", "")
+	if visibility != "" {
+		// Validate visibility
+		validVisibilities := []string{"app-user", "app-manager", "app-admin"}
+		visibilityValid := false
+		for _, validVisibility := range validVisibilities {
+			if visibility == validVisibility {
+				visibilityValid = true
+				break
+			}
+		}
+		if !visibilityValid {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid visibility '%s'. Valid visibilities are: %v", visibility, validVisibilities)), nil
+		}
+		params["visibility"] = visibility
+	}
+
+	result, err := kc.Call(ctx, "createComment", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create comment: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskCommentsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllComments(ctx, taskId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get task comments: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getCommentHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	commentId, err := request.RequireInt("comment_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetComment(ctx, commentId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get comment details: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateCommentHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireInt("id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	content, err := request.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"id": id, "content": content}
+	result, err := kc.Call(ctx, "updateComment", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update comment: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeCommentHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	commentId, err := request.RequireInt("comment_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"comment_id": commentId}
+	result, err := kc.Call(ctx, "removeComment", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove comment: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
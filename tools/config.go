@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"kanboard-mcp/logging"
+)
+
+// CoreDomain is the pseudo-domain of mcp-tools-config.yaml made of the Core tools
+const CoreDomain = "corerules"
+
+// DomainConfig enables a domain of tools in mcp-tools-config.yaml
+type DomainConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Tools narrows the domain to these tools, all tools of the domain when empty
+	Tools []string `yaml:"tools"`
+}
+
+// Config is mcp-tools-config.yaml: DomainConfig entries keyed by registry domain
+type Config map[string]DomainConfig
+
+// LoadConfig loads the MCP tools configuration from a YAML file. A missing
+// file returns a nil configuration, which enables every tool.
+func LoadConfig(configPath string) (Config, error) {
+	// Default config path if not specified
+	if configPath == "" {
+		configPath = "mcp-tools-config.yaml"
+	}
+
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return config, nil
+}
+
+// EnabledTools resolves the configuration against the registry. A domain enabled
+// without a tool list enables all of its tools (corerules: the Core tools).
+// Unknown domains and tools are logged and ignored.
+func (config Config) EnabledTools(r *Registry) map[string]bool {
+	enabled := make(map[string]bool)
+	for _, domain := range slices.Sorted(maps.Keys(config)) {
+		domainConfig := config[domain]
+		if !domainConfig.Enabled {
+			continue
+		}
+
+		var tools []*Tool
+		if domain == CoreDomain {
+			tools = r.CoreTools()
+		} else {
+			tools = r.DomainTools(domain)
+		}
+		if len(tools) == 0 {
+			logging.Tools.Warn("MCP tools config enables an unknown domain", "domain", domain, "domains", r.Domains())
+			continue
+		}
+
+		if len(domainConfig.Tools) == 0 {
+			for _, tool := range tools {
+				enabled[tool.Name()] = true
+			}
+			continue
+		}
+		for _, name := range domainConfig.Tools {
+			if _, ok := r.Lookup(name); !ok {
+				logging.Tools.Warn("MCP tools config lists an unknown tool", "domain", domain, "tool", name)
+				continue
+			}
+			enabled[name] = true
+		}
+	}
+	return enabled
+}
@@ -0,0 +1,365 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+)
+
+// defaultConfirmTools are the destructive tools gated when MCP_CONFIRM_TOOLS is not set
+var defaultConfirmTools = []string{
+	"remove_project",
+	"remove_all_project_files",
+	"remove_all_task_files",
+	"remove_user",
+	"delete_column",
+	"remove_swimlane",
+}
+
+// How long a confirm token stays valid
+const confirmTokenTTL = 5 * time.Minute
+
+// ConfirmToolsFromEnv reads MCP_CONFIRM_TOOLS as a comma-separated list ("none" disables
+// the gate). Tools unknown to the registry are skipped with a warning.
+func ConfirmToolsFromEnv(r *Registry) map[string]bool {
+	names := defaultConfirmTools
+	if value, ok := os.LookupEnv("MCP_CONFIRM_TOOLS"); ok {
+		names = strings.Split(value, ",")
+	}
+
+	tools := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.EqualFold(name, "none") {
+			continue
+		}
+		if _, ok := r.Lookup(name); !ok {
+			logging.Tools.Warn("MCP_CONFIRM_TOOLS lists an unknown tool", "tool", name)
+			continue
+		}
+		tools[name] = true
+	}
+	return tools
+}
+
+// pendingConfirmation is a destructive call waiting for its confirm token
+type pendingConfirmation struct {
+	tool      string
+	argsHash  string
+	sessionID string
+	expiresAt time.Time
+}
+
+// confirmationStore keeps the confirm tokens handed out by the first call
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{pending: make(map[string]pendingConfirmation)}
+}
+
+// issue creates a one-time token bound to the tool, its arguments and the session
+func (cs *confirmationStore) issue(tool, argsHash, sessionID string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate confirm token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	now := time.Now()
+	for key, pending := range cs.pending {
+		if now.After(pending.expiresAt) {
+			delete(cs.pending, key)
+		}
+	}
+	cs.pending[token] = pendingConfirmation{
+		tool:      tool,
+		argsHash:  argsHash,
+		sessionID: sessionID,
+		expiresAt: now.Add(confirmTokenTTL),
+	}
+	return token, nil
+}
+
+// consume validates a token and removes it so it can't be replayed
+func (cs *confirmationStore) consume(token, tool, argsHash, sessionID string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	pending, ok := cs.pending[token]
+	if !ok {
+		return fmt.Errorf("unknown or already used confirm token")
+	}
+	if time.Now().After(pending.expiresAt) {
+		delete(cs.pending, token)
+		return fmt.Errorf("confirm token expired")
+	}
+	if pending.tool != tool || pending.argsHash != argsHash || pending.sessionID != sessionID {
+		return fmt.Errorf("confirm token was issued for a different call")
+	}
+	delete(cs.pending, token)
+	return nil
+}
+
+// confirmationArgsHash fingerprints the call arguments, ignoring the gate's own parameters
+func confirmationArgsHash(args map[string]interface{}) string {
+	filtered := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if key == "confirm_token" || key == "dry_run" {
+			continue
+		}
+		filtered[key] = value
+	}
+	// encoding/json sorts map keys, so the encoding is stable
+	data, _ := json.Marshal(filtered)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ConfirmMiddleware makes the tools in Confirm return a confirm token on the first call
+// and only run when called again with that token and the same arguments.
+// MCP elicitation would let us ask the user directly, but mcp-go v0.33.0 can't send
+// elicitation requests, so every client goes through the token flow.
+func (r *Registry) ConfirmMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolName := request.Params.Name
+			// Dry runs don't delete anything, so they don't need confirmation
+			if !r.Confirm[toolName] || kanboard.DryRunRecorderFromContext(ctx) != nil {
+				return next(ctx, request)
+			}
+
+			args := request.GetArguments()
+			argsHash := confirmationArgsHash(args)
+			sessionID := ""
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				sessionID = session.SessionID()
+			}
+
+			if token := request.GetString("confirm_token", ""); token != "" {
+				if err := r.confirmations.consume(token, toolName, argsHash, sessionID); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s was not executed: %v. Call it again without confirm_token to get a new token", toolName, err)), nil
+				}
+				return next(ctx, request)
+			}
+
+			token, err := r.confirmations.issue(toolName, argsHash, sessionID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			response := map[string]interface{}{
+				"confirmation_required": true,
+				"tool":                  toolName,
+				"confirm_token":         token,
+				"expires_in":            confirmTokenTTL.String(),
+				"message":               fmt.Sprintf("%s is destructive and was not executed. Show the summary to the user and, once they agree, call %s again with the same arguments and confirm_token.", toolName, toolName),
+			}
+			summary, err := deletionSummary(ctx, ClientFromContext(ctx), toolName, args)
+			if err != nil {
+				response["summary_error"] = err.Error()
+			} else {
+				response["will_delete"] = summary
+			}
+
+			resultBytes, err := json.MarshalIndent(response, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal confirmation: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(resultBytes)), nil
+		}
+	}
+}
+
+// deletionSummary describes what a destructive tool call is about to delete
+func deletionSummary(ctx context.Context, kc *kanboard.Client, toolName string, args map[string]interface{}) (map[string]interface{}, error) {
+	summary := make(map[string]interface{})
+
+	switch toolName {
+	case "remove_project":
+		projectID, err := RequireProjectArgument(ctx, kc, args, "project_id")
+		if err != nil {
+			return nil, err
+		}
+		calls := []kanboard.BatchCall{
+			{Method: "getProjectById", Params: map[string]interface{}{"project_id": projectID}},
+			{Method: "getAllTasks", Params: map[string]interface{}{"project_id": projectID, "status_id": 1}},
+			{Method: "getAllTasks", Params: map[string]interface{}{"project_id": projectID, "status_id": 0}},
+			{Method: "getAllProjectFiles", Params: map[string]interface{}{"project_id": projectID}},
+		}
+		results, err := kc.CallBatch(ctx, calls)
+		if err != nil {
+			return nil, err
+		}
+		project, err := kanboard.BatchObject[kanboard.Project](results[0], "project", projectID)
+		if err != nil {
+			return nil, err
+		}
+		summary["project"] = project.Name
+		summary["project_id"] = projectID
+		for i, key := range []string{"open_tasks", "closed_tasks"} {
+			tasks, err := kanboard.BatchList[kanboard.Task](results[i+1], "getAllTasks")
+			if err != nil {
+				return nil, err
+			}
+			summary[key] = len(tasks)
+		}
+		files, err := kanboard.BatchList[kanboard.ProjectFile](results[3], "getAllProjectFiles")
+		if err != nil {
+			return nil, err
+		}
+		summary["files"] = len(files)
+
+	case "remove_all_project_files":
+		projectID, err := RequireProjectArgument(ctx, kc, args, "project_id")
+		if err != nil {
+			return nil, err
+		}
+		names, err := listNames(ctx, kc, "getAllProjectFiles", map[string]interface{}{"project_id": projectID}, "name")
+		if err != nil {
+			return nil, err
+		}
+		summary["project_id"] = projectID
+		summary["files"] = len(names)
+		summary["file_names"] = names
+
+	case "remove_all_task_files":
+		taskID, err := TaskArgument(ctx, kc, args, "task_id")
+		if err != nil {
+			return nil, err
+		}
+		files, err := kc.GetAllTaskFiles(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("getAllTaskFiles failed: %w", err)
+		}
+		names := make([]string, len(files))
+		for i, file := range files {
+			names[i] = string(file.Name)
+		}
+		summary["task_id"] = taskID
+		summary["files"] = len(names)
+		summary["file_names"] = names
+
+	case "remove_user":
+		userID, err := RequireNamedArgument(ctx, args, "user_id", kc.ResolveUser)
+		if err != nil {
+			return nil, err
+		}
+		user, err := kc.GetUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		summary["user_id"] = userID
+		summary["username"] = user.Username
+		summary["name"] = user.Name
+
+	case "delete_column":
+		projectID, _, err := ProjectArgument(ctx, kc, args, "project_id")
+		if err != nil {
+			return nil, err
+		}
+		columnID, err := RequireNamedArgument(ctx, args, "column_id", kc.ColumnResolver(projectID))
+		if err != nil {
+			return nil, err
+		}
+		column, err := kc.GetColumn(ctx, columnID)
+		if err != nil {
+			return nil, err
+		}
+		projectID = int(column.ProjectID)
+		tasks, err := kc.GetAllTasks(ctx, projectID, 1)
+		if err != nil {
+			return nil, fmt.Errorf("getAllTasks failed: %w", err)
+		}
+		count := countTasks(tasks, func(task kanboard.Task) bool { return int(task.ColumnID) == columnID })
+		summary["column"] = column.Title
+		summary["column_id"] = columnID
+		summary["project_id"] = projectID
+		summary["open_tasks_in_column"] = count
+
+	case "remove_swimlane":
+		projectID, err := RequireProjectArgument(ctx, kc, args, "project_id")
+		if err != nil {
+			return nil, err
+		}
+		swimlaneID, err := RequireNamedArgument(ctx, args, "swimlane_id", kc.SwimlaneResolver(projectID))
+		if err != nil {
+			return nil, err
+		}
+		results, err := kc.CallBatch(ctx, []kanboard.BatchCall{
+			{Method: "getSwimlaneById", Params: map[string]interface{}{"swimlane_id": swimlaneID}},
+			{Method: "getAllTasks", Params: map[string]interface{}{"project_id": projectID, "status_id": 1}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		swimlane, err := kanboard.BatchObject[kanboard.Swimlane](results[0], "swimlane", swimlaneID)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := kanboard.BatchList[kanboard.Task](results[1], "getAllTasks")
+		if err != nil {
+			return nil, err
+		}
+		count := countTasks(tasks, func(task kanboard.Task) bool { return int(task.SwimlaneID) == swimlaneID })
+		summary["swimlane"] = swimlane.Name
+		summary["swimlane_id"] = swimlaneID
+		summary["project_id"] = projectID
+		summary["open_tasks_in_swimlane"] = count
+
+	default:
+		// Tools added through MCP_CONFIRM_TOOLS without a dedicated summary
+		for key, value := range args {
+			if key != "confirm_token" && key != "dry_run" {
+				summary[key] = value
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// listNames returns one field of every object in a list returned by Kanboard
+func listNames(ctx context.Context, kc *kanboard.Client, method string, params map[string]interface{}, field string) ([]string, error) {
+	result, err := kc.Call(ctx, method, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", method, err)
+	}
+	list, _ := result.([]interface{})
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		if object, ok := item.(map[string]interface{}); ok {
+			names = append(names, fmt.Sprint(object[field]))
+		}
+	}
+	return names, nil
+}
+
+// countTasks counts the tasks for which match returns true
+func countTasks(tasks []kanboard.Task, match func(kanboard.Task) bool) int {
+	count := 0
+	for _, task := range tasks {
+		if match(task) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,138 @@
+// Package dashboard registers the tools of the dashboard domain (dashboard and activity).
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "dashboard", Description: "Dashboard and activity"}
+
+// Register adds the dashboard tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_my_dashboard",
+			mcp.WithDescription("Get current user's dashboard with assigned tasks, projects, and activities"),
+		),
+		Handler:   getMyDashboardHandler,
+		Method:    "getMyDashboard",
+		Procedure: "meprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_my_activity_stream",
+			mcp.WithDescription("Get the last 100 events for the logged user"),
+		),
+		Handler:   getMyActivityStreamHandler,
+		Method:    "getMyActivityStream",
+		Procedure: "meprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_my_overdue_tasks",
+			mcp.WithDescription("Get all overdue tasks assigned to the current user"),
+		),
+		Handler:   getMyOverdueTasksHandler,
+		Method:    "getMyOverdueTasks",
+		Procedure: "meprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_overdue_tasks",
+			mcp.WithDescription("Get all tasks past their due date across all accessible projects"),
+		),
+		Handler:   getOverdueTasksHandler,
+		Method:    "getOverdueTasks",
+		Procedure: "taskprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_overdue_tasks_by_project",
+			mcp.WithDescription("Get all tasks past their due date for a specific project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+		),
+		Handler:   getOverdueTasksByProjectHandler,
+		Method:    "getOverdueTasksByProject",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+}
+
+func getMyDashboardHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.Call(ctx, "getMyDashboard", nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getMyActivityStreamHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.Call(ctx, "getMyActivityStream", nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getMyOverdueTasksHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetMyOverdueTasks(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getOverdueTasksHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetOverdueTasks(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get overdue tasks: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getOverdueTasksByProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetOverdueTasksByProject(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get overdue tasks: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,74 @@
+// Package externallinks registers the tools of the external_links domain (external link providers).
+package externallinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "external_links", Description: "External link providers"}
+
+// Register adds the external link tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_external_task_link_types",
+			mcp.WithDescription("Get available external link providers (GitHub, GitLab, web URLs, etc.)"),
+		),
+		Handler:   getExternalTaskLinkTypesHandler,
+		Method:    "getExternalTaskLinkTypes",
+		Procedure: "taskexternallinkprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_ext_link_provider_deps",
+			mcp.WithDescription("Get available dependencies for a given provider"),
+			mcp.WithString("provider",
+				mcp.Required(),
+				mcp.Description("Provider name"),
+			),
+		),
+		Handler:   getExternalTaskLinkProviderDependenciesHandler,
+		Method:    "getExternalTaskLinkProviderDependencies",
+		Procedure: "taskexternallinkprocedure",
+		ReadOnly:  true,
+	},
+}
+
+func getExternalTaskLinkTypesHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.Call(ctx, "getExternalTaskLinkTypes", nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getExternalTaskLinkProviderDependenciesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	providerName, err := request.RequireString("provider")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]string{"providerName": providerName}
+	result, err := kc.Call(ctx, "getExternalTaskLinkProviderDependencies", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,358 @@
+// Package groups registers the tools of the groups domain (group management).
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "groups", Description: "Group management"}
+
+// Register adds the group tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("create_group",
+			mcp.WithDescription("Create a new user group for bulk permission management"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the group to create"),
+			),
+			mcp.WithString("external_id",
+				mcp.Description("External ID for the group (optional)"),
+			),
+		),
+		Handler:   createGroupHandler,
+		Method:    "createGroup",
+		Procedure: "groupprocedure",
+	},
+	{
+		Definition: mcp.NewTool("update_group",
+			mcp.WithDescription("Update group name or external ID"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group to update"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name for the group (optional)"),
+			),
+			mcp.WithString("external_id",
+				mcp.Description("New external ID for the group (optional)"),
+			),
+		),
+		Handler:   updateGroupHandler,
+		Method:    "updateGroup",
+		Procedure: "groupprocedure",
+	},
+	{
+		Definition: mcp.NewTool("remove_group",
+			mcp.WithDescription("Delete a group (users remain but lose group-based permissions)"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group to remove"),
+			),
+		),
+		Handler:     removeGroupHandler,
+		Method:      "removeGroup",
+		Procedure:   "groupprocedure",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("get_group",
+			mcp.WithDescription("Get group details by ID including name and member count"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group to retrieve"),
+			),
+		),
+		Handler:   getGroupHandler,
+		Method:    "getGroup",
+		Procedure: "groupprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_groups",
+			mcp.WithDescription("Get all user groups in the system"),
+		),
+		Handler:   getAllGroupsHandler,
+		Method:    "getAllGroups",
+		Procedure: "groupprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_member_groups",
+			mcp.WithDescription("Get all groups that a specific user belongs to"),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user"),
+			),
+		),
+		Handler:   getMemberGroupsHandler,
+		Method:    "getMemberGroups",
+		Procedure: "groupmemberprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_group_members",
+			mcp.WithDescription("Get list of all users in a group"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group"),
+			),
+		),
+		Handler:   getGroupMembersHandler,
+		Method:    "getGroupMembers",
+		Procedure: "groupmemberprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("add_group_member",
+			mcp.WithDescription("Add a user to a group for shared permissions"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to add"),
+			),
+		),
+		Handler:      addGroupMemberHandler,
+		Method:       "addGroupMember",
+		Procedure:    "groupmemberprocedure",
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("remove_group_member",
+			mcp.WithDescription("Remove a user from a group (revokes group-based permissions)"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group")),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to remove"),
+			),
+		),
+		Handler:      removeGroupMemberHandler,
+		Method:       "removeGroupMember",
+		Procedure:    "groupmemberprocedure",
+		Destructive:  true,
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("is_group_member",
+			mcp.WithDescription("Check if a user belongs to a specific group (returns boolean)"),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user"),
+			),
+		),
+		Handler:   isGroupMemberHandler,
+		Method:    "isGroupMember",
+		Procedure: "groupmemberprocedure",
+		ReadOnly:  true,
+	},
+}
+
+func createGroupHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"name": name}
+	externalId := request.GetString("external_id", "")
+	if externalId != "" {
+		params["external_id"] = externalId
+	}
+
+	result, err := kc.Call(ctx, "createGroup", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateGroupHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"group_id": groupId}
+	name := request.GetString("name", "")
+	if name != "" {
+		params["name"] = name
+	}
+	externalId := request.GetString("external_id", "")
+	if externalId != "" {
+		params["external_id"] = externalId
+	}
+
+	result, err := kc.Call(ctx, "updateGroup", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeGroupHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"group_id": groupId}
+	result, err := kc.Call(ctx, "removeGroup", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getGroupHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetGroup(ctx, groupId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllGroupsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAllGroups(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getMemberGroupsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetMemberGroups(ctx, userId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getGroupMembersHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetGroupMembers(ctx, groupId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func addGroupMemberHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"group_id": groupId, "user_id": userId}
+	result, err := kc.Call(ctx, "addGroupMember", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeGroupMemberHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"group_id": groupId, "user_id": userId}
+	result, err := kc.Call(ctx, "removeGroupMember", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func isGroupMemberHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"group_id": groupId, "user_id": userId}
+	result, err := kc.Call(ctx, "isGroupMember", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
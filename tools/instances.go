@@ -0,0 +1,294 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+
+	"kanboard-mcp/kanboard"
+)
+
+// Instance is a Kanboard installation tool calls can be routed to
+type Instance struct {
+	Name        string
+	Description string
+	Client      *kanboard.Client
+	// Enabled is the tool configuration of the instance, every tool when empty
+	Enabled map[string]bool
+	// ReadOnly instances refuse the tools that modify Kanboard
+	ReadOnly bool
+}
+
+// enables reports whether the tool may be called on the instance
+func (i *Instance) enables(tool *Tool) bool {
+	return tool.AlwaysEnabled || len(i.Enabled) == 0 || i.Enabled[tool.Name()]
+}
+
+// InstanceConfig is one Kanboard installation of the instances config file. The endpoint
+// may reference environment variables, a credential may be given as "${NAME}" to be read
+// from the environment.
+type InstanceConfig struct {
+	Description string `yaml:"description"`
+	Endpoint    string `yaml:"endpoint"`
+	AuthMethod  string `yaml:"auth_method"`
+	APIKey      string `yaml:"api_key"`
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+	ReadOnly    bool   `yaml:"read_only"`
+	// Tools uses the format of mcp-tools-config.yaml, which applies when it is empty
+	Tools Config `yaml:"tools"`
+}
+
+// InstancesConfig is the file named by KANBOARD_INSTANCES_CONFIG
+type InstancesConfig struct {
+	// Default serves calls without an instance argument; without it the argument is required
+	Default   string                    `yaml:"default"`
+	Instances map[string]InstanceConfig `yaml:"instances"`
+}
+
+var (
+	instanceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	envReferencePattern = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*)\}$`)
+)
+
+// LoadInstancesConfig reads and validates the instances config file
+func LoadInstancesConfig(path string) (*InstancesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instances config: %w", err)
+	}
+	var config InstancesConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse instances config %s: %w", path, err)
+	}
+
+	if len(config.Instances) == 0 {
+		return nil, fmt.Errorf("instances config %s defines no instances", path)
+	}
+	for _, name := range config.Names() {
+		instance := config.Instances[name]
+		instance.Endpoint = os.ExpandEnv(instance.Endpoint)
+		instance.APIKey = expandEnvReference(instance.APIKey)
+		instance.Username = expandEnvReference(instance.Username)
+		instance.Password = expandEnvReference(instance.Password)
+		switch {
+		case !instanceNamePattern.MatchString(name):
+			return nil, fmt.Errorf("invalid instance name %q: use lowercase letters, digits, - and _", name)
+		case instance.Endpoint == "":
+			return nil, fmt.Errorf("instance %s has no endpoint", name)
+		case instance.APIKey == "" && (instance.Username == "" || instance.Password == ""):
+			return nil, fmt.Errorf("instance %s needs an api_key or a username and password", name)
+		}
+		switch strings.ToLower(instance.AuthMethod) {
+		case "", "global_token", "user_token", "bearer":
+		default:
+			return nil, fmt.Errorf("instance %s: unsupported auth_method %s (supported: global_token, user_token, bearer)", name, instance.AuthMethod)
+		}
+		config.Instances[name] = instance
+	}
+	if _, ok := config.Instances[config.Default]; config.Default != "" && !ok {
+		return nil, fmt.Errorf("default instance %s is not defined", config.Default)
+	}
+	return &config, nil
+}
+
+// expandEnvReference reads a "${NAME}" value from the environment. Other values are kept
+// as they are, so that a password may contain a $.
+func expandEnvReference(value string) string {
+	if match := envReferencePattern.FindStringSubmatch(value); match != nil {
+		return os.Getenv(match[1])
+	}
+	return value
+}
+
+// Names returns the instance names in alphabetical order
+func (config *InstancesConfig) Names() []string {
+	return slices.Sorted(maps.Keys(config.Instances))
+}
+
+type instanceContextKey struct{}
+
+type toolContextKey struct{}
+
+// ClientFromContext returns the Kanboard client of the instance a tool call is routed to,
+// nil outside the InstanceMiddleware
+func ClientFromContext(ctx context.Context) *kanboard.Client {
+	if instance := InstanceFromContext(ctx); instance != nil {
+		return instance.Client
+	}
+	return nil
+}
+
+// InstanceFromContext returns the instance a tool call is routed to
+func InstanceFromContext(ctx context.Context) *Instance {
+	instance, _ := ctx.Value(instanceContextKey{}).(*Instance)
+	return instance
+}
+
+// ToolFromContext returns the registered tool being called, nil outside the
+// InstanceMiddleware
+func ToolFromContext(ctx context.Context) *Tool {
+	tool, _ := ctx.Value(toolContextKey{}).(*Tool)
+	return tool
+}
+
+// InstanceMiddleware routes every tool call to the instance named by its instance
+// argument, or the default one, with the session credentials if any. It must run before the middlewares and handlers that
+// call Kanboard, which take the client from the context. Destructive tools only accept
+// whole names in place of IDs.
+func (r *Registry) InstanceMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			instance, err := r.routeInstance(request)
+			if err == nil {
+				instance, err = r.sessionInstance(ctx, instance, request.Params.Name)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if tool, ok := r.Lookup(request.Params.Name); ok {
+				ctx = context.WithValue(ctx, toolContextKey{}, tool)
+				if tool.Destructive {
+					ctx = kanboard.WithExactNames(ctx)
+				}
+			}
+			return next(context.WithValue(ctx, instanceContextKey{}, instance), request)
+		}
+	}
+}
+
+// routeInstance picks the instance of a tool call and checks the tool may run there
+func (r *Registry) routeInstance(request mcp.CallToolRequest) (*Instance, error) {
+	if len(r.Instances) == 0 {
+		return nil, fmt.Errorf("no Kanboard instance is configured")
+	}
+	toolName := request.Params.Name
+	tool, known := r.Lookup(toolName)
+	name := request.GetString("instance", "")
+
+	var instance *Instance
+	switch {
+	case name != "":
+		for _, candidate := range r.Instances {
+			if candidate.Name == name {
+				instance = candidate
+			}
+		}
+		if instance == nil {
+			return nil, fmt.Errorf("unknown instance %q (instances: %s)", name, strings.Join(r.instanceNames(), ", "))
+		}
+	case r.DefaultInstance != nil:
+		instance = r.DefaultInstance
+	case len(r.Instances) == 1 || (known && tool.Local):
+		// Local tools don't talk to Kanboard, any instance will do
+		instance = r.Instances[0]
+	default:
+		return nil, fmt.Errorf("%s needs the instance argument, one of: %s", toolName, strings.Join(r.instanceNames(), ", "))
+	}
+
+	if !known || tool.Local {
+		return instance, nil
+	}
+	if !instance.enables(tool) {
+		return nil, fmt.Errorf("%s is not enabled on instance %s", toolName, instance.Name)
+	}
+	if instance.ReadOnly && r.Mutating(toolName) {
+		return nil, fmt.Errorf("access denied: %s modifies Kanboard and instance %s is read-only", toolName, instance.Name)
+	}
+	return instance, nil
+}
+
+// sessionInstance swaps the client of the instance for one using the Kanboard credentials
+// sent by the MCP client, as the SessionCredentials mode asks
+func (r *Registry) sessionInstance(ctx context.Context, instance *Instance, toolName string) (*Instance, error) {
+	if tool, known := r.Lookup(toolName); r.SessionCredentials == "" || r.SessionCredentials == SessionCredentialsOff || (known && tool.Local) {
+		return instance, nil
+	}
+	credentials, ok := SessionCredentialsFromContext(ctx)
+	if !ok {
+		if r.SessionCredentials == SessionCredentialsRequired {
+			return nil, fmt.Errorf("authentication required: %s needs your Kanboard credentials, send your username and personal API token with HTTP Basic authentication or the X-Kanboard-Username and X-Kanboard-Token headers", toolName)
+		}
+		return instance, nil
+	}
+	return r.sessionClients.instance(instance, credentials, r.SessionClientEvicted), nil
+}
+
+func (r *Registry) instanceNames() []string {
+	names := make([]string, len(r.Instances))
+	for i, instance := range r.Instances {
+		names[i] = instance.Name
+	}
+	return names
+}
+
+// instanceArgument returns the instance argument added to the Kanboard tools when
+// more than one instance is configured
+func (r *Registry) instanceArgument() mcp.ToolOption {
+	description := "Kanboard instance to call, see list_instances"
+	if r.DefaultInstance != nil {
+		description += fmt.Sprintf(" (default: %s)", r.DefaultInstance.Name)
+	}
+	return mcp.WithString("instance", mcp.Description(description), mcp.Enum(r.instanceNames()...))
+}
+
+// instancesTool returns list_instances, exposed on every instance
+func (r *Registry) instancesTool() Tool {
+	return Tool{
+		Definition: mcp.NewTool("list_instances",
+			mcp.WithDescription("List the Kanboard instances this server can call, for the instance argument of the other tools"),
+		),
+		Handler:       r.listInstancesHandler,
+		Local:         true,
+		ReadOnly:      true,
+		AlwaysEnabled: true,
+	}
+}
+
+// instanceInfo describes an instance in the list_instances output
+type instanceInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Endpoint    string `json:"endpoint"`
+	Auth        string `json:"auth"`
+	Default     bool   `json:"default"`
+	ReadOnly    bool   `json:"read_only"`
+	Tools       int    `json:"tools"`
+	Breaker     string `json:"circuit_breaker"`
+}
+
+func (r *Registry) listInstancesHandler(_ context.Context, _ *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	instances := make([]instanceInfo, 0, len(r.Instances))
+	for _, instance := range r.Instances {
+		info := instanceInfo{
+			Name:        instance.Name,
+			Description: instance.Description,
+			Endpoint:    instance.Client.Endpoint(),
+			Auth:        instance.Client.Authentication(),
+			Default:     instance == r.DefaultInstance || len(r.Instances) == 1,
+			ReadOnly:    instance.ReadOnly || instance.Client.ReadOnly,
+			Breaker:     instance.Client.Breaker.Status().State,
+		}
+		for _, tool := range r.installed {
+			if !tool.Local && instance.enables(tool) && !(info.ReadOnly && r.Mutating(tool.Name())) {
+				info.Tools++
+			}
+		}
+		instances = append(instances, info)
+	}
+
+	resultBytes, err := json.MarshalIndent(instances, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal instances: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
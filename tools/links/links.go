@@ -0,0 +1,252 @@
+// Package links registers the tools of the links domain (task link management).
+package links
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "links", Description: "Task link management"}
+
+// Register adds the link tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	// Link Management
+	{
+		Definition: mcp.NewTool("get_all_links",
+			mcp.WithDescription("Get all available task relationship types (e.g., blocks/blocked by, parent/child)"),
+		),
+		Handler:   getAllLinksHandler,
+		Method:    "getAllLinks",
+		Procedure: "linkprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_opposite_link_id",
+			mcp.WithDescription("Get the reverse relationship ID (e.g., 'blocked by' for 'blocks')"),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the link to get the opposite ID for"),
+			),
+		),
+		Handler:   getOppositeLinkIdHandler,
+		Method:    "getOppositeLinkId",
+		Procedure: "linkprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_link_by_label",
+			mcp.WithDescription("Get task relationship type by its label name (e.g., 'blocks', 'relates to')"),
+			mcp.WithString("label",
+				mcp.Required(),
+				mcp.Description("Label of the link to retrieve"),
+			),
+		),
+		Handler:   getLinkByLabelHandler,
+		Method:    "getLinkByLabel",
+		Procedure: "linkprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_link_by_id",
+			mcp.WithDescription("Get task relationship type definition by ID"),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the link to retrieve"),
+			),
+		),
+		Handler:   getLinkByIdHandler,
+		Method:    "getLinkById",
+		Procedure: "linkprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_link",
+			mcp.WithDescription("Define a new task relationship type with label and opposite label"),
+			mcp.WithString("label",
+				mcp.Required(),
+				mcp.Description("Label of the new link"),
+			),
+			mcp.WithString("opposite_label",
+				mcp.Description("Label of the opposite link (optional)"),
+			),
+		),
+		Handler:   createLinkHandler,
+		Method:    "createLink",
+		Procedure: "linkprocedure",
+	},
+	{
+		Definition: mcp.NewTool("update_link",
+			mcp.WithDescription("Update a task relationship type definition"),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the link to update"),
+			),
+			mcp.WithNumber("opposite_link_id",
+				mcp.Required(),
+				mcp.Description("ID of the opposite link"),
+			),
+			mcp.WithString("label",
+				mcp.Required(),
+				mcp.Description("New label for the link"),
+			),
+		),
+		Handler:   updateLinkHandler,
+		Method:    "updateLink",
+		Procedure: "linkprocedure",
+	},
+	{
+		Definition: mcp.NewTool("remove_link",
+			mcp.WithDescription("Delete a task relationship type definition"),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the link to remove"),
+			),
+		),
+		Handler:     removeLinkHandler,
+		Method:      "removeLink",
+		Procedure:   "linkprocedure",
+		Destructive: true,
+	},
+}
+
+func getAllLinksHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAllLinks(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getOppositeLinkIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"link_id": linkId}
+	result, err := kc.Call(ctx, "getOppositeLinkId", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getLinkByLabelHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	label, err := request.RequireString("label")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetLinkByLabel(ctx, label)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getLinkByIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetLinkByID(ctx, linkId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	label, err := request.RequireString("label")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	oppositeLabel := request.GetString("opposite_label", "")
+
+	params := map[string]interface{}{
+		"label":          label,
+		"opposite_label": oppositeLabel,
+	}
+
+	result, err := kc.Call(ctx, "createLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	oppositeLinkId, err := request.RequireInt("opposite_link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	label, err := request.RequireString("label")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"link_id":          linkId,
+		"opposite_link_id": oppositeLinkId,
+		"label":            label,
+	}
+
+	result, err := kc.Call(ctx, "updateLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"link_id": linkId}
+	result, err := kc.Call(ctx, "removeLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
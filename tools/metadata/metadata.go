@@ -0,0 +1,341 @@
+// Package metadata registers the tools of the metadata domain (metadata operations).
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "metadata", Description: "Metadata operations"}
+
+// Register adds the metadata tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	// Task Metadata Management
+	{
+		Definition: mcp.NewTool("get_task_metadata",
+			mcp.WithDescription("Get all metadata related to a task by task unique id"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get metadata from"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getTaskMetadataHandler,
+		Method:    "getTaskMetadata",
+		Procedure: "taskmetadataprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_task_metadata_by_name",
+			mcp.WithDescription("Get metadata related to a task by task unique id and metakey (name)"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get metadata from"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the metadata key"),
+			),
+		),
+		Handler:   getTaskMetadataByNameHandler,
+		Method:    "getTaskMetadataByName",
+		Procedure: "taskmetadataprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("save_task_metadata",
+			mcp.WithDescription("Save or update custom metadata key-value pair on a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to save/update metadata for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithObject("values",
+				mcp.Required(),
+				mcp.Description("Dictionary of metadata values (key-value pairs)"),
+			),
+		),
+		Handler:   saveTaskMetadataHandler,
+		Method:    "saveTaskMetadata",
+		Procedure: "taskmetadataprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_task_metadata",
+			mcp.WithDescription("Delete a custom metadata field from a task by key name"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to remove metadata from"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the metadata key to remove"),
+			),
+		),
+		Handler:     removeTaskMetadataHandler,
+		Method:      "removeTaskMetadata",
+		Procedure:   "taskmetadataprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		Destructive: true,
+	},
+	// Project Metadata Management
+	{
+		Definition: mcp.NewTool("get_project_metadata",
+			mcp.WithDescription("Get all custom metadata key-value pairs for a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get metadata from"),
+			),
+		),
+		Handler:   getProjectMetadataHandler,
+		Method:    "getProjectMetadata",
+		Procedure: "projectmetadataprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_metadata_by_name",
+			mcp.WithDescription("Get a single project metadata value by key name"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the metadata key"),
+			),
+		),
+		Handler:   getProjectMetadataByNameHandler,
+		Method:    "getProjectMetadataByName",
+		Procedure: "projectmetadataprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("save_project_metadata",
+			mcp.WithDescription("Save or update custom metadata key-value pair on a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithObject("values",
+				mcp.Required(),
+				mcp.Description("Dictionary of metadata values (key-value pairs)"),
+			),
+		),
+		Handler:   saveProjectMetadataHandler,
+		Method:    "saveProjectMetadata",
+		Procedure: "projectmetadataprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_project_metadata",
+			mcp.WithDescription("Delete a custom metadata field from a project by key name"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the metadata key to remove"),
+			),
+		),
+		Handler:     removeProjectMetadataHandler,
+		Method:      "removeProjectMetadata",
+		Procedure:   "projectmetadataprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		Destructive: true,
+	},
+}
+
+func getProjectMetadataHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "getProjectMetadata", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectMetadataByNameHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "name": name}
+	result, err := kc.Call(ctx, "getProjectMetadataByName", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func saveProjectMetadataHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args := request.GetArguments()
+	values, ok := args["values"].(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid 'values' parameter"), nil
+	}
+
+	params := map[string]interface{}{"project_id": projectId, "values": values}
+	result, err := kc.Call(ctx, "saveProjectMetadata", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeProjectMetadataHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "name": name}
+	result, err := kc.Call(ctx, "removeProjectMetadata", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskMetadataHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetTaskMetadata(ctx, taskId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskMetadataByNameHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetTaskMetadataByName(ctx, taskId, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+func saveTaskMetadataHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Extract 'values' as a map[string]string
+	args := request.GetArguments()
+	valuesMap, ok := args["values"].(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid 'values' parameter. Expected a map."), nil
+	}
+
+	stringValues := make(map[string]string)
+	for key, val := range valuesMap {
+		strVal, isString := val.(string)
+		if !isString {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid value for metadata key '%s'. Expected string.", key)), nil
+		}
+		stringValues[key] = strVal
+	}
+
+	result, err := kc.SaveTaskMetadata(ctx, taskId, stringValues)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
+
+func removeTaskMetadataHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.RemoveTaskMetadata(ctx, taskId, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
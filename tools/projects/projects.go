@@ -0,0 +1,1436 @@
+// Package projects registers the tools of the projects domain (project management tools).
+package projects
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "projects", Description: "Project management tools"}
+
+// Register adds the project tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_projects",
+			mcp.WithDescription("List all projects accessible to the current user with basic details (ID, name, status)"),
+		),
+		Handler:   getProjectsHandler,
+		Method:    "getAllProjects",
+		Procedure: "projectprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_project",
+			mcp.WithDescription("Create a new project with name, identifier, description, and optional owner assignment"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the project to create"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the project (optional)"),
+			),
+			mcp.WithString("owner_id",
+				mcp.Description("ID or username of the project owner (optional)"),
+			),
+			mcp.WithString("identifier",
+				mcp.Description("Alphanumeric project identifier (optional)"),
+			),
+			mcp.WithString("start_date",
+				mcp.Description("Project start date in ISO8601 format (optional)"),
+			),
+			mcp.WithString("end_date",
+				mcp.Description("Project end date in ISO8601 format (optional)"),
+			),
+			mcp.WithNumber("priority_default",
+				mcp.Description("Default task priority (optional)"),
+			),
+			mcp.WithNumber("priority_start",
+				mcp.Description("Start priority (optional)"),
+			),
+			mcp.WithNumber("priority_end",
+				mcp.Description("End priority (optional)"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Project email address (optional)"),
+			),
+		),
+		Handler:   createProjectHandler,
+		Method:    "createProject",
+		Procedure: "projectprocedure",
+	},
+	{
+		Definition: mcp.NewTool("assign_user_to_project",
+			mcp.WithDescription("Assign a user to a project with a specific role"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to assign the user to"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to assign"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role to assign (e.g., project-member, project-manager)"),
+			),
+		),
+		Handler:      assignUserToProjectHandler,
+		Method:       "addProjectUser",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		Core:         true,
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("create_my_private_project",
+			mcp.WithDescription("Create a private project for the logged user"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the private project to create"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the private project (optional)"),
+			),
+		),
+		Handler:   createMyPrivateProjectHandler,
+		Method:    "createMyPrivateProject",
+		Procedure: "meprocedure",
+	},
+	{
+		Definition: mcp.NewTool("get_my_projects_list",
+			mcp.WithDescription("Get list of projects the current user has access to (IDs and names only)"),
+		),
+		Handler:   getMyProjectsListHandler,
+		Method:    "getMyProjectsList",
+		Procedure: "meprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_my_projects",
+			mcp.WithDescription("Get projects of connected user with full details"),
+		),
+		Handler:   getMyProjectsHandler,
+		Method:    "getMyProjects",
+		Procedure: "meprocedure",
+		ReadOnly:  true,
+	},
+	// Project Management
+	{
+		Definition: mcp.NewTool("get_project_by_id",
+			mcp.WithDescription("Get detailed project information by ID including settings, members count, and configuration"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to retrieve"),
+			),
+		),
+		Handler:   getProjectByIdHandler,
+		Method:    "getProjectById",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_by_name",
+			mcp.WithDescription("Get detailed project information by exact name match"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the project to retrieve"),
+			),
+		),
+		Handler:   getProjectByNameHandler,
+		Method:    "getProjectByName",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProjectName,
+		Arg:       "name",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_by_identifier",
+			mcp.WithDescription("Get detailed project information by unique project identifier/slug"),
+			mcp.WithString("identifier",
+				mcp.Required(),
+				mcp.Description("Identifier of the project to retrieve"),
+			),
+		),
+		Handler:   getProjectByIdentifierHandler,
+		Method:    "getProjectByIdentifier",
+		Procedure: "projectprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_by_email",
+			mcp.WithDescription("Get project information associated with a specific email address"),
+			mcp.WithString("email",
+				mcp.Required(),
+				mcp.Description("Email of the project to retrieve"),
+			),
+		),
+		Handler:   getProjectByEmailHandler,
+		Method:    "getProjectByEmail",
+		Procedure: "projectprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_projects",
+			mcp.WithDescription("Get all projects in the system (admin only) with full details"),
+		),
+		Handler:   getAllProjectsHandler,
+		Method:    "getAllProjects",
+		Procedure: "projectprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("update_project",
+			mcp.WithDescription("Update project properties including name, description, identifier, owner, and settings"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to update"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name for the project (optional)"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description for the project (optional)"),
+			),
+			mcp.WithString("owner_id",
+				mcp.Description("New owner ID or username for the project (optional)"),
+			),
+			mcp.WithString("identifier",
+				mcp.Description("New alphanumeric identifier for the project (optional)"),
+			),
+			mcp.WithString("start_date",
+				mcp.Description("New start date in ISO8601 format (optional)"),
+			),
+			mcp.WithString("end_date",
+				mcp.Description("New end date in ISO8601 format (optional)"),
+			),
+			mcp.WithNumber("priority_default",
+				mcp.Description("New default task priority (optional)"),
+			),
+			mcp.WithNumber("priority_start",
+				mcp.Description("New start priority (optional)"),
+			),
+			mcp.WithNumber("priority_end",
+				mcp.Description("New end priority (optional)"),
+			),
+			mcp.WithString("email",
+				mcp.Description("New project email address (optional)"),
+			),
+		),
+		Handler:   updateProjectHandler,
+		Method:    "updateProject",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_project",
+			mcp.WithDescription("Permanently delete a project and all associated tasks, files, and history"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to remove"),
+			),
+		),
+		Handler:     removeProjectHandler,
+		Method:      "removeProject",
+		Procedure:   "projectprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("enable_project",
+			mcp.WithDescription("Activate a disabled project, making it visible and accessible to members"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to enable"),
+			),
+		),
+		Handler:   enableProjectHandler,
+		Method:    "enableProject",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("disable_project",
+			mcp.WithDescription("Deactivate a project, hiding it from users while preserving all data"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to disable"),
+			),
+		),
+		Handler:   disableProjectHandler,
+		Method:    "disableProject",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("enable_project_public_access",
+			mcp.WithDescription("Enable public read-only access to a project board via shareable URL"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to enable public access for"),
+			),
+		),
+		Handler:   enableProjectPublicAccessHandler,
+		Method:    "enableProjectPublicAccess",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("disable_project_public_access",
+			mcp.WithDescription("Disable public URL access, making the project private to members only"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to disable public access for"),
+			),
+		),
+		Handler:   disableProjectPublicAccessHandler,
+		Method:    "disableProjectPublicAccess",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("get_project_activity",
+			mcp.WithDescription("Get recent activity events for a project (task changes, comments, file uploads)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get activity for"),
+			),
+		),
+		Handler:   getProjectActivityHandler,
+		Method:    "getProjectActivity",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_activities",
+			mcp.WithDescription("Get paginated activity feed for one or more projects with filtering options"),
+			mcp.WithArray("project_ids",
+				mcp.Required(),
+				mcp.WithStringItems(),
+				mcp.Description("Array of project IDs, names or identifiers to get activities for"),
+			),
+		),
+		Handler:   getProjectActivitiesHandler,
+		Method:    "getProjectActivities",
+		Procedure: "projectprocedure",
+		Scope:     tools.ScopeProjectList,
+		Arg:       "project_ids",
+		ReadOnly:  true,
+	},
+	// Project File Management
+	{
+		Definition: mcp.NewTool("create_project_file",
+			mcp.WithDescription("Upload and attach a file to a project (base64 encoded content)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to attach the file to"),
+			),
+			mcp.WithString("filename",
+				mcp.Required(),
+				mcp.Description("Name of the file, or file path if blob is not provided"),
+			),
+			mcp.WithString("blob",
+				mcp.Description("File content encoded in base64. If not provided, filename will be treated as a file path and read from disk (relative paths will be resolved from current working directory)"),
+			),
+		),
+		Handler:   createProjectFileHandler,
+		Method:    "createProjectFile",
+		Procedure: "projectfileprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_project_files",
+			mcp.WithDescription("Get list of all file attachments on a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get files from"),
+			),
+		),
+		Handler:   getAllProjectFilesHandler,
+		Method:    "getAllProjectFiles",
+		Procedure: "projectfileprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_file",
+			mcp.WithDescription("Get project file attachment metadata (name, size, upload date) by ID"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithNumber("file_id",
+				mcp.Required(),
+				mcp.Description("ID of the file to retrieve"),
+			),
+		),
+		Handler:     getProjectFileHandler,
+		Method:      "getProjectFile",
+		Procedure:   "projectfileprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		ObjectArg:   "file_id",
+		ObjectScope: tools.ScopeProjectFile,
+		ReadOnly:    true,
+	},
+	{
+		Definition: mcp.NewTool("download_project_file",
+			mcp.WithDescription("Download project file contents (encoded in base64). Optionally save to disk if save_path is provided."),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithNumber("file_id",
+				mcp.Required(),
+				mcp.Description("ID of the file to download"),
+			),
+			mcp.WithString("save_path",
+				mcp.Description("Optional path to save the file. If 'temp', saves to OS temp directory. If empty, returns base64 content only. If directory, saves with original filename. If full path, saves to that location."),
+			),
+		),
+		Handler:     downloadProjectFileHandler,
+		Method:      "downloadProjectFile",
+		Procedure:   "projectfileprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		ObjectArg:   "file_id",
+		ObjectScope: tools.ScopeProjectFile,
+		ReadOnly:    true,
+		Core:        true,
+	},
+	{
+		Definition: mcp.NewTool("remove_project_file",
+			mcp.WithDescription("Delete a file attachment from a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithNumber("file_id",
+				mcp.Required(),
+				mcp.Description("ID of the file to remove"),
+			),
+		),
+		Handler:     removeProjectFileHandler,
+		Method:      "removeProjectFile",
+		Procedure:   "projectfileprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		ObjectArg:   "file_id",
+		ObjectScope: tools.ScopeProjectFile,
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("remove_all_project_files",
+			mcp.WithDescription("Delete all file attachments from a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to remove all files from"),
+			),
+		),
+		Handler:     removeAllProjectFilesHandler,
+		Method:      "removeAllProjectFiles",
+		Procedure:   "projectfileprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		Destructive: true,
+	},
+	// Project Permission Management
+	{
+		Definition: mcp.NewTool("get_project_users",
+			mcp.WithDescription("Get all users with access to a project and their roles"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get users from"),
+			),
+		),
+		Handler:   getProjectUsersHandler,
+		Method:    "getProjectUsers",
+		Procedure: "projectpermissionprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("add_project_user",
+			mcp.WithDescription("Grant access to a project for a user"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role to assign (optional)"),
+			),
+		),
+		Handler:      addProjectUserHandler,
+		Method:       "addProjectUser",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("add_project_group",
+			mcp.WithDescription("Grant access to a project for a group"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role to assign (optional)"),
+			),
+		),
+		Handler:      addProjectGroupHandler,
+		Method:       "addProjectGroup",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("remove_project_user",
+			mcp.WithDescription("Revoke user access to a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user"),
+			),
+		),
+		Handler:      removeProjectUserHandler,
+		Method:       "removeProjectUser",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		Destructive:  true,
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("remove_project_group",
+			mcp.WithDescription("Revoke group access to a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group"),
+			),
+		),
+		Handler:      removeProjectGroupHandler,
+		Method:       "removeProjectGroup",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		Destructive:  true,
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("change_project_user_role",
+			mcp.WithDescription("Change a user's role in a project (manager, member, or viewer)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user"),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("New role to assign"),
+			),
+		),
+		Handler:      changeProjectUserRoleHandler,
+		Method:       "changeProjectUserRole",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("change_project_group_role",
+			mcp.WithDescription("Change a group's role in a project (manager, member, or viewer)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("ID of the group"),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("New role to assign"),
+			),
+		),
+		Handler:      changeProjectGroupRoleHandler,
+		Method:       "changeProjectGroupRole",
+		Procedure:    "projectpermissionprocedure",
+		Scope:        tools.ScopeProject,
+		Arg:          "project_id",
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("get_project_user_role",
+			mcp.WithDescription("Get a user's current role (manager, member, viewer) in a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user"),
+			),
+		),
+		Handler:   getProjectUserRoleHandler,
+		Method:    "getProjectUserRole",
+		Procedure: "projectpermissionprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+}
+
+func getProjectsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAllProjects(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"name": name}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	ownerId, err := tools.OptionalNamed(ctx, request, "owner_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if ownerId != 0 {
+		params["owner_id"] = ownerId
+	}
+
+	identifier := request.GetString("identifier", "")
+	if identifier != "" {
+		params["identifier"] = identifier
+	}
+
+	startDate := request.GetString("start_date", "")
+	if startDate != "" {
+		params["start_date"] = startDate
+	}
+
+	endDate := request.GetString("end_date", "")
+	if endDate != "" {
+		params["end_date"] = endDate
+	}
+
+	priorityDefault := request.GetInt("priority_default", 0)
+	if priorityDefault != 0 {
+		params["priority_default"] = priorityDefault
+	}
+
+	priorityStart := request.GetInt("priority_start", 0)
+	if priorityStart != 0 {
+		params["priority_start"] = priorityStart
+	}
+
+	priorityEnd := request.GetInt("priority_end", 0)
+	if priorityEnd != 0 {
+		params["priority_end"] = priorityEnd
+	}
+
+	email := request.GetString("email", "")
+	if email != "" {
+		params["email"] = email
+	}
+
+	result, err := kc.Call(ctx, "createProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createMyPrivateProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]string{"name": name}
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+	result, err := kc.Call(ctx, "createMyPrivateProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getMyProjectsListHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.Call(ctx, "getMyProjectsList", nil)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getMyProjectsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetMyProjects(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func assignUserToProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	role := request.GetString("role", "project-member") // Default to project-member
+
+	// Validate role
+	validRoles := []string{"project-member", "project-manager", "project-viewer"}
+	roleValid := false
+	for _, validRole := range validRoles {
+		if role == validRole {
+			roleValid = true
+			break
+		}
+	}
+	if !roleValid {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid role '%s'. Valid roles are: %v", role, validRoles)), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectId,
+		"user_id":    userId,
+		"role":       role,
+	}
+
+	result, err := kc.Call(ctx, "addProjectUser", params)
+	if err != nil {
+		// Provide more helpful error message for 403 errors
+		if err.Error() == "API request failed with status code: 403, Response: 403 Forbidden" {
+			return mcp.NewToolResultError(fmt.Sprintf("Permission denied (403 Forbidden). The API user does not have sufficient privileges to assign users to projects. Please ensure the API user has 'app-admin' role in Kanboard. Project ID: %d, User ID: %d, Role: %s", projectId, userId, role)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to assign user to project: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectByIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetProjectByID(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectByNameHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetProjectByName(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project by name: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectByIdentifierHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	identifier, err := request.RequireString("identifier")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetProjectByIdentifier(ctx, identifier)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project by identifier: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectByEmailHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	email, err := request.RequireString("email")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetProjectByEmail(ctx, email)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project by email: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllProjectsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAllProjects(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"project_id": projectId}
+
+	name := request.GetString("name", "")
+	if name != "" {
+		params["name"] = name
+	}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	ownerId, err := tools.OptionalNamed(ctx, request, "owner_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if ownerId != 0 {
+		params["owner_id"] = ownerId
+	}
+
+	identifier := request.GetString("identifier", "")
+	if identifier != "" {
+		params["identifier"] = identifier
+	}
+
+	startDate := request.GetString("start_date", "")
+	if startDate != "" {
+		params["start_date"] = startDate
+	}
+
+	endDate := request.GetString("end_date", "")
+	if endDate != "" {
+		params["end_date"] = endDate
+	}
+
+	priorityDefault := request.GetInt("priority_default", 0)
+	if priorityDefault != 0 {
+		params["priority_default"] = priorityDefault
+	}
+
+	priorityStart := request.GetInt("priority_start", 0)
+	if priorityStart != 0 {
+		params["priority_start"] = priorityStart
+	}
+
+	priorityEnd := request.GetInt("priority_end", 0)
+	if priorityEnd != 0 {
+		params["priority_end"] = priorityEnd
+	}
+
+	email := request.GetString("email", "")
+	if email != "" {
+		params["email"] = email
+	}
+
+	result, err := kc.Call(ctx, "updateProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update project: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "removeProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove project: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func enableProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "enableProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to enable project: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func disableProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "disableProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to disable project: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func enableProjectPublicAccessHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "enableProjectPublicAccess", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to enable project public access: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func disableProjectPublicAccessHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "disableProjectPublicAccess", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to disable project public access: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectActivityHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "getProjectActivity", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project activity: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectActivitiesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectIds, err := tools.RequireProjectList(ctx, kc, request.GetArguments(), "project_ids")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_ids": projectIds}
+	result, err := kc.Call(ctx, "getProjectActivities", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project activities: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createProjectFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	filename, err := request.RequireString("filename")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get blob, but make it optional
+	blob := request.GetString("blob", "")
+
+	// If blob is missing/empty, treat filename as a file path
+	if blob == "" {
+		var err error
+		blob, err = tools.ReadFileAsBase64(filename)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to read file: %v", err)), nil
+		}
+		// Extract just the filename from the path for the API
+		filename = filepath.Base(filename)
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectId,
+		"filename":   filename,
+		"blob":       blob,
+	}
+
+	result, err := kc.Call(ctx, "createProjectFile", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllProjectFilesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllProjectFiles(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	fileId, err := request.RequireInt("file_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetProjectFile(ctx, projectId, fileId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func downloadProjectFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	fileId, err := request.RequireInt("file_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	savePath := request.GetString("save_path", "")
+
+	// Get file info first to extract filename
+	fileInfo, err := kc.GetProjectFile(ctx, projectId, fileId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	filename := string(fileInfo.Name)
+	if filename == "" {
+		filename = fmt.Sprintf("file_%d", fileId)
+	}
+
+	// Download file content
+	params := map[string]int{"project_id": projectId, "file_id": fileId}
+	result, err := kc.Call(ctx, "downloadProjectFile", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Extract base64 content from result
+	var base64Content string
+	if resultMap, ok := result.(map[string]interface{}); ok {
+		if data, exists := resultMap["data"]; exists {
+			base64Content = fmt.Sprintf("%v", data)
+		} else {
+			// If result is a map but no "data" key, try to use the whole result as string
+			resultBytes, _ := json.Marshal(result)
+			base64Content = string(resultBytes)
+		}
+	} else if resultStr, ok := result.(string); ok {
+		base64Content = resultStr
+	} else {
+		return mcp.NewToolResultError(fmt.Sprintf("unexpected result type: %T", result)), nil
+	}
+
+	// If save_path is provided, decode base64 and save to file
+	if savePath != "" {
+		savedPath, err := tools.SaveBase64ToFile(base64Content, savePath, filename)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to save file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("File saved to: %s", savedPath)), nil
+	}
+
+	// If save_path is not provided, decode base64 to string and return text content
+	decodedContent, err := base64.StdEncoding.DecodeString(base64Content)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to decode base64 content: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(decodedContent)), nil
+}
+
+func removeProjectFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	fileId, err := request.RequireInt("file_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId, "file_id": fileId}
+	result, err := kc.Call(ctx, "removeProjectFile", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeAllProjectFilesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"project_id": projectId}
+	result, err := kc.Call(ctx, "removeAllProjectFiles", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectUsersHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId}
+	result, err := kc.Call(ctx, "getProjectUsers", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func addProjectUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	role := request.GetString("role", "")
+	params := map[string]interface{}{"project_id": projectId, "user_id": userId}
+	if role != "" {
+		params["role"] = role
+	}
+	result, err := kc.Call(ctx, "addProjectUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func addProjectGroupHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	role := request.GetString("role", "")
+	params := map[string]interface{}{"project_id": projectId, "group_id": groupId}
+	if role != "" {
+		params["role"] = role
+	}
+	result, err := kc.Call(ctx, "addProjectGroup", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeProjectUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "user_id": userId}
+	result, err := kc.Call(ctx, "removeProjectUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeProjectGroupHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "group_id": groupId}
+	result, err := kc.Call(ctx, "removeProjectGroup", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func changeProjectUserRoleHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	role, err := request.RequireString("role")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "user_id": userId, "role": role}
+	result, err := kc.Call(ctx, "changeProjectUserRole", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func changeProjectGroupRoleHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	groupId, err := request.RequireInt("group_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	role, err := request.RequireString("role")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "group_id": groupId, "role": role}
+	result, err := kc.Call(ctx, "changeProjectGroupRole", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectUserRoleHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "user_id": userId}
+	result, err := kc.Call(ctx, "getProjectUserRole", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,262 @@
+// Package tools holds the tool registry: every tool with the metadata that RBAC, the
+// tools config, tool_search and the generated docs derive from.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/kanboard"
+)
+
+// Handler serves a tool call with the Kanboard client the call goes to
+type Handler func(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// Scope tells the RBAC middleware how to find the project a tool call operates on
+type Scope int
+
+const (
+	// ScopeApplication checks the application-level "api" access map
+	ScopeApplication Scope = iota
+	// ScopeProject resolves the project ID, name or identifier in Arg (falls back to a "project_name" argument)
+	ScopeProject
+	// ScopeProjectName resolves the project name, identifier or ID in Arg
+	ScopeProjectName
+	// ScopeProjectList checks every project ID in the array argument Arg
+	ScopeProjectList
+	// The remaining scopes load the referenced object to find its project
+	ScopeTask
+	ScopeColumn
+	ScopeCategory
+	ScopeSwimlane
+	ScopeComment
+	ScopeSubtask
+	ScopeTaskLink
+	ScopeTaskFile
+	ScopeTag
+	ScopeSprint
+	ScopeAction
+	// ScopeProjectFile and ScopeExternalLink are only used as the ObjectScope of a tool
+	ScopeProjectFile
+	ScopeExternalLink
+)
+
+// Tool is an MCP tool together with the metadata that the tool configuration,
+// the permission checks, tool_search and the docs are derived from
+type Tool struct {
+	// Definition is the MCP tool: name, description and input schema
+	Definition mcp.Tool
+	Handler    Handler
+	// Domain groups tools in mcp-tools-config.yaml and the docs, set by Register
+	Domain string
+
+	// Method is the Kanboard JSON-RPC method checked against the RBAC access maps,
+	// Procedure the API procedure class implementing it
+	Method    string
+	Procedure string
+	// Scope and Arg describe how the project ID is resolved
+	Scope Scope
+	Arg   string
+	// ObjectArg names a second object the call acts on, of kind ObjectScope. Kanboard
+	// loads it by its ID alone, so it must belong to the project of a ScopeProject tool
+	// or to the task of a ScopeTask tool.
+	ObjectArg   string
+	ObjectScope Scope
+	// DestinationArg names the project a task is moved or copied to, checked as well
+	DestinationArg string
+
+	// ReadOnly tools never change Kanboard data, Destructive tools remove some
+	ReadOnly    bool
+	Destructive bool
+	// Local tools don't talk to Kanboard and need no permission check
+	Local bool
+	// ChangesRoles tools modify role assignments, cached roles are reloaded after them
+	ChangesRoles bool
+
+	// Core tools make up the corerules domain of the default tool configuration
+	Core bool
+	// AlwaysEnabled tools are exposed whatever the tool configuration says
+	AlwaysEnabled bool
+}
+
+// Name returns the MCP name of the tool
+func (t *Tool) Name() string {
+	return t.Definition.Name
+}
+
+// Domain is a group of tools that mcp-tools-config.yaml enables together
+type Domain struct {
+	Name        string
+	Description string
+}
+
+// Registry is the catalogue of every tool the server can expose
+type Registry struct {
+	tools   []*Tool
+	byName  map[string]*Tool
+	domains []Domain
+
+	// ReadOnly hides the tools that modify Kanboard (--read-only or MCP_READ_ONLY=true)
+	ReadOnly bool
+	// DryRun previews every mutating call instead of sending it (--dry-run or MCP_DRY_RUN=true)
+	DryRun bool
+	// Confirm is the set of destructive tools that need a confirm token
+	Confirm map[string]bool
+	// Instances are the Kanboard installations tool calls are routed to, see InstanceMiddleware.
+	// DefaultInstance serves calls without an instance argument.
+	Instances       []*Instance
+	DefaultInstance *Instance
+	// SessionCredentials is off, optional or required: whether calls use the Kanboard
+	// credentials sent by the MCP client, see SessionCredentialsModeFromEnv
+	SessionCredentials string
+	// SessionClientEvicted is called with the client of idle session credentials once dropped
+	SessionClientEvicted func(*kanboard.Client)
+	// ToolFilter hides tools from the tool_search results of a caller, as the server's
+	// tool filter hides them from tools/list
+	ToolFilter server.ToolFilterFunc
+
+	confirmations  *confirmationStore
+	sessionClients *sessionClients
+	installed      []*Tool
+}
+
+// NewRegistry creates a registry holding the tool_search and list_instances tools
+func NewRegistry() *Registry {
+	r := &Registry{
+		byName:         make(map[string]*Tool),
+		confirmations:  newConfirmationStore(),
+		sessionClients: newSessionClients(),
+	}
+	r.Register(Domain{Name: "system"}, r.searchTool(), r.instancesTool())
+	return r
+}
+
+// Register adds the tools of a domain. A domain may be registered more than once,
+// the first non-empty description is kept. Tools with inconsistent metadata are
+// programming errors and make Register panic.
+func (r *Registry) Register(domain Domain, tools ...Tool) {
+	r.addDomain(domain)
+	for _, tool := range tools {
+		tool := tool
+		tool.Domain = domain.Name
+		if err := r.validate(&tool); err != nil {
+			panic(err)
+		}
+		r.tools = append(r.tools, &tool)
+		r.byName[tool.Name()] = &tool
+	}
+}
+
+func (r *Registry) addDomain(domain Domain) {
+	for i := range r.domains {
+		if r.domains[i].Name == domain.Name {
+			if r.domains[i].Description == "" {
+				r.domains[i].Description = domain.Description
+			}
+			return
+		}
+	}
+	r.domains = append(r.domains, domain)
+}
+
+func (r *Registry) validate(tool *Tool) error {
+	name := tool.Name()
+	switch {
+	case name == "":
+		return fmt.Errorf("tool in domain %s has no name", tool.Domain)
+	case r.byName[name] != nil:
+		return fmt.Errorf("tool %s is registered twice", name)
+	case tool.Handler == nil:
+		return fmt.Errorf("tool %s has no handler", name)
+	case tool.Local:
+		if !tool.ReadOnly {
+			return fmt.Errorf("local tool %s must be read-only", name)
+		}
+		return nil
+	}
+
+	procedure, ok := kanboard.ProcedureForMethod(tool.Method)
+	if !ok {
+		return fmt.Errorf("tool %s maps to unknown Kanboard method %q", name, tool.Method)
+	}
+	if tool.Procedure != procedure {
+		return fmt.Errorf("tool %s: Kanboard method %s belongs to %s, not %q", name, tool.Method, procedure, tool.Procedure)
+	}
+	if tool.ReadOnly == kanboard.IsMutatingMethod(tool.Method) {
+		return fmt.Errorf("tool %s: ReadOnly must be %t for Kanboard method %s", name, !tool.ReadOnly, tool.Method)
+	}
+	if tool.Destructive && tool.ReadOnly {
+		return fmt.Errorf("tool %s can't be both read-only and destructive", name)
+	}
+	if tool.Scope != ScopeApplication && tool.Arg == "" {
+		return fmt.Errorf("tool %s has a project scope but no argument to resolve it from", name)
+	}
+	if tool.ObjectArg != "" {
+		switch {
+		case tool.Scope == ScopeProject && (tool.ObjectScope == ScopeTask || tool.ObjectScope == ScopeProjectFile):
+		case tool.Scope == ScopeTask && (tool.ObjectScope == ScopeSubtask || tool.ObjectScope == ScopeTaskLink || tool.ObjectScope == ScopeExternalLink):
+		default:
+			return fmt.Errorf("tool %s: ObjectScope %d can't be checked against scope %d", name, tool.ObjectScope, tool.Scope)
+		}
+	}
+	if tool.Scope == ScopeProjectFile || tool.Scope == ScopeExternalLink {
+		return fmt.Errorf("tool %s: scope %d is only valid as ObjectScope", name, tool.Scope)
+	}
+	if tool.DestinationArg != "" && tool.Scope != ScopeTask {
+		return fmt.Errorf("tool %s: DestinationArg needs ScopeTask", name)
+	}
+	return nil
+}
+
+// Lookup returns the tool with the given name
+func (r *Registry) Lookup(name string) (*Tool, bool) {
+	tool, ok := r.byName[name]
+	return tool, ok
+}
+
+// Tools returns every registered tool in registration order
+func (r *Registry) Tools() []*Tool {
+	return r.tools
+}
+
+// Domains returns the tool domains in registration order
+func (r *Registry) Domains() []Domain {
+	return r.domains
+}
+
+// DomainTools returns the tools of a domain in registration order
+func (r *Registry) DomainTools(domain string) []*Tool {
+	var tools []*Tool
+	for _, tool := range r.tools {
+		if tool.Domain == domain {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// CoreTools returns the tools of the corerules domain in registration order
+func (r *Registry) CoreTools() []*Tool {
+	var tools []*Tool
+	for _, tool := range r.tools {
+		if tool.Core {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// Mutating reports whether a tool changes Kanboard data. Unknown tools are treated
+// as mutating so they never leak into read-only mode.
+func (r *Registry) Mutating(name string) bool {
+	tool, ok := r.byName[name]
+	return !ok || !tool.ReadOnly
+}
+
+// IsDryRunRequest reports whether a call should be previewed: globally or with dry_run=true
+func (r *Registry) IsDryRunRequest(request mcp.CallToolRequest) bool {
+	return r.DryRun || request.GetBool("dry_run", false)
+}
@@ -0,0 +1,278 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+)
+
+// SearchResult represents a search result with relevance score
+type SearchResult struct {
+	Name        string  `json:"name"`
+	Domain      string  `json:"domain"`
+	Description string  `json:"description"`
+	Score       float64 `json:"score"`
+}
+
+// searchTool returns tool_search, always exposed as it's how clients find the other tools
+func (r *Registry) searchTool() Tool {
+	return Tool{
+		Definition: mcp.NewTool("tool_search",
+			mcp.WithDescription("Search for available tools by name or description using regex or BM25 keyword matching"),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query - can be a regex pattern or keywords to find relevant tools"),
+			),
+			mcp.WithString("search_type",
+				mcp.Description("Search algorithm: 'regex' for pattern matching, 'bm25' for keyword relevance, 'auto' tries regex first then BM25 (default: auto)"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description("Maximum number of results to return (default: 10)"),
+			),
+		),
+		Handler:       r.toolSearchHandler,
+		Local:         true,
+		ReadOnly:      true,
+		AlwaysEnabled: true,
+	}
+}
+
+// toolSearchHandler searches the installed tools using regex or BM25 search
+func (r *Registry) toolSearchHandler(ctx context.Context, _ *kanboard.Client, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := req.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+
+	searchType := req.GetString("search_type", "auto")
+	maxResults := req.GetInt("max_results", 10)
+
+	var results []SearchResult
+	visible := r.visibleTools(ctx)
+
+	switch searchType {
+	case "regex":
+		results = searchToolsRegex(visible, query, maxResults)
+	case "bm25":
+		results = searchToolsBM25(visible, query, maxResults)
+	default: // "auto" - try regex first, fall back to BM25
+		results = searchToolsRegex(visible, query, maxResults)
+		if len(results) == 0 {
+			results = searchToolsBM25(visible, query, maxResults)
+		}
+	}
+
+	// Build response
+	response := map[string]interface{}{
+		"query":        query,
+		"search_type":  searchType,
+		"read_only":    r.ReadOnly,
+		"total_tools":  len(visible),
+		"result_count": len(results),
+		"results":      results,
+	}
+	if r.ReadOnly {
+		response["note"] = "Server is in read-only mode: tools that create, update, move or remove Kanboard data are not available"
+	}
+
+	jsonResponse, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResponse)), nil
+}
+
+// visibleTools returns the installed tools the caller may see, those passing ToolFilter
+func (r *Registry) visibleTools(ctx context.Context) []*Tool {
+	if r.ToolFilter == nil {
+		return r.installed
+	}
+	definitions := make([]mcp.Tool, len(r.installed))
+	for i, tool := range r.installed {
+		definitions[i] = tool.Definition
+	}
+	var visible []*Tool
+	for _, definition := range r.ToolFilter(ctx, definitions) {
+		if tool, ok := r.byName[definition.Name]; ok {
+			visible = append(visible, tool)
+		}
+	}
+	return visible
+}
+
+// searchToolsRegex searches tools using regex pattern matching
+func searchToolsRegex(tools []*Tool, pattern string, maxResults int) []SearchResult {
+	var results []SearchResult
+
+	// Compile regex (case-insensitive)
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		// If pattern is invalid, treat it as a literal string search
+		pattern = regexp.QuoteMeta(pattern)
+		re, _ = regexp.Compile("(?i)" + pattern)
+	}
+
+	for _, tool := range tools {
+		// Check if pattern matches name or description
+		nameMatch := re.MatchString(tool.Name())
+		descMatch := re.MatchString(tool.Definition.Description)
+
+		if nameMatch || descMatch {
+			score := 0.0
+			if nameMatch {
+				score += 1.0 // Higher score for name match
+			}
+			if descMatch {
+				score += 0.5 // Lower score for description match
+			}
+			results = append(results, SearchResult{
+				Name:        tool.Name(),
+				Domain:      tool.Domain,
+				Description: tool.Definition.Description,
+				Score:       score,
+			})
+		}
+	}
+
+	// Sort by score descending
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	// Limit results
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+
+	return results
+}
+
+// searchToolsBM25 searches tools using BM25 algorithm for keyword relevance
+func searchToolsBM25(tools []*Tool, query string, maxResults int) []SearchResult {
+	// Tokenize query
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	// BM25 parameters
+	k1 := 1.2
+	b := 0.75
+
+	// Calculate average document length
+	totalLen := 0
+	for _, tool := range tools {
+		totalLen += len(tokenize(tool.Name() + " " + tool.Definition.Description))
+	}
+	avgDocLen := float64(totalLen) / float64(len(tools))
+
+	// Calculate document frequencies for query terms
+	docFreq := make(map[string]int)
+	for _, term := range queryTerms {
+		for _, tool := range tools {
+			docText := strings.ToLower(tool.Name() + " " + tool.Definition.Description)
+			if strings.Contains(docText, term) {
+				docFreq[term]++
+			}
+		}
+	}
+
+	// Calculate BM25 scores
+	var results []SearchResult
+	N := float64(len(tools))
+
+	for _, tool := range tools {
+		docText := tool.Name() + " " + tool.Definition.Description
+		docTokens := tokenize(docText)
+		docLen := float64(len(docTokens))
+
+		// Count term frequencies in document
+		termFreq := make(map[string]int)
+		for _, token := range docTokens {
+			termFreq[token]++
+		}
+
+		// Calculate BM25 score
+		score := 0.0
+		for _, term := range queryTerms {
+			tf := float64(termFreq[term])
+			df := float64(docFreq[term])
+
+			if df == 0 {
+				continue
+			}
+
+			// IDF component
+			idf := math.Log((N - df + 0.5) / (df + 0.5))
+
+			// TF component with length normalization
+			tfNorm := (tf * (k1 + 1)) / (tf + k1*(1-b+b*(docLen/avgDocLen)))
+
+			score += idf * tfNorm
+		}
+
+		// Boost score if query matches tool name
+		nameLower := strings.ToLower(tool.Name())
+		for _, term := range queryTerms {
+			if strings.Contains(nameLower, term) {
+				score *= 1.5 // 50% boost for name matches
+				break
+			}
+		}
+
+		if score > 0 {
+			results = append(results, SearchResult{
+				Name:        tool.Name(),
+				Domain:      tool.Domain,
+				Description: tool.Definition.Description,
+				Score:       score,
+			})
+		}
+	}
+
+	// Sort by score descending
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	// Limit results
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+
+	return results
+}
+
+// tokenize splits text into lowercase tokens
+func tokenize(text string) []string {
+	// Convert to lowercase and split on non-alphanumeric characters
+	text = strings.ToLower(text)
+	re := regexp.MustCompile(`[^a-z0-9]+`)
+	words := re.Split(text, -1)
+
+	// Filter empty strings and common stop words
+	stopWords := map[string]bool{
+		"a": true, "an": true, "the": true, "and": true, "or": true,
+		"is": true, "are": true, "was": true, "were": true, "be": true,
+		"to": true, "of": true, "in": true, "for": true, "on": true,
+		"with": true, "by": true, "from": true, "as": true, "at": true,
+	}
+
+	var tokens []string
+	for _, word := range words {
+		if word != "" && !stopWords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,65 @@
+// Package search registers the tools of the search domain (search operations).
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "search", Description: "Search operations"}
+
+// Register adds the search tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("search_tasks",
+			mcp.WithDescription("Search tasks using Kanboard query syntax (supports: assignee, status, due date, category, tag filters)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to search tasks in"),
+			),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query string"),
+			),
+		),
+		Handler:   searchTasksHandler,
+		Method:    "searchTasks",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+}
+
+func searchTasksHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := kc.SearchTasks(ctx, projectId, query)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search tasks: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
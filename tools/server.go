@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/logging"
+)
+
+// Install adds the tools enabled on at least one instance to the MCP server. Handlers
+// call the client of the instance InstanceMiddleware routed the call to. In read-only
+// mode tools that modify Kanboard are never exposed.
+func (r *Registry) Install(s *server.MCPServer) {
+	r.installed = nil
+	for _, tool := range r.tools {
+		name := tool.Name()
+		if r.ReadOnly && r.Mutating(name) {
+			logging.Tools.Debug("skipped tool (read-only mode)", "tool", name)
+			continue
+		}
+		if !slices.ContainsFunc(r.Instances, func(instance *Instance) bool { return instance.enables(tool) }) {
+			logging.Tools.Debug("skipped tool (not enabled)", "tool", name)
+			continue
+		}
+
+		s.AddTool(r.definition(tool), bindHandler(tool.Handler))
+		r.installed = append(r.installed, tool)
+		logging.Tools.Debug("registered tool", "tool", name, "domain", tool.Domain)
+	}
+	logging.Tools.Debug("tool registration finished", "total_tools", len(r.installed))
+}
+
+// definition returns the MCP tool as exposed: annotated from the metadata, with the
+// dry_run argument on mutating tools, confirm_token on the confirmed ones and instance
+// when there is more than one Kanboard instance
+func (r *Registry) definition(tool *Tool) mcp.Tool {
+	definition := tool.Definition
+	definition.InputSchema.Properties = make(map[string]any, len(tool.Definition.InputSchema.Properties)+2)
+	for name, property := range tool.Definition.InputSchema.Properties {
+		definition.InputSchema.Properties[name] = property
+	}
+
+	mcp.WithReadOnlyHintAnnotation(tool.ReadOnly)(&definition)
+	mcp.WithDestructiveHintAnnotation(tool.Destructive)(&definition)
+	mcp.WithOpenWorldHintAnnotation(!tool.Local)(&definition)
+
+	if r.Mutating(tool.Name()) {
+		mcp.WithBoolean("dry_run", mcp.Description("Validate and preview the Kanboard API calls without executing them"))(&definition)
+	}
+	if r.Confirm[tool.Name()] {
+		mcp.WithString("confirm_token", mcp.Description("Token returned by the first call, pass it once the user has confirmed the deletion"))(&definition)
+	}
+	if len(r.Instances) > 1 && !tool.Local {
+		r.instanceArgument()(&definition)
+	}
+	return definition
+}
+
+func bindHandler(handler Handler) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handler(ctx, ClientFromContext(ctx), request)
+	}
+}
+
+// BreakerMiddleware fails tool calls fast while the circuit breaker is open.
+// Local tools keep working since they don't need Kanboard.
+func (r *Registry) BreakerMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if tool, ok := r.Lookup(request.Params.Name); ok && tool.Local {
+				return next(ctx, request)
+			}
+			if err := ClientFromContext(ctx).Breaker.OpenError(); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
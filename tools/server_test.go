@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/kanboard"
+)
+
+// testTools are a read tool, a write and a deletion on tasks, whose handlers only echo their name
+func testTools() []Tool {
+	handler := func(_ context.Context, _ *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("called " + request.Params.Name), nil
+	}
+	return []Tool{
+		{Definition: mcp.NewTool("get_task", mcp.WithDescription("Get a task")), Handler: handler,
+			Method: "getTask", Procedure: "taskprocedure", ReadOnly: true},
+		{Definition: mcp.NewTool("create_task", mcp.WithDescription("Create a task")), Handler: handler,
+			Method: "createTask", Procedure: "taskprocedure"},
+		{Definition: mcp.NewTool("remove_task", mcp.WithDescription("Remove a task")), Handler: handler,
+			Method: "removeTask", Procedure: "taskprocedure", Destructive: true},
+	}
+}
+
+// newTestMCPServer installs the test tools on an MCP server routing calls like main does
+func newTestMCPServer(readOnly bool, instances ...*Instance) *server.MCPServer {
+	r := NewRegistry()
+	r.Register(Domain{Name: "tasks"}, testTools()...)
+	r.ReadOnly = readOnly
+	r.Instances = instances
+	r.DefaultInstance = instances[0]
+	s := server.NewMCPServer("test", "1", server.WithToolCapabilities(false), server.WithToolHandlerMiddleware(r.InstanceMiddleware()))
+	r.Install(s)
+	return s
+}
+
+// request sends a JSON-RPC request to the MCP server and returns the reply
+func request(t *testing.T, s *server.MCPServer, method string, params any) mcp.JSONRPCMessage {
+	t.Helper()
+	raw, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.HandleMessage(context.Background(), raw)
+}
+
+// listedTools returns the names of the tools in tools/list
+func listedTools(t *testing.T, s *server.MCPServer) []string {
+	t.Helper()
+	reply, ok := request(t, s, "tools/list", map[string]any{}).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("tools/list failed: %#v", reply)
+	}
+	result, ok := reply.Result.(mcp.ListToolsResult)
+	if !ok {
+		t.Fatalf("unexpected tools/list result %#v", reply.Result)
+	}
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// callText calls a tool and returns the text of the result or the JSON-RPC error
+func callText(t *testing.T, s *server.MCPServer, tool string, arguments map[string]any) string {
+	t.Helper()
+	switch reply := request(t, s, "tools/call", map[string]any{"name": tool, "arguments": arguments}).(type) {
+	case mcp.JSONRPCError:
+		return reply.Error.Message
+	case mcp.JSONRPCResponse:
+		var text strings.Builder
+		for _, content := range reply.Result.(mcp.CallToolResult).Content {
+			if content, ok := content.(mcp.TextContent); ok {
+				text.WriteString(content.Text)
+			}
+		}
+		return text.String()
+	default:
+		t.Fatalf("unexpected reply %#v", reply)
+		return ""
+	}
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	instance := &Instance{Name: "default", Client: kanboard.NewClient("http://kanboard.invalid/jsonrpc.php", "token", "", "")}
+	cases := []struct {
+		name       string
+		readOnly   bool
+		listed     []string
+		calls      map[string]string
+		searchHits []string
+	}{
+		{
+			name:       "read-write",
+			listed:     []string{"create_task", "get_task", "list_instances", "remove_task", "tool_search"},
+			calls:      map[string]string{"get_task": "called get_task", "create_task": "called create_task", "remove_task": "called remove_task"},
+			searchHits: []string{`"name": "get_task"`, `"name": "create_task"`, `"name": "remove_task"`, `"total_tools": 5`},
+		},
+		{
+			// Tools that modify Kanboard are neither listed, found nor callable
+			name: "read-only", readOnly: true,
+			listed:     []string{"get_task", "list_instances", "tool_search"},
+			calls:      map[string]string{"get_task": "called get_task", "create_task": "tool 'create_task' not found", "remove_task": "tool 'remove_task' not found"},
+			searchHits: []string{`"name": "get_task"`, `"total_tools": 3`, `"read_only": true`},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := newTestMCPServer(c.readOnly, instance)
+			if listed := listedTools(t, s); !slices.Equal(listed, c.listed) {
+				t.Errorf("tools/list = %v, want %v", listed, c.listed)
+			}
+			for tool, want := range c.calls {
+				if text := callText(t, s, tool, map[string]any{}); !strings.Contains(text, want) {
+					t.Errorf("%s: got %q, want %q", tool, text, want)
+				}
+			}
+
+			found := callText(t, s, "tool_search", map[string]any{"query": "task"})
+			for _, want := range c.searchHits {
+				if !strings.Contains(found, want) {
+					t.Errorf("tool_search misses %s:\n%s", want, found)
+				}
+			}
+			for _, hidden := range []string{"create_task", "remove_task"} {
+				if c.readOnly && strings.Contains(found, hidden) {
+					t.Errorf("tool_search shows %s in read-only mode:\n%s", hidden, found)
+				}
+			}
+		})
+	}
+}
+
+// A read-only instance refuses writes that another instance of the server may accept
+func TestReadOnlyInstance(t *testing.T) {
+	s := newTestMCPServer(false,
+		&Instance{Name: "eng", Client: kanboard.NewClient("http://eng.invalid/jsonrpc.php", "token", "", "")},
+		&Instance{Name: "ops", Client: kanboard.NewClient("http://ops.invalid/jsonrpc.php", "token", "", ""), ReadOnly: true},
+	)
+	cases := map[string]string{
+		"eng": "called create_task",
+		"ops": "access denied: create_task modifies Kanboard and instance ops is read-only",
+	}
+	for instance, want := range cases {
+		if text := callText(t, s, "create_task", map[string]any{"instance": instance}); !strings.Contains(text, want) {
+			t.Errorf("%s: got %q, want %q", instance, text, want)
+		}
+	}
+	if text := callText(t, s, "get_task", map[string]any{"instance": "ops"}); text != "called get_task" {
+		t.Errorf("reads on the read-only instance: got %q", text)
+	}
+}
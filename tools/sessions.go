@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+)
+
+// Session credentials modes, set by MCP_SESSION_CREDENTIALS
+const (
+	// SessionCredentialsOff calls Kanboard with the server's credentials, whatever the client sends
+	SessionCredentialsOff = "off"
+	// SessionCredentialsOptional uses the client's credentials when it sends some
+	SessionCredentialsOptional = "optional"
+	// SessionCredentialsRequired refuses Kanboard tools to clients without credentials
+	SessionCredentialsRequired = "required"
+)
+
+// sessionClientIdleTTL is how long the client of a set of credentials is kept unused.
+// Streamable HTTP sessions end without notice, so clients can't be dropped with them.
+const sessionClientIdleTTL = 30 * time.Minute
+
+// SessionCredentials are the Kanboard username and personal API token an MCP client
+// sent with its HTTP request
+type SessionCredentials struct {
+	Username string
+	Token    string
+}
+
+type sessionCredentialsContextKey struct{}
+
+// WithSessionCredentials returns a context carrying the credentials of the MCP client
+func WithSessionCredentials(ctx context.Context, credentials SessionCredentials) context.Context {
+	return context.WithValue(ctx, sessionCredentialsContextKey{}, credentials)
+}
+
+// SessionCredentialsFromContext returns the credentials sent by the MCP client, if any
+func SessionCredentialsFromContext(ctx context.Context) (SessionCredentials, bool) {
+	credentials, ok := ctx.Value(sessionCredentialsContextKey{}).(SessionCredentials)
+	return credentials, ok && credentials.Username != "" && credentials.Token != ""
+}
+
+// SessionCredentialsModeFromEnv reads MCP_SESSION_CREDENTIALS: off (default), optional or required
+func SessionCredentialsModeFromEnv() (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("MCP_SESSION_CREDENTIALS"))); mode {
+	case "", SessionCredentialsOff:
+		return SessionCredentialsOff, nil
+	case SessionCredentialsOptional, SessionCredentialsRequired:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid MCP_SESSION_CREDENTIALS %q (expected off, optional or required)", mode)
+	}
+}
+
+// sessionClients keeps a Kanboard client per instance and set of credentials, so that
+// every person gets their own client and RBAC user context
+type sessionClients struct {
+	mu      sync.Mutex
+	clients map[string]*sessionClient
+}
+
+type sessionClient struct {
+	client   *kanboard.Client
+	lastUsed time.Time
+}
+
+func newSessionClients() *sessionClients {
+	return &sessionClients{clients: make(map[string]*sessionClient)}
+}
+
+// instance returns the instance as seen by the owner of the credentials. Clients idle
+// for longer than sessionClientIdleTTL are dropped and passed to evicted.
+func (c *sessionClients) instance(instance *Instance, credentials SessionCredentials, evicted func(*kanboard.Client)) *Instance {
+	token := sha256.Sum256([]byte(credentials.Token))
+	key := instance.Name + "\x00" + credentials.Username + "\x00" + hex.EncodeToString(token[:])
+	now := time.Now()
+
+	c.mu.Lock()
+	var dropped []*kanboard.Client
+	for k, entry := range c.clients {
+		if now.Sub(entry.lastUsed) > sessionClientIdleTTL {
+			delete(c.clients, k)
+			dropped = append(dropped, entry.client)
+		}
+	}
+	entry, ok := c.clients[key]
+	if !ok {
+		entry = &sessionClient{client: instance.Client.WithUserToken(credentials.Username, credentials.Token)}
+		c.clients[key] = entry
+		logging.Tools.Debug("created Kanboard client for session credentials", "instance", instance.Name, "username", credentials.Username)
+	}
+	entry.lastUsed = now
+	c.mu.Unlock()
+
+	if evicted != nil {
+		for _, client := range dropped {
+			evicted(client)
+		}
+	}
+	session := *instance
+	session.Client = entry.client
+	return &session
+}
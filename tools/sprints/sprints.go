@@ -0,0 +1,268 @@
+// Package sprints registers the tools of the sprints domain (sprint management).
+package sprints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "sprints", Description: "Sprint management"}
+
+// Register adds the sprint tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	// ScrumSprint Plugin API
+	{
+		Definition: mcp.NewTool("create_sprint",
+			mcp.WithDescription("Create a new sprint/iteration for agile project management with start and end dates"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to create the sprint in"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new sprint"),
+			),
+			mcp.WithString("start_date",
+				mcp.Required(),
+				mcp.Description("Start date of the sprint (YYYY-MM-DD)"),
+			),
+			mcp.WithString("end_date",
+				mcp.Required(),
+				mcp.Description("End date of the sprint (YYYY-MM-DD)"),
+			),
+		),
+		Handler:   createSprintHandler,
+		Method:    "createSprint",
+		Procedure: "sprintprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("get_sprint_by_id",
+			mcp.WithDescription("Get sprint details including dates, status, and associated tasks"),
+			mcp.WithNumber("sprint_id",
+				mcp.Required(),
+				mcp.Description("ID of the sprint to retrieve"),
+			),
+		),
+		Handler:   getSprintByIdHandler,
+		Method:    "getSprintById",
+		Procedure: "sprintprocedure",
+		Scope:     tools.ScopeSprint,
+		Arg:       "sprint_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("update_sprint",
+			mcp.WithDescription("Update sprint name, dates, or status"),
+			mcp.WithNumber("sprint_id",
+				mcp.Required(),
+				mcp.Description("ID of the sprint to update"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name for the sprint (optional)"),
+			),
+			mcp.WithString("start_date",
+				mcp.Description("New start date for the sprint (YYYY-MM-DD) (optional)"),
+			),
+			mcp.WithString("end_date",
+				mcp.Description("New end date for the sprint (YYYY-MM-DD) (optional)"),
+			),
+			mcp.WithString("sprint_goal",
+				mcp.Description("New goal for the sprint (optional)"),
+			),
+			mcp.WithBoolean("is_completed",
+				mcp.Description("Whether the sprint is completed (optional)"),
+			),
+			mcp.WithBoolean("is_active",
+				mcp.Description("Whether the sprint is active (optional)"),
+			),
+		),
+		Handler:   updateSprintHandler,
+		Method:    "updateSprint",
+		Procedure: "sprintprocedure",
+		Scope:     tools.ScopeSprint,
+		Arg:       "sprint_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_sprint",
+			mcp.WithDescription("Delete a sprint (tasks remain but lose sprint association)"),
+			mcp.WithNumber("sprint_id",
+				mcp.Required(),
+				mcp.Description("ID of the sprint to remove"),
+			),
+		),
+		Handler:     removeSprintHandler,
+		Method:      "removeSprint",
+		Procedure:   "sprintprocedure",
+		Scope:       tools.ScopeSprint,
+		Arg:         "sprint_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_sprints_by_project",
+			mcp.WithDescription("Retrieve all sprints for a given project."),
+			mcp.WithString("project_name",
+				mcp.Required(),
+				mcp.Description("Name, identifier or ID of the project to retrieve sprints from"),
+			),
+		),
+		Handler:   getAllSprintsByProjectHandler,
+		Method:    "getAllSprintsByProject",
+		Procedure: "sprintprocedure",
+		Scope:     tools.ScopeProjectName,
+		Arg:       "project_name",
+		ReadOnly:  true,
+	},
+}
+
+func createSprintHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Prefer project_id if provided, project_name otherwise
+	projectID, ok, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err == nil && !ok {
+		projectID, ok, err = tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_name")
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !ok {
+		return mcp.NewToolResultError("Project ID or name is required for createSprint"), nil
+	}
+
+	name := request.GetString("name", "")
+	if name == "" {
+		return mcp.NewToolResultError("Sprint name is required"), nil
+	}
+	startDate := request.GetString("start_date", "")
+	if startDate == "" {
+		return mcp.NewToolResultError("Start date is required"), nil
+	}
+	endDate := request.GetString("end_date", "")
+	if endDate == "" {
+		return mcp.NewToolResultError("End date is required"), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectID,
+		"name":       name,
+		"start_date": startDate,
+		"end_date":   endDate,
+	}
+
+	sprintResult, err := kc.Call(ctx, "createSprint", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create sprint: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(sprintResult, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getSprintByIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sprintID := request.GetInt("sprint_id", 0)
+	if sprintID == 0 {
+		return mcp.NewToolResultError("Sprint ID is required"), nil
+	}
+
+	result, err := kc.GetSprintByID(ctx, sprintID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get sprint by ID: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateSprintHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sprintID := request.GetInt("sprint_id", 0)
+	if sprintID == 0 {
+		return mcp.NewToolResultError("Sprint ID is required"), nil
+	}
+
+	params := make(map[string]interface{})
+	params["sprint_id"] = sprintID
+
+	if name := request.GetString("name", ""); name != "" {
+		params["name"] = name
+	}
+	if startDate := request.GetString("start_date", ""); startDate != "" {
+		params["start_date"] = startDate
+	}
+	if endDate := request.GetString("end_date", ""); endDate != "" {
+		params["end_date"] = endDate
+	}
+
+	isCompleted := request.GetBool("is_completed", false)
+	isActive := request.GetBool("is_active", false)
+
+	params["is_completed"] = isCompleted
+	params["is_active"] = isActive
+
+	result, err := kc.Call(ctx, "updateSprint", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update sprint: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeSprintHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sprintID := request.GetInt("sprint_id", 0)
+	if sprintID == 0 {
+		return mcp.NewToolResultError("Sprint ID is required"), nil
+	}
+
+	result, err := kc.Call(ctx, "removeSprint", map[string]int{"sprint_id": sprintID})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove sprint: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprint result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllSprintsByProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	sprintsResult, err := kc.GetAllSprintsByProject(ctx, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get all sprints by project: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(sprintsResult, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal sprints result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
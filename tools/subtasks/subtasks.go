@@ -0,0 +1,447 @@
+// Package subtasks registers the tools of the subtasks domain (subtask management).
+package subtasks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "subtasks", Description: "Subtask management"}
+
+// Register adds the subtask tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	// Subtask Management
+	{
+		Definition: mcp.NewTool("create_subtask",
+			mcp.WithDescription("Create a subtask/checklist item within a task with title, assignee, and time estimate"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to associate the subtask with"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the subtask"),
+			),
+			mcp.WithString("user_id",
+				mcp.Description("ID or username of the user assigned to the subtask (optional)"),
+			),
+			mcp.WithNumber("time_estimated",
+				mcp.Description("Estimated time for the subtask in hours (optional)"),
+			),
+			mcp.WithNumber("time_spent",
+				mcp.Description("Time spent on the subtask in hours (optional)"),
+			),
+			mcp.WithNumber("status",
+				mcp.Description("Status of the subtask (0: Todo, 1: In Progress, 2: Done) (optional)"),
+			),
+		),
+		Handler:   createSubtaskHandler,
+		Method:    "createSubtask",
+		Procedure: "subtaskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+	},
+	{
+		Definition: mcp.NewTool("get_subtask",
+			mcp.WithDescription("Get subtask details including status, assignee, and time tracking"),
+			mcp.WithNumber("subtask_id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask to retrieve"),
+			),
+		),
+		Handler:   getSubtaskHandler,
+		Method:    "getSubtask",
+		Procedure: "subtaskprocedure",
+		Scope:     tools.ScopeSubtask,
+		Arg:       "subtask_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_subtasks",
+			mcp.WithDescription("Get all subtasks/checklist items for a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get subtasks for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getAllSubtasksHandler,
+		Method:    "getAllSubtasks",
+		Procedure: "subtaskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("update_subtask",
+			mcp.WithDescription("Update subtask title, status, assignee, or time estimate"),
+			mcp.WithNumber("id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask to update"),
+			),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the associated task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title for the subtask (optional)"),
+			),
+			mcp.WithString("user_id",
+				mcp.Description("New user ID or username assigned to the subtask (optional)"),
+			),
+			mcp.WithNumber("time_estimated",
+				mcp.Description("New estimated time for the subtask in hours (optional)"),
+			),
+			mcp.WithNumber("time_spent",
+				mcp.Description("New time spent on the subtask in hours (optional)"),
+			),
+			mcp.WithNumber("status",
+				mcp.Description("New status of the subtask (0: Todo, 1: In Progress, 2: Done) (optional)"),
+			),
+		),
+		Handler:     updateSubtaskHandler,
+		Method:      "updateSubtask",
+		Procedure:   "subtaskprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		ObjectArg:   "id",
+		ObjectScope: tools.ScopeSubtask,
+	},
+	{
+		Definition: mcp.NewTool("remove_subtask",
+			mcp.WithDescription("Delete a subtask from a task"),
+			mcp.WithNumber("subtask_id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask to remove"),
+			),
+		),
+		Handler:     removeSubtaskHandler,
+		Method:      "removeSubtask",
+		Procedure:   "subtaskprocedure",
+		Scope:       tools.ScopeSubtask,
+		Arg:         "subtask_id",
+		Destructive: true,
+	},
+	// Subtask Time Tracking
+	{
+		Definition: mcp.NewTool("has_subtask_timer",
+			mcp.WithDescription("Check if a timer is started for the given subtask and user"),
+			mcp.WithNumber("subtask_id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask"),
+			),
+			mcp.WithString("user_id",
+				mcp.Description("ID or username of the user (optional)"),
+			),
+		),
+		Handler:   hasSubtaskTimerHandler,
+		Method:    "hasSubtaskTimer",
+		Procedure: "subtasktimetrackingprocedure",
+		Scope:     tools.ScopeSubtask,
+		Arg:       "subtask_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("set_subtask_start_time",
+			mcp.WithDescription("Start time tracking timer for a subtask (for logging work hours)"),
+			mcp.WithNumber("subtask_id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask"),
+			),
+			mcp.WithString("user_id",
+				mcp.Description("ID or username of the user (optional)"),
+			),
+		),
+		Handler:   setSubtaskStartTimeHandler,
+		Method:    "setSubtaskStartTime",
+		Procedure: "subtasktimetrackingprocedure",
+		Scope:     tools.ScopeSubtask,
+		Arg:       "subtask_id",
+	},
+	{
+		Definition: mcp.NewTool("set_subtask_end_time",
+			mcp.WithDescription("Stop time tracking timer for a subtask and log elapsed time"),
+			mcp.WithNumber("subtask_id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask"),
+			),
+			mcp.WithString("user_id",
+				mcp.Description("ID or username of the user (optional)"),
+			),
+		),
+		Handler:   setSubtaskEndTimeHandler,
+		Method:    "setSubtaskEndTime",
+		Procedure: "subtasktimetrackingprocedure",
+		Scope:     tools.ScopeSubtask,
+		Arg:       "subtask_id",
+	},
+	{
+		Definition: mcp.NewTool("get_subtask_time_spent",
+			mcp.WithDescription("Get total time logged on a subtask by a specific user"),
+			mcp.WithNumber("subtask_id",
+				mcp.Required(),
+				mcp.Description("ID of the subtask"),
+			),
+			mcp.WithString("user_id",
+				mcp.Description("ID or username of the user (optional)"),
+			),
+		),
+		Handler:   getSubtaskTimeSpentHandler,
+		Method:    "getSubtaskTimeSpent",
+		Procedure: "subtasktimetrackingprocedure",
+		Scope:     tools.ScopeSubtask,
+		Arg:       "subtask_id",
+		ReadOnly:  true,
+	},
+}
+
+func createSubtaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"task_id": taskId, "title": title}
+
+	userId, err := tools.OptionalNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if userId != 0 {
+		params["user_id"] = userId
+	}
+	timeEstimated := request.GetInt("time_estimated", 0)
+	if timeEstimated != 0 {
+		params["time_estimated"] = timeEstimated
+	}
+	timeSpent := request.GetInt("time_spent", 0)
+	if timeSpent != 0 {
+		params["time_spent"] = timeSpent
+	}
+	status := request.GetInt("status", 0)
+	if status != 0 {
+		params["status"] = status
+	}
+
+	result, err := kc.Call(ctx, "createSubtask", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getSubtaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subtaskId, err := request.RequireInt("subtask_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetSubtask(ctx, subtaskId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllSubtasksHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllSubtasks(ctx, taskId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateSubtaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireInt("id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"id": id, "task_id": taskId}
+
+	title := request.GetString("title", "")
+	if title != "" {
+		params["title"] = title
+	}
+	userId, err := tools.OptionalNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if userId != 0 {
+		params["user_id"] = userId
+	}
+	timeEstimated := request.GetInt("time_estimated", 0)
+	if timeEstimated != 0 {
+		params["time_estimated"] = timeEstimated
+	}
+	timeSpent := request.GetInt("time_spent", 0)
+	if timeSpent != 0 {
+		params["time_spent"] = timeSpent
+	}
+	status := request.GetInt("status", 0)
+	if status != 0 {
+		params["status"] = status
+	}
+
+	result, err := kc.Call(ctx, "updateSubtask", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeSubtaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subtaskId, err := request.RequireInt("subtask_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"subtask_id": subtaskId}
+	result, err := kc.Call(ctx, "removeSubtask", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func hasSubtaskTimerHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subtaskId, err := request.RequireInt("subtask_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"subtask_id": subtaskId}
+	userId, err := tools.OptionalNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if userId != 0 {
+		params["user_id"] = userId
+	}
+	result, err := kc.Call(ctx, "hasSubtaskTimer", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func setSubtaskStartTimeHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subtaskId, err := request.RequireInt("subtask_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"subtask_id": subtaskId}
+	userId, err := tools.OptionalNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if userId != 0 {
+		params["user_id"] = userId
+	}
+	result, err := kc.Call(ctx, "setSubtaskStartTime", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func setSubtaskEndTimeHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subtaskId, err := request.RequireInt("subtask_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"subtask_id": subtaskId}
+	userId, err := tools.OptionalNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if userId != 0 {
+		params["user_id"] = userId
+	}
+	result, err := kc.Call(ctx, "setSubtaskEndTime", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getSubtaskTimeSpentHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subtaskId, err := request.RequireInt("subtask_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"subtask_id": subtaskId}
+	userId, err := tools.OptionalNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if userId != 0 {
+		params["user_id"] = userId
+	}
+	result, err := kc.Call(ctx, "getSubtaskTimeSpent", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,428 @@
+// Package swimlanes registers the tools of the swimlanes domain (swimlane management).
+package swimlanes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "swimlanes", Description: "Swimlane management"}
+
+// Register adds the swimlane tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_swimlanes",
+			mcp.WithDescription("List all swimlanes of a project (enabled or disabled) and sorted by position"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get swimlanes from"),
+			),
+		),
+		Handler:   getAllSwimlanesHandler,
+		Method:    "getAllSwimlanes",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_active_swimlanes",
+			mcp.WithDescription("Get the list of enabled swimlanes of a project (include default swimlane if enabled)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get active swimlanes from"),
+			),
+		),
+		Handler:   getActiveSwimlanesHandler,
+		Method:    "getActiveSwimlanes",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_swimlane",
+			mcp.WithDescription("Get swimlane details by ID including name, description, and position"),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to retrieve"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the swimlane belongs to, required when swimlane_id is a name (optional)"),
+			),
+		),
+		Handler:   getSwimlaneHandler,
+		Method:    "getSwimlane",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeSwimlane,
+		Arg:       "swimlane_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_swimlane_by_id",
+			mcp.WithDescription("Get swimlane details by ID including name, description, and position"),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to retrieve"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the swimlane belongs to, required when swimlane_id is a name (optional)"),
+			),
+		),
+		Handler:   getSwimlaneByIdHandler,
+		Method:    "getSwimlaneById",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeSwimlane,
+		Arg:       "swimlane_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_swimlane_by_name",
+			mcp.WithDescription("Get swimlane details by exact name match within a project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project the swimlane belongs to"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the swimlane to retrieve"),
+			),
+		),
+		Handler:   getSwimlaneByNameHandler,
+		Method:    "getSwimlaneByName",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("change_swimlane_position",
+			mcp.WithDescription("Reorder swimlane by setting new position (affects board layout)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project containing the swimlane"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to reorder"),
+			),
+			mcp.WithNumber("position",
+				mcp.Required(),
+				mcp.Description("New position for the swimlane (must be >= 1)"),
+			),
+		),
+		Handler:   changeSwimlanePositionHandler,
+		Method:    "changeSwimlanePosition",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("create_swimlane",
+			mcp.WithDescription("Create a new horizontal swimlane for task organization (e.g., by team, priority, or epic)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to add the swimlane to"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the swimlane to create"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the swimlane (optional)"),
+			),
+		),
+		Handler:   addSwimlaneHandler,
+		Method:    "addSwimlane",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("update_swimlane",
+			mcp.WithDescription("Update swimlane name or description"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project the swimlane belongs to"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to update"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name for the swimlane (optional)"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description for the swimlane (optional)"),
+			),
+		),
+		Handler:   updateSwimlaneHandler,
+		Method:    "updateSwimlane",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_swimlane",
+			mcp.WithDescription("Delete a swimlane (tasks will move to default swimlane)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project the swimlane belongs to"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to remove"),
+			),
+		),
+		Handler:     removeSwimlaneHandler,
+		Method:      "removeSwimlane",
+		Procedure:   "swimlaneprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("disable_swimlane",
+			mcp.WithDescription("Hide a swimlane from the board while preserving its tasks"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project the swimlane belongs to"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to disable"),
+			),
+		),
+		Handler:   disableSwimlaneHandler,
+		Method:    "disableSwimlane",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("enable_swimlane",
+			mcp.WithDescription("Show a previously disabled swimlane on the board"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project the swimlane belongs to"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to enable"),
+			),
+		),
+		Handler:   enableSwimlaneHandler,
+		Method:    "enableSwimlane",
+		Procedure: "swimlaneprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+}
+
+func getSwimlaneHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the swimlane by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetSwimlane(ctx, swimlaneId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getSwimlaneByIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the swimlane by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetSwimlaneById(ctx, swimlaneId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getActiveSwimlanesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetActiveSwimlanes(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllSwimlanesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllSwimlanes(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getSwimlaneByNameHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetSwimlaneByName(ctx, projectId, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func changeSwimlanePositionHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	position, err := request.RequireInt("position")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.ChangeSwimlanePosition(ctx, projectId, swimlaneId, position)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
+
+func addSwimlaneHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	description := request.GetString("description", "")
+	result, err := kc.AddSwimlane(ctx, projectId, name, description)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.Itoa(result)), nil
+}
+
+func updateSwimlaneHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name := request.GetString("name", "")
+	description := request.GetString("description", "")
+	result, err := kc.UpdateSwimlane(ctx, projectId, swimlaneId, name, description)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
+
+func removeSwimlaneHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.RemoveSwimlane(ctx, projectId, swimlaneId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
+
+func disableSwimlaneHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.DisableSwimlane(ctx, projectId, swimlaneId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
+
+func enableSwimlaneHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.EnableSwimlane(ctx, projectId, swimlaneId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strconv.FormatBool(result)), nil
+}
@@ -0,0 +1,246 @@
+// Package system registers the tools of the system domain (system information and utilities).
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+	"kanboard-mcp/rbac"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "system", Description: "System information and utilities"}
+
+// Register adds the system tools to the registry. The RBAC manager
+// backs get_rbac_cache_stats.
+func Register(r *tools.Registry, manager *rbac.Manager) {
+	r.Register(domain, definitions(manager)...)
+}
+
+func definitions(manager *rbac.Manager) []tools.Tool {
+	return []tools.Tool{
+		{
+			Definition: mcp.NewTool("get_me",
+				mcp.WithDescription("Get current authenticated user's profile and session info"),
+			),
+			Handler:   getMeHandler,
+			Method:    "getMe",
+			Procedure: "meprocedure",
+			ReadOnly:  true,
+		},
+		// Application API Procedures
+		{
+			Definition: mcp.NewTool("get_version",
+				mcp.WithDescription("Get Kanboard application version number"),
+			),
+			Handler:   getVersionHandler,
+			Method:    "getVersion",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_timezone",
+				mcp.WithDescription("Get the timezone setting for the current user's session"),
+			),
+			Handler:   getTimezoneHandler,
+			Method:    "getTimezone",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_default_task_colors",
+				mcp.WithDescription("Get all available task color options with hex values and names"),
+			),
+			Handler:   getDefaultTaskColorsHandler,
+			Method:    "getDefaultTaskColors",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_default_task_color",
+				mcp.WithDescription("Get the system default color applied to new tasks"),
+			),
+			Handler:   getDefaultTaskColorHandler,
+			Method:    "getDefaultTaskColor",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_color_list",
+				mcp.WithDescription("Get mapping of color IDs to color names for task coloring"),
+			),
+			Handler:   getColorListHandler,
+			Method:    "getColorList",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_application_roles",
+				mcp.WithDescription("Get available system-wide roles (admin, manager, user)"),
+			),
+			Handler:   getApplicationRolesHandler,
+			Method:    "getApplicationRoles",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_project_roles",
+				mcp.WithDescription("Get available project-level roles (manager, member, viewer)"),
+			),
+			Handler:   getProjectRolesHandler,
+			Method:    "getProjectRoles",
+			Procedure: "appprocedure",
+			ReadOnly:  true,
+		},
+		{
+			Definition: mcp.NewTool("get_rbac_cache_stats",
+				mcp.WithDescription("Get RBAC user context cache statistics (TTL, hits, misses, invalidations)"),
+			),
+			Handler:  rbacCacheStatsHandler(manager),
+			Local:    true,
+			ReadOnly: true,
+		},
+		{
+			Definition: mcp.NewTool("cache_clear",
+				mcp.WithDescription("Clear cached Kanboard reference data (columns, swimlanes, categories, tags, links, roles, project lookups) and show cache statistics"),
+				mcp.WithString("method", mcp.Description("Only clear this Kanboard method, e.g. getColumns (optional)")),
+				mcp.WithString("project_id", mcp.Description("Only clear entries of this project, given by ID, name or identifier (optional)")),
+			),
+			Handler:  cacheClearHandler,
+			Local:    true,
+			ReadOnly: true,
+		},
+	}
+}
+
+func getMeHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetMe(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getVersionHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetVersion(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+func getTimezoneHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetTimezone(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+func getDefaultTaskColorsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetDefaultTaskColors(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getDefaultTaskColorHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetDefaultTaskColor(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+func getColorListHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetColorList(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getApplicationRolesHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetApplicationRoles(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getProjectRolesHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetProjectRoles(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+// rbacCacheStatsHandler reports the counters of the RBAC user context cache
+func rbacCacheStatsHandler(manager *rbac.Manager) tools.Handler {
+	return func(_ context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		resultBytes, err := json.MarshalIndent(manager.CacheStats(kc), "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal cache stats: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(resultBytes)), nil
+	}
+}
+
+// cacheClearHandler empties the response cache, optionally for one method or project
+func cacheClearHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if kc.Responses == nil {
+		return mcp.NewToolResultError("the response cache is disabled (KANBOARD_RESPONSE_CACHE=false)"), nil
+	}
+
+	var methods []string
+	if method := request.GetString("method", ""); method != "" {
+		if !kc.Responses.Cacheable(method) {
+			return mcp.NewToolResultError(fmt.Sprintf("method %s is not cached (cached methods: %s)", method, strings.Join(kc.Responses.CachedMethods(), ", "))), nil
+		}
+		methods = []string{method}
+	}
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	cleared := kc.Responses.Clear(methods, projectID)
+	logging.HTTP.Info("response cache cleared", "method", methods, "project_id", projectID, "entries", cleared)
+
+	resultBytes, err := json.MarshalIndent(map[string]interface{}{
+		"cleared": cleared,
+		"stats":   kc.Responses.Stats(),
+	}, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal cache stats: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,301 @@
+// Package tags registers the tools of the tags domain (tag management).
+package tags
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "tags", Description: "Tag management"}
+
+// Register adds the tag tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	// Tag Management
+	{
+		Definition: mcp.NewTool("get_all_tags",
+			mcp.WithDescription("Get all tags defined in the system (global tags)"),
+		),
+		Handler:   getAllTagsHandler,
+		Method:    "getAllTags",
+		Procedure: "tagprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_tags_by_project",
+			mcp.WithDescription("Get all tags available for a specific project"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get tags for"),
+			),
+		),
+		Handler:   getTagsByProjectHandler,
+		Method:    "getTagsByProject",
+		Procedure: "tagprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_tag",
+			mcp.WithDescription("Create a new tag for labeling and filtering tasks"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to associate the tag with"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Name of the tag"),
+			),
+			mcp.WithNumber("color_id",
+				mcp.Description("ID of the color for the tag (optional)"),
+			),
+		),
+		Handler:   createTagHandler,
+		Method:    "createTag",
+		Procedure: "tagprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+	},
+	{
+		Definition: mcp.NewTool("update_tag",
+			mcp.WithDescription("Rename an existing tag (updates all tagged tasks)"),
+			mcp.WithString("tag_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the tag to update"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the tag belongs to, used to look up tag_id by name (optional)"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("New name for the tag"),
+			),
+			mcp.WithNumber("color_id",
+				mcp.Description("New color ID for the tag (optional)"),
+			),
+		),
+		Handler:   updateTagHandler,
+		Method:    "updateTag",
+		Procedure: "tagprocedure",
+		Scope:     tools.ScopeTag,
+		Arg:       "tag_id",
+	},
+	{
+		Definition: mcp.NewTool("remove_tag",
+			mcp.WithDescription("Delete a tag (removes from all tasks)"),
+			mcp.WithString("tag_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the tag to remove"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project the tag belongs to, used to look up tag_id by name (optional)"),
+			),
+		),
+		Handler:     removeTagHandler,
+		Method:      "removeTag",
+		Procedure:   "tagprocedure",
+		Scope:       tools.ScopeTag,
+		Arg:         "tag_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("set_task_tags",
+			mcp.WithDescription("Assign/Create/Update tags for a task"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task"),
+			),
+			mcp.WithArray("tags",
+				mcp.Required(),
+				mcp.WithStringItems(),
+				mcp.Description("List of tags (array of strings)"),
+			),
+		),
+		Handler:     setTaskTagsHandler,
+		Method:      "setTaskTags",
+		Procedure:   "tasktagprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		ObjectArg:   "task_id",
+		ObjectScope: tools.ScopeTask,
+		Core:        true,
+	},
+	{
+		Definition: mcp.NewTool("get_task_tags",
+			mcp.WithDescription("Get all tags currently assigned to a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getTaskTagsHandler,
+		Method:    "getTaskTags",
+		Procedure: "tasktagprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+	},
+}
+
+func getAllTagsHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAllTags(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTagsByProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetTagsByProject(ctx, projectId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createTagHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tag, err := request.RequireString("tag")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "tag": tag}
+	colorId := request.GetInt("color_id", 0)
+	if colorId != 0 {
+		params["color_id"] = colorId
+	}
+	result, err := kc.Call(ctx, "createTag", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateTagHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the tag by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tagId, err := tools.RequireNamed(ctx, request, "tag_id", kc.TagResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tag, err := request.RequireString("tag")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"tag_id": tagId, "tag": tag}
+	colorId := request.GetInt("color_id", 0)
+	if colorId != 0 {
+		params["color_id"] = colorId
+	}
+	result, err := kc.Call(ctx, "updateTag", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeTagHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// The project is only needed to look up the tag by name
+	projectID, _, err := tools.ProjectArgument(ctx, kc, request.GetArguments(), "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tagId, err := tools.RequireNamed(ctx, request, "tag_id", kc.TagResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"tag_id": tagId}
+	result, err := kc.Call(ctx, "removeTag", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func setTaskTagsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tags, err := request.RequireStringSlice("tags")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"project_id": projectId, "task_id": taskId, "tags": tags}
+	result, err := kc.Call(ctx, "setTaskTags", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskTagsHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"task_id": taskId}
+	result, err := kc.Call(ctx, "getTaskTags", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
@@ -0,0 +1,1815 @@
+// Package tasks registers the tools of the tasks domain (task-related operations).
+package tasks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "tasks", Description: "Task-related operations"}
+
+// Register adds the task tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_tasks",
+			mcp.WithDescription("Get all tasks for a project with optional status filter (open/closed/all)"),
+			mcp.WithString("project_name",
+				mcp.Required(),
+				mcp.Description("Name, identifier or ID of the project to get tasks from"),
+			),
+		),
+		Handler:   getTasksHandler,
+		Method:    "getAllTasks",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProjectName,
+		Arg:       "project_name",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("create_task",
+			mcp.WithDescription("Create a new task with title, description, assignee, due date, color, category, and column placement"),
+			mcp.WithString("project_name",
+				mcp.Required(),
+				mcp.Description("Name, identifier or ID of the project to add the task to"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the task to create"),
+			),
+			mcp.WithString("color_id",
+				mcp.Description("Color ID for the task (optional)"),
+			),
+			mcp.WithString("column_id",
+				mcp.Description("ID or title of the column to add the task to (optional)"),
+			),
+			mcp.WithString("owner_id",
+				mcp.Description("ID or username of the task owner (optional)"),
+			),
+			mcp.WithString("creator_id",
+				mcp.Description("ID or username of the task creator (optional)"),
+			),
+			mcp.WithString("date_due",
+				mcp.Description("Due date in YYYY-MM-DD HH:MM format (optional)"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Markdown content for the task description (optional)"),
+			),
+			mcp.WithString("category_id",
+				mcp.Description("ID or name of the task category (optional)"),
+			),
+			mcp.WithNumber("score",
+				mcp.Description("Complexity score of the task (optional)"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Description("ID or name of the swimlane to add the task to (optional)"),
+			),
+			mcp.WithNumber("priority",
+				mcp.Description("Priority of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_status",
+				mcp.Description("Recurrence status of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_trigger",
+				mcp.Description("Recurrence trigger of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_factor",
+				mcp.Description("Recurrence factor of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_timeframe",
+				mcp.Description("Recurrence timeframe of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_basedate",
+				mcp.Description("Recurrence base date of the task (optional)"),
+			),
+			mcp.WithString("reference",
+				mcp.Description("External reference for the task (optional)"),
+			),
+			mcp.WithArray("tags",
+				mcp.WithStringItems(),
+				mcp.Description("List of tags (array of strings) (optional)"),
+			),
+			mcp.WithString("date_started",
+				mcp.Description("Start date in YYYY-MM-DD HH:MM format (optional)"),
+			),
+		),
+		Handler:   createTaskHandler,
+		Method:    "createTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProjectName,
+		Arg:       "project_name",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("create_test_task",
+			mcp.WithDescription("Create a task with only a title and description, to tell permission errors apart from invalid task fields when create_task fails"),
+			mcp.WithString("project_name",
+				mcp.Required(),
+				mcp.Description("Name, identifier or ID of the project to create the task in"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the task to create"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the task (optional)"),
+			),
+		),
+		Handler:   createTestTaskHandler,
+		Method:    "createTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProjectName,
+		Arg:       "project_name",
+	},
+	{
+		Definition: mcp.NewTool("update_task",
+			mcp.WithDescription("Update task properties: title, description, assignee, due date, color, category, priority, or column"),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to update"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title for the task (optional)"),
+			),
+			mcp.WithString("color_id",
+				mcp.Description("New color ID for the task (optional)"),
+			),
+			mcp.WithString("owner_id",
+				mcp.Description("New owner ID or username for the task (optional)"),
+			),
+			mcp.WithString("date_due",
+				mcp.Description("New due date in YYYY-MM-DD HH:MM format (optional)"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New Markdown content for the task description (optional)"),
+			),
+			mcp.WithString("category_id",
+				mcp.Description("New ID or name of the task category (optional)"),
+			),
+			mcp.WithNumber("score",
+				mcp.Description("New complexity score of the task (optional)"),
+			),
+			mcp.WithNumber("priority",
+				mcp.Description("New priority of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_status",
+				mcp.Description("New recurrence status of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_trigger",
+				mcp.Description("New recurrence trigger of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_factor",
+				mcp.Description("New recurrence factor of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_timeframe",
+				mcp.Description("New recurrence timeframe of the task (optional)"),
+			),
+			mcp.WithNumber("recurrence_basedate",
+				mcp.Description("New recurrence base date of the task (optional)"),
+			),
+			mcp.WithString("reference",
+				mcp.Description("New external reference for the task (optional)"),
+			),
+			mcp.WithArray("tags",
+				mcp.WithStringItems(),
+				mcp.Description("New list of tags (array of strings) (optional)"),
+			),
+			mcp.WithString("date_started",
+				mcp.Description("New start date in YYYY-MM-DD HH:MM format (optional)"),
+			),
+		),
+		Handler:   updateTaskHandler,
+		Method:    "updateTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("delete_task",
+			mcp.WithDescription("Permanently delete a task and all associated comments, files, and subtasks"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to delete"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:     deleteTaskHandler,
+		Method:      "removeTask",
+		Procedure:   "taskprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		Destructive: true,
+		Core:        true,
+	},
+	{
+		Definition: mcp.NewTool("get_task",
+			mcp.WithDescription("Get complete task details by ID including metadata, tags, and time tracking info"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get details for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getTaskHandler,
+		Method:    "getTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("move_task_position",
+			mcp.WithDescription("Move a task to another column, position or swimlane inside the same board"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project containing the task"),
+			),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to move"),
+			),
+			mcp.WithString("column_id",
+				mcp.Required(),
+				mcp.Description("ID or title of the column to move the task to"),
+			),
+			mcp.WithNumber("position",
+				mcp.Required(),
+				mcp.Description("New position for the task (must be >= 1)"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Required(),
+				mcp.Description("ID or name of the swimlane to move the task to"),
+			),
+		),
+		Handler:     moveTaskPositionHandler,
+		Method:      "moveTaskPosition",
+		Procedure:   "taskprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		ObjectArg:   "task_id",
+		ObjectScope: tools.ScopeTask,
+		Core:        true,
+	},
+	{
+		Definition: mcp.NewTool("assign_task",
+			mcp.WithDescription("Assign or reassign a task to a user by user ID"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to assign"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to assign the task to"),
+			),
+		),
+		Handler:   assignTaskHandler,
+		Method:    "updateTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("set_task_due_date",
+			mcp.WithDescription("Set or update a task's due date/deadline (ISO format or timestamp)"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to set the due date for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("due_date",
+				mcp.Required(),
+				mcp.Description("Due date in YYYY-MM-DD format"),
+			),
+		),
+		Handler:   setTaskDueDateHandler,
+		Method:    "updateTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+	},
+	{
+		Definition: mcp.NewTool("create_external_task_link",
+			mcp.WithDescription("Attach an external URL or reference to a task (GitHub issue, GitLab MR, web link)"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithString("url",
+				mcp.Required(),
+				mcp.Description("URL of the external link"),
+			),
+			mcp.WithString("dependency",
+				mcp.Required(),
+				mcp.Description("Dependency of the external link"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Type of the external link (optional)"),
+			),
+			mcp.WithString("title",
+				mcp.Description("Title of the external link (optional)"),
+			),
+		),
+		Handler:   createExternalTaskLinkHandler,
+		Method:    "createExternalTaskLink",
+		Procedure: "taskexternallinkprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+	},
+	{
+		Definition: mcp.NewTool("update_external_task_link",
+			mcp.WithDescription("Update an external link's URL, title, or type"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the external link to update"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title for the external link"),
+			),
+			mcp.WithString("url",
+				mcp.Description("New URL for the external link")),
+			mcp.WithString("dependency",
+				mcp.Description("New dependency for the external link"),
+			),
+		),
+		Handler:     updateExternalTaskLinkHandler,
+		Method:      "updateExternalTaskLink",
+		Procedure:   "taskexternallinkprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		ObjectArg:   "link_id",
+		ObjectScope: tools.ScopeExternalLink,
+	},
+	{
+		Definition: mcp.NewTool("get_external_task_link_by_id",
+			mcp.WithDescription("Get external link details including URL, provider, and dependency info"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the external link to retrieve")),
+		),
+		Handler:     getExternalTaskLinkByIdHandler,
+		Method:      "getExternalTaskLinkById",
+		Procedure:   "taskexternallinkprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		ObjectArg:   "link_id",
+		ObjectScope: tools.ScopeExternalLink,
+		ReadOnly:    true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_external_task_links",
+			mcp.WithDescription("Get all external URLs and references attached to a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get external links for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getAllExternalTaskLinksHandler,
+		Method:    "getAllExternalTaskLinks",
+		Procedure: "taskexternallinkprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("remove_external_task_link",
+			mcp.WithDescription("Remove an external URL or reference from a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the external link to remove"),
+			),
+		),
+		Handler:     removeExternalTaskLinkHandler,
+		Method:      "removeExternalTaskLink",
+		Procedure:   "taskexternallinkprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		ObjectArg:   "link_id",
+		ObjectScope: tools.ScopeExternalLink,
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("create_task_link",
+			mcp.WithDescription("Create a relationship between two tasks (blocks, is blocked by, relates to, etc.)"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the first task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id and opposite_task_id in when they are external task references (optional)"),
+			),
+			mcp.WithString("opposite_task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the opposite task"),
+			),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the link type"),
+			),
+		),
+		Handler:   createTaskLinkHandler,
+		Method:    "createTaskLink",
+		Procedure: "tasklinkprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+	},
+	{
+		Definition: mcp.NewTool("update_task_link",
+			mcp.WithDescription("Update the relationship type between two linked tasks"),
+			mcp.WithNumber("task_link_id",
+				mcp.Required(),
+				mcp.Description("ID of the task link to update"),
+			),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the first task"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id and opposite_task_id in when they are external task references (optional)"),
+			),
+			mcp.WithString("opposite_task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the opposite task"),
+			),
+			mcp.WithNumber("link_id",
+				mcp.Required(),
+				mcp.Description("ID of the link type"),
+			),
+		),
+		Handler:     updateTaskLinkHandler,
+		Method:      "updateTaskLink",
+		Procedure:   "tasklinkprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		ObjectArg:   "task_link_id",
+		ObjectScope: tools.ScopeTaskLink,
+	},
+	{
+		Definition: mcp.NewTool("get_task_link_by_id",
+			mcp.WithDescription("Get task link details including source task, target task, and relationship type"),
+			mcp.WithNumber("task_link_id",
+				mcp.Required(),
+				mcp.Description("ID of the task link to retrieve"),
+			),
+		),
+		Handler:   getTaskLinkByIdHandler,
+		Method:    "getTaskLinkById",
+		Procedure: "tasklinkprocedure",
+		Scope:     tools.ScopeTaskLink,
+		Arg:       "task_link_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_task_links",
+			mcp.WithDescription("Get all task relationships (dependencies, blockers, related tasks) for a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to get links for"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getAllTaskLinksHandler,
+		Method:    "getAllTaskLinks",
+		Procedure: "tasklinkprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("remove_task_link",
+			mcp.WithDescription("Remove a link between two tasks"),
+			mcp.WithNumber("task_link_id",
+				mcp.Required(),
+				mcp.Description("ID of the task link to remove"),
+			),
+		),
+		Handler:     removeTaskLinkHandler,
+		Method:      "removeTaskLink",
+		Procedure:   "tasklinkprocedure",
+		Scope:       tools.ScopeTaskLink,
+		Arg:         "task_link_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("create_task_file",
+			mcp.WithDescription("Upload and attach a file to a task (base64 encoded content)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("The project ID, name or identifier"),
+			),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("The task ID or reference (#123, PROJ-123)"),
+			),
+			mcp.WithString("filename",
+				mcp.Required(),
+				mcp.Description("The filename, or file path if blob is not provided"),
+			),
+			mcp.WithString("blob",
+				mcp.Description("File content encoded in base64. If not provided, filename will be treated as a file path and read from disk (relative paths will be resolved from current working directory)"),
+			),
+		),
+		Handler:     createTaskFileHandler,
+		Method:      "createTaskFile",
+		Procedure:   "taskfileprocedure",
+		Scope:       tools.ScopeProject,
+		Arg:         "project_id",
+		ObjectArg:   "task_id",
+		ObjectScope: tools.ScopeTask,
+		Core:        true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_task_files",
+			mcp.WithDescription("Get list of all file attachments on a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("The task ID or reference (#123, PROJ-123)"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   getAllTaskFilesHandler,
+		Method:    "getAllTaskFiles",
+		Procedure: "taskfileprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("get_task_file",
+			mcp.WithDescription("Get file attachment metadata (name, size, upload date) by ID"),
+			mcp.WithNumber("file_id",
+				mcp.Required(),
+				mcp.Description("The file ID"),
+			),
+		),
+		Handler:   getTaskFileHandler,
+		Method:    "getTaskFile",
+		Procedure: "taskfileprocedure",
+		Scope:     tools.ScopeTaskFile,
+		Arg:       "file_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("download_task_file",
+			mcp.WithDescription("Download file contents (encoded in base64). Optionally save to disk if save_path is provided."),
+			mcp.WithNumber("file_id",
+				mcp.Required(),
+				mcp.Description("The file ID"),
+			),
+			mcp.WithString("save_path",
+				mcp.Description("Optional path to save the file. If 'temp', saves to OS temp directory. If empty, returns base64 content only. If directory, saves with original filename. If full path, saves to that location."),
+			),
+		),
+		Handler:   downloadTaskFileHandler,
+		Method:    "downloadTaskFile",
+		Procedure: "taskfileprocedure",
+		Scope:     tools.ScopeTaskFile,
+		Arg:       "file_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("remove_task_file",
+			mcp.WithDescription("Delete a file attachment from a task"),
+			mcp.WithNumber("file_id",
+				mcp.Required(),
+				mcp.Description("The file ID"),
+			),
+		),
+		Handler:     removeTaskFileHandler,
+		Method:      "removeTaskFile",
+		Procedure:   "taskfileprocedure",
+		Scope:       tools.ScopeTaskFile,
+		Arg:         "file_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("remove_all_task_files",
+			mcp.WithDescription("Delete all file attachments from a task"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("The task ID or reference (#123, PROJ-123)"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:     removeAllTaskFilesHandler,
+		Method:      "removeAllTaskFiles",
+		Procedure:   "taskfileprocedure",
+		Scope:       tools.ScopeTask,
+		Arg:         "task_id",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("get_task_by_reference",
+			mcp.WithDescription("Get task by external reference ID (for integration with external systems like GitHub/GitLab)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithString("reference",
+				mcp.Required(),
+				mcp.Description("External reference for the task"),
+			),
+		),
+		Handler:   getTaskByReferenceHandler,
+		Method:    "getTaskByReference",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_all_tasks",
+			mcp.WithDescription("Get all tasks across all projects (admin only) with optional status and project filters"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to get tasks from"),
+			),
+			mcp.WithNumber("status_id",
+				mcp.Required(),
+				mcp.Description("The value 1 for active tasks and 0 for inactive"),
+			),
+		),
+		Handler:   getAllTasksHandler,
+		Method:    "getAllTasks",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("open_task",
+			mcp.WithDescription("Reopen a closed task, setting status back to open/active"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to open"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   openTaskHandler,
+		Method:    "openTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("close_task",
+			mcp.WithDescription("Close/complete a task, marking it as done"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to close"),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("Project to look up task_id in when it is an external task reference (optional)"),
+			),
+		),
+		Handler:   closeTaskHandler,
+		Method:    "closeTask",
+		Procedure: "taskprocedure",
+		Scope:     tools.ScopeTask,
+		Arg:       "task_id",
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("move_task_to_project",
+			mcp.WithDescription("Move a task to a different project, specifying target column and swimlane"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to move"),
+			),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to move the task to"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Description("ID or name of the swimlane (optional)"),
+			),
+			mcp.WithString("column_id",
+				mcp.Description("ID or title of the column (optional)"),
+			),
+			mcp.WithString("category_id",
+				mcp.Description("ID or name of the category (optional)"),
+			),
+			mcp.WithString("owner_id",
+				mcp.Description("ID or username of the owner (optional)"),
+			),
+		),
+		Handler:        moveTaskToProjectHandler,
+		Method:         "moveTaskToProject",
+		Procedure:      "taskprocedure",
+		Scope:          tools.ScopeTask,
+		Arg:            "task_id",
+		DestinationArg: "project_id",
+	},
+	{
+		Definition: mcp.NewTool("duplicate_task_to_project",
+			mcp.WithDescription("Copy a task to another project with optional inclusion of subtasks, comments, and files"),
+			mcp.WithString("task_id",
+				mcp.Required(),
+				mcp.Description("ID or reference (#123, PROJ-123) of the task to duplicate"),
+			),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project to duplicate the task to"),
+			),
+			mcp.WithString("swimlane_id",
+				mcp.Description("ID or name of the swimlane (optional)"),
+			),
+			mcp.WithString("column_id",
+				mcp.Description("ID or title of the column (optional)"),
+			),
+			mcp.WithString("category_id",
+				mcp.Description("ID or name of the category (optional)"),
+			),
+			mcp.WithString("owner_id",
+				mcp.Description("ID or username of the owner (optional)"),
+			),
+		),
+		Handler:        duplicateTaskToProjectHandler,
+		Method:         "duplicateTaskToProject",
+		Procedure:      "taskprocedure",
+		Scope:          tools.ScopeTask,
+		Arg:            "task_id",
+		DestinationArg: "project_id",
+	},
+}
+
+func getTasksHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := kc.GetAllTasks(ctx, projectID, 1)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get tasks: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectID,
+		"title":      title,
+	}
+
+	colorId := request.GetString("color_id", "")
+	if colorId != "" {
+		params["color_id"] = colorId
+	}
+
+	columnId, err := tools.OptionalNamed(ctx, request, "column_id", kc.ColumnResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if columnId != 0 {
+		params["column_id"] = columnId
+	}
+
+	ownerId, err := tools.OptionalNamed(ctx, request, "owner_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if ownerId != 0 {
+		params["owner_id"] = ownerId
+	}
+
+	creatorId, err := tools.OptionalNamed(ctx, request, "creator_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if creatorId != 0 {
+		params["creator_id"] = creatorId
+	}
+
+	dateDue := request.GetString("date_due", "")
+	if dateDue != "" {
+		params["date_due"] = dateDue
+	}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	categoryId, err := tools.OptionalNamed(ctx, request, "category_id", kc.CategoryResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if categoryId != 0 {
+		params["category_id"] = categoryId
+	}
+
+	score := request.GetInt("score", 0)
+	if score != 0 {
+		params["score"] = score
+	}
+
+	swimlaneId, err := tools.OptionalNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectID))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if swimlaneId != 0 {
+		params["swimlane_id"] = swimlaneId
+	}
+
+	priority := request.GetInt("priority", 0)
+	if priority != 0 {
+		params["priority"] = priority
+	}
+
+	recurrenceStatus := request.GetInt("recurrence_status", 0)
+	if recurrenceStatus != 0 {
+		params["recurrence_status"] = recurrenceStatus
+	}
+
+	recurrenceTrigger := request.GetInt("recurrence_trigger", 0)
+	if recurrenceTrigger != 0 {
+		params["recurrence_trigger"] = recurrenceTrigger
+	}
+
+	recurrenceFactor := request.GetInt("recurrence_factor", 0)
+	if recurrenceFactor != 0 {
+		params["recurrence_factor"] = recurrenceFactor
+	}
+
+	recurrenceTimeframe := request.GetInt("recurrence_timeframe", 0)
+	if recurrenceTimeframe != 0 {
+		params["recurrence_timeframe"] = recurrenceTimeframe
+	}
+
+	recurrenceBasedate := request.GetInt("recurrence_basedate", 0)
+	if recurrenceBasedate != 0 {
+		params["recurrence_basedate"] = recurrenceBasedate
+	}
+
+	reference := request.GetString("reference", "")
+	if reference != "" {
+		params["reference"] = reference
+	}
+
+	tags := request.GetStringSlice("tags", []string{})
+	if len(tags) > 0 {
+		params["tags"] = tags
+	}
+
+	dateStarted := request.GetString("date_started", "")
+	if dateStarted != "" {
+		params["date_started"] = dateStarted
+	}
+
+	result, err := kc.Call(ctx, "createTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createTestTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id": projectID,
+		"title":      title,
+	}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	result, err := kc.Call(ctx, "createTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create test task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Test task created successfully:\n%s", string(resultBytes))), nil
+}
+
+func updateTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := tools.RequireTaskID(ctx, kc, request, "id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"id": id}
+
+	title := request.GetString("title", "")
+	if title != "" {
+		params["title"] = title
+	}
+
+	colorId := request.GetString("color_id", "")
+	if colorId != "" {
+		params["color_id"] = colorId
+	}
+
+	ownerId, err := tools.OptionalNamed(ctx, request, "owner_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if ownerId != 0 {
+		params["owner_id"] = ownerId
+	}
+
+	dateDue := request.GetString("date_due", "")
+	if dateDue != "" {
+		params["date_due"] = dateDue
+	}
+
+	description := request.GetString("description", "")
+	if description != "" {
+		params["description"] = description
+	}
+
+	categoryId, err := tools.OptionalNamed(ctx, request, "category_id", func(ctx context.Context, ref string) (int, error) {
+		// Category names are looked up in the task's project
+		projectID, err := kc.TaskProjectID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return kc.ResolveCategory(ctx, projectID, ref)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if categoryId != 0 {
+		params["category_id"] = categoryId
+	}
+
+	score := request.GetInt("score", 0)
+	if score != 0 {
+		params["score"] = score
+	}
+
+	priority := request.GetInt("priority", 0)
+	if priority != 0 {
+		params["priority"] = priority
+	}
+
+	recurrenceStatus := request.GetInt("recurrence_status", 0)
+	if recurrenceStatus != 0 {
+		params["recurrence_status"] = recurrenceStatus
+	}
+
+	recurrenceTrigger := request.GetInt("recurrence_trigger", 0)
+	if recurrenceTrigger != 0 {
+		params["recurrence_trigger"] = recurrenceTrigger
+	}
+
+	recurrenceFactor := request.GetInt("recurrence_factor", 0)
+	if recurrenceFactor != 0 {
+		params["recurrence_factor"] = recurrenceFactor
+	}
+
+	recurrenceTimeframe := request.GetInt("recurrence_timeframe", 0)
+	if recurrenceTimeframe != 0 {
+		params["recurrence_timeframe"] = recurrenceTimeframe
+	}
+
+	recurrenceBasedate := request.GetInt("recurrence_basedate", 0)
+	if recurrenceBasedate != 0 {
+		params["recurrence_basedate"] = recurrenceBasedate
+	}
+
+	reference := request.GetString("reference", "")
+	if reference != "" {
+		params["reference"] = reference
+	}
+
+	tags := request.GetStringSlice("tags", []string{})
+	if len(tags) > 0 {
+		params["tags"] = tags
+	}
+
+	dateStarted := request.GetString("date_started", "")
+	if dateStarted != "" {
+		params["date_started"] = dateStarted
+	}
+
+	result, err := kc.Call(ctx, "updateTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func deleteTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"task_id": taskId}
+	result, err := kc.Call(ctx, "removeTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetTask(ctx, taskId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get task details: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func moveTaskPositionHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.RequireNamed(ctx, request, "column_id", kc.ColumnResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	position, err := request.RequireInt("position")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.RequireNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"project_id":  projectId,
+		"task_id":     taskId,
+		"column_id":   columnId,
+		"position":    position,
+		"swimlane_id": swimlaneId,
+	}
+
+	result, err := kc.Call(ctx, "moveTaskPosition", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to move task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createExternalTaskLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	url, err := request.RequireString("url")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	dependency, err := request.RequireString("dependency")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"task_id":    taskId,
+		"url":        url,
+		"dependency": dependency,
+	}
+
+	typeName := request.GetString("type", "")
+	if typeName != "" {
+		params["type"] = typeName
+	}
+
+	title := request.GetString("title", "")
+	if title != "" {
+		params["title"] = title
+	}
+
+	result, err := kc.Call(ctx, "createExternalTaskLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateExternalTaskLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"task_id": taskId, "link_id": linkId}
+
+	title := request.GetString("title", "")
+	if title != "" {
+		params["title"] = title
+	}
+	url := request.GetString("url", "")
+	if url != "" {
+		params["url"] = url
+	}
+	dependency := request.GetString("dependency", "")
+	if dependency != "" {
+		params["dependency"] = dependency
+	}
+
+	result, err := kc.Call(ctx, "updateExternalTaskLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getExternalTaskLinkByIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetExternalTaskLink(ctx, taskId, linkId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllExternalTaskLinksHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllExternalTaskLinks(ctx, taskId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeExternalTaskLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"task_id": taskId, "link_id": linkId}
+	result, err := kc.Call(ctx, "removeExternalTaskLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func assignTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"id": taskId, "owner_id": userId}
+	result, err := kc.Call(ctx, "updateTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to assign task: %v", err)), nil
+	}
+
+	// Check if the result is false (Kanboard API returns false when update fails)
+	if resultBool, ok := result.(bool); ok && !resultBool {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to assign task: updateTask returned false. The user (ID: %d) may not be assigned to the project, or you may not have permission to assign tasks.", userId)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func setTaskDueDateHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	dueDate, err := request.RequireString("due_date")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"id": taskId, "date_due": dueDate}
+	result, err := kc.Call(ctx, "updateTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to set task due date: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createTaskLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	oppositeTaskId, err := tools.RequireTaskID(ctx, kc, request, "opposite_task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{
+		"task_id":          taskId,
+		"opposite_task_id": oppositeTaskId,
+		"link_id":          linkId,
+	}
+	result, err := kc.Call(ctx, "createTaskLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateTaskLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskLinkId, err := request.RequireInt("task_link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	oppositeTaskId, err := tools.RequireTaskID(ctx, kc, request, "opposite_task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	linkId, err := request.RequireInt("link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{
+		"task_link_id":     taskLinkId,
+		"task_id":          taskId,
+		"opposite_task_id": oppositeTaskId,
+		"link_id":          linkId,
+	}
+	result, err := kc.Call(ctx, "updateTaskLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskLinkByIdHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskLinkId, err := request.RequireInt("task_link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetTaskLink(ctx, taskLinkId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllTaskLinksHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllTaskLinks(ctx, taskId)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeTaskLinkHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskLinkId, err := request.RequireInt("task_link_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"task_link_id": taskLinkId}
+	result, err := kc.Call(ctx, "removeTaskLink", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createTaskFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskID, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	filename := request.GetString("filename", "")
+	if filename == "" {
+		return mcp.NewToolResultError("filename is required"), nil
+	}
+
+	// Get blob, but make it optional
+	blob := request.GetString("blob", "")
+
+	// If blob is missing/empty, treat filename as a file path
+	if blob == "" {
+		var err error
+		blob, err = tools.ReadFileAsBase64(filename)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to read file: %v", err)), nil
+		}
+		// Extract just the filename from the path for the API
+		filename = filepath.Base(filename)
+	}
+
+	result, err := kc.CreateTaskFile(ctx,
+		projectID,
+		taskID,
+		filename,
+		blob,
+	)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllTaskFilesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := kc.GetAllTaskFiles(ctx, taskID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fileID := request.GetInt("file_id", 0)
+	if fileID == 0 {
+		return mcp.NewToolResultError("file_id is required"), nil
+	}
+
+	result, err := kc.GetTaskFile(ctx, fileID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func downloadTaskFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fileID := request.GetInt("file_id", 0)
+	if fileID == 0 {
+		return mcp.NewToolResultError("file_id is required"), nil
+	}
+	savePath := request.GetString("save_path", "")
+
+	// Get file info first to extract filename
+	fileInfo, err := kc.GetTaskFile(ctx, fileID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	filename := string(fileInfo.Name)
+	if filename == "" {
+		filename = fmt.Sprintf("file_%d", fileID)
+	}
+
+	// Download file content
+	base64Content, err := kc.DownloadTaskFile(ctx, fileID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// If save_path is provided, decode base64 and save to file
+	if savePath != "" {
+		savedPath, err := tools.SaveBase64ToFile(base64Content, savePath, filename)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to save file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("File saved to: %s", savedPath)), nil
+	}
+
+	// If save_path is not provided, decode base64 to string and return text content
+	decodedContent, err := base64.StdEncoding.DecodeString(base64Content)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to decode base64 content: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(decodedContent)), nil
+}
+
+func removeTaskFileHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fileID := request.GetInt("file_id", 0)
+	if fileID == 0 {
+		return mcp.NewToolResultError("file_id is required"), nil
+	}
+
+	result, err := kc.RemoveTaskFile(ctx, fileID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeAllTaskFilesHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := kc.RemoveAllTaskFiles(ctx, taskID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getTaskByReferenceHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	reference, err := request.RequireString("reference")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetTaskByReference(ctx, projectId, reference)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAllTasksHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	statusId, err := request.RequireInt("status_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetAllTasks(ctx, projectId, statusId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get tasks: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func openTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"task_id": taskId}
+	result, err := kc.Call(ctx, "openTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to open task: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func closeTaskHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]interface{}{"task_id": taskId}
+	result, err := kc.Call(ctx, "closeTask", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to close task: %v", err)), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func moveTaskToProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.OptionalNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.OptionalNamed(ctx, request, "column_id", kc.ColumnResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	categoryId, err := tools.OptionalNamed(ctx, request, "category_id", kc.CategoryResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ownerId, err := tools.OptionalNamed(ctx, request, "owner_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"task_id":    taskId,
+		"project_id": projectId,
+	}
+	if swimlaneId != 0 {
+		params["swimlane_id"] = swimlaneId
+	}
+	if columnId != 0 {
+		params["column_id"] = columnId
+	}
+	if categoryId != 0 {
+		params["category_id"] = categoryId
+	}
+	if ownerId != 0 {
+		params["owner_id"] = ownerId
+	}
+
+	result, err := kc.Call(ctx, "moveTaskToProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to move task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func duplicateTaskToProjectHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := tools.RequireTaskID(ctx, kc, request, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	swimlaneId, err := tools.OptionalNamed(ctx, request, "swimlane_id", kc.SwimlaneResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	columnId, err := tools.OptionalNamed(ctx, request, "column_id", kc.ColumnResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	categoryId, err := tools.OptionalNamed(ctx, request, "category_id", kc.CategoryResolver(projectId))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ownerId, err := tools.OptionalNamed(ctx, request, "owner_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"task_id":    taskId,
+		"project_id": projectId,
+	}
+	if swimlaneId != 0 {
+		params["swimlane_id"] = swimlaneId
+	}
+	if columnId != 0 {
+		params["column_id"] = columnId
+	}
+	if categoryId != 0 {
+		params["category_id"] = categoryId
+	}
+	if ownerId != 0 {
+		params["owner_id"] = ownerId
+	}
+
+	result, err := kc.Call(ctx, "duplicateTaskToProject", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to duplicate task: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
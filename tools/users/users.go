@@ -0,0 +1,438 @@
+// Package users registers the tools of the users domain (user management).
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/tools"
+)
+
+var domain = tools.Domain{Name: "users", Description: "User management"}
+
+// Register adds the user tools to the registry
+func Register(r *tools.Registry) {
+	r.Register(domain, definitions...)
+}
+
+var definitions = []tools.Tool{
+	{
+		Definition: mcp.NewTool("get_users",
+			mcp.WithDescription("List all users in the system including active and disabled accounts"),
+		),
+		Handler:   getUsersHandler,
+		Method:    "getAllUsers",
+		Procedure: "userprocedure",
+		ReadOnly:  true,
+		Core:      true,
+	},
+	{
+		Definition: mcp.NewTool("create_user",
+			mcp.WithDescription("Create a new local user account with username, password, email, and role assignment"),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username for the new user (must be unique)"),
+			),
+			mcp.WithString("password",
+				mcp.Required(),
+				mcp.Description("Password for the new user (must have at least 6 characters)"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Full name of the new user (optional)"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Email address of the new user (optional)"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role for the user (app-admin, app-manager, app-user) (optional)"),
+			),
+		),
+		Handler:   createUserHandler,
+		Method:    "createUser",
+		Procedure: "userprocedure",
+	},
+	{
+		Definition: mcp.NewTool("create_ldap_user",
+			mcp.WithDescription("Create a user account linked to LDAP/Active Directory authentication"),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username for the LDAP user"),
+			),
+		),
+		Handler:   createLdapUserHandler,
+		Method:    "createLdapUser",
+		Procedure: "userprocedure",
+	},
+	{
+		Definition: mcp.NewTool("get_user",
+			mcp.WithDescription("Get user profile by ID including email, role, status, and group memberships"),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to retrieve"),
+			),
+		),
+		Handler:   getUserHandler,
+		Method:    "getUser",
+		Procedure: "userprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_user_by_name",
+			mcp.WithDescription("Get user profile by username including email, role, status, and group memberships"),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user to retrieve"),
+			),
+		),
+		Handler:   getUserByNameHandler,
+		Method:    "getUserByName",
+		Procedure: "userprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("update_user",
+			mcp.WithDescription("Update user properties: name, email, password, role, or notification settings"),
+			mcp.WithNumber("id",
+				mcp.Required(),
+				mcp.Description("ID of the user to update"),
+			),
+			mcp.WithString("username",
+				mcp.Description("New username (optional)"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New full name (optional)"),
+			),
+			mcp.WithString("email",
+				mcp.Description("New email address (optional)"),
+			),
+			mcp.WithString("role",
+				mcp.Description("New role (app-admin, app-manager, app-user) (optional)"),
+			),
+		),
+		Handler:      updateUserHandler,
+		Method:       "updateUser",
+		Procedure:    "userprocedure",
+		ChangesRoles: true,
+	},
+	{
+		Definition: mcp.NewTool("remove_user",
+			mcp.WithDescription("Permanently delete a user account (tasks will be unassigned)"),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to remove"),
+			),
+		),
+		Handler:     removeUserHandler,
+		Method:      "removeUser",
+		Procedure:   "userprocedure",
+		Destructive: true,
+	},
+	{
+		Definition: mcp.NewTool("disable_user",
+			mcp.WithDescription("Deactivate a user account, preventing login while preserving task history"),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to disable"),
+			),
+		),
+		Handler:   disableUserHandler,
+		Method:    "disableUser",
+		Procedure: "userprocedure",
+	},
+	{
+		Definition: mcp.NewTool("enable_user",
+			mcp.WithDescription("Reactivate a disabled user account, restoring login access"),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to enable"),
+			),
+		),
+		Handler:   enableUserHandler,
+		Method:    "enableUser",
+		Procedure: "userprocedure",
+	},
+	{
+		Definition: mcp.NewTool("is_active_user",
+			mcp.WithDescription("Check if a user account is active (returns boolean)"),
+			mcp.WithString("user_id",
+				mcp.Required(),
+				mcp.Description("ID or username of the user to check"),
+			),
+		),
+		Handler:   isActiveUserHandler,
+		Method:    "isActiveUser",
+		Procedure: "userprocedure",
+		ReadOnly:  true,
+	},
+	{
+		Definition: mcp.NewTool("get_assignable_users",
+			mcp.WithDescription("Get users that can be assigned to a task for a project (all members except viewers)"),
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("ID, name or identifier of the project"),
+			),
+			mcp.WithBoolean("prepend_unassigned",
+				mcp.Description("Prepend the 'Unassigned' option (optional, default is false)"),
+			),
+		),
+		Handler:   getAssignableUsersHandler,
+		Method:    "getAssignableUsers",
+		Procedure: "projectpermissionprocedure",
+		Scope:     tools.ScopeProject,
+		Arg:       "project_id",
+		ReadOnly:  true,
+	},
+}
+
+func getUsersHandler(ctx context.Context, kc *kanboard.Client, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, err := kc.GetAllUsers(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getUserByNameHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	username, err := request.RequireString("username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetUserByName(ctx, username)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get user by name: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	username, err := request.RequireString("username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	password, err := request.RequireString("password")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{
+		"username": username,
+		"password": password,
+	}
+
+	name := request.GetString("name", "")
+	if name != "" {
+		params["name"] = name
+	}
+
+	email := request.GetString("email", "")
+	if email != "" {
+		params["email"] = email
+	}
+
+	role := request.GetString("role", "")
+	if role != "" {
+		params["role"] = role
+	}
+
+	result, err := kc.Call(ctx, "createUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func updateUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireInt("id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := map[string]interface{}{"id": id}
+
+	username := request.GetString("username", "")
+	if username != "" {
+		params["username"] = username
+	}
+
+	name := request.GetString("name", "")
+	if name != "" {
+		params["name"] = name
+	}
+
+	email := request.GetString("email", "")
+	if email != "" {
+		params["email"] = email
+	}
+
+	role := request.GetString("role", "")
+	if role != "" {
+		params["role"] = role
+	}
+
+	result, err := kc.Call(ctx, "updateUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func removeUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"user_id": userId}
+	result, err := kc.Call(ctx, "removeUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getAssignableUsersHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectId, err := tools.RequireProject(ctx, kc, request, "project_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	prependUnassigned := request.GetBool("prepend_unassigned", false)
+
+	params := map[string]interface{}{"project_id": projectId, "prepend_unassigned": prependUnassigned}
+	result, err := kc.Call(ctx, "getAssignableUsers", params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func createLdapUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	username, err := request.RequireString("username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]string{"username": username}
+	result, err := kc.Call(ctx, "createLdapUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create LDAP user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func getUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := kc.GetUser(ctx, userId)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func disableUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"user_id": userId}
+	result, err := kc.Call(ctx, "disableUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to disable user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func enableUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"user_id": userId}
+	result, err := kc.Call(ctx, "enableUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to enable user: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
+
+func isActiveUserHandler(ctx context.Context, kc *kanboard.Client, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userId, err := tools.RequireNamed(ctx, request, "user_id", kc.ResolveUser)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := map[string]int{"user_id": userId}
+	result, err := kc.Call(ctx, "isActiveUser", params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to check if user is active: %v", err)), nil
+	}
+
+	resultBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal API result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(resultBytes)), nil
+}
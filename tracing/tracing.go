@@ -0,0 +1,460 @@
+// Package tracing records spans for tool calls and Kanboard requests and exports them
+// as OTLP/HTTP JSON.
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/logging"
+)
+
+// Span kinds and status codes as defined by the OTLP trace protocol
+const (
+	KindInternal   = 1
+	spanKindServer = 2
+	KindClient     = 3
+
+	spanStatusUnset = 0
+	spanStatusError = 2
+)
+
+// Batching of exported spans
+const (
+	traceExportInterval  = 5 * time.Second
+	traceExportBatchSize = 512
+	traceQueueSize       = 4096
+)
+
+// spanContext identifies a span within a trace, local or received through traceparent
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+// traceparent renders the W3C trace context header value
+func (sc spanContext) traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(sc.traceID[:]), hex.EncodeToString(sc.spanID[:]))
+}
+
+// parseTraceparent reads a W3C traceparent header ("00-<trace-id>-<span-id>-<flags>")
+func parseTraceparent(value string) (spanContext, bool) {
+	var sc spanContext
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return sc, false
+	}
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return sc, false
+	}
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return sc, false
+	}
+	copy(sc.traceID[:], traceID)
+	copy(sc.spanID[:], spanID)
+	if sc.traceID == [16]byte{} || sc.spanID == [8]byte{} {
+		return sc, false
+	}
+	return sc, true
+}
+
+// Span is one timed operation of a trace. A nil span is a no-op, so callers
+// don't have to check whether tracing is enabled.
+type Span struct {
+	tracer   *Tracer
+	sc       spanContext
+	parentID [8]byte
+	name     string
+	kind     int
+	start    time.Time
+
+	mu         sync.Mutex
+	attributes map[string]interface{}
+	statusCode int
+	statusMsg  string
+	ended      bool
+}
+
+// SetAttribute records a string, bool, int or float attribute
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+}
+
+// RecordError marks the span as failed
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusCode = spanStatusError
+	s.statusMsg = err.Error()
+}
+
+// End finishes the span and queues it for export
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.mu.Unlock()
+	s.tracer.export(s, time.Now())
+}
+
+type spanContextKey struct{}
+type remoteSpanContextKey struct{}
+
+// SpanFromContext returns the active span, nil when tracing is off
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanContextKey{}).(*Span)
+	return s
+}
+
+// contextWithRemoteParent stores a span context received from a client
+func contextWithRemoteParent(ctx context.Context, sc spanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanContextKey{}, sc)
+}
+
+// Tracer creates spans and exports them as OTLP/HTTP JSON. The zero value is disabled.
+type Tracer struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	version     string
+	client      *http.Client
+
+	queue    chan otlpSpan
+	done     chan struct{}
+	wg       sync.WaitGroup
+	shutdown sync.Once
+}
+
+// Default is the process-wide tracer, disabled unless OTEL_TRACES_EXPORTER=otlp
+var Default = &Tracer{}
+
+// NewFromEnv configures OTLP export from the standard OpenTelemetry variables;
+// version is reported as the service version
+func NewFromEnv(version string) (*Tracer, error) {
+	exporter := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")))
+	switch exporter {
+	case "", "none":
+		return &Tracer{}, nil
+	case "otlp":
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER: %s (supported: otlp, none)", exporter)
+	}
+
+	if protocol := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); protocol != "" && protocol != "http/json" {
+		return nil, fmt.Errorf("unsupported OTEL_EXPORTER_OTLP_PROTOCOL: %s (supported: http/json)", protocol)
+	}
+
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			base = "http://localhost:4318"
+		}
+		endpoint = strings.TrimRight(base, "/") + "/v1/traces"
+	}
+
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), ",") {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "kanboard-mcp"
+	}
+
+	return newOTLPTracer(endpoint, serviceName, version, headers), nil
+}
+
+// newOTLPTracer starts a tracer that exports batches of spans to endpoint
+func newOTLPTracer(endpoint, serviceName, version string, headers map[string]string) *Tracer {
+	t := &Tracer{
+		endpoint:    endpoint,
+		headers:     headers,
+		serviceName: serviceName,
+		version:     version,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan otlpSpan, traceQueueSize),
+		done:        make(chan struct{}),
+	}
+	t.wg.Add(1)
+	go t.run()
+	return t
+}
+
+func (t *Tracer) enabled() bool {
+	return t != nil && t.queue != nil
+}
+
+// Start begins a span as a child of the span (or remote parent) in ctx
+func (t *Tracer) Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if !t.enabled() {
+		return ctx, nil
+	}
+
+	s := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		s.sc.traceID = parent.sc.traceID
+		s.parentID = parent.sc.spanID
+	} else if remote, ok := ctx.Value(remoteSpanContextKey{}).(spanContext); ok {
+		s.sc.traceID = remote.traceID
+		s.parentID = remote.spanID
+	} else {
+		rand.Read(s.sc.traceID[:])
+	}
+	rand.Read(s.sc.spanID[:])
+
+	return context.WithValue(ctx, spanContextKey{}, s), s
+}
+
+func (t *Tracer) export(s *Span, end time.Time) {
+	s.mu.Lock()
+	exported := otlpSpan{
+		TraceID:           hex.EncodeToString(s.sc.traceID[:]),
+		SpanID:            hex.EncodeToString(s.sc.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(end.UnixNano(), 10),
+		Attributes:        otlpAttributes(s.attributes),
+		Status:            otlpStatus{Code: s.statusCode, Message: s.statusMsg},
+	}
+	s.mu.Unlock()
+	if s.parentID != [8]byte{} {
+		exported.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+
+	select {
+	case t.queue <- exported:
+	default:
+		logging.Transport.Warn("trace export queue full, dropping span", "span", s.name)
+	}
+}
+
+// run batches queued spans and posts them to the collector
+func (t *Tracer) run() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(traceExportInterval)
+	defer ticker.Stop()
+
+	var batch []otlpSpan
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.post(batch); err != nil {
+			logging.Transport.Warn("failed to export spans", "count", len(batch), "error", err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case s := <-t.queue:
+			batch = append(batch, s)
+			if len(batch) >= traceExportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-t.done:
+			for {
+				select {
+				case s := <-t.queue:
+					batch = append(batch, s)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Shutdown exports the remaining spans and stops the exporter. It may be called more
+// than once, from the signal handler and after the transport returns.
+func (t *Tracer) Shutdown() {
+	if !t.enabled() {
+		return
+	}
+	t.shutdown.Do(func() { close(t.done) })
+	t.wg.Wait()
+}
+
+func (t *Tracer) post(spans []otlpSpan) error {
+	payload := otlpTraceRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: otlpAttributes(map[string]interface{}{
+			"service.name":    t.serviceName,
+			"service.version": t.version,
+		})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "kanboard-mcp", Version: t.version},
+			Spans: spans,
+		}},
+	}}}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spans: %w", err)
+	}
+	req, err := http.NewRequest("POST", t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Middleware opens the root span of every tool call
+func Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, s := Default.Start(ctx, "tools/call "+request.Params.Name, spanKindServer)
+		defer s.End()
+		s.SetAttribute("mcp.tool.name", request.Params.Name)
+		if requestID := logging.RequestIDFromContext(ctx); requestID != "" {
+			s.SetAttribute("mcp.request_id", requestID)
+		}
+
+		result, err := next(ctx, request)
+		if err != nil {
+			s.RecordError(err)
+		} else if result != nil && result.IsError {
+			s.RecordError(fmt.Errorf("%s", logging.TruncateText(logging.ResultText(result))))
+		}
+		return result, err
+	}
+}
+
+// ContextFromRequest continues a trace started by the MCP client (HTTP transports)
+func ContextFromRequest(ctx context.Context, r *http.Request) context.Context {
+	if sc, ok := parseTraceparent(r.Header.Get("traceparent")); ok {
+		return contextWithRemoteParent(ctx, sc)
+	}
+	return ctx
+}
+
+// InjectTraceparent propagates the active span to an outbound request
+func InjectTraceparent(ctx context.Context, req *http.Request) {
+	if s := SpanFromContext(ctx); s != nil {
+		req.Header.Set("traceparent", s.sc.traceparent())
+	}
+}
+
+// OTLP/HTTP JSON payload (opentelemetry-proto trace.v1)
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+func otlpAttributes(attributes map[string]interface{}) []otlpKeyValue {
+	keyValues := make([]otlpKeyValue, 0, len(attributes))
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		var value map[string]interface{}
+		switch v := attributes[key].(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		keyValues = append(keyValues, otlpKeyValue{Key: key, Value: value})
+	}
+	return keyValues
+}
@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestOTLPExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []otlpTraceRequest
+		headers  []http.Header
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload otlpTraceRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("collector got an invalid payload: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, payload)
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	tracer := newOTLPTracer(collector.URL+"/v1/traces", "kanboard-mcp-test", "1.2.3", map[string]string{"X-Collector-Key": "secret"})
+
+	const remoteTraceID, remoteSpanID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	incoming := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	incoming.Header.Set("traceparent", "00-"+remoteTraceID+"-"+remoteSpanID+"-01")
+	ctx := ContextFromRequest(context.Background(), incoming)
+
+	ctx, root := tracer.Start(ctx, "tools/call get_task", spanKindServer)
+	root.SetAttribute("mcp.tool.name", "get_task")
+	childCtx, child := tracer.Start(ctx, "getTask", KindClient)
+	child.SetAttribute("kanboard.task_id", 42)
+	child.RecordError(errors.New("HTTP 500"))
+
+	outgoing := httptest.NewRequest(http.MethodPost, "http://kanboard/jsonrpc.php", nil)
+	InjectTraceparent(childCtx, outgoing)
+	if want := child.sc.traceparent(); outgoing.Header.Get("traceparent") != want {
+		t.Fatalf("outbound traceparent = %q, want %q", outgoing.Header.Get("traceparent"), want)
+	}
+
+	child.End()
+	root.End()
+	root.End() // ending twice exports once
+	tracer.Shutdown()
+	tracer.Shutdown()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("collector got %d requests, want 1", len(requests))
+	}
+	if headers[0].Get("Content-Type") != "application/json" || headers[0].Get("X-Collector-Key") != "secret" {
+		t.Errorf("unexpected export headers %v", headers[0])
+	}
+	resource := requests[0].ResourceSpans[0]
+	if attribute(resource.Resource.Attributes, "service.name") != "kanboard-mcp-test" ||
+		attribute(resource.Resource.Attributes, "service.version") != "1.2.3" {
+		t.Errorf("unexpected resource %+v", resource.Resource)
+	}
+	spans := resource.ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(spans))
+	}
+	exportedChild, exportedRoot := spans[0], spans[1]
+
+	if exportedRoot.TraceID != remoteTraceID || exportedRoot.ParentSpanID != remoteSpanID {
+		t.Errorf("root span does not continue the remote trace: %+v", exportedRoot)
+	}
+	if exportedChild.TraceID != remoteTraceID || exportedChild.ParentSpanID != exportedRoot.SpanID {
+		t.Errorf("child span is not a child of the root span: %+v", exportedChild)
+	}
+	if exportedChild.SpanID == exportedRoot.SpanID || len(exportedChild.SpanID) != 16 {
+		t.Errorf("unexpected span IDs %s and %s", exportedChild.SpanID, exportedRoot.SpanID)
+	}
+	if exportedRoot.Kind != spanKindServer || exportedChild.Kind != KindClient {
+		t.Errorf("unexpected kinds %d and %d", exportedRoot.Kind, exportedChild.Kind)
+	}
+	if exportedChild.Status.Code != spanStatusError || exportedChild.Status.Message != "HTTP 500" || exportedRoot.Status.Code != spanStatusUnset {
+		t.Errorf("unexpected statuses %+v and %+v", exportedChild.Status, exportedRoot.Status)
+	}
+	if got := exportedChild.Attributes[0]; got.Key != "kanboard.task_id" || got.Value["intValue"] != "42" {
+		t.Errorf("unexpected attribute %+v", got)
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": true,
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01": false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01": false,
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": false,
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01":   false,
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": false,
+		"": false,
+	} {
+		if _, ok := parseTraceparent(value); ok != valid {
+			t.Errorf("parseTraceparent(%q) = %v, want %v", value, ok, valid)
+		}
+	}
+}
+
+func attribute(attributes []otlpKeyValue, key string) interface{} {
+	for _, kv := range attributes {
+		if kv.Key == key {
+			return kv.Value["stringValue"]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Package transport serves the MCP server over stdio, SSE or Streamable HTTP.
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"kanboard-mcp/auth"
+	"kanboard-mcp/kanboard"
+	"kanboard-mcp/logging"
+	"kanboard-mcp/metrics"
+	"kanboard-mcp/tools"
+	"kanboard-mcp/tracing"
+)
+
+// Transport modes supported by the MCP server
+const (
+	Stdio          = "stdio"
+	SSE            = "sse"
+	StreamableHTTP = "streamablehttp"
+)
+
+// Config describes how the MCP server is served
+type Config struct {
+	Mode string
+	Port string
+	// Version and BuildTime are reported by the health endpoint
+	Version   string
+	BuildTime string
+	// Auth authenticates the requests to the MCP endpoints, which are open when it is nil
+	Auth *auth.Authenticator
+}
+
+// Start starts the MCP server with the specified transport mode. The health endpoint
+// reports on the Kanboard instances.
+func Start(s *server.MCPServer, instances []*tools.Instance, config Config) {
+	switch config.Mode {
+	case SSE:
+		startSSEServer(s, instances, config)
+	case StreamableHTTP:
+		startStreamableHTTPServer(s, instances, config)
+	case Stdio:
+		fallthrough
+	default:
+		startStdioServer(s)
+	}
+}
+
+// startStdioServer starts the MCP server with stdio transport
+func startStdioServer(s *server.MCPServer) {
+	logging.Transport.Debug("starting MCP server with stdio transport")
+	if err := server.ServeStdio(s); err != nil {
+		logging.Transport.Error("server error", "error", err)
+		os.Exit(1)
+	}
+}
+
+// startSSEServer starts the MCP server with SSE (Server-Sent Events) transport
+func startSSEServer(s *server.MCPServer, instances []*tools.Instance, config Config) {
+	addr := ":" + config.Port
+
+	sseServer := server.NewSSEServer(s, server.WithSSEContextFunc(ContextFromRequest))
+
+	// Create HTTP server with SSE handlers
+	mux := http.NewServeMux()
+	mux.Handle("/sse", config.Auth.Handler(sseServer))
+	mux.Handle("/message", config.Auth.Handler(sseServer))
+	mux.HandleFunc("/health", healthCheckHandler(instances, config))
+	mux.HandleFunc("/metrics", metrics.Handler)
+
+	logging.Transport.Info("KanboardMCP SSE server listening",
+		"addr", addr,
+		"sse_endpoint", "http://localhost"+addr+"/sse",
+		"message_endpoint", "http://localhost"+addr+"/message",
+		"health_endpoint", "http://localhost"+addr+"/health",
+		"metrics_endpoint", "http://localhost"+addr+"/metrics")
+
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logging.Transport.Error("SSE server error", "error", err)
+		os.Exit(1)
+	}
+}
+
+// startStreamableHTTPServer starts the MCP server with Streamable HTTP transport
+func startStreamableHTTPServer(s *server.MCPServer, instances []*tools.Instance, config Config) {
+	addr := ":" + config.Port
+
+	httpServer := server.NewStreamableHTTPServer(s, server.WithHTTPContextFunc(ContextFromRequest))
+
+	// Create HTTP server with Streamable HTTP handlers
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", config.Auth.Handler(httpServer))
+	mux.HandleFunc("/health", healthCheckHandler(instances, config))
+	mux.HandleFunc("/metrics", metrics.Handler)
+
+	logging.Transport.Info("KanboardMCP Streamable HTTP server listening",
+		"addr", addr,
+		"mcp_endpoint", "http://localhost"+addr+"/mcp",
+		"health_endpoint", "http://localhost"+addr+"/health",
+		"metrics_endpoint", "http://localhost"+addr+"/metrics")
+
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logging.Transport.Error("HTTP server error", "error", err)
+		os.Exit(1)
+	}
+}
+
+// ContextFromRequest prepares the context of an MCP request: the trace context of the
+// caller, and the Kanboard credentials sent with HTTP Basic authentication (username and
+// personal API token) or the X-Kanboard-Username and X-Kanboard-Token headers
+func ContextFromRequest(ctx context.Context, r *http.Request) context.Context {
+	ctx = tracing.ContextFromRequest(ctx, r)
+	username, token, ok := r.BasicAuth()
+	if !ok {
+		username, token = r.Header.Get("X-Kanboard-Username"), r.Header.Get("X-Kanboard-Token")
+	}
+	if username == "" || token == "" {
+		return ctx
+	}
+	return tools.WithSessionCredentials(ctx, tools.SessionCredentials{Username: username, Token: token})
+}
+
+// healthCheckHandler provides a simple health check endpoint.
+// An open circuit breaker reports "degraded" but keeps the 200 status, since
+// restarting the server would not bring Kanboard back. With several instances
+// each one is reported under "instances", "kanboard" is the first one.
+func healthCheckHandler(instances []*tools.Instance, config Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := "healthy"
+		kanboards := make(map[string]interface{}, len(instances))
+		for _, instance := range instances {
+			breaker := instance.Client.Breaker.Status()
+			if breaker.State == kanboard.BreakerOpen || breaker.State == kanboard.BreakerHalfOpen {
+				status = "degraded"
+			}
+			kanboards[instance.Name] = map[string]interface{}{
+				"circuit_breaker": breaker,
+			}
+		}
+
+		response := map[string]interface{}{
+			"status":    status,
+			"service":   "kanboard-mcp",
+			"version":   config.Version,
+			"buildTime": config.BuildTime,
+		}
+		if len(instances) > 0 {
+			response["kanboard"] = kanboards[instances[0].Name]
+		}
+		if len(instances) > 1 {
+			response["instances"] = kanboards
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}